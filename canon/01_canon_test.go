@@ -0,0 +1,115 @@
+// /home/krylon/go/src/ticker/canon/01_canon_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:38:21 krylon>
+
+package canon
+
+import "testing"
+
+func TestURL(t *testing.T) {
+	type testCase struct {
+		raw       string
+		canonical string
+	}
+
+	var cases = []testCase{
+		{
+			raw:       "https://www.example.com/news/story?utm_source=rss&utm_medium=feed",
+			canonical: "https://www.example.com/news/story",
+		},
+		{
+			raw:       "http://www.example.com/news/story?id=42&fbclid=abc123&ref=rss",
+			canonical: "http://www.example.com/news/story?id=42",
+		},
+		{
+			raw:       "https://WWW.Example.COM:443/news/story?UTM_Campaign=x#comments",
+			canonical: "https://www.example.com/news/story#comments",
+		},
+		{
+			// An http-only site must stay on http.
+			raw:       "http://legacy.example.net/news/story",
+			canonical: "http://legacy.example.net/news/story",
+		},
+		{
+			// On a site that has nothing to do with AMP, /amp/ is
+			// just part of the path.
+			raw:       "https://www.example.com/amp/news/story?id=42",
+			canonical: "https://www.example.com/amp/news/story?id=42",
+		},
+		{
+			raw:       "https://www.example.com/news/story/amp/",
+			canonical: "https://www.example.com/news/story/amp/",
+		},
+		{
+			raw:       "https://amp.example.com/news/story.amp?utm_source=twitter",
+			canonical: "https://amp.example.com/news/story.amp",
+		},
+		{
+			raw:       "https://www.google.com/amp/s/www.example.com/news/story",
+			canonical: "https://www.example.com/news/story",
+		},
+		{
+			raw:       "https://www-example-com.cdn.ampproject.org/c/s/www.example.com/news/story",
+			canonical: "https://www.example.com/news/story",
+		},
+		{
+			raw:       "https://www.example.com/science/ampere",
+			canonical: "https://www.example.com/science/ampere",
+		},
+		{
+			raw:       "mailto:news@example.com",
+			canonical: "mailto:news@example.com",
+		},
+	}
+
+	for _, c := range cases {
+		var s = URL(c.raw)
+
+		if s != c.canonical {
+			t.Errorf("Unexpected canonical form of %s:\n%s\n(expected %s)",
+				c.raw,
+				s,
+				c.canonical)
+		}
+	}
+} // func TestURL(t *testing.T)
+
+func TestRulesConfigure(t *testing.T) {
+	var (
+		err error
+		r   = Rules{
+			Strip: []string{"Session*"},
+			HTTPS: true,
+			Rewrites: []Rewrite{
+				{
+					Domain:  "example.org",
+					Pattern: `/print/(\d+)`,
+					Replace: "/article/$1",
+				},
+			},
+		}
+	)
+
+	defer Configure(DefaultRules) // nolint: errcheck
+
+	if err = Configure(Rules{Rewrites: []Rewrite{{Pattern: "("}}}); err == nil {
+		t.Error("Configure should reject an invalid pattern")
+	} else if err = Configure(r); err != nil {
+		t.Fatalf("Cannot configure Rules: %s", err.Error())
+	} else if r.Strip[0] != "Session*" {
+		t.Errorf("Configure modified the Rules it was given: %q", r.Strip[0])
+	}
+
+	var (
+		s1 = URL("http://news.example.org/print/42?sessionid=abc&utm_source=x")
+		s2 = URL("http://www.example.com/print/42")
+	)
+
+	if s1 != "https://news.example.org/article/42?utm_source=x" {
+		t.Errorf("Unexpected canonical form: %s", s1)
+	} else if s2 != "https://www.example.com/print/42" {
+		t.Errorf("Rewrite for example.org should not apply to %s", s2)
+	}
+} // func TestRulesConfigure(t *testing.T)
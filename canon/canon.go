@@ -0,0 +1,259 @@
+// /home/krylon/go/src/ticker/canon/canon.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:38:21 krylon>
+
+// Package canon turns the links of news Items into a canonical form, by
+// removing tracking parameters, replacing AMP variants with the regular
+// page and the like, so the same article ends up with the same URL no
+// matter where we found it.
+package canon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Rewrite replaces the parts of a URL that match Pattern with Replace,
+// which may refer to submatches as $1, $2 and so on. Domain restricts the
+// Rewrite to URLs on that host or its subdomains, if it is empty, the
+// Rewrite applies to all URLs.
+type Rewrite struct {
+	Domain  string `json:"domain"`
+	Pattern string `json:"pattern"`
+	Replace string `json:"replace"`
+	re      *regexp.Regexp
+}
+
+// Matches returns true if the Rewrite applies to the given host.
+func (rw *Rewrite) Matches(host string) bool {
+	return rw.Domain == "" ||
+		host == rw.Domain ||
+		strings.HasSuffix(host, "."+rw.Domain)
+} // func (rw *Rewrite) Matches(host string) bool
+
+// Rules describe how we canonicalize URLs.
+type Rules struct {
+	// Strip lists the names of query parameters to remove. A name
+	// ending in "*" matches all parameters starting with it. Names are
+	// matched case-insensitively.
+	Strip []string `json:"strip"`
+	// HTTPS replaces http with https. It is off by default, since not
+	// every site that offers its Feed over http serves its pages over
+	// https, too.
+	HTTPS bool `json:"https"`
+	// Rewrites are applied in order, after the parameters have been
+	// stripped.
+	Rewrites []Rewrite `json:"rewrites"`
+}
+
+// DefaultRules are the Rules we use unless told otherwise. They only
+// unwrap links that went through an AMP cache, since there is no telling
+// what an AMP-looking path or host name means on any other site. Rewrites
+// for the AMP pages of particular sites belong in the rules file, with a
+// Domain.
+var DefaultRules = Rules{
+	Strip: []string{
+		"utm_*",
+		"fbclid",
+		"gclid",
+		"dclid",
+		"msclkid",
+		"mc_cid",
+		"mc_eid",
+		"igshid",
+		"yclid",
+		"_hsenc",
+		"_hsmi",
+		"ref",
+		"ref_src",
+		"ref_url",
+		"cmpid",
+		"at_medium",
+		"at_campaign",
+		"wt_mc",
+		"wt.mc_id",
+		"ns_source",
+		"ns_mchannel",
+		"ns_campaign",
+		"amp",
+		"outputtype",
+	},
+	Rewrites: []Rewrite{
+		// Google's AMP cache
+		{Domain: "google.com", Pattern: `^https?://[^/]+/amp/s/(.*)$`, Replace: "https://$1"},
+		{Domain: "ampproject.org", Pattern: `^https?://[^/]+/c/s/(.*)$`, Replace: "https://$1"},
+	},
+}
+
+var (
+	rulesLock sync.RWMutex
+	rules     = DefaultRules
+)
+
+func init() {
+	if err := rules.compile(); err != nil {
+		panic(err)
+	}
+} // func init()
+
+// compile checks the Rules and compiles the patterns of their Rewrites.
+func (r *Rules) compile() error {
+	var err error
+
+	for i := range r.Rewrites {
+		var rw = &r.Rewrites[i]
+
+		if rw.re, err = regexp.Compile(rw.Pattern); err != nil {
+			return fmt.Errorf("Invalid pattern %q in rewrite #%d: %w",
+				rw.Pattern,
+				i+1,
+				err)
+		}
+	}
+
+	for i, s := range r.Strip {
+		r.Strip[i] = strings.ToLower(s)
+	}
+
+	return nil
+} // func (r *Rules) compile() error
+
+// Configure checks the given Rules and makes them the ones URL uses from
+// now on.
+func Configure(r Rules) error {
+	var err error
+
+	// Don't modify the caller's slices
+	r.Strip = append([]string(nil), r.Strip...)
+	r.Rewrites = append([]Rewrite(nil), r.Rewrites...)
+
+	if err = r.compile(); err != nil {
+		return err
+	}
+
+	rulesLock.Lock()
+	rules = r
+	rulesLock.Unlock()
+
+	return nil
+} // func Configure(r Rules) error
+
+// Load reads Rules in JSON format from the given file.
+func Load(path string) (Rules, error) {
+	var (
+		err  error
+		data []byte
+		r    Rules
+	)
+
+	if data, err = os.ReadFile(path); err != nil {
+		return r, err
+	} else if err = json.Unmarshal(data, &r); err != nil {
+		return r, fmt.Errorf("Cannot parse %s: %w", path, err)
+	}
+
+	return r, nil
+} // func Load(path string) (Rules, error)
+
+// URL returns the canonical form of the given URL, according to the Rules
+// passed to Configure or DefaultRules. Strings that are not absolute HTTP(S)
+// URLs are returned as they are.
+func URL(raw string) string {
+	rulesLock.RLock()
+	defer rulesLock.RUnlock()
+
+	return rules.Canonicalize(raw)
+} // func URL(raw string) string
+
+// Canonicalize returns the canonical form of the given URL according to the
+// Rules. The Rules must have been passed to Configure before.
+func (r *Rules) Canonicalize(raw string) string {
+	var (
+		err error
+		u   *url.URL
+		s   string
+	)
+
+	if u, err = url.Parse(strings.TrimSpace(raw)); err != nil {
+		return raw
+	} else if u.Scheme = strings.ToLower(u.Scheme); u.Scheme != "http" && u.Scheme != "https" {
+		return raw
+	} else if u.Host == "" {
+		return raw
+	}
+
+	if r.HTTPS {
+		u.Scheme = "https"
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	if (u.Scheme == "https" && u.Port() == "443") || (u.Scheme == "http" && u.Port() == "80") {
+		u.Host = u.Hostname()
+	}
+
+	if u.RawQuery != "" {
+		var (
+			q        = u.Query()
+			stripped bool
+		)
+
+		for name := range q {
+			if r.strip(name) {
+				q.Del(name)
+				stripped = true
+			}
+		}
+
+		if stripped {
+			u.RawQuery = q.Encode()
+		}
+	}
+
+	u.ForceQuery = false
+	s = u.String()
+
+	var host = u.Hostname()
+
+	for i := range r.Rewrites {
+		var rw = &r.Rewrites[i]
+
+		if rw.re == nil || !rw.Matches(host) {
+			continue
+		} else if n := rw.re.ReplaceAllString(s, rw.Replace); n != s {
+			// A Rewrite may move the URL to a different host.
+			if u, err = url.Parse(n); err != nil {
+				return s
+			}
+
+			s = n
+			host = u.Hostname()
+		}
+	}
+
+	return s
+} // func (r *Rules) Canonicalize(raw string) string
+
+// strip returns true if the query parameter with the given name is to be
+// removed.
+func (r *Rules) strip(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, s := range r.Strip {
+		if strings.HasSuffix(s, "*") {
+			if strings.HasPrefix(name, s[:len(s)-1]) {
+				return true
+			}
+		} else if name == s {
+			return true
+		}
+	}
+
+	return false
+} // func (r *Rules) strip(name string) bool
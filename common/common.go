@@ -135,6 +135,10 @@ var CacheDir = filepath.Join(BaseDir, "cache")
 // ArchiveDir is the folder where downloaded/archived pages are stored.
 var ArchiveDir = filepath.Join(BaseDir, "archive")
 
+// EnclosureDir is the folder where downloaded enclosures, e.g. podcast
+// episodes, are stored.
+var EnclosureDir = filepath.Join(BaseDir, "enclosures")
+
 // ClassifierDir is the path to the Shield classifier's databases.
 var ClassifierDir = filepath.Join(BaseDir, "classifier")
 
@@ -148,6 +152,7 @@ func InitApp() error {
 
 	CacheDir = filepath.Join(BaseDir, "cache")
 	ArchiveDir = filepath.Join(BaseDir, "archive")
+	EnclosureDir = filepath.Join(BaseDir, "enclosures")
 	ClassifierDir = filepath.Join(BaseDir, "classifier")
 	AdvisorDir = filepath.Join(BaseDir, "advisor")
 
@@ -161,6 +166,10 @@ func InitApp() error {
 		return fmt.Errorf("Error creating ArchiveDir %s: %s",
 			ArchiveDir,
 			err.Error())
+	} else if err = os.Mkdir(EnclosureDir, 0700); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("Error creating EnclosureDir %s: %s",
+			EnclosureDir,
+			err.Error())
 	} else if err = os.Mkdir(ClassifierDir, 0700); err != nil && !os.IsExist(err) {
 		return fmt.Errorf("Error creating folder for classifier database %s: %s",
 			ClassifierDir,
@@ -0,0 +1,62 @@
+// /home/krylon/go/src/ticker/common/health.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 03:08:12 krylon>
+
+package common
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HealthConfig describes how patient we are with Feeds we cannot fetch.
+type HealthConfig struct {
+	// BackoffCeiling is the longest we wait between attempts to refresh a
+	// Feed that keeps failing, unless the Feed's regular interval is even
+	// longer.
+	BackoffCeiling time.Duration
+	// FailureLimit is how long a Feed may keep failing before it is
+	// deactivated.
+	FailureLimit time.Duration
+}
+
+// DefaultHealthConfig is the configuration we use unless told otherwise.
+var DefaultHealthConfig = HealthConfig{
+	BackoffCeiling: time.Hour * 24,
+	FailureLimit:   time.Hour * 24 * 7,
+}
+
+var (
+	healthLock   sync.RWMutex
+	healthConfig = DefaultHealthConfig
+)
+
+// ConfigureHealth checks the given configuration and makes it the one
+// HealthSettings returns from now on.
+func ConfigureHealth(cfg HealthConfig) error {
+	if cfg.BackoffCeiling <= 0 {
+		return fmt.Errorf("Backoff ceiling must be positive, not %s",
+			cfg.BackoffCeiling)
+	} else if cfg.FailureLimit <= 0 {
+		return fmt.Errorf("Failure limit must be positive, not %s",
+			cfg.FailureLimit)
+	}
+
+	healthLock.Lock()
+	healthConfig = cfg
+	healthLock.Unlock()
+
+	return nil
+} // func ConfigureHealth(cfg HealthConfig) error
+
+// HealthSettings returns the thresholds for failing Feeds. Unless
+// ConfigureHealth has been called, it returns DefaultHealthConfig.
+func HealthSettings() HealthConfig {
+	healthLock.RLock()
+	defer healthLock.RUnlock()
+
+	return healthConfig
+} // func HealthSettings() HealthConfig
@@ -0,0 +1,266 @@
+// /home/krylon/go/src/ticker/common/http.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:08:04 krylon>
+
+package common
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrResponseTooLarge indicates that a server sent us more data than we are
+// willing to accept.
+var ErrResponseTooLarge = errors.New("response exceeds maximum size")
+
+// HTTPConfig describes how we talk to other web servers. All the parts of
+// the application that fetch data from the web - Feeds, archived pages,
+// prefetched images, WebSub hubs - use clients built from it.
+type HTTPConfig struct {
+	// Timeout limits the time for an entire request, including reading
+	// the response body.
+	Timeout time.Duration
+	// ConnectTimeout limits the time it takes to establish a connection,
+	// including the TLS handshake.
+	ConnectTimeout time.Duration
+	// UserAgent is sent with every request that does not set its own.
+	UserAgent string
+	// Proxy is the URL of a HTTP(S) or SOCKS5 proxy, e.g.
+	// socks5://localhost:1080. If it is empty, we use the proxy set in
+	// the environment, if any.
+	Proxy string
+	// MaxResponseSize is the maximum number of bytes we read from a
+	// response body. Zero means no limit.
+	MaxResponseSize int64
+	// TLSMinVersion is the oldest version of TLS we accept, e.g. "1.2".
+	TLSMinVersion string
+	// TLSInsecure disables the verification of server certificates.
+	TLSInsecure bool
+	// TLSCAFile is the path of a PEM file with additional CA certificates
+	// we trust.
+	TLSCAFile string
+}
+
+// DefaultHTTPConfig is the configuration we use unless told otherwise.
+var DefaultHTTPConfig = HTTPConfig{
+	Timeout:         time.Second * 60,
+	ConnectTimeout:  time.Second * 15,
+	UserAgent:       AppName + "/" + Version,
+	MaxResponseSize: 64 << 20,
+	TLSMinVersion:   "1.2",
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var (
+	httpLock   sync.Mutex
+	httpClient *http.Client
+	httpConfig = DefaultHTTPConfig
+)
+
+// ConfigureHTTP checks the given configuration and makes it the one
+// HTTPClient uses from now on.
+func ConfigureHTTP(cfg HTTPConfig) error {
+	var (
+		err error
+		c   *http.Client
+	)
+
+	if c, err = NewHTTPClient(cfg); err != nil {
+		return err
+	}
+
+	httpLock.Lock()
+	httpClient = c
+	httpConfig = cfg
+	httpLock.Unlock()
+
+	return nil
+} // func ConfigureHTTP(cfg HTTPConfig) error
+
+// HTTPSettings returns the configuration HTTPClient uses. Callers that need
+// different limits, e.g. to download large files, can modify it and pass
+// it to NewHTTPClient.
+func HTTPSettings() HTTPConfig {
+	httpLock.Lock()
+	defer httpLock.Unlock()
+
+	return httpConfig
+} // func HTTPSettings() HTTPConfig
+
+// HTTPClient returns the shared HTTP client. Unless ConfigureHTTP has been
+// called, it uses DefaultHTTPConfig.
+func HTTPClient() *http.Client {
+	httpLock.Lock()
+	defer httpLock.Unlock()
+
+	if httpClient == nil {
+		var err error
+
+		if httpClient, err = NewHTTPClient(DefaultHTTPConfig); err != nil {
+			panic(fmt.Sprintf("Invalid default HTTP configuration: %s", err.Error()))
+		}
+	}
+
+	return httpClient
+} // func HTTPClient() *http.Client
+
+// NewHTTPClient creates a new HTTP client from the given configuration.
+func NewHTTPClient(cfg HTTPConfig) (*http.Client, error) {
+	var (
+		err   error
+		proxy func(*http.Request) (*url.URL, error)
+		tcfg  = &tls.Config{
+			InsecureSkipVerify: cfg.TLSInsecure, // nolint: gosec
+		}
+	)
+
+	if cfg.Proxy == "" {
+		proxy = http.ProxyFromEnvironment
+	} else {
+		var purl *url.URL
+
+		if purl, err = url.Parse(cfg.Proxy); err != nil {
+			return nil, fmt.Errorf("Invalid proxy URL %q: %w", cfg.Proxy, err)
+		}
+
+		switch purl.Scheme {
+		case "http", "https", "socks5":
+			proxy = http.ProxyURL(purl)
+		default:
+			return nil, fmt.Errorf("Unsupported proxy type %q, use http, https or socks5",
+				purl.Scheme)
+		}
+	}
+
+	if cfg.TLSMinVersion != "" {
+		var ok bool
+
+		if tcfg.MinVersion, ok = tlsVersions[cfg.TLSMinVersion]; !ok {
+			return nil, fmt.Errorf("Unknown TLS version %q", cfg.TLSMinVersion)
+		}
+	}
+
+	if cfg.TLSCAFile != "" {
+		var pem []byte
+
+		if pem, err = os.ReadFile(cfg.TLSCAFile); err != nil {
+			return nil, fmt.Errorf("Cannot read CA certificates from %s: %w",
+				cfg.TLSCAFile,
+				err)
+		} else if tcfg.RootCAs, err = x509.SystemCertPool(); err != nil {
+			tcfg.RootCAs = x509.NewCertPool()
+		}
+
+		if !tcfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("No certificates found in %s", cfg.TLSCAFile)
+		}
+	}
+
+	var dialer = &net.Dialer{
+		Timeout:   cfg.ConnectTimeout,
+		KeepAlive: time.Second * 30,
+	}
+
+	var trans = &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tcfg,
+		TLSHandshakeTimeout:   cfg.ConnectTimeout,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       time.Second * 90,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &httpTransport{
+			base:      trans,
+			userAgent: cfg.UserAgent,
+			maxSize:   cfg.MaxResponseSize,
+		},
+	}, nil
+} // func NewHTTPClient(cfg HTTPConfig) (*http.Client, error)
+
+// httpTransport sets the User-Agent header on outgoing requests and limits
+// the size of the responses.
+type httpTransport struct {
+	base      http.RoundTripper
+	userAgent string
+	maxSize   int64
+}
+
+func (t *httpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var (
+		err error
+		res *http.Response
+	)
+
+	if t.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	if res, err = t.base.RoundTrip(req); err != nil || t.maxSize <= 0 {
+		return res, err
+	} else if res.ContentLength > t.maxSize {
+		res.Body.Close() // nolint: errcheck
+		return nil, fmt.Errorf("%w: %s announced %d bytes, limit is %d",
+			ErrResponseTooLarge,
+			req.URL.Host,
+			res.ContentLength,
+			t.maxSize)
+	}
+
+	res.Body = &limitedBody{ReadCloser: res.Body, remaining: t.maxSize}
+
+	return res, nil
+} // func (t *httpTransport) RoundTrip(req *http.Request) (*http.Response, error)
+
+// limitedBody returns ErrResponseTooLarge once the server sends more data
+// than we allow, instead of silently truncating the response like
+// io.LimitReader does.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		var (
+			buf    [1]byte
+			n, err = b.ReadCloser.Read(buf[:])
+		)
+
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+
+		return 0, err
+	}
+
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+
+	var n, err = b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+} // func (b *limitedBody) Read(p []byte) (int, error)
@@ -6,7 +6,11 @@
 
 package database
 
-import "github.com/blicero/ticker/feed"
+import (
+	"reflect"
+
+	"github.com/blicero/ticker/feed"
+)
 
 func feedEqual(f1, f2 *feed.Feed) bool {
 	if f1 == f2 {
@@ -18,5 +22,17 @@ func feedEqual(f1, f2 *feed.Feed) bool {
 		f1.URL == f2.URL &&
 		f1.Interval == f2.Interval &&
 		f1.LastUpdate.Unix() == f2.LastUpdate.Unix() &&
-		f1.Active == f2.Active
+		f1.Active == f2.Active &&
+		f1.ETag == f2.ETag &&
+		f1.LastModified == f2.LastModified &&
+		f1.FailCount == f2.FailCount &&
+		f1.FailSince.Unix() == f2.FailSince.Unix() &&
+		f1.LastError == f2.LastError &&
+		f1.LastSuccess.Unix() == f2.LastSuccess.Unix() &&
+		f1.HTTPStatus == f2.HTTPStatus &&
+		f1.Adaptive == f2.Adaptive &&
+		f1.IntervalMin == f2.IntervalMin &&
+		f1.IntervalMax == f2.IntervalMax &&
+		f1.AdaptiveInterval == f2.AdaptiveInterval &&
+		reflect.DeepEqual(f1.Auth, f2.Auth)
 } // func feedEqual(f1, f2 *feed.Feed) bool
@@ -7,7 +7,10 @@
 package database
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
+	"github.com/blicero/ticker/common"
 	"github.com/blicero/ticker/feed"
 	"time"
 )
@@ -144,6 +147,352 @@ Got:      %s
 	}
 } // func TestFeedSetTimestamp(t *testing.T)
 
+func TestFeedSetCacheInfo(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	for idx, r := range testFeeds {
+		var (
+			err error
+			f   *feed.Feed
+		)
+
+		r.ETag = fmt.Sprintf("\"etag%04d\"", idx)
+		r.LastModified = time.Now().UTC().Format(http.TimeFormat)
+
+		if err = db.FeedSetCacheInfo(r); err != nil {
+			t.Errorf("Cannot set cache info for Feed %s (%d): %s",
+				r.Name,
+				r.ID,
+				err.Error())
+		} else if f, err = db.FeedGetByID(r.ID); err != nil {
+			t.Errorf("Cannot get Feed %s by ID (%d): %s",
+				r.Name,
+				r.ID,
+				err.Error())
+		} else if f == nil {
+			t.Errorf("Did not find Feed %s by ID (%d)",
+				r.Name,
+				r.ID)
+		} else if !feedEqual(r, f) {
+			t.Errorf(`Feed %s as returned by FeedGetByID does not equal reference Feed:
+Expected: %s
+Got:      %s
+`,
+				r.Name,
+				r,
+				f)
+		}
+	}
+} // func TestFeedSetCacheInfo(t *testing.T)
+
+func TestFeedSetFailure(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const failCnt = 3
+
+	for _, r := range testFeeds {
+		var (
+			err   error
+			f     *feed.Feed
+			stamp = time.Now()
+		)
+
+		r.HTTPStatus = http.StatusServiceUnavailable
+
+		for i := 0; i < failCnt; i++ {
+			var ferr = fmt.Errorf("Test failure #%d", i+1)
+
+			if err = db.FeedSetFailure(r, ferr, stamp.Add(time.Minute*time.Duration(i))); err != nil {
+				t.Fatalf("Cannot record failure for Feed %s (%d): %s",
+					r.Name,
+					r.ID,
+					err.Error())
+			}
+		}
+
+		if r.FailCount != failCnt {
+			t.Errorf("Unexpected failure count for Feed %s: %d (expected %d)",
+				r.Name,
+				r.FailCount,
+				failCnt)
+		} else if r.FailSince.Unix() != stamp.Unix() {
+			t.Errorf("Unexpected FailSince for Feed %s: %s (expected %s)",
+				r.Name,
+				r.FailSince.Format(common.TimestampFormat),
+				stamp.Format(common.TimestampFormat))
+		} else if f, err = db.FeedGetByID(r.ID); err != nil {
+			t.Errorf("Cannot get Feed %s by ID (%d): %s",
+				r.Name,
+				r.ID,
+				err.Error())
+		} else if f == nil {
+			t.Errorf("Did not find Feed %s by ID (%d)",
+				r.Name,
+				r.ID)
+		} else if !feedEqual(r, f) {
+			t.Errorf(`Feed %s as returned by FeedGetByID does not equal reference Feed:
+Expected: %s
+Got:      %s
+`,
+				r.Name,
+				r,
+				f)
+		} else if f.RetryInterval() <= f.Interval {
+			t.Errorf("Failing Feed %s is not backed off: %s",
+				f.Name,
+				f.RetryInterval())
+		}
+	}
+} // func TestFeedSetFailure(t *testing.T)
+
+func TestFeedSetSuccess(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	for _, r := range testFeeds {
+		var (
+			err   error
+			f     *feed.Feed
+			stamp = time.Now()
+		)
+
+		r.HTTPStatus = http.StatusOK
+
+		if err = db.FeedSetSuccess(r, stamp); err != nil {
+			t.Errorf("Cannot record success for Feed %s (%d): %s",
+				r.Name,
+				r.ID,
+				err.Error())
+		} else if r.IsFailing() {
+			t.Errorf("Feed %s is still failing after success was recorded",
+				r.Name)
+		} else if f, err = db.FeedGetByID(r.ID); err != nil {
+			t.Errorf("Cannot get Feed %s by ID (%d): %s",
+				r.Name,
+				r.ID,
+				err.Error())
+		} else if f == nil {
+			t.Errorf("Did not find Feed %s by ID (%d)",
+				r.Name,
+				r.ID)
+		} else if !feedEqual(r, f) {
+			t.Errorf(`Feed %s as returned by FeedGetByID does not equal reference Feed:
+Expected: %s
+Got:      %s
+`,
+				r.Name,
+				r,
+				f)
+		}
+	}
+} // func TestFeedSetSuccess(t *testing.T)
+
+func TestFeedSetAdaptive(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	for _, r := range testFeeds {
+		var (
+			err error
+			f   *feed.Feed
+		)
+
+		if err = db.FeedSetAdaptiveInterval(r, time.Hour*3); err != nil {
+			t.Fatalf("Cannot set adaptive interval for Feed %s (%d): %s",
+				r.Name,
+				r.ID,
+				err.Error())
+		} else if err = db.FeedSetAdaptive(r, true, time.Minute*10, time.Hour*2); err != nil {
+			t.Fatalf("Cannot enable adaptive mode for Feed %s (%d): %s",
+				r.Name,
+				r.ID,
+				err.Error())
+		} else if r.AdaptiveInterval != time.Hour*2 {
+			t.Errorf("Adaptive interval of Feed %s was not clamped to new bounds: %s",
+				r.Name,
+				r.AdaptiveInterval)
+		} else if f, err = db.FeedGetByID(r.ID); err != nil {
+			t.Errorf("Cannot get Feed %s by ID (%d): %s",
+				r.Name,
+				r.ID,
+				err.Error())
+		} else if f == nil {
+			t.Errorf("Did not find Feed %s by ID (%d)",
+				r.Name,
+				r.ID)
+		} else if !feedEqual(r, f) {
+			t.Errorf(`Feed %s as returned by FeedGetByID does not equal reference Feed:
+Expected: %s
+Got:      %s
+`,
+				r.Name,
+				r,
+				f)
+		} else if f.EffectiveInterval() != time.Hour*2 {
+			t.Errorf("Unexpected effective interval for Feed %s: %s",
+				f.Name,
+				f.EffectiveInterval())
+		}
+	}
+} // func TestFeedSetAdaptive(t *testing.T)
+
+// TestFeedGetDueBounds checks that FeedGetDue agrees with Feed.IsDue when the
+// stored adaptive interval lies outside the Feed's bounds, e.g. because it
+// was computed before the user changed them.
+func TestFeedGetDueBounds(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	type testCase struct {
+		f     *feed.Feed
+		aival time.Duration
+		age   time.Duration
+	}
+
+	var (
+		err   error
+		due   []feed.Feed
+		cases = []testCase{
+			{
+				// The stored interval is above the upper bound, so
+				// the Feed is due even though the interval has not
+				// passed.
+				f: &feed.Feed{
+					Name:     "Bounds Above",
+					URL:      "https://above.example.com/feed.xml",
+					Homepage: "https://above.example.com/",
+					Interval: time.Hour,
+					Active:   true,
+				},
+				aival: time.Hour * 3,
+				age:   time.Minute * 150,
+			},
+			{
+				// The stored interval is below the lower bound, so
+				// the Feed is not due yet, even though the interval
+				// has passed.
+				f: &feed.Feed{
+					Name:     "Bounds Below",
+					URL:      "https://below.example.com/feed.xml",
+					Homepage: "https://below.example.com/",
+					Interval: time.Hour,
+					Active:   true,
+				},
+				aival: time.Minute * 5,
+				age:   time.Minute * 7,
+			},
+		}
+	)
+
+	for _, c := range cases {
+		if err = db.FeedAdd(c.f); err != nil {
+			t.Fatalf("Cannot add Feed %s: %s", c.f.Name, err.Error())
+		} else if err = db.FeedSetAdaptive(c.f, true, time.Minute*10, time.Hour*2); err != nil {
+			t.Fatalf("Cannot enable adaptive mode for Feed %s: %s",
+				c.f.Name,
+				err.Error())
+		} else if err = db.FeedSetAdaptiveInterval(c.f, c.aival); err != nil {
+			t.Fatalf("Cannot set adaptive interval for Feed %s: %s",
+				c.f.Name,
+				err.Error())
+		} else if err = db.FeedSetTimestamp(c.f, time.Now().Add(-c.age)); err != nil {
+			t.Fatalf("Cannot set timestamp for Feed %s: %s",
+				c.f.Name,
+				err.Error())
+		}
+	}
+
+	if due, err = db.FeedGetDue(); err != nil {
+		t.Fatalf("Cannot get due Feeds: %s", err.Error())
+	}
+
+	for _, c := range cases {
+		var (
+			f     *feed.Feed
+			isDue bool
+		)
+
+		for _, d := range due {
+			if d.ID == c.f.ID {
+				isDue = true
+				break
+			}
+		}
+
+		if f, err = db.FeedGetByID(c.f.ID); err != nil {
+			t.Fatalf("Cannot get Feed %s by ID (%d): %s",
+				c.f.Name,
+				c.f.ID,
+				err.Error())
+		} else if isDue != f.IsDue() {
+			t.Errorf("FeedGetDue disagrees about Feed %s: due = %t, effective interval = %s",
+				f.Name,
+				isDue,
+				f.EffectiveInterval())
+		}
+	}
+
+	for _, c := range cases {
+		if err = db.FeedDelete(c.f.ID); err != nil {
+			t.Fatalf("Cannot delete Feed %s: %s", c.f.Name, err.Error())
+		}
+	}
+} // func TestFeedGetDueBounds(t *testing.T)
+
+func TestFeedSetAuth(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err  error
+		f    *feed.Feed
+		r    = testFeeds[0]
+		auth = &feed.Auth{
+			Username: "krylon",
+			Password: "geheim",
+			Headers:  map[string]string{"X-Api-Key": "0815"},
+		}
+	)
+
+	if err = db.FeedSetAuth(r, auth); err != nil {
+		t.Fatalf("Cannot set credentials for Feed %s: %s",
+			r.Name,
+			err.Error())
+	} else if f, err = db.FeedGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot get Feed %s by ID (%d): %s",
+			r.Name,
+			r.ID,
+			err.Error())
+	} else if !feedEqual(r, f) {
+		t.Errorf("Credentials of Feed %s were not stored correctly: %s",
+			r.Name,
+			f.Auth)
+	} else if err = db.FeedSetAuth(r, new(feed.Auth)); err != nil {
+		t.Fatalf("Cannot clear credentials for Feed %s: %s",
+			r.Name,
+			err.Error())
+	} else if r.Auth != nil {
+		t.Errorf("Credentials of Feed %s were not cleared", r.Name)
+	} else if f, err = db.FeedGetByID(r.ID); err != nil {
+		t.Fatalf("Cannot get Feed %s by ID (%d): %s",
+			r.Name,
+			r.ID,
+			err.Error())
+	} else if f.Auth != nil {
+		t.Errorf("Credentials of Feed %s are still in database: %s",
+			r.Name,
+			f.Auth)
+	}
+} // func TestFeedSetAuth(t *testing.T)
+
 func TestFeedDelete(t *testing.T) {
 	if db == nil {
 		t.SkipNow()
@@ -0,0 +1,126 @@
+// /home/krylon/go/src/ticker/database/06_database_websub_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:50:03 krylon>
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+	"github.com/blicero/ticker/websub"
+)
+
+func isDue(t *testing.T, id int64) bool {
+	var (
+		err   error
+		feeds []feed.Feed
+	)
+
+	if feeds, err = db.FeedGetDue(); err != nil {
+		t.Fatalf("Cannot get due Feeds: %s", err.Error())
+	}
+
+	for _, f := range feeds {
+		if f.ID == id {
+			return true
+		}
+	}
+
+	return false
+} // func isDue(t *testing.T, id int64) bool
+
+func subEqual(s1, s2 *websub.Subscription) bool {
+	return s1.FeedID == s2.FeedID &&
+		s1.Hub == s2.Hub &&
+		s1.Topic == s2.Topic &&
+		s1.Secret == s2.Secret &&
+		s1.State == s2.State &&
+		s1.Lease == s2.Lease &&
+		s1.Expires.Unix() == s2.Expires.Unix() &&
+		s1.Requested.Unix() == s2.Requested.Unix()
+} // func subEqual(s1, s2 *websub.Subscription) bool
+
+func TestWebSub(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err error
+		f   *feed.Feed
+		s   *websub.Subscription
+		ref = testFeeds[0]
+		sub = &websub.Subscription{
+			FeedID:    ref.ID,
+			Hub:       "https://hub.example.com/",
+			Topic:     ref.URL,
+			Secret:    "geheim",
+			State:     websub.Pending,
+			Requested: time.Now().Add(-time.Hour * 3),
+		}
+	)
+
+	if err = db.FeedSetTimestamp(ref, time.Now().Add(-time.Hour*2)); err != nil {
+		t.Fatalf("Cannot set timestamp on Feed %s: %s",
+			ref.Name,
+			err.Error())
+	} else if !isDue(t, ref.ID) {
+		t.Fatalf("Feed %s should be due", ref.Name)
+	} else if err = db.WebSubAdd(sub); err != nil {
+		t.Fatalf("Cannot add WebSub Subscription: %s", err.Error())
+	} else if s, err = db.WebSubGetByFeed(ref.ID); err != nil {
+		t.Fatalf("Cannot get WebSub Subscription: %s", err.Error())
+	} else if s == nil {
+		t.Fatalf("WebSub Subscription for Feed %s was not found", ref.Name)
+	} else if !subEqual(s, sub) {
+		t.Fatalf(`WebSub Subscription from database does not match:
+Expected: %#v
+Got:      %#v`,
+			sub,
+			s)
+	} else if !isDue(t, ref.ID) {
+		t.Errorf("Feed %s should be due while its Subscription is Pending", ref.Name)
+	}
+
+	if err = db.WebSubSetState(sub, websub.Active, time.Hour); err != nil {
+		t.Fatalf("Cannot activate WebSub Subscription: %s", err.Error())
+	} else if f, err = db.FeedGetByID(ref.ID); err != nil {
+		t.Fatalf("Cannot get Feed %s: %s", ref.Name, err.Error())
+	} else if !f.IsPushed() {
+		t.Errorf("Feed %s should be pushed", f.Name)
+	} else if f.EffectiveInterval() != feed.PushInterval {
+		t.Errorf("Unexpected interval for pushed Feed %s: %s",
+			f.Name,
+			f.EffectiveInterval())
+	} else if isDue(t, ref.ID) {
+		t.Errorf("Pushed Feed %s should not be due", ref.Name)
+	}
+
+	var subs []websub.Subscription
+
+	if subs, err = db.WebSubGetExpiring(); err != nil {
+		t.Fatalf("Cannot get expiring Subscriptions: %s", err.Error())
+	} else if len(subs) != 1 || subs[0].FeedID != ref.ID {
+		t.Errorf("Unexpected list of expiring Subscriptions: %v", subs)
+	} else if err = db.WebSubSetRequested(sub, time.Now()); err != nil {
+		t.Fatalf("Cannot set request timestamp: %s", err.Error())
+	} else if subs, err = db.WebSubGetExpiring(); err != nil {
+		t.Fatalf("Cannot get expiring Subscriptions: %s", err.Error())
+	} else if len(subs) != 0 {
+		t.Errorf("Subscriptions we just tried to renew should not be returned: %v", subs)
+	}
+
+	if err = db.WebSubDelete(ref.ID); err != nil {
+		t.Fatalf("Cannot delete WebSub Subscription: %s", err.Error())
+	} else if s, err = db.WebSubGetByFeed(ref.ID); err != nil {
+		t.Fatalf("Cannot get WebSub Subscription: %s", err.Error())
+	} else if s != nil {
+		t.Errorf("WebSub Subscription was not deleted")
+	} else if !isDue(t, ref.ID) {
+		t.Errorf("Feed %s should be due again after Subscription was deleted", ref.Name)
+	}
+} // func TestWebSub(t *testing.T)
@@ -0,0 +1,97 @@
+// /home/krylon/go/src/ticker/database/07_database_enclosure_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:08:04 krylon>
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestEnclosureAdd(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err   error
+		item  *feed.Item
+		enc   *feed.Enclosure
+		list  []feed.Enclosure
+		media = []feed.Enclosure{
+			{
+				URL:      "https://www.example.com/podcast/episode1.mp3",
+				Type:     "audio/mpeg",
+				Length:   23 << 20,
+				Duration: time.Minute * 42,
+			},
+			{
+				URL:  "https://www.example.com/podcast/episode1.jpg",
+				Type: "image/jpeg",
+			},
+		}
+		podcast = feed.Item{
+			FeedID:      testFeeds[0].ID,
+			URL:         "https://www.example.com/podcast/episode1",
+			Title:       "Episode 1",
+			Description: "The first episode of our podcast",
+			Timestamp:   time.Now(),
+			// Some Feeds list the same file more than once.
+			Enclosures: append(media, media[0]),
+		}
+	)
+
+	if err = db.ItemAdd(&podcast); err != nil {
+		t.Fatalf("Cannot add Item with Enclosures: %s", err.Error())
+	} else if podcast.Enclosures[0].ID == 0 || podcast.Enclosures[1].ID == 0 {
+		t.Fatal("Enclosures have no ID")
+	} else if list, err = db.EnclosureGetByItem(podcast.ID); err != nil {
+		t.Fatalf("Cannot load Enclosures of Item %d: %s",
+			podcast.ID,
+			err.Error())
+	} else if len(list) != len(media) {
+		t.Fatalf("Unexpected number of Enclosures: %d (expected %d)",
+			len(list),
+			len(media))
+	}
+
+	for idx, e := range list {
+		media[idx].ID = podcast.Enclosures[idx].ID
+		media[idx].ItemID = podcast.ID
+
+		if e != media[idx] {
+			t.Errorf("Enclosure %d was not stored correctly:\n%#v\n%#v",
+				idx,
+				e,
+				media[idx])
+		}
+	}
+
+	if item, err = db.ItemGetByID(podcast.ID); err != nil {
+		t.Fatalf("Cannot load Item %d: %s", podcast.ID, err.Error())
+	} else if len(item.Enclosures) != len(media) {
+		t.Errorf("Item %d has %d Enclosures (expected %d)",
+			item.ID,
+			len(item.Enclosures),
+			len(media))
+	}
+
+	if enc, err = db.EnclosureGetByID(media[0].ID); err != nil {
+		t.Fatalf("Cannot load Enclosure %d: %s", media[0].ID, err.Error())
+	} else if enc == nil {
+		t.Fatalf("Enclosure %d was not found", media[0].ID)
+	} else if *enc != media[0] {
+		t.Errorf("Unexpected Enclosure:\n%#v\n%#v", *enc, media[0])
+	}
+
+	if enc, err = db.EnclosureGetByID(media[1].ID + 1000); err != nil {
+		t.Errorf("Error looking up non-existent Enclosure: %s", err.Error())
+	} else if enc != nil {
+		t.Errorf("Found non-existent Enclosure: %#v", *enc)
+	}
+} // func TestEnclosureAdd(t *testing.T)
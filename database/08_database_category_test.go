@@ -0,0 +1,124 @@
+// /home/krylon/go/src/ticker/database/08_database_category_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:47:50 krylon>
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+	"github.com/blicero/ticker/tag"
+)
+
+func TestCategoryAdd(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err          error
+		sci, oss     *tag.Tag
+		m            *feed.CategoryTag
+		mappings     []feed.CategoryTag
+		cats         []feed.Category
+		items        []feed.Item
+		item         *feed.Item
+		feedID       = testFeeds[1].ID
+		first, other = feed.Item{
+			FeedID:      feedID,
+			URL:         "https://www.example.com/blog/categories/1",
+			Title:       "Categorized post",
+			Description: "A post with an author and some categories",
+			Timestamp:   time.Now(),
+			Author:      "Jane Doe",
+			AuthorURI:   "mailto:jane@example.com",
+			Categories:  []string{"Science", "Open Source"},
+		}, feed.Item{
+			FeedID:      feedID,
+			URL:         "https://www.example.com/blog/categories/2",
+			Title:       "Another categorized post",
+			Description: "Upstream categories are not consistently capitalized",
+			Timestamp:   time.Now(),
+			Author:      "John Roe",
+			Categories:  []string{"open source"},
+		}
+	)
+
+	if sci, err = db.TagCreate("Category Test Science", "", 0); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	} else if oss, err = db.TagCreate("Category Test Open Source", "", 0); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	} else if m, err = db.CategoryTagAdd(feedID, "Science", sci.ID); err != nil {
+		t.Fatalf("Cannot map category to Tag: %s", err.Error())
+	} else if m.ID == 0 {
+		t.Fatal("Category mapping has no ID")
+	} else if _, err = db.CategoryTagAdd(feedID, "Science", sci.ID); err == nil {
+		t.Error("Adding the same mapping twice should fail")
+	}
+
+	if err = db.ItemAdd(&first); err != nil {
+		t.Fatalf("Cannot add Item: %s", err.Error())
+	} else if item, err = db.ItemGetByID(first.ID); err != nil {
+		t.Fatalf("Cannot load Item %d: %s", first.ID, err.Error())
+	} else if item.Author != first.Author || item.AuthorURI != first.AuthorURI {
+		t.Errorf("Unexpected author: %q <%s> (expected %q <%s>)",
+			item.Author,
+			item.AuthorURI,
+			first.Author,
+			first.AuthorURI)
+	} else if len(item.Categories) != 2 ||
+		item.Categories[0] != "Science" ||
+		item.Categories[1] != "Open Source" {
+		t.Errorf("Unexpected categories: %v", item.Categories)
+	} else if !item.HasTag(sci.ID) {
+		t.Errorf("Item should have been tagged %q by its category", sci.Name)
+	} else if item.HasTag(oss.ID) {
+		t.Errorf("Item should not be tagged %q, yet", oss.Name)
+	}
+
+	if err = db.ItemAdd(&other); err != nil {
+		t.Fatalf("Cannot add Item: %s", err.Error())
+	}
+
+	// Adding a mapping tags the Items we already have.
+	if _, err = db.CategoryTagAdd(feedID, "Open Source", oss.ID); err != nil {
+		t.Fatalf("Cannot map category to Tag: %s", err.Error())
+	} else if items, err = db.ItemGetByTag(oss); err != nil {
+		t.Fatalf("Cannot load Items by Tag: %s", err.Error())
+	} else if len(items) != 2 {
+		t.Errorf("Unexpected number of Items tagged %q: %d (expected 2)",
+			oss.Name,
+			len(items))
+	}
+
+	if items, err = db.ItemGetByCategory("OPEN SOURCE"); err != nil {
+		t.Fatalf("Cannot load Items by category: %s", err.Error())
+	} else if len(items) != 2 {
+		t.Errorf("Unexpected number of Items in category: %d (expected 2)",
+			len(items))
+	}
+
+	if items, err = db.ItemGetByAuthor("jane"); err != nil {
+		t.Fatalf("Cannot load Items by author: %s", err.Error())
+	} else if len(items) != 1 || items[0].ID != first.ID {
+		t.Errorf("Unexpected Items by author: %v", items)
+	}
+
+	if cats, err = db.CategoryGetByFeed(feedID); err != nil {
+		t.Fatalf("Cannot load categories of Feed %d: %s", feedID, err.Error())
+	} else if len(cats) != 2 {
+		t.Errorf("Unexpected categories of Feed %d: %v", feedID, cats)
+	}
+
+	if err = db.CategoryTagDelete(m.ID); err != nil {
+		t.Fatalf("Cannot delete category mapping: %s", err.Error())
+	} else if mappings, err = db.CategoryTagGetByFeed(feedID); err != nil {
+		t.Fatalf("Cannot load category mappings: %s", err.Error())
+	} else if len(mappings) != 1 || mappings[0].TagID != oss.ID {
+		t.Errorf("Unexpected category mappings: %v", mappings)
+	}
+} // func TestCategoryAdd(t *testing.T)
@@ -0,0 +1,98 @@
+// /home/krylon/go/src/ticker/database/09_database_duplicate_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:36:42 krylon>
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestItemDuplicate(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const story = "The city council voted on Tuesday to approve the new budget for " +
+		"public transport, which includes funding for three additional tram lines, " +
+		"an extension of the night bus service and lower fares for students and " +
+		"pensioners starting next spring."
+
+	var (
+		err                 error
+		feeds               []int64
+		item                *feed.Item
+		now                 = time.Now()
+		wire, paper, remote = feed.Item{
+			FeedID:      testFeeds[0].ID,
+			URL:         "https://wire.example.com/2026/10/council-budget",
+			Title:       "Council approves transport budget",
+			Description: story,
+			Timestamp:   now,
+		}, feed.Item{
+			FeedID:      testFeeds[1].ID,
+			URL:         "https://paper.example.com/local/council-approves-budget?src=rss",
+			Title:       "City council approves transport budget",
+			Description: story + " The opposition criticized the plan.",
+			Timestamp:   now.Add(time.Hour * 3),
+		}, feed.Item{
+			FeedID:      testFeeds[1].ID,
+			URL:         "https://paper.example.com/sports/derby",
+			Title:       "Home team wins the derby",
+			Description: "In a thrilling match on Saturday evening the home team beat their rivals two to one after extra time.",
+			Timestamp:   now,
+		}
+	)
+
+	for _, i := range []*feed.Item{&wire, &paper, &remote} {
+		if err = db.ItemAdd(i); err != nil {
+			t.Fatalf("Cannot add Item %q: %s", i.Title, err.Error())
+		}
+	}
+
+	if paper.DupGroup != wire.ID {
+		t.Errorf("Item %q should be in duplicate group %d, not %d",
+			paper.Title,
+			wire.ID,
+			paper.DupGroup)
+	} else if remote.DupGroup != 0 {
+		t.Errorf("Item %q should not be in a duplicate group (%d)",
+			remote.Title,
+			remote.DupGroup)
+	} else if item, err = db.ItemGetByID(wire.ID); err != nil {
+		t.Fatalf("Cannot load Item %d: %s", wire.ID, err.Error())
+	} else if item.DupGroup != wire.ID {
+		t.Errorf("Item %q should be in duplicate group %d, not %d",
+			item.Title,
+			wire.ID,
+			item.DupGroup)
+	} else if feeds, err = db.ItemGetDuplicateFeeds(wire.ID); err != nil {
+		t.Fatalf("Cannot load Feeds of duplicate group %d: %s",
+			wire.ID,
+			err.Error())
+	} else if len(feeds) != 2 {
+		t.Errorf("Expected 2 Feeds in duplicate group, got %d: %v",
+			len(feeds),
+			feeds)
+	}
+
+	var byGroup map[int64][]int64
+
+	if byGroup, err = db.ItemGetDuplicateFeedsByGroups([]int64{wire.ID, remote.ID}); err != nil {
+		t.Fatalf("Cannot load Feeds of duplicate groups: %s", err.Error())
+	} else if len(byGroup) != 1 {
+		t.Errorf("Expected 1 duplicate group, got %d: %v",
+			len(byGroup),
+			byGroup)
+	} else if len(byGroup[wire.ID]) != len(feeds) {
+		t.Errorf("Expected %d Feeds in duplicate group %d, got %v",
+			len(feeds),
+			wire.ID,
+			byGroup[wire.ID])
+	}
+} // func TestItemDuplicate(t *testing.T)
@@ -0,0 +1,161 @@
+// /home/krylon/go/src/ticker/database/10_database_retention_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 03:06:32 krylon>
+
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+	"github.com/blicero/ticker/tag"
+)
+
+func TestRetention(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err      error
+		t1       *tag.Tag
+		policies []feed.Retention
+		expired  []feed.Item
+		f        = &feed.Feed{
+			Name:     "Retention Feed",
+			URL:      "https://old.example.com/feed.xml",
+			Homepage: "https://old.example.com/",
+			Interval: time.Minute * 30,
+			Active:   true,
+		}
+		pol = feed.Retention{
+			MaxAge: feed.Day * 90,
+		}
+		now   = time.Now()
+		items = make([]feed.Item, 5)
+	)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	}
+
+	// Four old Items, three of which are protected, and a recent one.
+	for idx := range items {
+		items[idx] = feed.Item{
+			FeedID:      f.ID,
+			URL:         fmt.Sprintf("https://old.example.com/%d", idx),
+			Title:       fmt.Sprintf("Old story #%d", idx),
+			Description: fmt.Sprintf("Story number %d about the old days", idx),
+			Timestamp:   now.Add(-feed.Day * 120),
+		}
+
+		if idx == 4 {
+			items[idx].Timestamp = now.Add(-feed.Day)
+		}
+
+		if err = db.ItemAdd(&items[idx]); err != nil {
+			t.Fatalf("Cannot add Item %q: %s", items[idx].Title, err.Error())
+		}
+	}
+
+	if t1, err = db.TagCreate("Retention Test", "", 0); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	} else if err = db.TagLinkCreate(items[1].ID, t1.ID); err != nil {
+		t.Fatalf("Cannot attach Tag to Item: %s", err.Error())
+	} else if err = db.ItemRatingSet(&items[2], 0.5); err != nil {
+		t.Fatalf("Cannot rate Item: %s", err.Error())
+	} else if _, err = db.ReadLaterAdd(&items[3], "", now.Add(feed.Day)); err != nil {
+		t.Fatalf("Cannot add Item to read-later list: %s", err.Error())
+	}
+
+	if err = db.RetentionSet(&feed.Retention{MaxAge: time.Hour}); err == nil {
+		t.Error("RetentionSet should reject a period of less than one day")
+	} else if err = db.RetentionSet(&pol); err != nil {
+		t.Fatalf("Cannot set Retention policy: %s", err.Error())
+	}
+
+	pol.ReadOnly = true
+
+	if err = db.RetentionSet(&pol); err != nil {
+		t.Fatalf("Cannot update Retention policy: %s", err.Error())
+	} else if policies, err = db.RetentionGetAll(); err != nil {
+		t.Fatalf("Cannot load Retention policies: %s", err.Error())
+	} else if len(policies) != 1 {
+		t.Fatalf("Unexpected number of Retention policies: %d (expected 1)",
+			len(policies))
+	} else if policies[0] != pol {
+		t.Errorf("Unexpected Retention policy: %#v (expected %#v)",
+			policies[0],
+			pol)
+	} else if expired, err = db.ItemGetExpired(f.ID, &pol, now); err != nil {
+		t.Fatalf("Cannot load expired Items: %s", err.Error())
+	} else if len(expired) != 0 {
+		t.Errorf("Unread Items should not expire: %d", len(expired))
+	}
+
+	pol.ReadOnly = false
+
+	if expired, err = db.ItemGetExpired(f.ID, &pol, now); err != nil {
+		t.Fatalf("Cannot load expired Items: %s", err.Error())
+	} else if len(expired) != 1 || expired[0].ID != items[0].ID {
+		t.Fatalf("Unexpected expired Items: %v (expected only Item %d)",
+			expired,
+			items[0].ID)
+	} else if err = db.ItemDelete(expired[0].ID); err != nil {
+		t.Fatalf("Cannot delete Item %d: %s", expired[0].ID, err.Error())
+	} else if err = db.RetentionDelete(0); err != nil {
+		t.Fatalf("Cannot delete Retention policy: %s", err.Error())
+	}
+
+	var (
+		item *feed.Item
+		refs map[string][]int64
+		pat  = regexp.MustCompile(`Story number (\d+) about`)
+		edit = items[4]
+		// The deleted Item must no longer show up.
+		expect = map[string]int64{
+			"1": items[1].ID,
+			"2": items[2].ID,
+			"3": items[3].ID,
+			"4": items[4].ID,
+			"7": items[2].ID,
+		}
+	)
+
+	// Revisions and extracted content may refer to cached files, too.
+	edit.Description = "A story about nothing in particular"
+
+	if err = db.ItemUpdate(items[4].ID, &edit); err != nil {
+		t.Fatalf("Cannot update Item %d: %s", items[4].ID, err.Error())
+	} else if err = db.ItemContentSet(&items[2], "Story number 7 about the future"); err != nil {
+		t.Fatalf("Cannot set content of Item %d: %s", items[2].ID, err.Error())
+	}
+
+	if item, err = db.ItemGetByID(items[0].ID); err != nil {
+		t.Fatalf("Cannot look up Item %d: %s", items[0].ID, err.Error())
+	} else if item != nil {
+		t.Errorf("Item %d was not deleted", items[0].ID)
+	} else if refs, err = db.ItemCacheRefs(pat); err != nil {
+		t.Fatalf("Cannot count references: %s", err.Error())
+	} else if len(refs) != len(expect) {
+		t.Errorf("Unexpected references: %v", refs)
+	} else if policies, err = db.RetentionGetAll(); err != nil {
+		t.Fatalf("Cannot load Retention policies: %s", err.Error())
+	} else if len(policies) != 0 {
+		t.Errorf("Retention policy was not deleted: %v", policies)
+	}
+
+	for name, id := range expect {
+		if ids := refs[name]; len(ids) != 1 || ids[0] != id {
+			t.Errorf("Unexpected references to %s: %v (expected Item %d)",
+				name,
+				ids,
+				id)
+		}
+	}
+} // func TestRetention(t *testing.T)
@@ -0,0 +1,119 @@
+// /home/krylon/go/src/ticker/database/11_database_group_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:14:30 krylon>
+
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestGroups(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err         error
+		news, local *feed.Group
+		groups      []feed.Group
+		items       []feed.Item
+		unread      map[int64]int64
+		f           *feed.Feed
+		feeds       = make([]*feed.Feed, 2)
+	)
+
+	if news, err = db.GroupAdd("News", 0); err != nil {
+		t.Fatalf("Cannot add Group: %s", err.Error())
+	} else if local, err = db.GroupAdd("Local", news.ID); err != nil {
+		t.Fatalf("Cannot add Group: %s", err.Error())
+	}
+
+	for idx := range feeds {
+		feeds[idx] = &feed.Feed{
+			Name:     fmt.Sprintf("Group Feed %d", idx),
+			URL:      fmt.Sprintf("https://group%d.example.com/feed.xml", idx),
+			Homepage: fmt.Sprintf("https://group%d.example.com/", idx),
+			Interval: time.Minute * 30,
+			Active:   true,
+		}
+
+		if err = db.FeedAdd(feeds[idx]); err != nil {
+			t.Fatalf("Cannot add Feed %s: %s", feeds[idx].Name, err.Error())
+		}
+
+		var i = feed.Item{
+			FeedID:      feeds[idx].ID,
+			URL:         fmt.Sprintf("https://group%d.example.com/story", idx),
+			Title:       fmt.Sprintf("Story from Group Feed %d", idx),
+			Description: fmt.Sprintf("Something happened in group %d", idx),
+			Timestamp:   time.Now(),
+		}
+
+		if err = db.ItemAdd(&i); err != nil {
+			t.Fatalf("Cannot add Item %q: %s", i.Title, err.Error())
+		}
+	}
+
+	if err = db.FeedSetGroup(feeds[0].ID, news.ID); err != nil {
+		t.Fatalf("Cannot put Feed in Group: %s", err.Error())
+	} else if err = db.FeedSetGroup(feeds[1].ID, local.ID); err != nil {
+		t.Fatalf("Cannot put Feed in Group: %s", err.Error())
+	} else if f, err = db.FeedGetByID(feeds[1].ID); err != nil {
+		t.Fatalf("Cannot load Feed: %s", err.Error())
+	} else if f.GroupID != local.ID {
+		t.Errorf("Feed %s should be in Group %d, not %d",
+			f.Name,
+			local.ID,
+			f.GroupID)
+	}
+
+	if groups, err = db.GroupGetAll(); err != nil {
+		t.Fatalf("Cannot load Groups: %s", err.Error())
+	} else if len(groups) != 2 {
+		t.Fatalf("Unexpected number of Groups: %d (expected 2)", len(groups))
+	} else if groups[1].Path != "News/Local" || groups[1].Level != 1 {
+		t.Errorf("Unexpected Group: %s (level %d)", groups[1].Path, groups[1].Level)
+	}
+
+	if unread, err = db.GroupGetUnread(); err != nil {
+		t.Fatalf("Cannot count unread Items: %s", err.Error())
+	} else if unread[news.ID] != 2 || unread[local.ID] != 1 {
+		t.Errorf("Unexpected unread counts: %v", unread)
+	} else if items, err = db.ItemGetByGroup(news.ID, 10); err != nil {
+		t.Fatalf("Cannot load Items of Group: %s", err.Error())
+	} else if len(items) != 2 {
+		t.Errorf("Unexpected number of Items in Group %s: %d (expected 2)",
+			news.Name,
+			len(items))
+	}
+
+	if err = db.GroupSetActive(news.ID, false); err != nil {
+		t.Fatalf("Cannot deactivate Group: %s", err.Error())
+	} else if err = db.GroupSetInterval(news.ID, time.Hour); err != nil {
+		t.Fatalf("Cannot set interval for Group: %s", err.Error())
+	} else if f, err = db.FeedGetByID(feeds[1].ID); err != nil {
+		t.Fatalf("Cannot load Feed: %s", err.Error())
+	} else if f.Active || f.Interval != time.Hour {
+		t.Errorf("Bulk update did not apply to Feed %s: %t / %s",
+			f.Name,
+			f.Active,
+			f.Interval)
+	}
+
+	if err = db.GroupDelete(news.ID); err == nil {
+		t.Error("GroupDelete should refuse to delete a Group with subgroups")
+	} else if err = db.GroupDelete(local.ID); err != nil {
+		t.Fatalf("Cannot delete Group: %s", err.Error())
+	} else if f, err = db.FeedGetByID(feeds[1].ID); err != nil {
+		t.Fatalf("Cannot load Feed: %s", err.Error())
+	} else if f.GroupID != 0 {
+		t.Errorf("Feed %s is still in deleted Group %d", f.Name, f.GroupID)
+	}
+} // func TestGroups(t *testing.T)
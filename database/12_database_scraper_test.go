@@ -0,0 +1,53 @@
+// /home/krylon/go/src/ticker/database/12_database_scraper_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:18:42 krylon>
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestFeedScraper(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err error
+		f2  *feed.Feed
+		f   = &feed.Feed{
+			Name:     "Scraped Feed",
+			URL:      "https://nofeed.example.com/news.html",
+			Homepage: "https://nofeed.example.com/",
+			Interval: time.Hour,
+			Active:   true,
+		}
+		s = &feed.Scraper{
+			Item:  "article",
+			Title: "h2",
+			Date:  "time",
+		}
+	)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	} else if err = db.FeedSetScraper(f, s); err != nil {
+		t.Fatalf("Cannot set selectors for Feed %s: %s", f.Name, err.Error())
+	} else if f2, err = db.FeedGetByID(f.ID); err != nil {
+		t.Fatalf("Cannot load Feed %d: %s", f.ID, err.Error())
+	} else if f2.Scraper == nil || *f2.Scraper != *s {
+		t.Fatalf("Selectors of Feed %s were not stored: %v", f.Name, f2.Scraper)
+	} else if err = db.FeedSetScraper(f, nil); err != nil {
+		t.Fatalf("Cannot remove selectors from Feed %s: %s", f.Name, err.Error())
+	} else if f2, err = db.FeedGetByID(f.ID); err != nil {
+		t.Fatalf("Cannot load Feed %d: %s", f.ID, err.Error())
+	} else if f2.Scraper != nil {
+		t.Errorf("Feed %s should no longer be scraped: %v", f.Name, f2.Scraper)
+	}
+} // func TestFeedScraper(t *testing.T)
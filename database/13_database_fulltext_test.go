@@ -0,0 +1,165 @@
+// /home/krylon/go/src/ticker/database/13_database_fulltext_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:42:26 krylon>
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestItemContent(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const content = "<p>The whole story, including the word supercalifragilistic.</p>"
+
+	var (
+		err     error
+		f2      *feed.Feed
+		i2      *feed.Item
+		pending []feed.Item
+		found   []feed.Item
+		f       = &feed.Feed{
+			Name:     "Teaser Feed",
+			URL:      "https://teaser.example.com/feed.xml",
+			Homepage: "https://teaser.example.com/",
+			Interval: time.Hour,
+			Active:   true,
+		}
+		i = feed.Item{
+			URL:         "https://teaser.example.com/2026/10/story",
+			Title:       "A teaser",
+			Description: "Read the rest on our website",
+			Timestamp:   time.Now(),
+		}
+	)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	}
+
+	i.FeedID = f.ID
+
+	if err = db.ItemAdd(&i); err != nil {
+		t.Fatalf("Cannot add Item %q: %s", i.Title, err.Error())
+	} else if pending, err = db.ItemGetContentPending(time.Now().Add(-time.Hour), 10); err != nil {
+		t.Fatalf("Cannot get Items without content: %s", err.Error())
+	} else if len(pending) != 0 {
+		t.Fatalf("Feed %s does not want full text, but %d Items are pending",
+			f.Name,
+			len(pending))
+	} else if err = db.FeedSetFullText(f, true); err != nil {
+		t.Fatalf("Cannot enable full text for Feed %s: %s", f.Name, err.Error())
+	} else if f2, err = db.FeedGetByID(f.ID); err != nil {
+		t.Fatalf("Cannot load Feed %d: %s", f.ID, err.Error())
+	} else if !f2.FullText {
+		t.Fatalf("Full text flag of Feed %s was not stored", f.Name)
+	} else if pending, err = db.ItemGetContentPending(time.Now().Add(-time.Hour), 10); err != nil {
+		t.Fatalf("Cannot get Items without content: %s", err.Error())
+	} else if len(pending) != 1 || pending[0].ID != i.ID {
+		t.Fatalf("Unexpected pending Items: %v", pending)
+	} else if err = db.ItemContentSet(&pending[0], content); err != nil {
+		t.Fatalf("Cannot store content of Item %q: %s", i.Title, err.Error())
+	} else if pending, err = db.ItemGetContentPending(time.Now().Add(-time.Hour), 10); err != nil {
+		t.Fatalf("Cannot get Items without content: %s", err.Error())
+	} else if len(pending) != 0 {
+		t.Errorf("Item %q is still pending after storing its content", i.Title)
+	}
+
+	if i2, err = db.ItemGetByID(i.ID); err != nil {
+		t.Fatalf("Cannot load Item %d: %s", i.ID, err.Error())
+	} else if i2.Content != content || i2.Body() != content {
+		t.Errorf("Unexpected content of Item %q: %q", i2.Title, i2.Content)
+	} else if found, err = db.ItemGetFTS("supercalifragilistic"); err != nil {
+		t.Fatalf("Cannot search for Items: %s", err.Error())
+	} else if len(found) != 1 || found[0].ID != i.ID {
+		t.Errorf("Full-text search does not find the content of Item %q: %v",
+			i.Title,
+			found)
+	} else if err = db.FTSRebuild(); err != nil {
+		t.Fatalf("Cannot rebuild full-text index: %s", err.Error())
+	} else if found, err = db.ItemGetFTS("supercalifragilistic"); err != nil {
+		t.Fatalf("Cannot search for Items: %s", err.Error())
+	} else if len(found) != 1 {
+		t.Errorf("Rebuilt full-text index does not contain the content of Item %q",
+			i.Title)
+	}
+} // func TestItemContent(t *testing.T)
+
+// Several Feeds may carry the same link, changing or deleting the Item of
+// one Feed must not affect the index entry of the other.
+func TestItemFTSSharedLink(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const link = "https://shared.example.com/2026/10/story"
+
+	var (
+		err   error
+		found []feed.Item
+		feeds = []*feed.Feed{
+			{
+				Name:     "Shared Feed A",
+				URL:      "https://shared-a.example.com/feed.xml",
+				Homepage: "https://shared-a.example.com/",
+				Interval: time.Hour,
+				Active:   true,
+			},
+			{
+				Name:     "Shared Feed B",
+				URL:      "https://shared-b.example.com/feed.xml",
+				Homepage: "https://shared-b.example.com/",
+				Interval: time.Hour,
+				Active:   true,
+			},
+		}
+		items = []*feed.Item{
+			{URL: link, Title: "Zebras everywhere", Description: "A story", Timestamp: time.Now()},
+			{URL: link, Title: "Walruses everywhere", Description: "A story", Timestamp: time.Now()},
+		}
+	)
+
+	for idx, f := range feeds {
+		if err = db.FeedAdd(f); err != nil {
+			t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+		}
+
+		items[idx].FeedID = f.ID
+
+		if err = db.ItemAdd(items[idx]); err != nil {
+			t.Fatalf("Cannot add Item %q: %s", items[idx].Title, err.Error())
+		}
+	}
+
+	if err = db.ItemContentSet(items[1], "<p>Also, an okapi.</p>"); err != nil {
+		t.Fatalf("Cannot store content of Item %q: %s", items[1].Title, err.Error())
+	} else if found, err = db.ItemGetFTS("okapi"); err != nil {
+		t.Fatalf("Cannot search for Items: %s", err.Error())
+	} else if len(found) != 1 || found[0].ID != items[1].ID {
+		t.Errorf("Search for okapi should only find Item %d: %v",
+			items[1].ID,
+			found)
+	} else if found, err = db.ItemGetFTS("zebras"); err != nil {
+		t.Fatalf("Cannot search for Items: %s", err.Error())
+	} else if len(found) != 1 || found[0].ID != items[0].ID {
+		t.Errorf("Search for zebras should only find Item %d: %v",
+			items[0].ID,
+			found)
+	} else if err = db.ItemDelete(items[0].ID); err != nil {
+		t.Fatalf("Cannot delete Item %d: %s", items[0].ID, err.Error())
+	} else if found, err = db.ItemGetFTS("okapi"); err != nil {
+		t.Fatalf("Cannot search for Items: %s", err.Error())
+	} else if len(found) != 1 {
+		t.Errorf("Deleting Item %d removed Item %d from the index",
+			items[0].ID,
+			items[1].ID)
+	}
+} // func TestItemFTSSharedLink(t *testing.T)
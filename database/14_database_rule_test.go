@@ -0,0 +1,103 @@
+// /home/krylon/go/src/ticker/database/14_database_rule_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:34:35 krylon>
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+	"github.com/blicero/ticker/tag"
+)
+
+func TestRuleCRUD(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err    error
+		tg     *tag.Tag
+		rules  []feed.Rule
+		active []feed.Rule
+		f      = &feed.Feed{
+			Name:     "Rule Feed",
+			URL:      "https://rules.example.com/feed.xml",
+			Homepage: "https://rules.example.com/",
+			Interval: time.Hour,
+			Active:   true,
+		}
+		r = &feed.Rule{
+			Name:     "Golang",
+			Active:   true,
+			Title:    `\bgo(lang)?\b`,
+			Language: "en",
+			MarkRead: true,
+		}
+	)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	} else if tg, err = db.TagCreate("rule-tag", "Attached by a Rule", 0); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	} else if err = db.RuleAdd(r); err != nil {
+		t.Fatalf("Cannot add Rule %s: %s", r.Name, err.Error())
+	} else if r.ID == 0 {
+		t.Fatalf("Rule %s was not assigned an ID", r.Name)
+	}
+
+	r.FeedID = f.ID
+	r.TagID = tg.ID
+	r.Rate = true
+	r.Rating = 1
+
+	if err = db.RuleUpdate(r); err != nil {
+		t.Fatalf("Cannot update Rule %s: %s", r.Name, err.Error())
+	} else if rules, err = db.RuleGetAll(); err != nil {
+		t.Fatalf("Cannot load Rules: %s", err.Error())
+	} else if len(rules) != 1 {
+		t.Fatalf("Unexpected number of Rules: %d (expected 1)", len(rules))
+	} else if rules[0].FeedID != f.ID ||
+		rules[0].TagID != tg.ID ||
+		!rules[0].Rate ||
+		rules[0].Rating != 1 ||
+		rules[0].Title != r.Title ||
+		!rules[0].MarkRead {
+		t.Errorf("Rule was not stored correctly: %#v", rules[0])
+	}
+
+	if err = db.RuleSetActive(r.ID, false); err != nil {
+		t.Fatalf("Cannot deactivate Rule %s: %s", r.Name, err.Error())
+	} else if active, err = db.RuleGetActive(); err != nil {
+		t.Fatalf("Cannot load active Rules: %s", err.Error())
+	} else if len(active) != 0 {
+		t.Errorf("Inactive Rule %s is returned as active", r.Name)
+	}
+
+	// Rules without conditions or actions, or with broken patterns, must
+	// be rejected.
+	for _, bad := range []feed.Rule{
+		{Name: "No conditions", MarkRead: true},
+		{Name: "No actions", Title: "foo"},
+		{Name: "Broken pattern", Title: "foo(", MarkRead: true},
+		{Name: "", Title: "foo", MarkRead: true},
+	} {
+		var rule = bad
+
+		if err = db.RuleAdd(&rule); err == nil {
+			t.Errorf("Invalid Rule %q was accepted", rule.Name)
+		}
+	}
+
+	if err = db.RuleDelete(r.ID); err != nil {
+		t.Fatalf("Cannot delete Rule %s: %s", r.Name, err.Error())
+	} else if rules, err = db.RuleGetAll(); err != nil {
+		t.Fatalf("Cannot load Rules: %s", err.Error())
+	} else if len(rules) != 0 {
+		t.Errorf("Rule %s is still there after deleting it", r.Name)
+	}
+} // func TestRuleCRUD(t *testing.T)
@@ -0,0 +1,87 @@
+// /home/krylon/go/src/ticker/database/15_database_mute_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:38:51 krylon>
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestMute(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	var (
+		err    error
+		all    []feed.Mute
+		active []feed.Mute
+		mutes  = []feed.Mute{
+			{Pattern: "election", Expires: time.Now().Add(feed.Day * 30)},
+			{Pattern: "^Sponsored", Regex: true},
+			{Pattern: "football", Created: time.Now().Add(-feed.Day * 2), Expires: time.Now().Add(-feed.Day)},
+		}
+	)
+
+	for idx := range mutes {
+		if err = db.MuteAdd(&mutes[idx]); err != nil {
+			t.Fatalf("Cannot add Mute %s: %s", &mutes[idx], err.Error())
+		} else if mutes[idx].ID == 0 {
+			t.Fatalf("Mute %s was not assigned an ID", &mutes[idx])
+		}
+	}
+
+	if err = db.MuteAdd(&feed.Mute{Pattern: "[", Regex: true}); err == nil {
+		t.Error("Invalid Mute was accepted")
+	}
+
+	if all, err = db.MuteGetAll(); err != nil {
+		t.Fatalf("Cannot load Mutes: %s", err.Error())
+	} else if len(all) != len(mutes) {
+		t.Fatalf("Unexpected number of Mutes: %d (expected %d)",
+			len(all),
+			len(mutes))
+	} else if active, err = db.MuteGetActive(); err != nil {
+		t.Fatalf("Cannot load active Mutes: %s", err.Error())
+	} else if len(active) != 2 {
+		t.Fatalf("Unexpected number of active Mutes: %d (expected 2)",
+			len(active))
+	}
+
+	for _, m := range active {
+		switch m.ID {
+		case mutes[0].ID:
+			if m.Expires.Unix() != mutes[0].Expires.Unix() {
+				t.Errorf("Unexpected expiry of Mute %s: %s",
+					&m,
+					m.Expires)
+			}
+		case mutes[1].ID:
+			if !m.Regex || !m.Expires.IsZero() {
+				t.Errorf("Mute %s was not stored correctly: %#v",
+					&m,
+					m)
+			}
+		default:
+			t.Errorf("Mute %s should have expired", &m)
+		}
+	}
+
+	for _, m := range mutes {
+		if err = db.MuteDelete(m.ID); err != nil {
+			t.Fatalf("Cannot delete Mute %s: %s", &m, err.Error())
+		}
+	}
+
+	if all, err = db.MuteGetAll(); err != nil {
+		t.Fatalf("Cannot load Mutes: %s", err.Error())
+	} else if len(all) != 0 {
+		t.Errorf("%d Mutes are left after deleting all of them", len(all))
+	}
+} // func TestMute(t *testing.T)
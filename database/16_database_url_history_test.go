@@ -0,0 +1,117 @@
+// /home/krylon/go/src/ticker/database/16_database_url_history_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:54:35 krylon>
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestFeedURLHistory(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const (
+		oldURL = "https://moving-old.example.com/feed.xml"
+		newURL = "https://moving-new.example.com/feed.xml"
+	)
+
+	var (
+		err   error
+		dup   bool
+		found *feed.Feed
+		moves []feed.Move
+		f     = &feed.Feed{
+			Name:     "Moving Feed",
+			URL:      oldURL,
+			Homepage: "https://moving-old.example.com/",
+			Interval: time.Hour,
+			Active:   true,
+		}
+		item = &feed.Item{
+			URL:       "https://moving-old.example.com/post/1",
+			Title:     "Moving day",
+			Timestamp: time.Now(),
+		}
+	)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	}
+
+	item.FeedID = f.ID
+
+	if err = db.ItemAdd(item); err != nil {
+		t.Fatalf("Cannot add Item %s: %s", item.Title, err.Error())
+	} else if err = db.FeedModify(f, f.Name, newURL, f.Homepage, f.Interval); err != nil {
+		t.Fatalf("Cannot move Feed %s: %s", f.Name, err.Error())
+	} else if f.URL != newURL {
+		t.Errorf("URL of Feed was not updated: %s", f.URL)
+	}
+
+	if moves, err = db.FeedURLHistoryGet(f.ID); err != nil {
+		t.Fatalf("Cannot load URL history of Feed %s: %s", f.Name, err.Error())
+	} else if len(moves) != 1 || moves[0].URL != oldURL {
+		t.Errorf("Unexpected URL history for Feed %s: %#v", f.Name, moves)
+	}
+
+	for _, u := range []string{oldURL, newURL} {
+		if found, err = db.FeedGetByURL(u); err != nil {
+			t.Errorf("Cannot look up Feed by URL %s: %s", u, err.Error())
+		} else if found == nil {
+			t.Errorf("Feed was not found by URL %s", u)
+		} else if found.ID != f.ID {
+			t.Errorf("Looking up %s returned the wrong Feed: %d (expected %d)",
+				u,
+				found.ID,
+				f.ID)
+		}
+	}
+
+	if found, err = db.FeedGetByURL("https://nowhere.example.com/feed.xml"); err != nil {
+		t.Errorf("Cannot look up Feed by unknown URL: %s", err.Error())
+	} else if found != nil {
+		t.Errorf("Looking up an unknown URL returned Feed %d", found.ID)
+	}
+
+	// A second subscription to the old address should recognize the
+	// Items we got from the Feed before it moved.
+	var again = &feed.Feed{
+		Name:     "Moving Feed (old)",
+		URL:      oldURL,
+		Homepage: "https://moving-old.example.com/",
+		Interval: time.Hour,
+		Active:   true,
+	}
+
+	if err = db.FeedAdd(again); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", again.Name, err.Error())
+	}
+
+	var copied = &feed.Item{
+		FeedID: again.ID,
+		URL:    "https://moving-old.example.com/post/1?utm_source=rss",
+		Title:  item.Title,
+	}
+
+	if dup, err = db.ItemHasDuplicate(copied); err != nil {
+		t.Errorf("Cannot check Item for duplicates: %s", err.Error())
+	} else if !dup {
+		t.Error("Item from old address of moved Feed was not detected as duplicate")
+	}
+
+	copied.Title = "Something else entirely"
+
+	if dup, err = db.ItemHasDuplicate(copied); err != nil {
+		t.Errorf("Cannot check Item for duplicates: %s", err.Error())
+	} else if dup {
+		t.Error("Unrelated Item was detected as duplicate")
+	}
+} // func TestFeedURLHistory(t *testing.T)
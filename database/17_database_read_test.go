@@ -0,0 +1,282 @@
+// /home/krylon/go/src/ticker/database/17_database_read_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 03:02:38 krylon>
+
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+	"github.com/blicero/ticker/tag"
+)
+
+func TestItemReadState(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const itemCnt = 4
+
+	var (
+		err                 error
+		cntBefore, cnt, res int64
+		unread              map[int64]int64
+		items               []feed.Item
+		parent, child       *tag.Tag
+		f                   = &feed.Feed{
+			Name:     "Unread Feed",
+			URL:      "https://unread.example.com/feed.xml",
+			Homepage: "https://unread.example.com/",
+			Interval: time.Hour,
+			Active:   true,
+		}
+	)
+
+	if cntBefore, err = db.ItemGetUnreadCnt(); err != nil {
+		t.Fatalf("Cannot count unread Items: %s", err.Error())
+	} else if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	}
+
+	for i := 0; i < itemCnt; i++ {
+		var item = &feed.Item{
+			FeedID:    f.ID,
+			URL:       fmt.Sprintf("https://unread.example.com/post/%d", i),
+			Title:     fmt.Sprintf("Unread post #%d", i),
+			Timestamp: time.Now().Add(time.Duration(-i) * time.Minute),
+		}
+
+		if err = db.ItemAdd(item); err != nil {
+			t.Fatalf("Cannot add Item %s: %s", item.Title, err.Error())
+		}
+
+		items = append(items, *item)
+	}
+
+	if cnt, err = db.ItemGetUnreadCnt(); err != nil {
+		t.Fatalf("Cannot count unread Items: %s", err.Error())
+	} else if cnt != cntBefore+itemCnt {
+		t.Errorf("Unexpected number of unread Items: %d (expected %d)",
+			cnt,
+			cntBefore+itemCnt)
+	}
+
+	if err = db.ItemMarkRead(&items[0]); err != nil {
+		t.Fatalf("Cannot mark Item %d as read: %s", items[0].ID, err.Error())
+	} else if unread, err = db.FeedGetUnread(); err != nil {
+		t.Fatalf("Cannot count unread Items per Feed: %s", err.Error())
+	} else if unread[f.ID] != itemCnt-1 {
+		t.Errorf("Unexpected number of unread Items in Feed %s: %d (expected %d)",
+			f.Name,
+			unread[f.ID],
+			itemCnt-1)
+	}
+
+	var list []feed.Item
+
+	if list, err = db.ItemGetAllUnread(-1, 0); err != nil {
+		t.Fatalf("Cannot load unread Items: %s", err.Error())
+	}
+
+	for _, i := range list {
+		if i.Read {
+			t.Errorf("ItemGetAllUnread returned Item %d, which has been read", i.ID)
+		} else if i.ID == items[0].ID {
+			t.Errorf("ItemGetAllUnread returned Item %d, which we marked as read", i.ID)
+		}
+	}
+
+	if err = db.ItemMarkUnread(&items[0]); err != nil {
+		t.Fatalf("Cannot mark Item %d as unread: %s", items[0].ID, err.Error())
+	} else if items[0].Read {
+		t.Errorf("Item %d is still flagged as read", items[0].ID)
+	} else if cnt, err = db.ItemGetUnreadCnt(); err != nil {
+		t.Fatalf("Cannot count unread Items: %s", err.Error())
+	} else if cnt != cntBefore+itemCnt {
+		t.Errorf("Unexpected number of unread Items after marking one as unread: %d (expected %d)",
+			cnt,
+			cntBefore+itemCnt)
+	}
+
+	// Marking the Items with a Tag as read includes the Items linked to
+	// its children.
+	if parent, err = db.TagCreate("unread-parent", "", 0); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	} else if child, err = db.TagCreate("unread-child", "", parent.ID); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	} else if err = db.TagLinkCreate(items[0].ID, parent.ID); err != nil {
+		t.Fatalf("Cannot attach Tag %s: %s", parent.Name, err.Error())
+	} else if err = db.TagLinkCreate(items[1].ID, child.ID); err != nil {
+		t.Fatalf("Cannot attach Tag %s: %s", child.Name, err.Error())
+	} else if res, err = db.ItemMarkReadByTag(parent.ID); err != nil {
+		t.Fatalf("Cannot mark Items with Tag %s as read: %s", parent.Name, err.Error())
+	} else if res != 2 {
+		t.Errorf("Unexpected number of Items marked as read by Tag: %d (expected 2)",
+			res)
+	}
+
+	if res, err = db.ItemMarkReadByFeed(f.ID); err != nil {
+		t.Fatalf("Cannot mark Items of Feed %s as read: %s", f.Name, err.Error())
+	} else if res != itemCnt-2 {
+		t.Errorf("Unexpected number of Items marked as read by Feed: %d (expected %d)",
+			res,
+			itemCnt-2)
+	} else if cnt, err = db.ItemGetUnreadCnt(); err != nil {
+		t.Fatalf("Cannot count unread Items: %s", err.Error())
+	} else if cnt != cntBefore {
+		t.Errorf("Unexpected number of unread Items after marking Feed as read: %d (expected %d)",
+			cnt,
+			cntBefore)
+	} else if unread, err = db.FeedGetUnread(); err != nil {
+		t.Fatalf("Cannot count unread Items per Feed: %s", err.Error())
+	} else if unread[f.ID] != 0 {
+		t.Errorf("Feed %s still has %d unread Items", f.Name, unread[f.ID])
+	}
+} // func TestItemReadState(t *testing.T)
+
+func TestItemUnreadFilter(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const itemCnt = 4
+
+	var (
+		err           error
+		cnt           int64
+		ids           []int64
+		list          []feed.Item
+		parent, child *tag.Tag
+		f             = &feed.Feed{
+			Name:     "Batch Feed",
+			URL:      "https://batch.example.com/feed.xml",
+			Homepage: "https://batch.example.com/",
+			Interval: time.Hour,
+			Active:   true,
+		}
+	)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	}
+
+	for i := 0; i < itemCnt; i++ {
+		var item = &feed.Item{
+			FeedID:    f.ID,
+			URL:       fmt.Sprintf("https://batch.example.com/post/%d", i),
+			Title:     fmt.Sprintf("Batch post #%d", i),
+			Timestamp: time.Now().Add(time.Duration(-i) * time.Minute),
+		}
+
+		if err = db.ItemAdd(item); err != nil {
+			t.Fatalf("Cannot add Item %s: %s", item.Title, err.Error())
+		}
+
+		ids = append(ids, item.ID)
+	}
+
+	if parent, err = db.TagCreate("batch-parent", "", 0); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	} else if child, err = db.TagCreate("batch-child", "", parent.ID); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	} else if err = db.TagLinkCreate(ids[0], parent.ID); err != nil {
+		t.Fatalf("Cannot attach Tag %s: %s", parent.Name, err.Error())
+	} else if err = db.TagLinkCreate(ids[1], child.ID); err != nil {
+		t.Fatalf("Cannot attach Tag %s: %s", child.Name, err.Error())
+	}
+
+	// Item IDs that are listed twice or have been read already are not
+	// counted.
+	if cnt, err = db.ItemMarkReadBatch([]int64{ids[0], ids[2], ids[2]}); err != nil {
+		t.Fatalf("Cannot mark Items as read: %s", err.Error())
+	} else if cnt != 2 {
+		t.Errorf("Unexpected number of Items marked as read: %d (expected 2)", cnt)
+	} else if cnt, err = db.ItemMarkReadBatch([]int64{ids[0]}); err != nil {
+		t.Fatalf("Cannot mark Items as read: %s", err.Error())
+	} else if cnt != 0 {
+		t.Errorf("Item %d was marked as read twice", ids[0])
+	}
+
+	if list, err = db.ItemGetByFeedUnread(f.ID, -1); err != nil {
+		t.Fatalf("Cannot load unread Items of Feed %s: %s", f.Name, err.Error())
+	} else if len(list) != itemCnt-2 {
+		t.Errorf("Unexpected number of unread Items in Feed %s: %d (expected %d)",
+			f.Name,
+			len(list),
+			itemCnt-2)
+	}
+
+	for _, i := range list {
+		if i.ID == ids[0] || i.ID == ids[2] {
+			t.Errorf("ItemGetByFeedUnread returned Item %d, which has been read", i.ID)
+		}
+	}
+
+	if cnt, err = db.ItemGetUnreadCntByFeed(f.ID); err != nil {
+		t.Fatalf("Cannot count unread Items of Feed %s: %s", f.Name, err.Error())
+	} else if cnt != itemCnt-2 {
+		t.Errorf("Unexpected number of unread Items in Feed %s: %d (expected %d)",
+			f.Name,
+			cnt,
+			itemCnt-2)
+	}
+
+	if list, err = db.ItemGetByTagUnread(parent); err != nil {
+		t.Fatalf("Cannot load unread Items with Tag %s: %s", parent.Name, err.Error())
+	} else if len(list) != 0 {
+		t.Errorf("Tag %s has %d unread Items (expected 0)", parent.Name, len(list))
+	} else if cnt, err = db.ItemGetUnreadCntByTag(parent); err != nil {
+		t.Fatalf("Cannot count unread Items with Tag %s: %s", parent.Name, err.Error())
+	} else if cnt != 0 {
+		t.Errorf("Tag %s has %d unread Items (expected 0)", parent.Name, cnt)
+	} else if list, err = db.ItemGetByTagRecursiveUnread(parent); err != nil {
+		t.Fatalf("Cannot load unread Items below Tag %s: %s", parent.Name, err.Error())
+	} else if len(list) != 1 || list[0].ID != ids[1] {
+		t.Errorf("Unexpected unread Items below Tag %s: %d (expected Item %d)",
+			parent.Name,
+			len(list),
+			ids[1])
+	} else if cnt, err = db.ItemGetUnreadCntByTagRecursive(parent); err != nil {
+		t.Fatalf("Cannot count unread Items below Tag %s: %s", parent.Name, err.Error())
+	} else if cnt != 1 {
+		t.Errorf("Tag %s has %d unread Items below it (expected 1)", parent.Name, cnt)
+	}
+} // func TestItemUnreadFilter(t *testing.T)
+
+func TestIDBatches(t *testing.T) {
+	var ids = make([]int64, 0, idBatchSize+2)
+
+	for i := int64(1); i <= idBatchSize+1; i++ {
+		ids = append(ids, i)
+	}
+
+	ids = append(ids, 1)
+
+	var batches = idBatches(ids)
+
+	if len(batches) != 2 {
+		t.Fatalf("Unexpected number of batches: %d (expected 2)", len(batches))
+	}
+
+	for i, b := range batches {
+		if len(b) != idBatchSize {
+			t.Errorf("Batch %d has %d IDs (expected %d)",
+				i,
+				len(b),
+				idBatchSize)
+		}
+	}
+
+	if id, ok := batches[1][0].(int64); !ok || id != idBatchSize+1 {
+		t.Errorf("Unexpected first ID in second batch: %v (expected %d)",
+			batches[1][0],
+			idBatchSize+1)
+	} else if batches[1][1] != nil {
+		t.Errorf("Second batch was not padded with NULL: %v", batches[1][1])
+	}
+} // func TestIDBatches(t *testing.T)
@@ -0,0 +1,132 @@
+// /home/krylon/go/src/ticker/database/18_database_stats_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:17:37 krylon>
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+	"github.com/blicero/ticker/tag"
+)
+
+func TestFeedStats(t *testing.T) {
+	if db == nil {
+		t.SkipNow()
+	}
+
+	const (
+		days  = 7
+		weeks = 4
+	)
+
+	var (
+		err   error
+		stats *feed.Stats
+		all   map[int64]*feed.Stats
+		tg    *tag.Tag
+		now   = time.Now()
+		items []feed.Item
+		f     = &feed.Feed{
+			Name:     "Statistics Feed",
+			URL:      "https://stats.example.com/feed.xml",
+			Homepage: "https://stats.example.com/",
+			Interval: time.Hour,
+			Active:   true,
+		}
+	)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	}
+
+	// Two Items today, one yesterday and one three weeks ago.
+	for i, age := range []time.Duration{0, 0, 24 * time.Hour, 21 * 24 * time.Hour} {
+		var item = &feed.Item{
+			FeedID:      f.ID,
+			URL:         fmt.Sprintf("https://stats.example.com/post/%d", i),
+			Title:       fmt.Sprintf("Statistics post #%d", i),
+			Description: "0123456789",
+			Timestamp:   now.Add(-age),
+		}
+
+		if err = db.ItemAdd(item); err != nil {
+			t.Fatalf("Cannot add Item %s: %s", item.Title, err.Error())
+		}
+
+		items = append(items, *item)
+	}
+
+	if err = db.ItemRatingSet(&items[0], 1); err != nil {
+		t.Fatalf("Cannot rate Item %d: %s", items[0].ID, err.Error())
+	} else if err = db.ItemRatingSet(&items[1], 0); err != nil {
+		t.Fatalf("Cannot rate Item %d: %s", items[1].ID, err.Error())
+	} else if err = db.ItemRatingSet(&items[2], 1); err != nil {
+		t.Fatalf("Cannot rate Item %d: %s", items[2].ID, err.Error())
+	} else if tg, err = db.TagCreate("stats-tag", "", 0); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	} else if err = db.TagLinkCreate(items[0].ID, tg.ID); err != nil {
+		t.Fatalf("Cannot attach Tag %s: %s", tg.Name, err.Error())
+	} else if err = db.FeedSetSuccess(f, now); err != nil {
+		t.Fatalf("Cannot record success for Feed %s: %s", f.Name, err.Error())
+	} else if err = db.FeedSetFailure(f, errors.New("Test failure"), now); err != nil {
+		t.Fatalf("Cannot record failure for Feed %s: %s", f.Name, err.Error())
+	}
+
+	if stats, err = db.FeedGetStats(f.ID, days, weeks, 5); err != nil {
+		t.Fatalf("Cannot get statistics for Feed %s: %s", f.Name, err.Error())
+	} else if stats.ItemCnt != 4 {
+		t.Errorf("Unexpected number of Items: %d (expected 4)", stats.ItemCnt)
+	} else if stats.Interesting != 2 || stats.Boring != 1 {
+		t.Errorf("Unexpected ratings: %d interesting, %d boring (expected 2/1)",
+			stats.Interesting,
+			stats.Boring)
+	} else if stats.AvgLength() != 10 {
+		t.Errorf("Unexpected average length: %d (expected 10)", stats.AvgLength())
+	} else if stats.FetchCnt != 2 || stats.ErrorCnt != 1 {
+		t.Errorf("Unexpected fetch counts: %d/%d (expected 2/1)",
+			stats.FetchCnt,
+			stats.ErrorCnt)
+	} else if stats.LastSuccess.Unix() != now.Unix() {
+		t.Errorf("Unexpected time of last success: %s (expected %s)",
+			stats.LastSuccess,
+			now)
+	} else if len(stats.PerDay) != days || len(stats.PerWeek) != weeks {
+		t.Errorf("Unexpected number of periods: %d days, %d weeks (expected %d/%d)",
+			len(stats.PerDay),
+			len(stats.PerWeek),
+			days,
+			weeks)
+	} else if stats.PerDay[days-1].Cnt != 2 || stats.PerDay[days-2].Cnt != 1 {
+		t.Errorf("Unexpected number of Items per day: %#v", stats.PerDay)
+	} else if len(stats.Tags) != 1 || stats.Tags[0].TagID != tg.ID || stats.Tags[0].Cnt != 1 {
+		t.Errorf("Unexpected Tags: %#v", stats.Tags)
+	}
+
+	var total int64
+
+	for _, c := range stats.PerWeek {
+		total += c.Cnt
+	}
+
+	if total != 4 {
+		t.Errorf("Unexpected number of Items in the last %d weeks: %d (expected 4)",
+			weeks,
+			total)
+	}
+
+	if all, err = db.FeedGetStatsAll(); err != nil {
+		t.Fatalf("Cannot get statistics for all Feeds: %s", err.Error())
+	} else if all[f.ID] == nil {
+		t.Errorf("Statistics for all Feeds do not include Feed %s", f.Name)
+	} else if all[f.ID].ItemCnt != 4 {
+		t.Errorf("Unexpected number of Items in overview: %d (expected 4)",
+			all[f.ID].ItemCnt)
+	}
+} // func TestFeedStats(t *testing.T)
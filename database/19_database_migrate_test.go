@@ -0,0 +1,320 @@
+// /home/krylon/go/src/ticker/database/19_database_migrate_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:42:26 krylon>
+
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/common"
+	"github.com/blicero/ticker/feed"
+)
+
+// baselineQueries is the schema as it was before we started keeping track
+// of schema versions.
+var baselineQueries = []string{
+	`
+CREATE TABLE feed (
+    id			INTEGER PRIMARY KEY,
+    name		TEXT NOT NULL,
+    url  		TEXT UNIQUE NOT NULL,
+    homepage            TEXT NOT NULL,
+    refresh_interval    INTEGER NOT NULL,
+    refresh_timestamp   INTEGER NOT NULL DEFAULT 0,
+    active              INTEGER NOT NULL DEFAULT 1,
+
+    CONSTRAINT interval_positive CHECK (refresh_interval > 0)
+)
+`,
+
+	`
+CREATE TABLE item (
+    id			INTEGER PRIMARY KEY,
+    feed_id		INTEGER NOT NULL,
+    link		TEXT NOT NULL,
+    title               TEXT NOT NULL,
+    description         TEXT NOT NULL,
+    timestamp           INTEGER NOT NULL,
+    read                INTEGER NOT NULL DEFAULT 0,
+    rating              REAL,
+    prefetch            INTEGER NOT NULL DEFAULT 0,
+
+    CHECK (rating IS NULL OR (rating BETWEEN 0.0 AND 1.0)),
+    CONSTRAINT feed_link_uniq UNIQUE (feed_id, link),
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+	"CREATE VIRTUAL TABLE item_index USING fts4(link, body)",
+
+	`
+CREATE TRIGGER tr_item_fts_insert
+AFTER INSERT ON item
+BEGIN
+    INSERT INTO item_index (link, body) VALUES (new.link, new.title || ' ' || new.description);
+END;
+`,
+	`
+CREATE TRIGGER tr_item_fts_delete
+AFTER DELETE ON item
+BEGIN
+    DELETE FROM item_index
+    WHERE item_index.link = old.link;
+END;
+`,
+
+	`
+CREATE TABLE tag (
+    id		INTEGER PRIMARY KEY,
+    name	TEXT UNIQUE NOT NULL,
+    parent      INTEGER,
+    description TEXT,
+    FOREIGN KEY (parent) REFERENCES tag (id)
+         ON DELETE RESTRICT
+         ON UPDATE RESTRICT
+)
+`,
+
+	`
+CREATE TABLE tag_link (
+    id		INTEGER PRIMARY KEY,
+    tag_id	INTEGER NOT NULL,
+    item_id	INTEGER NOT NULL,
+    CONSTRAINT tag_item_uniq UNIQUE (tag_id, item_id),
+    FOREIGN KEY (tag_id) REFERENCES tag (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT,
+    FOREIGN KEY (item_id) REFERENCES item (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+	`
+CREATE TABLE read_later (
+    id		INTEGER PRIMARY KEY,
+    item_id	INTEGER UNIQUE NOT NULL,
+    note        TEXT,
+    timestamp   INTEGER NOT NULL,
+    deadline	INTEGER,
+    read	INTEGER,
+    FOREIGN KEY (item_id) REFERENCES item (id)
+        ON DELETE CASCADE
+	ON UPDATE RESTRICT
+)
+`,
+
+	`INSERT INTO feed (name, url, homepage, refresh_interval)
+VALUES ('Old Feed', 'https://old.example.com/feed.xml', 'https://old.example.com/', 3600)`,
+
+	`INSERT INTO item (feed_id, link, title, description, timestamp)
+VALUES (1, 'https://old.example.com/post/1', 'Old post', 'Written before the migration', 1600000000)`,
+}
+
+func TestMigrate(t *testing.T) {
+	var (
+		err     error
+		raw     *sql.DB
+		mdb     *Database
+		version int
+		feeds   []feed.Feed
+		items   []feed.Item
+		path    = filepath.Join(common.BaseDir, "migrate.db")
+	)
+
+	if raw, err = sql.Open("sqlite3", path); err != nil {
+		t.Fatalf("Cannot create database %s: %s", path, err.Error())
+	}
+
+	for _, q := range baselineQueries {
+		if _, err = raw.Exec(q); err != nil {
+			raw.Close() // nolint: errcheck
+			t.Fatalf("Cannot execute baseline query: %s\n%s", err.Error(), q)
+		}
+	}
+
+	if err = raw.Close(); err != nil {
+		t.Fatalf("Cannot close database %s: %s", path, err.Error())
+	} else if mdb, err = Open(path); err != nil {
+		t.Fatalf("Cannot open/migrate database %s: %s", path, err.Error())
+	}
+
+	defer mdb.Close() // nolint: errcheck
+
+	if err = mdb.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatalf("Cannot query schema version: %s", err.Error())
+	} else if version != len(migrations) {
+		t.Errorf("Unexpected schema version %d (expected %d)",
+			version,
+			len(migrations))
+	}
+
+	if feeds, err = mdb.FeedGetAll(); err != nil {
+		t.Fatalf("Cannot load Feeds from migrated database: %s", err.Error())
+	} else if len(feeds) != 1 {
+		t.Fatalf("Unexpected number of Feeds: %d (expected 1)", len(feeds))
+	} else if items, err = mdb.ItemGetByFeed(feeds[0].ID, -1); err != nil {
+		t.Fatalf("Cannot load Items from migrated database: %s", err.Error())
+	} else if len(items) != 1 {
+		t.Fatalf("Unexpected number of Items: %d (expected 1)", len(items))
+	}
+
+	var (
+		id   int64
+		hash string
+	)
+
+	// The Item's link stands in for its GUID.
+	if id, hash, err = mdb.ItemLookupGUID(feeds[0].ID, items[0].URL); err != nil {
+		t.Fatalf("Cannot look up Item by GUID: %s", err.Error())
+	} else if id != items[0].ID {
+		t.Errorf("Unexpected ID of Item with GUID %s: %d (expected %d)",
+			items[0].URL,
+			id,
+			items[0].ID)
+	} else if hash != "" {
+		t.Errorf("Item %d should not have a content hash, yet: %q", id, hash)
+	}
+
+	var item = &feed.Item{
+		FeedID:      feeds[0].ID,
+		URL:         "https://old.example.com/post/2",
+		Title:       "New post",
+		Description: "Written after the migration",
+		Timestamp:   time.Now(),
+	}
+
+	if err = mdb.ItemAdd(item); err != nil {
+		t.Fatalf("Cannot add Item to migrated database: %s", err.Error())
+	} else if items, err = mdb.ItemGetFTS("migration"); err != nil {
+		t.Fatalf("Cannot search migrated database: %s", err.Error())
+	} else if len(items) != 2 {
+		t.Errorf("Unexpected number of search results: %d (expected 2)",
+			len(items))
+	}
+
+	compareSchema(t, mdb.db)
+
+	// Opening the database again must not try to migrate it again.
+	var again *Database
+
+	if again, err = Open(path); err != nil {
+		t.Fatalf("Cannot re-open migrated database: %s", err.Error())
+	} else if err = again.Close(); err != nil {
+		t.Errorf("Cannot close database: %s", err.Error())
+	}
+} // func TestMigrate(t *testing.T)
+
+// compareSchema checks that the tables, columns, indices and triggers of
+// a migrated database are the same as those of a freshly created one.
+func compareSchema(t *testing.T, migrated *sql.DB) {
+	var (
+		err   error
+		fresh *Database
+		want  map[string]string
+		have  map[string]string
+		path  = filepath.Join(common.BaseDir, "migrate_fresh.db")
+	)
+
+	if fresh, err = Open(path); err != nil {
+		t.Fatalf("Cannot create fresh database %s: %s", path, err.Error())
+	}
+
+	defer fresh.Close() // nolint: errcheck
+
+	if want, err = describeSchema(fresh.db); err != nil {
+		t.Fatalf("Cannot describe fresh schema: %s", err.Error())
+	} else if have, err = describeSchema(migrated); err != nil {
+		t.Fatalf("Cannot describe migrated schema: %s", err.Error())
+	}
+
+	for key, w := range want {
+		if h, ok := have[key]; !ok {
+			t.Errorf("Migrated database lacks %s", key)
+		} else if h != w {
+			t.Errorf("%s differs after migration: %s (expected %s)",
+				key,
+				h,
+				w)
+		}
+	}
+
+	for key := range have {
+		if _, ok := want[key]; !ok {
+			t.Errorf("Migrated database has extra %s", key)
+		}
+	}
+} // func compareSchema(t *testing.T, migrated *sql.DB)
+
+func describeSchema(db *sql.DB) (map[string]string, error) {
+	var (
+		err    error
+		rows   *sql.Rows
+		tables []string
+		schema = make(map[string]string)
+	)
+
+	if rows, err = db.Query("SELECT type, name FROM sqlite_master WHERE name NOT LIKE 'sqlite_%'"); err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var kind, name string
+
+		if err = rows.Scan(&kind, &name); err != nil {
+			rows.Close() // nolint: errcheck
+			return nil, err
+		}
+
+		schema[kind+" "+name] = kind
+		if kind == "table" {
+			tables = append(tables, name)
+		}
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, tbl := range tables {
+		if rows, err = db.Query(fmt.Sprintf("PRAGMA table_info(%s)", tbl)); err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var (
+				cid         int
+				name, ctype string
+				notNull, pk int
+				dflt        sql.NullString
+			)
+
+			if err = rows.Scan(&cid, &name, &ctype, &notNull, &dflt, &pk); err != nil {
+				rows.Close() // nolint: errcheck
+				return nil, err
+			}
+
+			schema[fmt.Sprintf("column %s.%s", tbl, name)] =
+				fmt.Sprintf("%s notnull=%d default=%s pk=%d",
+					ctype,
+					notNull,
+					dflt.String,
+					pk)
+		}
+
+		if err = rows.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+} // func describeSchema(db *sql.DB) (map[string]string, error)
@@ -10,6 +10,7 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -21,7 +22,9 @@ import (
 	"github.com/blicero/ticker/feed"
 	"github.com/blicero/ticker/logdomain"
 	"github.com/blicero/ticker/query"
+	"github.com/blicero/ticker/simhash"
 	"github.com/blicero/ticker/tag"
+	"github.com/blicero/ticker/websub"
 	"time"
 
 	"github.com/blicero/krylib"
@@ -75,6 +78,72 @@ func waitForRetry() {
 	time.Sleep(retryDelay)
 } // func waitForRetry()
 
+// setFeedHealthStamps converts the health-related timestamps stored in the
+// database to time.Time values.
+func setFeedHealthStamps(f *feed.Feed, failSince, lastSuccess int64) {
+	if failSince != 0 {
+		f.FailSince = time.Unix(failSince, 0)
+	}
+
+	if lastSuccess != 0 {
+		f.LastSuccess = time.Unix(lastSuccess, 0)
+	}
+} // func setFeedHealthStamps(f *feed.Feed, failSince, lastSuccess int64)
+
+// setFeedIntervals converts the bounds and the computed interval for
+// adaptive mode, which are stored in the database as seconds.
+func setFeedIntervals(f *feed.Feed, imin, imax, aival int64) {
+	f.IntervalMin = time.Second * time.Duration(imin)
+	f.IntervalMax = time.Second * time.Duration(imax)
+	f.AdaptiveInterval = time.Second * time.Duration(aival)
+} // func setFeedIntervals(f *feed.Feed, imin, imax, aival int64)
+
+// setFeedPushUntil sets the time until which the Feed pushes its Items to
+// us, if it has an active WebSub subscription.
+func setFeedPushUntil(f *feed.Feed, pushUntil int64) {
+	if pushUntil != 0 {
+		f.PushUntil = time.Unix(pushUntil, 0)
+	}
+} // func setFeedPushUntil(f *feed.Feed, pushUntil int64)
+
+// setFeedAuth decodes the Feed's credentials, which are stored in the
+// database as JSON.
+func setFeedAuth(f *feed.Feed, authStr string) error {
+	if authStr == "" {
+		return nil
+	}
+
+	f.Auth = new(feed.Auth)
+
+	return json.Unmarshal([]byte(authStr), f.Auth)
+} // func setFeedAuth(f *feed.Feed, authStr string) error
+
+// setFeedScraper decodes the selectors of a scraped Feed, which are stored
+// in the database as JSON.
+func setFeedScraper(f *feed.Feed, scraperStr string) error {
+	if scraperStr == "" {
+		return nil
+	}
+
+	f.Scraper = new(feed.Scraper)
+
+	return json.Unmarshal([]byte(scraperStr), f.Scraper)
+} // func setFeedScraper(f *feed.Feed, scraperStr string) error
+
+// setSubscriptionStamps converts the lease and timestamps of a WebSub
+// Subscription, which are stored in the database as seconds.
+func setSubscriptionStamps(s *websub.Subscription, lease, expires, requested int64) {
+	s.Lease = time.Second * time.Duration(lease)
+
+	if expires != 0 {
+		s.Expires = time.Unix(expires, 0)
+	}
+
+	if requested != 0 {
+		s.Requested = time.Unix(requested, 0)
+	}
+} // func setSubscriptionStamps(s *websub.Subscription, lease, expires, requested int64)
+
 // Database is the storage backend for managing Feeds and news.
 //
 // It is not safe to share a Database instance between goroutines, however
@@ -146,6 +215,9 @@ func Open(path string) (*Database, error) {
 		}
 		db.log.Printf("[INFO] Database at %s has been initialized\n",
 			path)
+	} else if err = db.migrate(); err != nil {
+		db.db.Close() // nolint: errcheck
+		return nil, err
 	}
 
 	return db, nil
@@ -182,6 +254,15 @@ func (db *Database) initialize() error {
 		}
 	}
 
+	var q = fmt.Sprintf("PRAGMA user_version = %d", len(migrations))
+
+	if _, err = tx.Exec(q); err != nil {
+		db.log.Printf("[ERROR] Cannot set schema version: %s\n",
+			err.Error())
+		tx.Rollback() // nolint: errcheck
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		db.log.Printf("[CANTHAPPEN] Failed to commit init transaction: %s\n",
 			err.Error())
@@ -668,11 +749,15 @@ EXEC_QUERY:
 
 	for rows.Next() {
 		var (
-			f               feed.Feed
-			interval, stamp int64
+			f                      feed.Feed
+			interval, stamp        int64
+			failSince, lastSuccess int64
+			imin, imax, aival      int64
+			pushUntil              int64
+			authStr, scraperStr    string
 		)
 
-		if err = rows.Scan(&f.ID, &f.Name, &f.URL, &f.Homepage, &interval, &stamp, &f.Active); err != nil {
+		if err = rows.Scan(&f.ID, &f.Name, &f.URL, &f.Homepage, &interval, &stamp, &f.Active, &f.ETag, &f.LastModified, &f.FailCount, &failSince, &f.LastError, &lastSuccess, &f.HTTPStatus, &f.Adaptive, &imin, &imax, &aival, &authStr, &pushUntil, &f.GroupID, &scraperStr, &f.FullText); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
 			return nil, err
 		} else if stamp != 0 {
@@ -680,6 +765,21 @@ EXEC_QUERY:
 		}
 
 		f.Interval = time.Second * time.Duration(interval)
+		setFeedHealthStamps(&f, failSince, lastSuccess)
+		setFeedIntervals(&f, imin, imax, aival)
+		setFeedPushUntil(&f, pushUntil)
+
+		if err = setFeedAuth(&f, authStr); err != nil {
+			db.log.Printf("[ERROR] Cannot decode credentials of Feed %d: %s\n",
+				f.ID,
+				err.Error())
+			return nil, err
+		} else if err = setFeedScraper(&f, scraperStr); err != nil {
+			db.log.Printf("[ERROR] Cannot decode selectors of Feed %d: %s\n",
+				f.ID,
+				err.Error())
+			return nil, err
+		}
 
 		list = append(list, f)
 	}
@@ -722,11 +822,15 @@ EXEC_QUERY:
 
 	for rows.Next() {
 		var (
-			f               feed.Feed
-			interval, stamp int64
+			f                      feed.Feed
+			interval, stamp        int64
+			failSince, lastSuccess int64
+			imin, imax, aival      int64
+			pushUntil              int64
+			authStr, scraperStr    string
 		)
 
-		if err = rows.Scan(&f.ID, &f.Name, &f.URL, &f.Homepage, &interval, &stamp, &f.Active); err != nil {
+		if err = rows.Scan(&f.ID, &f.Name, &f.URL, &f.Homepage, &interval, &stamp, &f.Active, &f.ETag, &f.LastModified, &f.FailCount, &failSince, &f.LastError, &lastSuccess, &f.HTTPStatus, &f.Adaptive, &imin, &imax, &aival, &authStr, &pushUntil, &f.GroupID, &scraperStr, &f.FullText); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
 			return nil, err
 		} else if stamp != 0 {
@@ -734,6 +838,21 @@ EXEC_QUERY:
 		}
 
 		f.Interval = time.Second * time.Duration(interval)
+		setFeedHealthStamps(&f, failSince, lastSuccess)
+		setFeedIntervals(&f, imin, imax, aival)
+		setFeedPushUntil(&f, pushUntil)
+
+		if err = setFeedAuth(&f, authStr); err != nil {
+			db.log.Printf("[ERROR] Cannot decode credentials of Feed %d: %s\n",
+				f.ID,
+				err.Error())
+			return nil, err
+		} else if err = setFeedScraper(&f, scraperStr); err != nil {
+			db.log.Printf("[ERROR] Cannot decode selectors of Feed %d: %s\n",
+				f.ID,
+				err.Error())
+			return nil, err
+		}
 
 		fmap[f.ID] = f
 	}
@@ -762,7 +881,10 @@ func (db *Database) FeedGetDue() ([]feed.Feed, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(now); err != nil {
+	// The adaptive interval is clamped to the Feed's bounds the same way
+	// Feed.Bounds does, so we agree with Feed.IsDue after the bounds have
+	// been changed.
+	if rows, err = stmt.Query(now, int64(feed.PushInterval.Seconds()), int64(feed.AdaptiveMaxDefault.Seconds()), int64(feed.AdaptiveMinDefault.Seconds()), feed.BackoffSteps, int64(common.HealthSettings().BackoffCeiling.Seconds()), now); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
@@ -779,13 +901,22 @@ EXEC_QUERY:
 		var (
 			id                  int64
 			name, url, homepage string
+			etag, lastModified  string
+			lastError           string
 			active              bool
 			f                   *feed.Feed
 			interval, stamp     int64
+			failCnt, failSince  int64
+			lastSuccess         int64
+			status              int
+			adaptive, fullText  bool
+			imin, imax, aival   int64
+			pushUntil, groupID  int64
+			authStr, scraperStr string
 		)
 
 		// if err = rows.Scan(&f.ID, &f.Name, &f.URL, &f.Homepage, &interval, &stamp, &f.Active); err != nil {
-		if err = rows.Scan(&id, &name, &url, &homepage, &interval, &stamp, &active); err != nil {
+		if err = rows.Scan(&id, &name, &url, &homepage, &interval, &stamp, &active, &etag, &lastModified, &failCnt, &failSince, &lastError, &lastSuccess, &status, &adaptive, &imin, &imax, &aival, &authStr, &pushUntil, &groupID, &scraperStr, &fullText); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
 			return nil, err
 		} else if f, err = feed.New(id, name, url, homepage, time.Second*time.Duration(interval), active); err != nil {
@@ -799,6 +930,30 @@ EXEC_QUERY:
 			f.LastUpdate = time.Unix(stamp, 0)
 		}
 
+		f.ETag = etag
+		f.LastModified = lastModified
+		f.FailCount = failCnt
+		f.LastError = lastError
+		f.HTTPStatus = status
+		setFeedHealthStamps(f, failSince, lastSuccess)
+		f.Adaptive = adaptive
+		setFeedIntervals(f, imin, imax, aival)
+		setFeedPushUntil(f, pushUntil)
+		f.GroupID = groupID
+		f.FullText = fullText
+
+		if err = setFeedAuth(f, authStr); err != nil {
+			db.log.Printf("[ERROR] Cannot decode credentials of Feed %d: %s\n",
+				f.ID,
+				err.Error())
+			return nil, err
+		} else if err = setFeedScraper(f, scraperStr); err != nil {
+			db.log.Printf("[ERROR] Cannot decode selectors of Feed %d: %s\n",
+				f.ID,
+				err.Error())
+			return nil, err
+		}
+
 		// f.Interval = time.Second * time.Duration(interval)
 
 		list = append(list, *f)
@@ -840,17 +995,36 @@ EXEC_QUERY:
 
 	if rows.Next() {
 		var (
-			fd              = &feed.Feed{ID: id}
-			stamp, interval int64
+			fd                     = &feed.Feed{ID: id}
+			stamp, interval        int64
+			failSince, lastSuccess int64
+			imin, imax, aival      int64
+			pushUntil              int64
+			authStr, scraperStr    string
 		)
 
-		if err = rows.Scan(&fd.Name, &fd.URL, &fd.Homepage, &interval, &stamp, &fd.Active); err != nil {
+		if err = rows.Scan(&fd.Name, &fd.URL, &fd.Homepage, &interval, &stamp, &fd.Active, &fd.ETag, &fd.LastModified, &fd.FailCount, &failSince, &fd.LastError, &lastSuccess, &fd.HTTPStatus, &fd.Adaptive, &imin, &imax, &aival, &authStr, &pushUntil, &fd.GroupID, &scraperStr, &fd.FullText); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
 		}
 
 		fd.Interval = time.Second * time.Duration(interval)
+		setFeedHealthStamps(fd, failSince, lastSuccess)
+		setFeedIntervals(fd, imin, imax, aival)
+		setFeedPushUntil(fd, pushUntil)
+
+		if err = setFeedAuth(fd, authStr); err != nil {
+			db.log.Printf("[ERROR] Cannot decode credentials of Feed %d: %s\n",
+				fd.ID,
+				err.Error())
+			return nil, err
+		} else if err = setFeedScraper(fd, scraperStr); err != nil {
+			db.log.Printf("[ERROR] Cannot decode selectors of Feed %d: %s\n",
+				fd.ID,
+				err.Error())
+			return nil, err
+		}
 		if stamp != 0 {
 			fd.LastUpdate = time.Unix(stamp, 0)
 		}
@@ -1008,9 +1182,10 @@ EXEC_QUERY:
 	return nil
 } // func (db *Database) FeedSetTimestamp(f *feed.Feed, stamp time.Time) error
 
-// FeedDelete deletes the Feed with the given ID from the database.
-func (db *Database) FeedDelete(id int64) error {
-	const qid = query.FeedDelete
+// FeedSetCacheInfo stores the Feed's ETag and Last-Modified values, so they
+// can be sent along with the next request for the Feed.
+func (db *Database) FeedSetCacheInfo(f *feed.Feed) error {
+	const qid = query.FeedSetCacheInfo
 	var (
 		err    error
 		msg    string
@@ -1062,14 +1237,15 @@ func (db *Database) FeedDelete(id int64) error {
 	)
 
 EXEC_QUERY:
-	if res, err = stmt.Exec(id); err != nil {
+	if res, err = stmt.Exec(f.ETag, f.LastModified, f.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		db.log.Printf("[ERROR] Cannot update timestamp for Feed %d: %s\n",
-			id,
+		db.log.Printf("[ERROR] Cannot update cache info for Feed %s (%d): %s\n",
+			f.Name,
+			f.ID,
 			err.Error())
 		return err
 	} else if cnt, err = res.RowsAffected(); err != nil {
@@ -1085,14 +1261,12 @@ EXEC_QUERY:
 
 	status = true
 	return nil
-} // func (db *Database) FeedDelete(id int64) error
+} // func (db *Database) FeedSetCacheInfo(f *feed.Feed) error
 
-// FeedModify modifies a Feed.
-func (db *Database) FeedModify(
-	f *feed.Feed,
-	name, lnk, homepage string,
-	interval time.Duration) error {
-	const qid query.ID = query.FeedModify
+// FeedSetAuth stores the credentials needed to fetch the Feed. Passing nil
+// or an empty Auth removes them.
+func (db *Database) FeedSetAuth(f *feed.Feed, a *feed.Auth) error {
+	const qid = query.FeedSetAuth
 	var (
 		err    error
 		msg    string
@@ -1101,6 +1275,24 @@ func (db *Database) FeedModify(
 		status bool
 	)
 
+	var authStr string
+
+	if !a.IsEmpty() {
+		var buf []byte
+
+		if buf, err = json.Marshal(a); err != nil {
+			db.log.Printf("[ERROR] Cannot encode credentials for Feed %s (%d): %s\n",
+				f.Name,
+				f.ID,
+				err.Error())
+			return err
+		}
+
+		authStr = string(buf)
+	} else {
+		a = nil
+	}
+
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid.String(),
@@ -1138,37 +1330,45 @@ func (db *Database) FeedModify(
 	}
 
 	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(
-		name,
-		lnk,
-		homepage,
-		interval.Seconds(),
-		f.ID); err != nil {
+	if res, err = stmt.Exec(authStr, f.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		db.log.Printf("[ERROR] Cannot update Feed %s (%d): %s\n",
+		db.log.Printf("[ERROR] Cannot update credentials for Feed %s (%d): %s\n",
 			f.Name,
 			f.ID,
 			err.Error())
 		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
 	}
 
-	f.Name = name
-	f.URL = lnk
-	f.Homepage = homepage
-	f.Interval = interval
+	f.Auth = a
+
 	status = true
 	return nil
-} // func (db *Database) FeedModify(...) error
+} // func (db *Database) FeedSetAuth(f *feed.Feed, a *feed.Auth) error
 
-// ItemAdd adds an Item to the database.
-func (db *Database) ItemAdd(item *feed.Item) error {
-	const qid = query.ItemAdd
+// FeedSetScraper stores the selectors used to extract Items from the Feed's
+// web page. Passing nil or an empty Scraper turns the Feed back into a
+// regular one.
+func (db *Database) FeedSetScraper(f *feed.Feed, sc *feed.Scraper) error {
+	const qid = query.FeedSetScraper
 	var (
 		err    error
 		msg    string
@@ -1177,6 +1377,24 @@ func (db *Database) ItemAdd(item *feed.Item) error {
 		status bool
 	)
 
+	var scraperStr string
+
+	if !sc.IsEmpty() {
+		var buf []byte
+
+		if buf, err = json.Marshal(sc); err != nil {
+			db.log.Printf("[ERROR] Cannot encode selectors for Feed %s (%d): %s\n",
+				f.Name,
+				f.ID,
+				err.Error())
+			return err
+		}
+
+		scraperStr = string(buf)
+	} else {
+		sc = nil
+	}
+
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid.String(),
@@ -1214,114 +1432,305 @@ func (db *Database) ItemAdd(item *feed.Item) error {
 	}
 
 	stmt = tx.Stmt(stmt)
-	var res sql.Result
+	var (
+		cnt int64
+		res sql.Result
+	)
 
 EXEC_QUERY:
-	if res, err = stmt.Exec(item.FeedID, item.URL, item.Title, item.Description, item.Timestamp.Unix()); err != nil {
+	if res, err = stmt.Exec(scraperStr, f.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("Cannot add Item %s (%s) to database: %s",
-				item.Title,
-				item.URL,
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
-		}
-	} else {
-		var itemID int64
-
-		if itemID, err = res.LastInsertId(); err != nil {
-			db.log.Printf("[ERROR] Cannot get ID of new Item %q: %s\n",
-				item.Title,
-				err.Error())
-			return err
 		}
 
-		status = true
-		item.ID = itemID
-		return nil
+		db.log.Printf("[ERROR] Cannot update selectors for Feed %s (%d): %s\n",
+			f.Name,
+			f.ID,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
 	}
-} // func (db *Database) ItemAdd(item *feed.Item) error
 
-// ItemGetRecent returns the <limit> most recent news Items.
-func (db *Database) ItemGetRecent(limit int) ([]feed.Item, error) {
-	const qid = query.ItemGetRecent
+	f.Scraper = sc
+
+	status = true
+	return nil
+} // func (db *Database) FeedSetScraper(f *feed.Feed, sc *feed.Scraper) error
+
+// FeedSetFullText sets the flag that tells us whether to fetch the full
+// content of the Feed's Items from the pages they link to.
+func (db *Database) FeedSetFullText(f *feed.Feed, fullText bool) error {
+	const qid = query.FeedSetFullText
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
+			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
 	}
 
-	var rows *sql.Rows
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(limit); err != nil {
+	if res, err = stmt.Exec(fullText, f.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		return nil, err
+		db.log.Printf("[ERROR] Cannot set full text flag for Feed %s (%d): %s\n",
+			f.Name,
+			f.ID,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
 	}
 
-	defer rows.Close() // nolint: errcheck,gosec
-
-	var items = make([]feed.Item, 0, limit)
+	f.FullText = fullText
 
-	for rows.Next() {
-		var (
-			item   feed.Item
-			rating *float64
-			stamp  int64
-		)
+	status = true
+	return nil
+} // func (db *Database) FeedSetFullText(f *feed.Feed, fullText bool) error
 
-		if err = rows.Scan(
-			&item.ID,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-			return nil, err
-		}
+// FeedSetAdaptive enables or disables adaptive mode for the Feed and sets
+// the bounds for the refresh interval.
+func (db *Database) FeedSetAdaptive(f *feed.Feed, adaptive bool, min, max time.Duration) error {
+	const qid = query.FeedSetAdaptive
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
 
-		if rating != nil {
-			item.Rating = *rating
-			item.ManuallyRated = true
 		} else {
-			item.Rating = math.NaN()
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
 		}
-		item.Timestamp = time.Unix(stamp, 0)
+	}
 
-		items = append(items, item)
+	// The bounds may have changed, so we clamp the interval we computed
+	// earlier right away instead of waiting for the next refresh.
+	var tmp = *f
+	tmp.IntervalMin, tmp.IntervalMax = min, max
+
+	var aival time.Duration
+
+	if f.AdaptiveInterval > 0 {
+		aival = tmp.Clamp(f.AdaptiveInterval)
 	}
 
-	return items, nil
-} // func (db *Database) ItemGetRecent(limit int) ([]feed.Item, error)
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
 
-// ItemGetRated returns all Items that have been rated.
-func (db *Database) ItemGetRated() ([]feed.Item, error) {
-	const qid = query.ItemGetRated
+EXEC_QUERY:
+	if res, err = stmt.Exec(adaptive, int64(min.Seconds()), int64(max.Seconds()), int64(aival.Seconds()), f.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot update adaptive mode for Feed %s (%d): %s\n",
+			f.Name,
+			f.ID,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	f.Adaptive = adaptive
+	f.IntervalMin = min
+	f.IntervalMax = max
+	f.AdaptiveInterval = aival
+	status = true
+	return nil
+} // func (db *Database) FeedSetAdaptive(f *feed.Feed, adaptive bool, min, max time.Duration) error
+
+// FeedSetAdaptiveInterval stores the refresh interval computed for a Feed in
+// adaptive mode.
+func (db *Database) FeedSetAdaptiveInterval(f *feed.Feed, ival time.Duration) error {
+	const qid = query.FeedSetAdaptiveInterval
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(int64(ival.Seconds()), f.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot update adaptive interval for Feed %s (%d): %s\n",
+			f.Name,
+			f.ID,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	f.AdaptiveInterval = ival
+	status = true
+	return nil
+} // func (db *Database) FeedSetAdaptiveInterval(f *feed.Feed, ival time.Duration) error
+
+// FeedGetItemStamps returns the timestamps of the most recent Items of the
+// given Feed, newest first.
+func (db *Database) FeedGetItemStamps(id int64, limit int) ([]time.Time, error) {
+	const qid = query.FeedGetItemStamps
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -1339,7 +1748,7 @@ func (db *Database) ItemGetRated() ([]feed.Item, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(); err != nil {
+	if rows, err = stmt.Query(id, limit); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
@@ -1350,50 +1759,26 @@ EXEC_QUERY:
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var items = make([]feed.Item, 0, 64)
+	var stamps = make([]time.Time, 0, limit)
 
 	for rows.Next() {
-		var (
-			rating *float64
-			stamp  int64
-			item   = feed.Item{ManuallyRated: true}
-		)
+		var stamp int64
 
-		if err = rows.Scan(
-			&item.ID,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
+		if err = rows.Scan(&stamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
 			return nil, err
 		}
 
-		if rating != nil {
-			item.Rating = *rating
-		} else {
-			item.Rating = math.NaN()
-		}
-		item.Timestamp = time.Unix(stamp, 0)
-		items = append(items, item)
+		stamps = append(stamps, time.Unix(stamp, 0))
 	}
 
-	return items, nil
-} // func (db *Database) ItemGetRated(limit int) ([]feed.Item, error)
+	return stamps, nil
+} // func (db *Database) FeedGetItemStamps(id int64, limit int) ([]time.Time, error)
 
-// ItemGetByID fetches an Item by its ID.
-func (db *Database) ItemGetByID(id int64) (*feed.Item, error) {
-	const qid = query.ItemGetByID
+// FeedGetUnread returns the number of unread Items in each Feed. Feeds
+// without unread Items are missing from the map.
+func (db *Database) FeedGetUnread() (map[int64]int64, error) {
+	const qid = query.FeedGetUnread
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -1411,58 +1796,78 @@ func (db *Database) ItemGetByID(id int64) (*feed.Item, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(id); err != nil {
+	if rows, err = stmt.Query(); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
+		db.log.Printf("[ERROR] Cannot count unread Items per Feed: %s\n",
+			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	if rows.Next() {
-		var (
-			stamp  int64
-			rating *float64
-			item   = &feed.Item{ID: id}
-		)
+	var counts = make(map[int64]int64)
 
-		if err = rows.Scan(
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
-			db.log.Printf("[ERROR] Cannot scan Row for Item %d: %s\n",
-				id,
-				err.Error())
-			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
+	for rows.Next() {
+		var id, cnt int64
+
+		if err = rows.Scan(&id, &cnt); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
-		} else if rating != nil {
-			item.ManuallyRated = true
-			item.Rating = *rating
 		}
 
-		item.Timestamp = time.Unix(stamp, 0)
+		counts[id] = cnt
+	}
 
-		return item, nil
+	return counts, nil
+} // func (db *Database) FeedGetUnread() (map[int64]int64, error)
+
+// FeedGetStatsAll returns a summary of the statistics of each Feed, without
+// the number of Items over time and the Tags.
+func (db *Database) FeedGetStatsAll() (map[int64]*feed.Stats, error) {
+	return db.feedStatsSummary(0)
+} // func (db *Database) FeedGetStatsAll() (map[int64]*feed.Stats, error)
+
+// FeedGetStats returns the statistics of the Feed with the given ID, or of
+// all Feeds combined if feedID is 0. The number of Items is counted for
+// each of the last days days and the last weeks weeks, including periods
+// without any Items. The list of Tags is limited to the tagCnt most common
+// ones.
+func (db *Database) FeedGetStats(feedID int64, days, weeks, tagCnt int) (*feed.Stats, error) {
+	var (
+		err     error
+		summary map[int64]*feed.Stats
+		stats   = &feed.Stats{FeedID: feedID}
+		now     = time.Now()
+		today   = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		monday  = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	)
+
+	if summary, err = db.feedStatsSummary(feedID); err != nil {
+		return nil, err
 	}
 
-	return nil, nil
-} // func (db *Database) ItemGetByID(id int64) (*feed.Item, error)
+	for _, s := range summary {
+		stats.Add(s)
+	}
 
-// ItemGetByURL fetches an Item by its URL.
-func (db *Database) ItemGetByURL(uri string) (*feed.Item, error) {
-	const qid = query.ItemGetByURL
+	if stats.PerDay, err = db.feedStatsCounts(query.FeedStatsPerDay, feedID, today.AddDate(0, 0, 1-days), days, 1); err != nil {
+		return nil, err
+	} else if stats.PerWeek, err = db.feedStatsCounts(query.FeedStatsPerWeek, feedID, monday.AddDate(0, 0, 7*(1-weeks)), weeks, 7); err != nil {
+		return nil, err
+	} else if stats.Tags, err = db.feedStatsTags(feedID, tagCnt); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+} // func (db *Database) FeedGetStats(feedID int64, days, weeks, tagCnt int) (*feed.Stats, error)
+
+func (db *Database) feedStatsSummary(feedID int64) (map[int64]*feed.Stats, error) {
+	const qid = query.FeedStatsSummary
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -1480,64 +1885,76 @@ func (db *Database) ItemGetByURL(uri string) (*feed.Item, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(uri); err != nil {
+	if rows, err = stmt.Query(feedID, feedID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
+		db.log.Printf("[ERROR] Cannot query statistics of Feed %d: %s\n",
+			feedID,
+			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	if rows.Next() {
+	var stats = make(map[int64]*feed.Stats)
+
+	for rows.Next() {
 		var (
-			stamp  int64
-			rating *float64
-			item   = &feed.Item{URL: uri}
+			s                    = new(feed.Stats)
+			oldest, newest, succ int64
 		)
 
 		if err = rows.Scan(
-			&item.ID,
-			&item.FeedID,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
-			db.log.Printf("[ERROR] Cannot scan Row for Item %s: %s\n",
-				uri,
-				err.Error())
-			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
+			&s.FeedID,
+			&s.ItemCnt,
+			&s.UnreadCnt,
+			&s.Interesting,
+			&s.Boring,
+			&s.TextLength,
+			&oldest,
+			&newest,
+			&s.FetchCnt,
+			&s.ErrorCnt,
+			&succ); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
-		} else if rating != nil {
-			item.ManuallyRated = true
-			item.Rating = *rating
 		}
 
-		item.Timestamp = time.Unix(stamp, 0)
+		if s.ItemCnt > 0 {
+			s.Oldest = time.Unix(oldest, 0)
+			s.Newest = time.Unix(newest, 0)
+		}
 
-		return item, nil
+		if succ != 0 {
+			s.LastSuccess = time.Unix(succ, 0)
+		}
+
+		stats[s.FeedID] = s
 	}
 
-	return nil, nil
-} // func (db *Database) ItemGetByURL(uri string) (*feed.Item, error)
+	return stats, nil
+} // func (db *Database) feedStatsSummary(feedID int64) (map[int64]*feed.Stats, error)
 
-// ItemGetByFeed fetches the <limit> most recent Items belonging to the
-// given <feedID>.
-func (db *Database) ItemGetByFeed(feedID, limit int64) ([]feed.Item, error) {
-	const qid query.ID = query.ItemGetByFeed
+// feedStatsCounts counts the Items in cnt consecutive periods of step days,
+// beginning at start.
+func (db *Database) feedStatsCounts(qid query.ID, feedID int64, start time.Time, cnt, step int) ([]feed.Count, error) {
+	const dateFmt = "2006-01-02"
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		stmt   *sql.Stmt
+		counts = make([]feed.Count, cnt)
+		index  = make(map[string]int, cnt)
 	)
 
+	for idx := range counts {
+		counts[idx].Start = start.AddDate(0, 0, idx*step)
+		index[counts[idx].Start.Format(dateFmt)] = idx
+	}
+
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid,
@@ -1550,68 +1967,40 @@ func (db *Database) ItemGetByFeed(feedID, limit int64) ([]feed.Item, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(feedID, limit); err != nil {
+	if rows, err = stmt.Query(feedID, feedID, start.Unix()); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
+		db.log.Printf("[ERROR] Cannot count Items of Feed %d over time: %s\n",
+			feedID,
+			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var allocSize int
-	if limit > 0 {
-		allocSize = int(limit)
-	}
-
-	var items = make([]feed.Item, 0, allocSize)
-
 	for rows.Next() {
 		var (
-			item   = feed.Item{FeedID: feedID}
-			rating *float64
-			stamp  int64
+			period string
+			n      int64
 		)
 
-		if err = rows.Scan(
-			&item.ID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
+		if err = rows.Scan(&period, &n); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-			return nil, err
-		}
-
-		if rating != nil {
-			item.ManuallyRated = true
-			item.Rating = *rating
-		} else {
-			item.Rating = math.NaN()
+		} else if idx, ok := index[period]; ok {
+			counts[idx].Cnt = n
 		}
-		item.Timestamp = time.Unix(stamp, 0)
-		items = append(items, item)
 	}
 
-	return items, nil
-} // func (db *Database) ItemGetByFeed(feedID, limit int64) ([]feed.Item, error)
+	return counts, nil
+} // func (db *Database) feedStatsCounts(qid query.ID, feedID int64, start time.Time, cnt, step int) ([]feed.Count, error)
 
-// ItemGetAll fetches items from all feeds, ordered by their timestamps in
-// descending order, skipping the first <offset> items, returning the next
-// <cnt> items.
-func (db *Database) ItemGetAll(cnt, offset int64) ([]feed.Item, error) {
-	const qid query.ID = query.ItemGetAll
+func (db *Database) feedStatsTags(feedID int64, cnt int) ([]feed.TagCount, error) {
+	const qid = query.FeedStatsTags
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -1629,234 +2018,5844 @@ func (db *Database) ItemGetAll(cnt, offset int64) ([]feed.Item, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(cnt, offset); err != nil {
+	if rows, err = stmt.Query(feedID, feedID, cnt); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
+		db.log.Printf("[ERROR] Cannot count Tags of Feed %d: %s\n",
+			feedID,
+			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var items []feed.Item
-
-	if cnt > 0 {
-		items = make([]feed.Item, 0, cnt)
-	} else {
-		items = make([]feed.Item, 0)
-	}
+	var tags = make([]feed.TagCount, 0, cnt)
 
 	for rows.Next() {
-		var (
-			item   feed.Item
-			rating *float64
-			stamp  int64
-		)
+		var t feed.TagCount
 
-		if err = rows.Scan(
-			&item.ID,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
+		if err = rows.Scan(&t.TagID, &t.Name, &t.Cnt); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-			return nil, err
-		} else if rating != nil {
-			item.ManuallyRated = true
-			item.Rating = *rating
-		} else {
-			item.Rating = math.NaN()
 		}
-		item.Timestamp = time.Unix(stamp, 0)
-		items = append(items, item)
+
+		tags = append(tags, t)
 	}
 
-	return items, nil
-} // func (db *Database) ItemGetAll(cnt, offset int64) ([]feed.Item, error)
+	return tags, nil
+} // func (db *Database) feedStatsTags(feedID int64, cnt int) ([]feed.TagCount, error)
 
-// ItemGetFTS retrieves Items that match a full-text search query.
-func (db *Database) ItemGetFTS(fts string) ([]feed.Item, error) {
-	const qid query.ID = query.ItemGetFTS
+// FeedSetSuccess records that the Feed has been refreshed successfully,
+// which resets its failure count.
+func (db *Database) FeedSetSuccess(f *feed.Feed, stamp time.Time) error {
+	const qid = query.FeedSetSuccess
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
+			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
 	}
 
-	var rows *sql.Rows
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(fts); err != nil {
+	if res, err = stmt.Exec(stamp.Unix(), f.HTTPStatus, f.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	var items = make([]feed.Item, 0, 32)
-
-	for rows.Next() {
-		var (
-			item   feed.Item
-			rating *float64
-			stamp  int64
-		)
-
-		if err = rows.Scan(
-			&item.ID,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-			return nil, err
-		}
-
-		if rating != nil {
-			item.ManuallyRated = true
-			item.Rating = *rating
-		} else {
-			item.Rating = math.NaN()
-		}
-		item.Timestamp = time.Unix(stamp, 0)
-		items = append(items, item)
+		db.log.Printf("[ERROR] Cannot record success for Feed %s (%d): %s\n",
+			f.Name,
+			f.ID,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
 	}
 
-	return items, nil
-} // func (db *Database) ItemGetFTS(fts string) ([]feed.Item, error)
+	f.FailCount = 0
+	f.FailSince = time.Time{}
+	f.LastError = ""
+	f.LastSuccess = stamp
+	status = true
+	return nil
+} // func (db *Database) FeedSetSuccess(f *feed.Feed, stamp time.Time) error
 
-// ItemGetSearchExtended performs an extended search on the database,
-// retrieving Items by a search string and a list of tags.
-func (db *Database) ItemGetSearchExtended(qstr string, tags []int64, begin, end time.Time) ([]feed.Item, error) {
-	const qid query.ID = query.ItemGetSearchExtended
+// FeedSetFailure records a failed attempt to refresh the Feed. Consecutive
+// failures cause the Feed to be checked less and less frequently.
+func (db *Database) FeedSetFailure(f *feed.Feed, ferr error, stamp time.Time) error {
+	const qid = query.FeedSetFailure
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
+			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
 	}
 
-	var rows *sql.Rows
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(qstr, begin.Unix(), end.Unix()); err != nil {
+	if res, err = stmt.Exec(stamp.Unix(), ferr.Error(), f.HTTPStatus, f.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	var items = make([]feed.Item, 0, 32)
-	var tmap = make(map[int64]bool, len(tags))
+		db.log.Printf("[ERROR] Cannot record failure for Feed %s (%d): %s\n",
+			f.Name,
+			f.ID,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	if f.FailCount == 0 {
+		f.FailSince = stamp
+	}
+
+	f.FailCount++
+	f.LastError = ferr.Error()
+	status = true
+	return nil
+} // func (db *Database) FeedSetFailure(f *feed.Feed, ferr error, stamp time.Time) error
+
+// FeedDelete deletes the Feed with the given ID from the database.
+func (db *Database) FeedDelete(id int64) error {
+	const qid = query.FeedDelete
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot update timestamp for Feed %d: %s\n",
+			id,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) FeedDelete(id int64) error
+
+// FeedModify modifies a Feed. If the Feed's URL changes, the old URL is
+// recorded in the Feed's URL history, so we still recognize it.
+func (db *Database) FeedModify(
+	f *feed.Feed,
+	name, lnk, homepage string,
+	interval time.Duration) error {
+	const qid query.ID = query.FeedModify
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		hstmt  *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if hstmt, err = db.getQuery(query.FeedURLHistoryAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.FeedURLHistoryAdd.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(
+		name,
+		lnk,
+		homepage,
+		interval.Seconds(),
+		f.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot update Feed %s (%d): %s\n",
+			f.Name,
+			f.ID,
+			err.Error())
+		return err
+	}
+
+	if lnk != f.URL {
+		hstmt = tx.Stmt(hstmt)
+
+	EXEC_HISTORY:
+		if _, err = hstmt.Exec(f.ID, f.URL, time.Now().Unix()); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_HISTORY
+			}
+
+			db.log.Printf("[ERROR] Cannot record old URL of Feed %s (%d): %s\n",
+				f.Name,
+				f.ID,
+				err.Error())
+			return err
+		}
+	}
+
+	f.Name = name
+	f.URL = lnk
+	f.Homepage = homepage
+	f.Interval = interval
+	status = true
+	return nil
+} // func (db *Database) FeedModify(...) error
+
+// FeedGetByURL looks up the Feed with the given URL. Feeds that have moved
+// are found by their old URLs, too. If there is no such Feed, FeedGetByURL
+// returns nil.
+func (db *Database) FeedGetByURL(u string) (*feed.Feed, error) {
+	const qid = query.FeedGetByURL
+	var (
+		err  error
+		id   int64
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(u, u); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if !rows.Next() {
+		return nil, nil
+	} else if err = rows.Scan(&id); err != nil {
+		db.log.Printf("[ERROR] Cannot scan row: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	rows.Close() // nolint: errcheck,gosec
+
+	return db.FeedGetByID(id)
+} // func (db *Database) FeedGetByURL(u string) (*feed.Feed, error)
+
+// FeedURLHistoryGet returns the URLs the given Feed used to have, the most
+// recent first.
+func (db *Database) FeedURLHistoryGet(feedID int64) ([]feed.Move, error) {
+	const qid = query.FeedURLHistoryGet
+	var (
+		err   error
+		stmt  *sql.Stmt
+		moves []feed.Move
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(feedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	for rows.Next() {
+		var (
+			stamp int64
+			m     feed.Move
+		)
+
+		if err = rows.Scan(&m.URL, &stamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		m.Moved = time.Unix(stamp, 0)
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+} // func (db *Database) FeedURLHistoryGet(feedID int64) ([]feed.Move, error)
+
+// FeedSetGroup puts the given Feed in the given Group. A Group ID of 0
+// removes the Feed from its Group.
+func (db *Database) FeedSetGroup(feedID, groupID int64) error {
+	const qid = query.FeedSetGroup
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+	var group *int64
+
+	if groupID != 0 {
+		group = &groupID
+	}
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(group, feedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot put Feed %d in Group %d: %s\n",
+			feedID,
+			groupID,
+			err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) FeedSetGroup(feedID, groupID int64) error
+
+// GroupAdd creates a new Group. A parent ID of 0 creates a top-level Group.
+func (db *Database) GroupAdd(name string, parentID int64) (*feed.Group, error) {
+	const qid = query.GroupAdd
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return nil, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+	var parent *int64
+
+	if parentID != 0 {
+		parent = &parentID
+	}
+
+	var res sql.Result
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(name, parent); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		err = fmt.Errorf("Cannot add Group %q to database: %s",
+			name,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return nil, err
+	}
+
+	var g = &feed.Group{
+		Name:   name,
+		Parent: parentID,
+	}
+
+	if g.ID, err = res.LastInsertId(); err != nil {
+		db.log.Printf("[ERROR] Cannot get ID of new Group %q: %s\n",
+			name,
+			err.Error())
+		return nil, err
+	}
+
+	status = true
+	return g, nil
+} // func (db *Database) GroupAdd(name string, parentID int64) (*feed.Group, error)
+
+// GroupDelete removes the Group with the given ID. The Feeds in the Group
+// are not deleted, they just no longer belong to any Group. A Group that
+// still contains other Groups cannot be deleted.
+func (db *Database) GroupDelete(id int64) error {
+	const qid = query.GroupDelete
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot delete Group %d: %s\n",
+			id,
+			err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) GroupDelete(id int64) error
+
+// GroupRename gives the Group with the given ID a new name.
+func (db *Database) GroupRename(id int64, name string) error {
+	const qid = query.GroupRename
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(name, id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot rename Group %d to %q: %s\n",
+			id,
+			name,
+			err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) GroupRename(id int64, name string) error
+
+// GroupSetParent moves the Group with the given ID into another Group. A
+// parent ID of 0 makes it a top-level Group. The caller is responsible for
+// not creating cycles.
+func (db *Database) GroupSetParent(id, parentID int64) error {
+	const qid = query.GroupSetParent
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+	var parent *int64
+
+	if parentID != 0 {
+		parent = &parentID
+	}
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(parent, id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot move Group %d into Group %d: %s\n",
+			id,
+			parentID,
+			err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) GroupSetParent(id, parentID int64) error
+
+// GroupSetActive activates or deactivates all Feeds in the given Group and
+// the Groups below it.
+func (db *Database) GroupSetActive(id int64, active bool) error {
+	const qid = query.GroupSetActive
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(id, active); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot set active flag of Feeds in Group %d: %s\n",
+			id,
+			err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) GroupSetActive(id int64, active bool) error
+
+// GroupSetInterval sets the refresh interval of all Feeds in the given Group
+// and the Groups below it.
+func (db *Database) GroupSetInterval(id int64, interval time.Duration) error {
+	const qid = query.GroupSetInterval
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(id, int64(interval.Seconds())); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot set refresh interval of Feeds in Group %d: %s\n",
+			id,
+			err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) GroupSetInterval(id int64, interval time.Duration) error
+
+// GroupGetAll returns all Groups, ordered by hierarchy.
+func (db *Database) GroupGetAll() ([]feed.Group, error) {
+	const qid = query.GroupGetAll
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Groups: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var groups []feed.Group
+
+	for rows.Next() {
+		var g feed.Group
+
+		if err = rows.Scan(&g.ID, &g.Name, &g.Parent, &g.Level, &g.Path); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+} // func (db *Database) GroupGetAll() ([]feed.Group, error)
+
+// GroupGetUnread returns the number of unread Items in each Group,
+// including the Groups below it. Groups without unread Items are missing
+// from the map.
+func (db *Database) GroupGetUnread() (map[int64]int64, error) {
+	const qid = query.GroupGetUnread
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot count unread Items per Group: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var counts = make(map[int64]int64)
+
+	for rows.Next() {
+		var id, cnt int64
+
+		if err = rows.Scan(&id, &cnt); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		counts[id] = cnt
+	}
+
+	return counts, nil
+} // func (db *Database) GroupGetUnread() (map[int64]int64, error)
+
+// WebSubAdd stores a WebSub Subscription. If there already is a
+// Subscription for the same Feed, it is replaced.
+func (db *Database) WebSubAdd(s *websub.Subscription) error {
+	const qid = query.WebSubAdd
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	var expires, requested int64
+
+	if !s.Expires.IsZero() {
+		expires = s.Expires.Unix()
+	}
+
+	if !s.Requested.IsZero() {
+		requested = s.Requested.Unix()
+	}
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(s.FeedID, s.Hub, s.Topic, s.Secret, s.State, int64(s.Lease.Seconds()), expires, requested); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot store WebSub Subscription for Feed %d at %s: %s\n",
+			s.FeedID,
+			s.Hub,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) WebSubAdd(s *websub.Subscription) error
+
+// WebSubGetByFeed returns the WebSub Subscription for the given Feed, if
+// there is one.
+func (db *Database) WebSubGetByFeed(feedID int64) (*websub.Subscription, error) {
+	const qid = query.WebSubGetByFeed
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(feedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var (
+			s                         = &websub.Subscription{FeedID: feedID}
+			lease, expires, requested int64
+		)
+
+		if err = rows.Scan(&s.Hub, &s.Topic, &s.Secret, &s.State, &lease, &expires, &requested); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		setSubscriptionStamps(s, lease, expires, requested)
+
+		return s, nil
+	}
+
+	return nil, nil
+} // func (db *Database) WebSubGetByFeed(feedID int64) (*websub.Subscription, error)
+
+// WebSubGetExpiring returns all Active Subscriptions whose lease expires
+// within websub.RenewBefore and that we have not tried to renew within
+// websub.RetryDelay.
+func (db *Database) WebSubGetExpiring() ([]websub.Subscription, error) {
+	const qid = query.WebSubGetExpiring
+	var (
+		err  error
+		stmt *sql.Stmt
+		now  = time.Now()
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(now.Add(websub.RenewBefore).Unix(), now.Add(-websub.RetryDelay).Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var list = make([]websub.Subscription, 0)
+
+	for rows.Next() {
+		var (
+			s                         websub.Subscription
+			lease, expires, requested int64
+		)
+
+		if err = rows.Scan(&s.FeedID, &s.Hub, &s.Topic, &s.Secret, &s.State, &lease, &expires, &requested); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
+			return nil, err
+		}
+
+		setSubscriptionStamps(&s, lease, expires, requested)
+		list = append(list, s)
+	}
+
+	return list, nil
+} // func (db *Database) WebSubGetExpiring() ([]websub.Subscription, error)
+// WebSubSetState updates the state of a Subscription after the hub has
+// verified or denied it. For an Active Subscription, the lease starts now.
+func (db *Database) WebSubSetState(s *websub.Subscription, state websub.State, lease time.Duration) error {
+	const qid = query.WebSubSetState
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	var expires time.Time
+
+	if state == websub.Active {
+		expires = time.Now().Add(lease)
+	} else {
+		expires = time.Unix(0, 0)
+	}
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(state, int64(lease.Seconds()), expires.Unix(), s.FeedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot set state of WebSub Subscription for Feed %d to %s: %s\n",
+			s.FeedID,
+			state,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	s.State = state
+	s.Lease = lease
+
+	if state == websub.Active {
+		s.Expires = expires
+	} else {
+		s.Expires = time.Time{}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) WebSubSetState(s *websub.Subscription, state websub.State, lease time.Duration) error
+// WebSubSetRequested records when we last sent a request for the
+// Subscription to the hub.
+func (db *Database) WebSubSetRequested(s *websub.Subscription, stamp time.Time) error {
+	const qid = query.WebSubSetRequested
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(stamp.Unix(), s.FeedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot set request timestamp of WebSub Subscription for Feed %d: %s\n",
+			s.FeedID,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	s.Requested = stamp
+
+	status = true
+	return nil
+} // func (db *Database) WebSubSetRequested(s *websub.Subscription, stamp time.Time) error
+// WebSubDelete removes the WebSub Subscription for the given Feed.
+func (db *Database) WebSubDelete(feedID int64) error {
+	const qid = query.WebSubDelete
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(feedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot delete WebSub Subscription for Feed %d: %s\n",
+			feedID,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) WebSubDelete(feedID int64) error
+
+// ItemAdd adds an Item to the database.
+func (db *Database) ItemAdd(item *feed.Item) error {
+	const qid = query.ItemAdd
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var res sql.Result
+
+	if item.Fingerprint == 0 {
+		item.Fingerprint = simhash.Hash(item.Plaintext())
+	}
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(
+		item.FeedID,
+		item.URL,
+		item.Title,
+		item.Description,
+		item.Timestamp.Unix(),
+		item.Author,
+		item.AuthorURI,
+		item.GUID,
+		item.ContentHash(),
+		int64(item.Fingerprint),
+		item.OriginalURL); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot add Item %s (%s) to database: %s",
+				item.Title,
+				item.URL,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	} else {
+		var itemID int64
+
+		if itemID, err = res.LastInsertId(); err != nil {
+			db.log.Printf("[ERROR] Cannot get ID of new Item %q: %s\n",
+				item.Title,
+				err.Error())
+			return err
+		}
+
+		item.ID = itemID
+
+		if err = db.enclosureAdd(tx, item); err != nil {
+			return err
+		}
+
+		if err = db.categoryAdd(tx, item); err != nil {
+			return err
+		} else if err = db.duplicateLink(tx, item); err != nil {
+			return err
+		}
+
+		status = true
+		return nil
+	}
+} // func (db *Database) ItemAdd(item *feed.Item) error
+
+// ItemGetRecent returns the <limit> most recent news Items.
+func (db *Database) ItemGetRecent(limit int) ([]feed.Item, error) {
+	const qid = query.ItemGetRecent
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(limit); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, limit)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if rating != nil {
+			item.Rating = *rating
+			item.ManuallyRated = true
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetRecent(limit int) ([]feed.Item, error)
+
+// ItemGetRated returns all Items that have been rated.
+func (db *Database) ItemGetRated() ([]feed.Item, error) {
+	const qid = query.ItemGetRated
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, 64)
+
+	for rows.Next() {
+		var (
+			rating  *float64
+			stamp   int64
+			updated int64
+			item    = feed.Item{ManuallyRated: true}
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if rating != nil {
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetRated(limit int) ([]feed.Item, error)
+
+// ItemGetByID fetches an Item by its ID.
+func (db *Database) ItemGetByID(id int64) (*feed.Item, error) {
+	const qid = query.ItemGetByID
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var (
+			stamp   int64
+			updated int64
+			rating  *float64
+			item    = &feed.Item{ID: id}
+		)
+
+		if err = rows.Scan(
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row for Item %d: %s\n",
+				id,
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		}
+
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+
+		return item, nil
+	}
+
+	return nil, nil
+} // func (db *Database) ItemGetByID(id int64) (*feed.Item, error)
+
+// ItemGetByURL fetches an Item by its URL.
+func (db *Database) ItemGetByURL(uri string) (*feed.Item, error) {
+	const qid = query.ItemGetByURL
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(uri); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var (
+			stamp   int64
+			updated int64
+			rating  *float64
+			item    = &feed.Item{URL: uri}
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row for Item %s: %s\n",
+				uri,
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		}
+
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+
+		return item, nil
+	}
+
+	return nil, nil
+} // func (db *Database) ItemGetByURL(uri string) (*feed.Item, error)
+
+// ItemGetByFeed fetches the <limit> most recent Items belonging to the
+// given <feedID>.
+func (db *Database) ItemGetByFeed(feedID, limit int64) ([]feed.Item, error) {
+	return db.itemGetByFeed(query.ItemGetByFeed, feedID, limit)
+} // func (db *Database) ItemGetByFeed(feedID, limit int64) ([]feed.Item, error)
+
+// ItemGetByFeedUnread fetches the <limit> most recent unread Items belonging
+// to the given <feedID>.
+func (db *Database) ItemGetByFeedUnread(feedID, limit int64) ([]feed.Item, error) {
+	return db.itemGetByFeed(query.ItemGetByFeedUnread, feedID, limit)
+} // func (db *Database) ItemGetByFeedUnread(feedID, limit int64) ([]feed.Item, error)
+
+func (db *Database) itemGetByFeed(qid query.ID, feedID, limit int64) ([]feed.Item, error) {
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(feedID, limit); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var allocSize int
+	if limit > 0 {
+		allocSize = int(limit)
+	}
+
+	var items = make([]feed.Item, 0, allocSize)
+
+	for rows.Next() {
+		var (
+			item    = feed.Item{FeedID: feedID}
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) itemGetByFeed(qid query.ID, feedID, limit int64) ([]feed.Item, error)
+
+// ItemGetAll fetches items from all feeds, ordered by their timestamps in
+// descending order, skipping the first <offset> items, returning the next
+// <cnt> items.
+func (db *Database) ItemGetAll(cnt, offset int64) ([]feed.Item, error) {
+	const qid query.ID = query.ItemGetAll
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(cnt, offset); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items []feed.Item
+
+	if cnt > 0 {
+		items = make([]feed.Item, 0, cnt)
+	} else {
+		items = make([]feed.Item, 0)
+	}
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetAll(cnt, offset int64) ([]feed.Item, error)
+
+// ItemGetFTS retrieves Items that match a full-text search query.
+func (db *Database) ItemGetFTS(fts string) ([]feed.Item, error) {
+	const qid query.ID = query.ItemGetFTS
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(fts); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, 32)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetFTS(fts string) ([]feed.Item, error)
+
+// ItemGetSearchExtended performs an extended search on the database,
+// retrieving Items by a search string and a list of tags.
+func (db *Database) ItemGetSearchExtended(qstr string, tags []int64, begin, end time.Time) ([]feed.Item, error) {
+	const qid query.ID = query.ItemGetSearchExtended
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(qstr, begin.Unix(), end.Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, 32)
+	var tmap = make(map[int64]bool, len(tags))
+
+	for _, tid := range tags {
+		tmap[tid] = true
+	}
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		}
+
+		var isTagged bool
+
+		if len(tags) == 0 {
+			isTagged = true
+		} else {
+			for _, t := range item.Tags {
+				if tmap[t.ID] {
+					isTagged = true
+				}
+			}
+		}
+
+		if !isTagged {
+			continue
+		} else if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetSearchExtended(qstr string, tags []int64) ([]feed.Item, error)
+
+// ItemGetByTag fetches all Items the given Tag is attached to.
+//
+// Currently, this does not take the Tag hierarchy into account.
+func (db *Database) ItemGetByTag(t *tag.Tag) ([]feed.Item, error) {
+	return db.itemGetByTag(query.ItemGetByTag, t)
+} // func (db *Database) ItemGetByTag(t *tag.Tag) ([]feed.Item, error)
+
+// ItemGetByTagUnread fetches all unread Items the given Tag is attached to.
+func (db *Database) ItemGetByTagUnread(t *tag.Tag) ([]feed.Item, error) {
+	return db.itemGetByTag(query.ItemGetByTagUnread, t)
+} // func (db *Database) ItemGetByTagUnread(t *tag.Tag) ([]feed.Item, error)
+
+func (db *Database) itemGetByTag(qid query.ID, t *tag.Tag) ([]feed.Item, error) {
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(t.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, 32)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) itemGetByTag(qid query.ID, t *tag.Tag) ([]feed.Item, error)
+
+// ItemGetByTagRecursive returns all Items marked with the given Tag or any
+// of its children (recursively, obviously).
+func (db *Database) ItemGetByTagRecursive(t *tag.Tag) ([]feed.Item, error) {
+	return db.itemGetByTagRecursive(query.ItemGetByTagRecursive, t)
+} // func (db *Database) ItemGetByTagRecursive(t *tag.Tag) ([]feed.Item, error)
+
+// ItemGetByTagRecursiveUnread returns all unread Items marked with the given
+// Tag or any of its children.
+func (db *Database) ItemGetByTagRecursiveUnread(t *tag.Tag) ([]feed.Item, error) {
+	return db.itemGetByTagRecursive(query.ItemGetByTagRecursiveUnread, t)
+} // func (db *Database) ItemGetByTagRecursiveUnread(t *tag.Tag) ([]feed.Item, error)
+
+func (db *Database) itemGetByTagRecursive(qid query.ID, t *tag.Tag) ([]feed.Item, error) {
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(t.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, 64)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) itemGetByTagRecursive(qid query.ID, t *tag.Tag) ([]feed.Item, error)
+
+// ItemGetByAuthor returns all Items whose author contains the given string,
+// ignoring case.
+func (db *Database) ItemGetByAuthor(author string) ([]feed.Item, error) {
+	const qid query.ID = query.ItemGetByAuthor
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(author); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Items by author %q: %s\n",
+			author,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, 64)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetByAuthor(author string) ([]feed.Item, error)
+
+// ItemGetByCategory returns all Items the Feed placed in the given
+// category, ignoring case.
+func (db *Database) ItemGetByCategory(category string) ([]feed.Item, error) {
+	const qid query.ID = query.ItemGetByCategory
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(category); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Items in category %q: %s\n",
+			category,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, 64)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetByCategory(category string) ([]feed.Item, error)
+
+// ItemGetByGroup returns the most recent Items from the Feeds in the given
+// Group and the Groups below it.
+func (db *Database) ItemGetByGroup(groupID int64, limit int) ([]feed.Item, error) {
+	return db.itemGetByGroup(query.ItemGetByGroup, groupID, limit)
+} // func (db *Database) ItemGetByGroup(groupID int64, limit int) ([]feed.Item, error)
+
+// ItemGetByGroupUnread returns the most recent unread Items from the Feeds in
+// the given Group and the Groups below it.
+func (db *Database) ItemGetByGroupUnread(groupID int64, limit int) ([]feed.Item, error) {
+	return db.itemGetByGroup(query.ItemGetByGroupUnread, groupID, limit)
+} // func (db *Database) ItemGetByGroupUnread(groupID int64, limit int) ([]feed.Item, error)
+
+func (db *Database) itemGetByGroup(qid query.ID, groupID int64, limit int) ([]feed.Item, error) {
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(groupID, limit); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Items in Group %d: %s\n",
+			groupID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, limit)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) itemGetByGroup(qid query.ID, groupID int64, limit int) ([]feed.Item, error)
+
+// ItemGetTotalCnt returns the total number of items in the database.
+func (db *Database) ItemGetTotalCnt() (int64, error) {
+	const qid query.ID = query.ItemGetTotalCnt
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return 0, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var cnt int64
+		if err = rows.Scan(&cnt); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
+			return 0, err
+		}
+
+		return cnt, nil
+	}
+
+	db.log.Printf("[CANTHAPPEN] Query %s returned 0 rows\n",
+		qid)
+	return 0, nil
+} // func (db *Database) ItemGetTotalCnt() (int64, error)
+
+// ItemGetUnreadCnt returns the number of unread Items in the database.
+func (db *Database) ItemGetUnreadCnt() (int64, error) {
+	const qid query.ID = query.ItemGetUnreadCnt
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return 0, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var cnt int64
+		if err = rows.Scan(&cnt); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
+			return 0, err
+		}
+
+		return cnt, nil
+	}
+
+	db.log.Printf("[CANTHAPPEN] Query %s returned 0 rows\n",
+		qid)
+	return 0, nil
+} // func (db *Database) ItemGetUnreadCnt() (int64, error)
+
+// ItemGetUnreadCntByFeed returns the number of unread Items in the given Feed.
+func (db *Database) ItemGetUnreadCntByFeed(feedID int64) (int64, error) {
+	return db.itemCount(query.ItemGetUnreadCntByFeed, feedID)
+} // func (db *Database) ItemGetUnreadCntByFeed(feedID int64) (int64, error)
+
+// ItemGetUnreadCntByTag returns the number of unread Items the given Tag is
+// attached to.
+func (db *Database) ItemGetUnreadCntByTag(t *tag.Tag) (int64, error) {
+	return db.itemCount(query.ItemGetUnreadCntByTag, t.ID)
+} // func (db *Database) ItemGetUnreadCntByTag(t *tag.Tag) (int64, error)
+
+// ItemGetUnreadCntByTagRecursive returns the number of unread Items marked
+// with the given Tag or any of its children.
+func (db *Database) ItemGetUnreadCntByTagRecursive(t *tag.Tag) (int64, error) {
+	return db.itemCount(query.ItemGetUnreadCntByTagRecursive, t.ID)
+} // func (db *Database) ItemGetUnreadCntByTagRecursive(t *tag.Tag) (int64, error)
+
+// itemCount runs a query that counts Items and takes a single ID as its
+// parameter.
+func (db *Database) itemCount(qid query.ID, id int64) (int64, error) {
+	var (
+		err  error
+		stmt *sql.Stmt
+		cnt  int64
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+EXEC_QUERY:
+	if err = stmt.QueryRow(id).Scan(&cnt); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot count Items with query %s for %d: %s\n",
+			qid,
+			id,
+			err.Error())
+		return 0, err
+	}
+
+	return cnt, nil
+} // func (db *Database) itemCount(qid query.ID, id int64) (int64, error)
+
+// ItemGetRecentUnread returns the <limit> most recent unread Items.
+func (db *Database) ItemGetRecentUnread(limit int) ([]feed.Item, error) {
+	return db.itemGet(query.ItemGetRecentUnread, limit)
+} // func (db *Database) ItemGetRecentUnread(limit int) ([]feed.Item, error)
+
+// ItemGetAllUnread returns unread Items in descending order of their
+// timestamps, skipping the first <offset> Items, returning the next <cnt>
+// Items.
+func (db *Database) ItemGetAllUnread(cnt, offset int64) ([]feed.Item, error) {
+	return db.itemGet(query.ItemGetAllUnread, cnt, offset)
+} // func (db *Database) ItemGetAllUnread(cnt, offset int64) ([]feed.Item, error)
+
+// itemGet runs a query that returns Items and loads their Tags, Enclosures
+// and Categories.
+func (db *Database) itemGet(qid query.ID, args ...interface{}) ([]feed.Item, error) {
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(args...); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) itemGet(qid query.ID, args ...interface{}) ([]feed.Item, error)
+
+// ItemGetPrefetch fetches a number of Items that have not been processed
+// for prefetching, yet.
+func (db *Database) ItemGetPrefetch(lim int) ([]feed.Item, error) {
+	const qid query.ID = query.ItemGetPrefetch
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(lim); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, lim)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetPrefetch(lim int) ([]feed.Item, error)
+
+// ItemPrefetchSet updates an Item after the Prefetcher has processed it.
+func (db *Database) ItemPrefetchSet(i *feed.Item, description string) error {
+	const qid query.ID = query.ItemPrefetchSet
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(description, i.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot rate Item %s (%s): %s",
+				i.Title,
+				i.URL,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	i.Description = description
+	status = true
+	return nil
+} // func (db *Database) ItemPrefetchSet(i *feed.Item, body string) error
+
+// ItemGetContentPending fetches up to lim Items published no earlier than
+// begin whose Feeds want their full content, but whose pages we have not
+// processed, yet.
+func (db *Database) ItemGetContentPending(begin time.Time, lim int) ([]feed.Item, error) {
+	const qid query.ID = query.ItemGetContentPending
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(begin.Unix(), lim); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items = make([]feed.Item, 0, lim)
+
+	for rows.Next() {
+		var (
+			item    feed.Item
+			rating  *float64
+			stamp   int64
+			updated int64
+		)
+
+		if err = rows.Scan(
+			&item.ID,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&stamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&updated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(item.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %q (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+			return nil, err
+		} else if rating != nil {
+			item.ManuallyRated = true
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
+		}
+		item.Timestamp = time.Unix(stamp, 0)
+		if updated > 0 {
+			item.Updated = time.Unix(updated, 0)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetContentPending(begin time.Time, lim int) ([]feed.Item, error)
+
+// ItemContentSet stores the full content of an Item we extracted from the
+// page it links to. An empty content marks the Item as processed, too, so
+// we do not try again.
+func (db *Database) ItemContentSet(i *feed.Item, content string) error {
+	const qid query.ID = query.ItemContentSet
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(content, i.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot store content of Item %s (%s): %s",
+				i.Title,
+				i.URL,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	i.Content = content
+	status = true
+	return nil
+} // func (db *Database) ItemContentSet(i *feed.Item, content string) error
+
+// ItemMarkRead marks an Item as read.
+func (db *Database) ItemMarkRead(i *feed.Item) error {
+	const qid query.ID = query.ItemMarkRead
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(i.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		err = fmt.Errorf("Cannot mark Item %s (%s) as read: %s",
+			i.Title,
+			i.URL,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	i.Read = true
+	status = true
+	return nil
+} // func (db *Database) ItemMarkRead(i *feed.Item) error
+
+// ItemMarkUnread marks an Item as unread.
+func (db *Database) ItemMarkUnread(i *feed.Item) error {
+	const qid query.ID = query.ItemMarkUnread
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(i.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		err = fmt.Errorf("Cannot mark Item %s (%s) as unread: %s",
+			i.Title,
+			i.URL,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	i.Read = false
+	status = true
+	return nil
+} // func (db *Database) ItemMarkUnread(i *feed.Item) error
+
+// ItemMarkReadByFeed marks all Items of the given Feed as read. It returns
+// the number of Items that were unread before.
+func (db *Database) ItemMarkReadByFeed(feedID int64) (int64, error) {
+	const qid query.ID = query.ItemMarkReadByFeed
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		res    sql.Result
+		cnt    int64
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return 0, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(feedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		err = fmt.Errorf("Cannot mark Items of Feed %d as read: %s",
+			feedID,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return 0, err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot get number of Items marked as read: %s\n",
+			err.Error())
+		return 0, err
+	}
+
+	status = true
+	return cnt, nil
+} // func (db *Database) ItemMarkReadByFeed(feedID int64) (int64, error)
+
+// ItemMarkReadBatch marks the Items with the given IDs as read in a single
+// statement. It returns the number of Items that were unread before.
+func (db *Database) ItemMarkReadBatch(ids []int64) (int64, error) {
+	const qid query.ID = query.ItemMarkReadBatch
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		res    sql.Result
+		cnt, n int64
+		status bool
+	)
+
+	if len(ids) == 0 {
+		return 0, nil
+	} else if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return 0, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+	for _, batch := range idBatches(ids) {
+	EXEC_QUERY:
+		if res, err = stmt.Exec(batch...); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_QUERY
+			}
+
+			err = fmt.Errorf("Cannot mark %d Items as read: %s",
+				len(ids),
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return 0, err
+		} else if n, err = res.RowsAffected(); err != nil {
+			db.log.Printf("[ERROR] Cannot get number of Items marked as read: %s\n",
+				err.Error())
+			return 0, err
+		}
+
+		cnt += n
+	}
+
+	status = true
+	return cnt, nil
+} // func (db *Database) ItemMarkReadBatch(ids []int64) (int64, error)
+
+// ItemMarkReadByTag marks all Items with the given Tag or one of its
+// children as read. It returns the number of Items that were unread before.
+func (db *Database) ItemMarkReadByTag(tagID int64) (int64, error) {
+	const qid query.ID = query.ItemMarkReadByTag
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		res    sql.Result
+		cnt    int64
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return 0, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return 0, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(tagID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		err = fmt.Errorf("Cannot mark Items tagged %d as read: %s",
+			tagID,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return 0, err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot get number of Items marked as read: %s\n",
+			err.Error())
+		return 0, err
+	}
+
+	status = true
+	return cnt, nil
+} // func (db *Database) ItemMarkReadByTag(tagID int64) (int64, error)
+
+// ItemRatingSet sets an Item's Rating.
+func (db *Database) ItemRatingSet(i *feed.Item, rating float64) error {
+	const qid = query.ItemRatingSet
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	// db.log.Printf("[TRACE] Rate Item %d (%q): %f\n",
+	// 	i.ID,
+	// 	i.Title,
+	// 	rating)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(rating, i.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot rate Item %s (%s): %s",
+				i.Title,
+				i.URL,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	status = true
+	i.Rating = rating
+	i.ManuallyRated = true
+	return nil
+} // func (db *Database) ItemRatingSet(i *feed.Item, rating float64) error
+
+// ItemRatingClear clears an Item's Rating.
+func (db *Database) ItemRatingClear(i *feed.Item) error {
+	const qid = query.ItemRatingClear
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(i.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot rate Item %s (%s): %s",
+				i.Title,
+				i.URL,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	status = true
+	i.Rating = math.NaN()
+	i.ManuallyRated = false
+	return nil
+} // func (db *Database) ItemRatingClear(i *feed.Item) error
+
+// ItemHasDuplicate checks if a possible duplicate of the given Item already
+// exists in the database, i.e. an Item with the same link, or an Item with
+// the same title in the same Feed. Feeds that share a URL, because one of
+// them used to live at the other's address, count as the same Feed.
+func (db *Database) ItemHasDuplicate(i *feed.Item) (bool, error) {
+	const qid query.ID = query.ItemHasDuplicate
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return false, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(i.URL, i.Title, i.FeedID, i.FeedID, i.FeedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return false, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var cnt int64
+
+		if err = rows.Scan(&cnt); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return false, err
+		}
+
+		return cnt > 0, nil
+	}
+
+	err = fmt.Errorf("query %s should always return exactly one row",
+		qid)
+
+	db.log.Printf("[CANTHAPPEN] %s\n",
+		err.Error())
+
+	return false, err
+} // func (db *Database) ItemHasDuplicate(i *feed.Item) (bool, error)
+
+// ItemLookupGUID looks up the Item with the given GUID in the given Feed. It
+// returns the Item's ID and the checksum of its content as we last received
+// it. If there is no such Item, the ID is 0.
+func (db *Database) ItemLookupGUID(feedID int64, guid string) (int64, string, error) {
+	const qid query.ID = query.ItemLookupGUID
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return 0, "", err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(feedID, guid); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return 0, "", err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var (
+			id   int64
+			hash string
+		)
+
+		if err = rows.Scan(&id, &hash); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return 0, "", err
+		}
+
+		return id, hash, nil
+	}
+
+	return 0, "", nil
+} // func (db *Database) ItemLookupGUID(feedID int64, guid string) (int64, string, error)
+
+// ItemHashSet records the content hash of an Item. Items stored before we
+// started keeping track of their content have no hash, yet.
+func (db *Database) ItemHashSet(id int64, hash string) error {
+	const qid query.ID = query.ItemHashSet
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(hash, id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		err = fmt.Errorf("Cannot set content hash of Item %d: %s",
+			id,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) ItemHashSet(id int64, hash string) error
+
+// ItemUpdate replaces the content of the Item with the given ID with that
+// of the given Item, which has changed upstream. The previous version is
+// kept as a Revision.
+func (db *Database) ItemUpdate(id int64, item *feed.Item) error {
+	var (
+		err          error
+		msg          string
+		stmt, revise *sql.Stmt
+		tx           *sql.Tx
+		status       bool
+		now          = time.Now()
+	)
+
+	if stmt, err = db.getQuery(query.ItemUpdate); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.ItemUpdate,
+			err.Error())
+		return err
+	} else if revise, err = db.getQuery(query.RevisionAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.RevisionAdd,
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	revise = tx.Stmt(revise)
+
+EXEC_REVISE:
+	if _, err = revise.Exec(now.Unix(), id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_REVISE
+		}
+
+		err = fmt.Errorf("Cannot save previous version of Item %d: %s",
+			id,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	var (
+		res sql.Result
+		cnt int64
+	)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(
+		item.URL,
+		item.Title,
+		item.Description,
+		item.Author,
+		item.AuthorURI,
+		item.ContentHash(),
+		int64(simhash.Hash(item.Plaintext())),
+		item.OriginalURL,
+		now.Unix(),
+		id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		err = fmt.Errorf("Cannot update Item %d (%s): %s",
+			id,
+			item.Title,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot get number of affected rows: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	item.ID = id
+	item.Updated = now
+
+	if err = db.enclosureAdd(tx, item); err != nil {
+		return err
+	} else if err = db.categoryAdd(tx, item); err != nil {
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) ItemUpdate(id int64, item *feed.Item) error
+
+// RevisionGetByItem returns the previous versions of the given Item, the
+// most recent first.
+func (db *Database) RevisionGetByItem(itemID int64) ([]feed.Revision, error) {
+	const qid = query.RevisionGetByItem
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(itemID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Revisions of Item #%d: %s\n",
+			itemID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var list []feed.Revision
+
+	for rows.Next() {
+		var (
+			stamp int64
+			rev   = feed.Revision{ItemID: itemID}
+		)
+
+		if err = rows.Scan(&rev.ID, &rev.URL, &rev.Title, &rev.Description, &stamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		rev.Timestamp = time.Unix(stamp, 0)
+		list = append(list, rev)
+	}
+
+	return list, nil
+} // func (db *Database) RevisionGetByItem(itemID int64) ([]feed.Revision, error)
+
+// duplicateLink looks for an Item from another Feed that carries the same
+// story as the given, freshly added Item, and if it finds one, puts both
+// Items in the same duplicate group.
+func (db *Database) duplicateLink(tx *sql.Tx, item *feed.Item) error {
+	var (
+		err        error
+		stmt, link *sql.Stmt
+		rows       *sql.Rows
+	)
+
+	if item.Fingerprint == 0 {
+		return nil
+	} else if stmt, err = db.getQuery(query.ItemGetFingerprints); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.ItemGetFingerprints,
+			err.Error())
+		return err
+	} else if link, err = db.getQuery(query.ItemDupGroupSet); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.ItemDupGroupSet,
+			err.Error())
+		return err
+	}
+
+	stmt = tx.Stmt(stmt)
+	link = tx.Stmt(link)
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(
+		item.FeedID,
+		item.Timestamp.Add(-simhash.Window).Unix(),
+		item.Timestamp.Add(simhash.Window).Unix()); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load fingerprints of recent Items: %s\n",
+			err.Error())
+		return err
+	}
+
+	var (
+		matchID, group int64
+		best           = simhash.Threshold + 1
+	)
+
+	for rows.Next() {
+		var id, fp, dup int64
+
+		if err = rows.Scan(&id, &fp, &dup); err != nil {
+			rows.Close() // nolint: errcheck,gosec
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return err
+		} else if id == item.ID || !simhash.IsDuplicate(uint64(fp), item.Fingerprint) {
+			continue
+		} else if d := simhash.Distance(uint64(fp), item.Fingerprint); d < best {
+			best = d
+			matchID = id
+			group = dup
+		}
+	}
+
+	rows.Close() // nolint: errcheck,gosec
+
+	if matchID == 0 {
+		return nil
+	} else if group == 0 {
+		group = matchID
+	}
+
+EXEC_LINK:
+	if _, err = link.Exec(group, matchID, item.ID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_LINK
+		}
+
+		err = fmt.Errorf("Cannot link Item %q to duplicate group %d: %s",
+			item.Title,
+			group,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	item.DupGroup = group
+	return nil
+} // func (db *Database) duplicateLink(tx *sql.Tx, item *feed.Item) error
+
+// ItemGetDuplicateFeeds returns the IDs of all Feeds that carry an Item from
+// the given duplicate group.
+func (db *Database) ItemGetDuplicateFeeds(group int64) ([]int64, error) {
+	const qid = query.ItemGetDuplicateFeeds
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(group); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Feeds of duplicate group %d: %s\n",
+			group,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var feeds []int64
+
+	for rows.Next() {
+		var id int64
+
+		if err = rows.Scan(&id); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		feeds = append(feeds, id)
+	}
+
+	return feeds, nil
+} // func (db *Database) ItemGetDuplicateFeeds(group int64) ([]int64, error)
+
+// ItemGetDuplicateFeedsByGroups returns the IDs of the Feeds that carry an
+// Item from any of the given duplicate groups, keyed by the group.
+func (db *Database) ItemGetDuplicateFeedsByGroups(groups []int64) (map[int64][]int64, error) {
+	const qid = query.ItemGetDuplicateFeedsByGroups
+	var (
+		err   error
+		stmt  *sql.Stmt
+		feeds = make(map[int64][]int64, len(groups))
+	)
+
+	if len(groups) == 0 {
+		return feeds, nil
+	} else if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	for _, batch := range idBatches(groups) {
+		if err = db.itemGetDuplicateFeedsBatch(stmt, batch, feeds); err != nil {
+			return nil, err
+		}
+	}
+
+	return feeds, nil
+} // func (db *Database) ItemGetDuplicateFeedsByGroups(groups []int64) (map[int64][]int64, error)
+
+// itemGetDuplicateFeedsBatch adds the IDs of the Feeds that carry an Item
+// from one batch of duplicate groups to feeds.
+func (db *Database) itemGetDuplicateFeedsBatch(stmt *sql.Stmt, batch []interface{}, feeds map[int64][]int64) error {
+	var (
+		err  error
+		rows *sql.Rows
+	)
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(batch...); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Feeds of duplicate groups: %s\n",
+			err.Error())
+		return err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	for rows.Next() {
+		var group, id int64
+
+		if err = rows.Scan(&group, &id); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return err
+		}
+
+		feeds[group] = append(feeds[group], id)
+	}
+
+	return nil
+} // func (db *Database) itemGetDuplicateFeedsBatch(stmt *sql.Stmt, batch []interface{}, feeds map[int64][]int64) error
+
+// idBatches splits a list of IDs into the parameters for queries that take
+// idBatchSize IDs at a time. IDs that occur more than once are only passed
+// once. The last batch is padded with NULL, which matches no ID.
+func idBatches(ids []int64) [][]interface{} {
+	var (
+		batches [][]interface{}
+		batch   []interface{}
+		seen    = make(map[int64]bool, len(ids))
+	)
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		batch = append(batch, id)
+
+		if len(batch) == idBatchSize {
+			batches = append(batches, batch)
+			batch = nil
+		}
+	}
+
+	if len(batch) > 0 {
+		for len(batch) < idBatchSize {
+			batch = append(batch, nil)
+		}
+
+		batches = append(batches, batch)
+	}
+
+	return batches
+} // func idBatches(ids []int64) [][]interface{}
+
+// ItemGetExpired returns the Items of the given Feed that the given
+// Retention policy allows us to delete. Items on the read-later list and
+// Items that have been tagged or rated are never returned. The Items only
+// carry their ID, link, title, description and timestamp.
+func (db *Database) ItemGetExpired(feedID int64, r *feed.Retention, now time.Time) ([]feed.Item, error) {
+	const qid = query.ItemGetExpired
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(feedID, now.Add(-r.MaxAge).Unix(), r.ReadOnly); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load expired Items of Feed %d: %s\n",
+			feedID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var items []feed.Item
+
+	for rows.Next() {
+		var (
+			stamp int64
+			item  = feed.Item{FeedID: feedID}
+		)
+
+		if err = rows.Scan(&item.ID, &item.URL, &item.Title, &item.Description, &stamp); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		item.Timestamp = time.Unix(stamp, 0)
+		items = append(items, item)
+	}
+
+	return items, nil
+} // func (db *Database) ItemGetExpired(feedID int64, r *feed.Retention, now time.Time) ([]feed.Item, error)
+
+// ItemDelete removes an Item from the database, along with its entry in the
+// full-text index and everything else that refers to it.
+func (db *Database) ItemDelete(id int64) error {
+	const qid = query.ItemDelete
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot delete Item %d: %s\n",
+			id,
+			err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) ItemDelete(id int64) error
+
+// ItemCacheRefs finds all references to prefetched images in a single pass,
+// looking at the Items' descriptions and content as well as their earlier
+// revisions. The first submatch of pat is the name of an image. It returns
+// the IDs of the Items referring to each image.
+func (db *Database) ItemCacheRefs(pat *regexp.Regexp) (map[string][]int64, error) {
+	const qid = query.ItemCacheRefs
+	var (
+		err       error
+		stmt      *sql.Stmt
+		rows      *sql.Rows
+		prefix, _ = pat.LiteralPrefix()
+		refs      = make(map[string][]int64)
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(prefix, prefix, prefix); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load references to cached files: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	for rows.Next() {
+		var (
+			id   int64
+			body string
+		)
+
+		if err = rows.Scan(&id, &body); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		// The rows are sorted by Item, so if an Item refers to an image
+		// more than once, we have just seen it.
+		for _, m := range pat.FindAllStringSubmatch(body, -1) {
+			if ids := refs[m[1]]; len(ids) == 0 || ids[len(ids)-1] != id {
+				refs[m[1]] = append(ids, id)
+			}
+		}
+	}
+
+	return refs, nil
+} // func (db *Database) ItemCacheRefs(pat *regexp.Regexp) (map[string][]int64, error)
+
+// enclosureAdd adds the Enclosures of a freshly added Item to the database,
+// as part of the transaction that added the Item.
+func (db *Database) enclosureAdd(tx *sql.Tx, item *feed.Item) error {
+	const qid = query.EnclosureAdd
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if len(item.Enclosures) == 0 {
+		return nil
+	} else if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	}
+
+	stmt = tx.Stmt(stmt)
+
+	for idx := range item.Enclosures {
+		var (
+			res sql.Result
+			cnt int64
+			e   = &item.Enclosures[idx]
+		)
+
+		e.ItemID = item.ID
+
+	EXEC_QUERY:
+		if res, err = stmt.Exec(e.ItemID, e.URL, e.Type, e.Length, int64(e.Duration.Seconds())); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_QUERY
+			}
+
+			err = fmt.Errorf("Cannot add Enclosure %s of Item %q to database: %s",
+				e.URL,
+				item.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		} else if cnt, err = res.RowsAffected(); err != nil {
+			db.log.Printf("[ERROR] Cannot get number of affected rows: %s\n",
+				err.Error())
+			return err
+		} else if cnt == 0 {
+			// The Item lists the same URL more than once.
+			continue
+		} else if e.ID, err = res.LastInsertId(); err != nil {
+			db.log.Printf("[ERROR] Cannot get ID of new Enclosure %s: %s\n",
+				e.URL,
+				err.Error())
+			return err
+		}
+	}
+
+	return nil
+} // func (db *Database) enclosureAdd(tx *sql.Tx, item *feed.Item) error
+
+// EnclosureGetByItem returns the Enclosures attached to the given Item.
+func (db *Database) EnclosureGetByItem(itemID int64) ([]feed.Enclosure, error) {
+	const qid = query.EnclosureGetByItem
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(itemID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Enclosures for Item #%d: %s\n",
+			itemID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var list []feed.Enclosure
+
+	for rows.Next() {
+		var (
+			secs int64
+			e    = feed.Enclosure{ItemID: itemID}
+		)
+
+		if err = rows.Scan(&e.ID, &e.URL, &e.Type, &e.Length, &secs); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		e.Duration = time.Second * time.Duration(secs)
+		list = append(list, e)
+	}
+
+	return list, nil
+} // func (db *Database) EnclosureGetByItem(itemID int64) ([]feed.Enclosure, error)
+
+// EnclosureGetByID loads an Enclosure by its ID. If there is no such
+// Enclosure, it returns nil and no error.
+func (db *Database) EnclosureGetByID(id int64) (*feed.Enclosure, error) {
+	const qid = query.EnclosureGetByID
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	if rows.Next() {
+		var (
+			secs int64
+			e    = &feed.Enclosure{ID: id}
+		)
+
+		if err = rows.Scan(&e.ItemID, &e.URL, &e.Type, &e.Length, &secs); err != nil {
+			db.log.Printf("[ERROR] Cannot scan Row for Enclosure %d: %s\n",
+				id,
+				err.Error())
+			return nil, err
+		}
+
+		e.Duration = time.Second * time.Duration(secs)
+		return e, nil
+	}
+
+	return nil, nil
+} // func (db *Database) EnclosureGetByID(id int64) (*feed.Enclosure, error)
+
+// categoryAdd adds the categories of a freshly added Item to the database,
+// as part of the transaction that added the Item, and attaches the Tags the
+// categories are mapped to.
+func (db *Database) categoryAdd(tx *sql.Tx, item *feed.Item) error {
+	var (
+		err         error
+		stmt, apply *sql.Stmt
+	)
+
+	if len(item.Categories) == 0 {
+		return nil
+	} else if stmt, err = db.getQuery(query.CategoryAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.CategoryAdd,
+			err.Error())
+		return err
+	} else if apply, err = db.getQuery(query.CategoryTagApply); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.CategoryTagApply,
+			err.Error())
+		return err
+	}
+
+	stmt = tx.Stmt(stmt)
+	apply = tx.Stmt(apply)
+
+	for _, c := range item.Categories {
+	EXEC_ADD:
+		if _, err = stmt.Exec(item.ID, c); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_ADD
+			}
+
+			err = fmt.Errorf("Cannot add category %q of Item %q to database: %s",
+				c,
+				item.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+
+	EXEC_APPLY:
+		if _, err = apply.Exec(item.ID, item.FeedID, c); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto EXEC_APPLY
+			}
+
+			err = fmt.Errorf("Cannot apply Tags for category %q to Item %q: %s",
+				c,
+				item.Title,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	return nil
+} // func (db *Database) categoryAdd(tx *sql.Tx, item *feed.Item) error
+
+// CategoryGetByItem returns the categories the Feed placed the given Item in.
+func (db *Database) CategoryGetByItem(itemID int64) ([]string, error) {
+	const qid = query.CategoryGetByItem
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(itemID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load categories for Item #%d: %s\n",
+			itemID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var list []string
+
+	for rows.Next() {
+		var name string
+
+		if err = rows.Scan(&name); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		list = append(list, name)
+	}
+
+	return list, nil
+} // func (db *Database) CategoryGetByItem(itemID int64) ([]string, error)
+
+// CategoryGetByFeed returns all categories used by the Items of the given
+// Feed, along with the number of Items in each.
+func (db *Database) CategoryGetByFeed(feedID int64) ([]feed.Category, error) {
+	const qid = query.CategoryGetByFeed
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(feedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load categories of Feed #%d: %s\n",
+			feedID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var list = make([]feed.Category, 0, 16)
+
+	for rows.Next() {
+		var c feed.Category
+
+		if err = rows.Scan(&c.Name, &c.Count); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		list = append(list, c)
+	}
+
+	return list, nil
+} // func (db *Database) CategoryGetByFeed(feedID int64) ([]feed.Category, error)
+
+// CategoryTagAdd maps a category of the given Feed to a Tag. The Tag is
+// attached to all Items of the Feed already in that category, and to all
+// new ones as they arrive.
+func (db *Database) CategoryTagAdd(feedID int64, category string, tagID int64) (*feed.CategoryTag, error) {
+	var (
+		err         error
+		msg         string
+		stmt, apply *sql.Stmt
+		tx          *sql.Tx
+		status      bool
+	)
+
+	if category == "" {
+		return nil, errors.New("Category must not be empty")
+	} else if stmt, err = db.getQuery(query.CategoryTagAdd); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.CategoryTagAdd,
+			err.Error())
+		return nil, err
+	} else if apply, err = db.getQuery(query.CategoryTagApplyFeed); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			query.CategoryTagApplyFeed,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return nil, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	apply = tx.Stmt(apply)
+
+	var (
+		res sql.Result
+		cnt int64
+		m   = &feed.CategoryTag{
+			FeedID:   feedID,
+			Category: category,
+			TagID:    tagID,
+		}
+	)
+
+EXEC_QUERY:
+	if res, err = stmt.Exec(feedID, category, tagID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		err = fmt.Errorf("Cannot map category %q of Feed %d to Tag %d: %s",
+			category,
+			feedID,
+			tagID,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return nil, err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot get number of affected rows: %s\n",
+			err.Error())
+		return nil, err
+	} else if cnt == 0 {
+		err = fmt.Errorf("Category %q of Feed %d is already mapped to Tag %d",
+			category,
+			feedID,
+			tagID)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return nil, err
+	} else if m.ID, err = res.LastInsertId(); err != nil {
+		db.log.Printf("[ERROR] Cannot get ID of new category mapping: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+EXEC_APPLY:
+	if _, err = apply.Exec(tagID, feedID, category); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_APPLY
+		}
+
+		err = fmt.Errorf("Cannot attach Tag %d to Items of Feed %d in category %q: %s",
+			tagID,
+			feedID,
+			category,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return nil, err
+	}
+
+	status = true
+	return m, nil
+} // func (db *Database) CategoryTagAdd(feedID int64, category string, tagID int64) (*feed.CategoryTag, error)
+
+// CategoryTagDelete removes a mapping from a Feed's category to a Tag.
+// Tags already attached to Items stay where they are.
+func (db *Database) CategoryTagDelete(id int64) error {
+	const qid = query.CategoryTagDelete
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot delete category mapping %d: %s\n",
+			id,
+			err.Error())
+		return err
+	}
+
+	status = true
+	return nil
+} // func (db *Database) CategoryTagDelete(id int64) error
+
+// CategoryTagGetByFeed returns the mappings from categories to Tags for the
+// given Feed.
+func (db *Database) CategoryTagGetByFeed(feedID int64) ([]feed.CategoryTag, error) {
+	const qid = query.CategoryTagGetByFeed
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(feedID); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load category mappings of Feed #%d: %s\n",
+			feedID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var list = make([]feed.CategoryTag, 0, 8)
+
+	for rows.Next() {
+		var m = feed.CategoryTag{FeedID: feedID}
+
+		if err = rows.Scan(&m.ID, &m.Category, &m.TagID); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		list = append(list, m)
+	}
+
+	return list, nil
+} // func (db *Database) CategoryTagGetByFeed(feedID int64) ([]feed.CategoryTag, error)
+
+// FTSRebuild rebuilds the index used in the full-text search.
+func (db *Database) FTSRebuild() error {
+	const (
+		qsel query.ID = query.ItemGetContent
+		qins query.ID = query.ItemInsertFTS
+		qdel query.ID = query.FTSClear
+	)
+	var (
+		err           error
+		status        bool
+		sel, ins, del *sql.Stmt
+	)
+
+	if sel, err = db.getQuery(qsel); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qsel,
+			err.Error())
+		return err
+	} else if ins, err = db.getQuery(qins); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qins,
+			err.Error())
+		return err
+	} else if del, err = db.getQuery(qdel); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qdel,
+			err.Error())
+		return err
+	} else if err = db.Begin(); err != nil {
+		db.log.Printf("[ERROR] Cannot begin transaction: %s\n",
+			err.Error())
+		return err
+	}
+
+	defer func() {
+		var x error
+		if status {
+			if x = db.Commit(); x != nil {
+				db.log.Printf("[ERROR] Cannot commit transaction: %s\n",
+					err.Error())
+			}
+		} else {
+			if x = db.Rollback(); x != nil {
+				db.log.Printf("[ERROR] Cannot roll back transaction: %s\n",
+					err.Error())
+			}
+		}
+	}()
+
+	sel = db.tx.Stmt(sel)
+	ins = db.tx.Stmt(ins)
+	del = db.tx.Stmt(del)
+
+	if _, err = del.Exec(); err != nil {
+		db.log.Printf("[ERROR] Cannot clear FTS index: %s\n",
+			err.Error())
+		return err
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = sel.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			db.log.Printf("[ERROR] Cannot execute query %s: %s\n",
+				qsel,
+				err.Error())
+			return err
+		}
+	}
+
+	defer rows.Close() // nolint: errcheck
+
+	for rows.Next() {
+		var (
+			id         int64
+			link, body string
+		)
+
+		if err = rows.Scan(&id, &link, &body); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return err
+		} else if _, err = ins.Exec(id, link, body); err != nil {
+			db.log.Printf("[ERROR] Cannot insert Item %s into full text index: %s\n",
+				link,
+				err.Error())
+			return err
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) FTSRebuild() error
+
+// TagCreate creates a new Tag. <parent> is the ID of the parent tag. A value
+// of 0 means no parent.
+func (db *Database) TagCreate(name, desc string, parentID int64) (*tag.Tag, error) {
+	const qid = query.TagCreate
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return nil, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+	var res sql.Result
+	var parent *int64
+
+	if parentID != 0 {
+		parent = &parentID
+	}
 
-	for _, tid := range tags {
-		tmap[tid] = true
+EXEC_QUERY:
+	if res, err = stmt.Exec(name, desc, parent); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot add Tag %s (%s) to database: %s",
+				name,
+				desc,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return nil, err
+		}
+	} else {
+		var tagID int64
+
+		if tagID, err = res.LastInsertId(); err != nil {
+			db.log.Printf("[ERROR] Cannot get ID of new Tag %q: %s\n",
+				name,
+				err.Error())
+			return nil, err
+		}
+
+		status = true
+		return &tag.Tag{
+			ID:          tagID,
+			Name:        name,
+			Description: desc,
+			Parent:      parentID,
+		}, nil
+	}
+} // func (db *Database) TagCreate(name, desc string, parentID int64) (*tag.Tag, error)
+
+// TagDelete removes the Tag with the given ID from the database.
+//
+// This will automatically remove all links of the given Tag to Items as well.
+func (db *Database) TagDelete(id int64) error {
+	const qid = query.TagDelete
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
+
+	stmt = tx.Stmt(stmt)
+
+EXEC_QUERY:
+	if _, err = stmt.Exec(id); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		} else {
+			err = fmt.Errorf("Cannot delete Tag %d: %s",
+				id,
+				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
+		}
+	}
+
+	status = true
+	return nil
+} // func (db *Database) TagDelete(id int64) error
+
+// TagGetAll fetches all Tags from the database, in no particular order.
+func (db *Database) TagGetAll() ([]tag.Tag, error) {
+	const qid query.ID = query.TagGetAll
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var tags = make([]tag.Tag, 0, 16)
+
+	for rows.Next() {
+		var (
+			t      tag.Tag
+			parent *int64
+			desc   *string
+		)
+
+		if err = rows.Scan(&t.ID, &t.Name, &desc, &parent); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		if parent != nil {
+			t.Parent = *parent
+		}
+		if desc != nil {
+			t.Description = *desc
+		}
+
+		tags = append(tags, t)
+	}
+
+	return tags, nil
+} // func (db *Database) TagGetAll() ([]tag.Tag, error)
+
+// TagGetHierarchy retrieves a slice of all Tags, organized in a hierarchical
+// fashion.
+func (db *Database) TagGetHierarchy() ([]tag.Tag, error) {
+	const (
+		qroot     query.ID = query.TagGetRoots
+		qchildren query.ID = query.TagGetChildren
+	)
+
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qroot); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qroot,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var tags = make([]tag.Tag, 0, 16)
+
+	for rows.Next() {
+		var (
+			t    tag.Tag
+			desc *string
+		)
+
+		if err = rows.Scan(&t.ID, &t.Name, &desc); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if t.Children, err = db.TagGetChildrenImmediate(t.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot get children of Tag %s (%d): %s\n",
+				t.Name,
+				t.ID,
+				err.Error())
+			return nil, err
+		}
+
+		if desc != nil {
+			t.Description = *desc
+		}
+
+		tags = append(tags, t)
+	}
+
+	return tags, nil
+} // func (db *Database) TagGetHierarchy() ([]tag.Tag, error)
+
+// TagGetAllByHierarchy returns all Tags ordered by hierarchy.
+func (db *Database) TagGetAllByHierarchy() ([]tag.Tag, error) {
+	const qid query.ID = query.TagGetAllByHierarchy
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		return nil, err
 	}
 
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var tags = make([]tag.Tag, 0, 16)
+
 	for rows.Next() {
 		var (
-			item   feed.Item
-			rating *float64
-			stamp  int64
+			t      tag.Tag
+			parent *int64
+			desc   *string
 		)
 
-		if err = rows.Scan(
-			&item.ID,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
+		if err = rows.Scan(&t.ID, &t.Name, &desc, &parent, &t.Level, &t.Path); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-			return nil, err
 		}
 
-		var isTagged bool
-
-		if len(tags) == 0 {
-			isTagged = true
-		} else {
-			for _, t := range item.Tags {
-				if tmap[t.ID] {
-					isTagged = true
-				}
-			}
+		if parent != nil {
+			t.Parent = *parent
 		}
-
-		if !isTagged {
-			continue
-		} else if rating != nil {
-			item.ManuallyRated = true
-			item.Rating = *rating
-		} else {
-			item.Rating = math.NaN()
+		if desc != nil {
+			t.Description = *desc
 		}
-		item.Timestamp = time.Unix(stamp, 0)
-		items = append(items, item)
+
+		tags = append(tags, t)
 	}
 
-	return items, nil
-} // func (db *Database) ItemGetSearchExtended(qstr string, tags []int64) ([]feed.Item, error)
+	return tags, nil
+} // func (db *Database) TagGetAllByHierarchy() ([]tag.Tag, error)
 
-// ItemGetByTag fetches all Items the given Tag is attached to.
-//
-// Currently, this does not take the Tag hierarchy into account.
-func (db *Database) ItemGetByTag(t *tag.Tag) ([]feed.Item, error) {
-	const qid query.ID = query.ItemGetByTag
+// TagGetChildren fetches all the children - recursively - of the given Tag.
+func (db *Database) TagGetChildren(id int64) ([]tag.Tag, error) {
+	const qid query.ID = query.TagGetChildren
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -1874,7 +7873,7 @@ func (db *Database) ItemGetByTag(t *tag.Tag) ([]feed.Item, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(t.ID); err != nil {
+	if rows, err = stmt.Query(id, id); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
@@ -1885,52 +7884,38 @@ EXEC_QUERY:
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var items = make([]feed.Item, 0, 32)
+	var tags = make([]tag.Tag, 0, 16)
 
 	for rows.Next() {
 		var (
-			item   feed.Item
-			rating *float64
-			stamp  int64
+			t      tag.Tag
+			parent *int64
+			desc   *string
 		)
 
-		if err = rows.Scan(
-			&item.ID,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
+		if err = rows.Scan(&t.ID, &t.Name, &desc, &parent); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-			return nil, err
 		}
 
-		if rating != nil {
-			item.ManuallyRated = true
-			item.Rating = *rating
-		} else {
-			item.Rating = math.NaN()
+		if parent != nil {
+			t.Parent = *parent
 		}
-		item.Timestamp = time.Unix(stamp, 0)
-		items = append(items, item)
+		if desc != nil {
+			t.Description = *desc
+		}
+
+		tags = append(tags, t)
 	}
 
-	return items, nil
-} // func (db *Database) ItemGetByTag(t *tag.Tag) ([]feed.Item, error)
+	return tags, nil
+} // func (db *Database) TagGetChildren(id int64) ([]tag.Tag, error)
 
-// ItemGetByTagRecursive returns all Items marked with the given Tag or any
-// of its children (recursively, obviously).
-func (db *Database) ItemGetByTagRecursive(t *tag.Tag) ([]feed.Item, error) {
-	const qid query.ID = query.ItemGetByTagRecursive
+// TagGetChildrenImmediate fetches all Tags that are directly descended from
+// the given parent Tag, i.e. Tags whose parent ID equals the argument.
+func (db *Database) TagGetChildrenImmediate(id int64) ([]tag.Tag, error) {
+	const qid query.ID = query.TagGetChildrenImmediate
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -1948,7 +7933,7 @@ func (db *Database) ItemGetByTagRecursive(t *tag.Tag) ([]feed.Item, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(t.ID); err != nil {
+	if rows, err = stmt.Query(id); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
@@ -1959,49 +7944,43 @@ EXEC_QUERY:
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var items = make([]feed.Item, 0, 64)
+	var tags = make([]tag.Tag, 0, 16)
 
 	for rows.Next() {
 		var (
-			item   feed.Item
-			rating *float64
-			stamp  int64
+			desc *string
+			t    tag.Tag
 		)
 
-		if err = rows.Scan(
-			&item.ID,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
+		if err = rows.Scan(&t.ID, &t.Name, &desc); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
+		}
+
+		t.Parent = id
+		if desc != nil {
+			t.Description = *desc
+		}
+
+		if t.Children, err = db.TagGetChildrenImmediate(t.ID); err != nil {
+			db.log.Printf("[ERROR] Cannot get immediate children of Tag %s (%d): %s\n",
+				t.Name,
+				t.ID,
 				err.Error())
 			return nil, err
-		} else if rating != nil {
-			item.ManuallyRated = true
-			item.Rating = *rating
-		} else {
-			item.Rating = math.NaN()
 		}
-		item.Timestamp = time.Unix(stamp, 0)
-		items = append(items, item)
+
+		tags = append(tags, t)
 	}
 
-	return items, nil
-} // func (db *Database) ItemGetByTagRecursive(t *tag.Tag) ([]feed.Item, error)
+	return tags, nil
+} // func (db *Database) TagGetChildrenImmediate(id int64) ([]tag.Tag, error)
+
+// TagGetByID loads a Tag by its database ID.
+func (db *Database) TagGetByID(id int64) (*tag.Tag, error) {
+	const qid query.ID = query.TagGetByID
 
-// ItemGetTotalCnt returns the total number of items in the database.
-func (db *Database) ItemGetTotalCnt() (int64, error) {
-	const qid query.ID = query.ItemGetTotalCnt
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -2011,7 +7990,7 @@ func (db *Database) ItemGetTotalCnt() (int64, error) {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid,
 			err.Error())
-		return 0, err
+		return nil, err
 	} else if db.tx != nil {
 		stmt = db.tx.Stmt(stmt)
 	}
@@ -2019,36 +7998,50 @@ func (db *Database) ItemGetTotalCnt() (int64, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(); err != nil {
+	if rows, err = stmt.Query(id); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		return 0, err
+		db.log.Printf("[ERROR] Cannot load Tag #%d: %s\n",
+			id,
+			err.Error())
+		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
 	if rows.Next() {
-		var cnt int64
-		if err = rows.Scan(&cnt); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n", err.Error())
-			return 0, err
+		var (
+			t      = &tag.Tag{ID: id}
+			parent *int64
+			desc   *string
+		)
+
+		if err = rows.Scan(&t.Name, &desc, &parent); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
 		}
 
-		return cnt, nil
+		if parent != nil {
+			t.Parent = *parent
+		}
+		if desc != nil {
+			t.Description = *desc
+		}
+
+		return t, nil
 	}
 
-	db.log.Printf("[CANTHAPPEN] Query %s returned 0 rows\n",
-		qid)
-	return 0, nil
-} // func (db *Database) ItemGetTotalCnt() (int64, error)
+	return nil, nil
+} // func (db *Database) TagGetByID(id int64) (*tag.Tag, error)
+
+// TagGetByName loads a Tag by its database ID.
+func (db *Database) TagGetByName(name string) (*tag.Tag, error) {
+	const qid query.ID = query.TagGetByName
 
-// ItemGetPrefetch fetches a number of Items that have not been processed
-// for prefetching, yet.
-func (db *Database) ItemGetPrefetch(lim int) ([]feed.Item, error) {
-	const qid query.ID = query.ItemGetPrefetch
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -2066,128 +8059,111 @@ func (db *Database) ItemGetPrefetch(lim int) ([]feed.Item, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(lim); err != nil {
+	if rows, err = stmt.Query(name); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
+		db.log.Printf("[ERROR] Cannot load Tag %s: %s\n",
+			name,
+			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var items = make([]feed.Item, 0, lim)
-
-	for rows.Next() {
+	if rows.Next() {
 		var (
-			item   feed.Item
-			rating *float64
-			stamp  int64
+			t      = &tag.Tag{Name: name}
+			parent *int64
+			desc   *string
 		)
 
-		if err = rows.Scan(
-			&item.ID,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&stamp,
-			&item.Read,
-			&rating); err != nil {
+		if err = rows.Scan(&t.ID, &desc, &parent); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(item.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %q (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-			return nil, err
-		} else if rating != nil {
-			item.ManuallyRated = true
-			item.Rating = *rating
-		} else {
-			item.Rating = math.NaN()
 		}
-		item.Timestamp = time.Unix(stamp, 0)
-		items = append(items, item)
+
+		if parent != nil {
+			t.Parent = *parent
+		}
+		if desc != nil {
+			t.Description = *desc
+		}
+
+		return t, nil
 	}
 
-	return items, nil
-} // func (db *Database) ItemGetPrefetch(lim int) ([]feed.Item, error)
+	return nil, nil
+} // func (db *Database) TagGetByName(id int64) (*tag.Tag, error)
 
-// ItemPrefetchSet updates an Item after the Prefetcher has processed it.
-func (db *Database) ItemPrefetchSet(i *feed.Item, description string) error {
-	const qid query.ID = query.ItemPrefetchSet
+// TagGetByItem returns a (possibly empty) slice of all Tags attached to an
+// Item.
+func (db *Database) TagGetByItem(itemID int64) ([]tag.Tag, error) {
+	const qid query.ID = query.TagGetByItem
 	var (
-		err    error
-		msg    string
-		stmt   *sql.Stmt
-		tx     *sql.Tx
-		status bool
+		err  error
+		stmt *sql.Stmt
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid.String(),
+			qid,
 			err.Error())
-		return err
+		return nil, err
 	} else if db.tx != nil {
-		tx = db.tx
-	} else {
-	BEGIN_AD_HOC:
-		if tx, err = db.db.Begin(); err != nil {
-			if worthARetry(err) {
-				waitForRetry()
-				goto BEGIN_AD_HOC
-			} else {
-				msg = fmt.Sprintf("Error starting transaction: %s\n",
-					err.Error())
-				db.log.Printf("[ERROR] %s\n", msg)
-				return errors.New(msg)
-			}
-		} else {
-			defer func() {
-				var err2 error
-				if status {
-					if err2 = tx.Commit(); err2 != nil {
-						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
-							err2.Error())
-					}
-				} else if err2 = tx.Rollback(); err2 != nil {
-					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
-						err2.Error())
-				}
-			}()
-		}
+		stmt = db.tx.Stmt(stmt)
 	}
 
-	stmt = tx.Stmt(stmt)
+	var rows *sql.Rows
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(description, i.ID); err != nil {
+	if rows, err = stmt.Query(itemID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("Cannot rate Item %s (%s): %s",
-				i.Title,
-				i.URL,
+		}
+
+		db.log.Printf("[ERROR] Cannot load Tags for Item #%d: %s\n",
+			itemID,
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+	var tags = make([]tag.Tag, 0, 4)
+
+	for rows.Next() {
+		var (
+			t      tag.Tag
+			desc   *string
+			parent *int64
+		)
+
+		if err = rows.Scan(&t.ID, &t.Name, &desc, &parent); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
+			return nil, err
+		}
+
+		if parent != nil {
+			t.Parent = *parent
+		}
+		if desc != nil {
+			t.Description = *desc
 		}
+
+		tags = append(tags, t)
 	}
 
-	i.Description = description
-	status = true
-	return nil
-} // func (db *Database) ItemPrefetchSet(i *feed.Item, body string) error
+	return tags, nil
+} // func (db *Database) TagGetByItem(itemID int64) ([]tag.Tag, error)
 
-// ItemRatingSet sets an Item's Rating.
-func (db *Database) ItemRatingSet(i *feed.Item, rating float64) error {
-	const qid = query.ItemRatingSet
+// TagNameUpdate renames the given Tag to the new name.
+func (db *Database) TagNameUpdate(t *tag.Tag, name string) error {
+	const qid = query.TagNameUpdate
 	var (
 		err    error
 		msg    string
@@ -2196,11 +8172,6 @@ func (db *Database) ItemRatingSet(i *feed.Item, rating float64) error {
 		status bool
 	)
 
-	// db.log.Printf("[TRACE] Rate Item %d (%q): %f\n",
-	// 	i.ID,
-	// 	i.Title,
-	// 	rating)
-
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid.String(),
@@ -2220,6 +8191,7 @@ func (db *Database) ItemRatingSet(i *feed.Item, rating float64) error {
 				db.log.Printf("[ERROR] %s\n", msg)
 				return errors.New(msg)
 			}
+
 		} else {
 			defer func() {
 				var err2 error
@@ -2239,14 +8211,15 @@ func (db *Database) ItemRatingSet(i *feed.Item, rating float64) error {
 	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(rating, i.ID); err != nil {
+	if _, err = stmt.Exec(name, t.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("Cannot rate Item %s (%s): %s",
-				i.Title,
-				i.URL,
+			err = fmt.Errorf("Cannot rename Tag %s (%d) to %s: %s",
+				t.Name,
+				t.ID,
+				name,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
@@ -2254,14 +8227,13 @@ EXEC_QUERY:
 	}
 
 	status = true
-	i.Rating = rating
-	i.ManuallyRated = true
+	t.Name = name
 	return nil
-} // func (db *Database) ItemRatingSet(i *feed.Item, rating float64) error
+} // func (db *Database) TagNameUpdate(t *tag.Tag, name string) error
 
-// ItemRatingClear clears an Item's Rating.
-func (db *Database) ItemRatingClear(i *feed.Item) error {
-	const qid = query.ItemRatingClear
+// TagDescriptionUpdate changes the given Tag's Description.
+func (db *Database) TagDescriptionUpdate(t *tag.Tag, desc string) error {
+	const qid = query.TagDescriptionUpdate
 	var (
 		err    error
 		msg    string
@@ -2302,185 +8274,36 @@ func (db *Database) ItemRatingClear(i *feed.Item) error {
 					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
 						err2.Error())
 				}
-			}()
-		}
-	}
-
-	stmt = tx.Stmt(stmt)
-
-EXEC_QUERY:
-	if _, err = stmt.Exec(i.ID); err != nil {
-		if worthARetry(err) {
-			waitForRetry()
-			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("Cannot rate Item %s (%s): %s",
-				i.Title,
-				i.URL,
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
-		}
-	}
-
-	status = true
-	i.Rating = math.NaN()
-	i.ManuallyRated = false
-	return nil
-} // func (db *Database) ItemRatingClear(i *feed.Item) error
-
-// ItemHasDuplicate checks if a possible duplicate of the given Item already
-// exists in the database.
-func (db *Database) ItemHasDuplicate(i *feed.Item) (bool, error) {
-	const qid query.ID = query.ItemHasDuplicate
-	var (
-		err  error
-		stmt *sql.Stmt
-	)
-
-	if stmt, err = db.getQuery(qid); err != nil {
-		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
-			err.Error())
-		return false, err
-	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
-	}
-
-	var rows *sql.Rows
-
-EXEC_QUERY:
-	if rows, err = stmt.Query(i.URL, i.FeedID, i.Title); err != nil {
-		if worthARetry(err) {
-			waitForRetry()
-			goto EXEC_QUERY
-		}
-
-		return false, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	if rows.Next() {
-		var cnt int64
-
-		if err = rows.Scan(&cnt); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return false, err
-		}
-
-		return cnt > 0, nil
-	}
-
-	err = fmt.Errorf("query %s should always return exactly one row",
-		qid)
-
-	db.log.Printf("[CANTHAPPEN] %s\n",
-		err.Error())
-
-	return false, err
-} // func (db *Database) ItemHasDuplicate(i *feed.Item) (bool, error)
-
-// FTSRebuild rebuilds the index used in the full-text search.
-func (db *Database) FTSRebuild() error {
-	const (
-		qsel query.ID = query.ItemGetContent
-		qins query.ID = query.ItemInsertFTS
-		qdel query.ID = query.FTSClear
-	)
-	var (
-		err           error
-		status        bool
-		sel, ins, del *sql.Stmt
-	)
-
-	if sel, err = db.getQuery(qsel); err != nil {
-		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qsel,
-			err.Error())
-		return err
-	} else if ins, err = db.getQuery(qins); err != nil {
-		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qins,
-			err.Error())
-		return err
-	} else if del, err = db.getQuery(qdel); err != nil {
-		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qdel,
-			err.Error())
-		return err
-	} else if err = db.Begin(); err != nil {
-		db.log.Printf("[ERROR] Cannot begin transaction: %s\n",
-			err.Error())
-		return err
-	}
-
-	defer func() {
-		var x error
-		if status {
-			if x = db.Commit(); x != nil {
-				db.log.Printf("[ERROR] Cannot commit transaction: %s\n",
-					err.Error())
-			}
-		} else {
-			if x = db.Rollback(); x != nil {
-				db.log.Printf("[ERROR] Cannot roll back transaction: %s\n",
-					err.Error())
-			}
-		}
-	}()
-
-	sel = db.tx.Stmt(sel)
-	ins = db.tx.Stmt(ins)
-	del = db.tx.Stmt(del)
-
-	if _, err = del.Exec(); err != nil {
-		db.log.Printf("[ERROR] Cannot clear FTS index: %s\n",
-			err.Error())
-		return err
+			}()
+		}
 	}
 
-	var rows *sql.Rows
+	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if rows, err = sel.Query(); err != nil {
+	if _, err = stmt.Exec(desc, t.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			db.log.Printf("[ERROR] Cannot execute query %s: %s\n",
-				qsel,
-				err.Error())
-			return err
-		}
-	}
-
-	defer rows.Close() // nolint: errcheck
-
-	for rows.Next() {
-		var link, body string
-
-		if err = rows.Scan(&link, &body); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return err
-		} else if _, err = ins.Exec(link, body); err != nil {
-			db.log.Printf("[ERROR] Cannot insert Item %s into full text index: %s\n",
-				link,
+			err = fmt.Errorf("cannot update description of Tag %s (%d) to %q: %s",
+				t.Name,
+				t.ID,
+				desc,
 				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
 		}
 	}
 
 	status = true
+	t.Description = desc
 	return nil
-} // func (db *Database) FTSRebuild() error
+} // func (db *Database) TagDescriptionUpdate(t *tag.Tag, desc string) error
 
-// TagCreate creates a new Tag. <parent> is the ID of the parent tag. A value
-// of 0 means no parent.
-func (db *Database) TagCreate(name, desc string, parentID int64) (*tag.Tag, error) {
-	const qid = query.TagCreate
+// TagParentSet updates the given Tag's Parent field.
+func (db *Database) TagParentSet(t *tag.Tag, parent int64) error {
+	const qid = query.TagParentSet
 	var (
 		err    error
 		msg    string
@@ -2493,7 +8316,7 @@ func (db *Database) TagCreate(name, desc string, parentID int64) (*tag.Tag, erro
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
 		tx = db.tx
 	} else {
@@ -2506,7 +8329,7 @@ func (db *Database) TagCreate(name, desc string, parentID int64) (*tag.Tag, erro
 				msg = fmt.Sprintf("Error starting transaction: %s\n",
 					err.Error())
 				db.log.Printf("[ERROR] %s\n", msg)
-				return nil, errors.New(msg)
+				return errors.New(msg)
 			}
 
 		} else {
@@ -2526,51 +8349,31 @@ func (db *Database) TagCreate(name, desc string, parentID int64) (*tag.Tag, erro
 	}
 
 	stmt = tx.Stmt(stmt)
-	var res sql.Result
-	var parent *int64
-
-	if parentID != 0 {
-		parent = &parentID
-	}
 
 EXEC_QUERY:
-	if res, err = stmt.Exec(name, desc, parent); err != nil {
+	if _, err = stmt.Exec(parent, t.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("Cannot add Tag %s (%s) to database: %s",
-				name,
-				desc,
+			err = fmt.Errorf("cannot set Parent of Tag %s (%d) to %d: %s",
+				t.Name,
+				t.ID,
+				parent,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
-			return nil, err
-		}
-	} else {
-		var tagID int64
-
-		if tagID, err = res.LastInsertId(); err != nil {
-			db.log.Printf("[ERROR] Cannot get ID of new Tag %q: %s\n",
-				name,
-				err.Error())
-			return nil, err
+			return err
 		}
-
-		status = true
-		return &tag.Tag{
-			ID:          tagID,
-			Name:        name,
-			Description: desc,
-			Parent:      parentID,
-		}, nil
 	}
-} // func (db *Database) TagCreate(name, desc string, parentID int64) (*tag.Tag, error)
 
-// TagDelete removes the Tag with the given ID from the database.
-//
-// This will automatically remove all links of the given Tag to Items as well.
-func (db *Database) TagDelete(id int64) error {
-	const qid = query.TagDelete
+	status = true
+	t.Parent = parent
+	return nil
+} // func (db *Database) TagParentSet(t *tag.Tag, parent int64) error
+
+// TagParentClear clears the given Tag's Parent field.
+func (db *Database) TagParentClear(t *tag.Tag) error {
+	const qid = query.TagParentClear
 	var (
 		err    error
 		msg    string
@@ -2618,13 +8421,14 @@ func (db *Database) TagDelete(id int64) error {
 	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(id); err != nil {
+	if _, err = stmt.Exec(t.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("Cannot delete Tag %d: %s",
-				id,
+			err = fmt.Errorf("cannot clear Parent of Tag %s (%d): %s",
+				t.Name,
+				t.ID,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
@@ -2632,256 +8436,231 @@ EXEC_QUERY:
 	}
 
 	status = true
+	t.Parent = 0
 	return nil
-} // func (db *Database) TagDelete(id int64) error
+} // func (db *Database) TagParentClear(t *tag.Tag) error
 
-// TagGetAll fetches all Tags from the database, in no particular order.
-func (db *Database) TagGetAll() ([]tag.Tag, error) {
-	const qid query.ID = query.TagGetAll
+// TagUpdate updates a Tag.
+func (db *Database) TagUpdate(t *tag.Tag) error {
+	const qid query.ID = query.TagUpdate
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
+			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
-	}
-
-	var rows *sql.Rows
-
-EXEC_QUERY:
-	if rows, err = stmt.Query(); err != nil {
-		if worthARetry(err) {
-			waitForRetry()
-			goto EXEC_QUERY
-		}
-
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	var tags = make([]tag.Tag, 0, 16)
-
-	for rows.Next() {
-		var (
-			t      tag.Tag
-			parent *int64
-			desc   *string
-		)
-
-		if err = rows.Scan(&t.ID, &t.Name, &desc, &parent); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return nil, err
-		}
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
 
-		if parent != nil {
-			t.Parent = *parent
-		}
-		if desc != nil {
-			t.Description = *desc
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
 		}
-
-		tags = append(tags, t)
 	}
 
-	return tags, nil
-} // func (db *Database) TagGetAll() ([]tag.Tag, error)
-
-// TagGetHierarchy retrieves a slice of all Tags, organized in a hierarchical
-// fashion.
-func (db *Database) TagGetHierarchy() ([]tag.Tag, error) {
-	const (
-		qroot     query.ID = query.TagGetRoots
-		qchildren query.ID = query.TagGetChildren
-	)
+	stmt = tx.Stmt(stmt)
 
 	var (
-		err  error
-		stmt *sql.Stmt
+		parent      *int64
+		description *string
 	)
 
-	if stmt, err = db.getQuery(qroot); err != nil {
-		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qroot,
-			err.Error())
-		return nil, err
-	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
+	if t.Parent != 0 {
+		parent = &t.Parent
 	}
 
-	var rows *sql.Rows
+	if t.Description != "" {
+		description = &t.Description
+	}
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(); err != nil {
+	if _, err = stmt.Exec(t.Name, parent, description, t.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		}
-
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	var tags = make([]tag.Tag, 0, 16)
-
-	for rows.Next() {
-		var (
-			t    tag.Tag
-			desc *string
-		)
-
-		if err = rows.Scan(&t.ID, &t.Name, &desc); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return nil, err
-		} else if t.Children, err = db.TagGetChildrenImmediate(t.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot get children of Tag %s (%d): %s\n",
+		} else {
+			err = fmt.Errorf("cannot update Tag %s (%d): %s",
 				t.Name,
 				t.ID,
 				err.Error())
-			return nil, err
-		}
-
-		if desc != nil {
-			t.Description = *desc
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
 		}
-
-		tags = append(tags, t)
 	}
 
-	return tags, nil
-} // func (db *Database) TagGetHierarchy() ([]tag.Tag, error)
+	status = true
+	return nil
+} // func (db *Database) TagUpdate(t *tag.Tag) error
 
-// TagGetAllByHierarchy returns all Tags ordered by hierarchy.
-func (db *Database) TagGetAllByHierarchy() ([]tag.Tag, error) {
-	const qid query.ID = query.TagGetAllByHierarchy
+// TagLinkCreate attaches the given Tag to the given Item.
+func (db *Database) TagLinkCreate(itemID, tagID int64) error {
+	const qid query.ID = query.TagLinkCreate
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
+			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
 	}
 
-	var rows *sql.Rows
+	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(); err != nil {
+	if _, err = stmt.Exec(tagID, itemID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		}
-
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	var tags = make([]tag.Tag, 0, 16)
-
-	for rows.Next() {
-		var (
-			t      tag.Tag
-			parent *int64
-			desc   *string
-		)
-
-		if err = rows.Scan(&t.ID, &t.Name, &desc, &parent, &t.Level, &t.Path); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+		} else {
+			err = fmt.Errorf("cannot attach Tag %d to Item %d: %s",
+				tagID,
+				itemID,
 				err.Error())
-			return nil, err
-		}
-
-		if parent != nil {
-			t.Parent = *parent
-		}
-		if desc != nil {
-			t.Description = *desc
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
 		}
-
-		tags = append(tags, t)
 	}
 
-	return tags, nil
-} // func (db *Database) TagGetAllByHierarchy() ([]tag.Tag, error)
+	status = true
+	return nil
+} // func (db *Database) TagLinkCreate(itemID, tagID int64) error
 
-// TagGetChildren fetches all the children - recursively - of the given Tag.
-func (db *Database) TagGetChildren(id int64) ([]tag.Tag, error) {
-	const qid query.ID = query.TagGetChildren
+// TagLinkDelete detaches a Tag from an Item.
+func (db *Database) TagLinkDelete(itemID, tagID int64) error {
+	const qid query.ID = query.TagLinkDelete
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
+			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
 	}
 
-	var rows *sql.Rows
+	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(id, id); err != nil {
+	if _, err = stmt.Exec(tagID, itemID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		}
-
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	var tags = make([]tag.Tag, 0, 16)
-
-	for rows.Next() {
-		var (
-			t      tag.Tag
-			parent *int64
-			desc   *string
-		)
-
-		if err = rows.Scan(&t.ID, &t.Name, &desc, &parent); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+		} else {
+			err = fmt.Errorf("cannot attach Tag %d to Item %d: %s",
+				tagID,
+				itemID,
 				err.Error())
-			return nil, err
-		}
-
-		if parent != nil {
-			t.Parent = *parent
-		}
-		if desc != nil {
-			t.Description = *desc
+			db.log.Printf("[ERROR] %s\n", err.Error())
+			return err
 		}
-
-		tags = append(tags, t)
 	}
 
-	return tags, nil
-} // func (db *Database) TagGetChildren(id int64) ([]tag.Tag, error)
+	status = true
+	return nil
+} // func (db *Database) TagLinkDelete(itemID, tagID int64) error
 
-// TagGetChildrenImmediate fetches all Tags that are directly descended from
-// the given parent Tag, i.e. Tags whose parent ID equals the argument.
-func (db *Database) TagGetChildrenImmediate(id int64) ([]tag.Tag, error) {
-	const qid query.ID = query.TagGetChildrenImmediate
+// TagLinkGetByItem returns a slice of *the IDs* of all the Tags attached
+// to a given Item.
+func (db *Database) TagLinkGetByItem(itemID int64) ([]int64, error) {
+	const qid query.ID = query.TagLinkGetByItem
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -2899,115 +8678,125 @@ func (db *Database) TagGetChildrenImmediate(id int64) ([]tag.Tag, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(id); err != nil {
+	if rows, err = stmt.Query(itemID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
+		db.log.Printf("[ERROR] Cannot load Tags for Item #%d: %s\n",
+			itemID,
+			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
-
-	var tags = make([]tag.Tag, 0, 16)
+	var tags = make([]int64, 0, 4)
 
 	for rows.Next() {
-		var (
-			desc *string
-			t    tag.Tag
-		)
+		var tagID int64
 
-		if err = rows.Scan(&t.ID, &t.Name, &desc); err != nil {
+		if err = rows.Scan(&tagID); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
 		}
 
-		t.Parent = id
-		if desc != nil {
-			t.Description = *desc
-		}
-
-		if t.Children, err = db.TagGetChildrenImmediate(t.ID); err != nil {
-			db.log.Printf("[ERROR] Cannot get immediate children of Tag %s (%d): %s\n",
-				t.Name,
-				t.ID,
-				err.Error())
-			return nil, err
-		}
-
-		tags = append(tags, t)
+		tags = append(tags, tagID)
 	}
 
 	return tags, nil
-} // func (db *Database) TagGetChildrenImmediate(id int64) ([]tag.Tag, error)
+} // func (db *Database) TagLinkGetByItem(itemID int64) ([]int64, error)
 
-// TagGetByID loads a Tag by its database ID.
-func (db *Database) TagGetByID(id int64) (*tag.Tag, error) {
-	const qid query.ID = query.TagGetByID
+// ReadLaterAdd adds a ReadLater note to the database.
+func (db *Database) ReadLaterAdd(item *feed.Item, note string, deadline time.Time) (*feed.ReadLater, error) {
+	const qid query.ID = query.ReadLaterAdd
+	var (
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid.String(),
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return nil, errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
+	}
 
+	stmt = tx.Stmt(stmt)
 	var (
-		err  error
-		stmt *sql.Stmt
+		now = time.Now()
+		res sql.Result
 	)
 
-	if stmt, err = db.getQuery(qid); err != nil {
-		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
-			err.Error())
-		return nil, err
-	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
-	}
-
-	var rows *sql.Rows
-
 EXEC_QUERY:
-	if rows, err = stmt.Query(id); err != nil {
+	if res, err = stmt.Exec(item.ID, note, now.Unix(), deadline.Unix()); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		}
-
-		db.log.Printf("[ERROR] Cannot load Tag #%d: %s\n",
-			id,
-			err.Error())
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	if rows.Next() {
-		var (
-			t      = &tag.Tag{ID: id}
-			parent *int64
-			desc   *string
-		)
-
-		if err = rows.Scan(&t.Name, &desc, &parent); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+		} else {
+			err = fmt.Errorf("Cannot add Item %s (%s) to database: %s",
+				item.Title,
+				item.URL,
 				err.Error())
+			db.log.Printf("[ERROR] %s\n", err.Error())
 			return nil, err
 		}
-
-		if parent != nil {
-			t.Parent = *parent
+	} else {
+		var later = &feed.ReadLater{
+			Item:     item,
+			ItemID:   item.ID,
+			Note:     note,
+			Deadline: deadline,
 		}
-		if desc != nil {
-			t.Description = *desc
+
+		if later.ID, err = res.LastInsertId(); err != nil {
+			db.log.Printf("[ERROR] Cannot get ID of new Item %q: %s\n",
+				item.Title,
+				err.Error())
+			return nil, err
 		}
 
-		return t, nil
+		status = true
+		return later, nil
 	}
+} // func (db *Database) ReadLaterAdd(item *feed.Item, note string, deadline time.Time) (*feed.ReadLater, error)
 
-	return nil, nil
-} // func (db *Database) TagGetByID(id int64) (*tag.Tag, error)
-
-// TagGetByName loads a Tag by its database ID.
-func (db *Database) TagGetByName(name string) (*tag.Tag, error) {
-	const qid query.ID = query.TagGetByName
-
+// ReadLaterGetByItem returns the ReadLater note for the given Item.
+func (db *Database) ReadLaterGetByItem(item *feed.Item) (*feed.ReadLater, error) {
+	const qid query.ID = query.ReadLaterGetByItem
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -3025,15 +8814,12 @@ func (db *Database) TagGetByName(name string) (*tag.Tag, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(name); err != nil {
+	if rows, err = stmt.Query(item.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		db.log.Printf("[ERROR] Cannot load Tag %s: %s\n",
-			name,
-			err.Error())
 		return nil, err
 	}
 
@@ -3041,34 +8827,45 @@ EXEC_QUERY:
 
 	if rows.Next() {
 		var (
-			t      = &tag.Tag{Name: name}
-			parent *int64
-			desc   *string
+			stamp    int64
+			deadline *int64
+			note     *string
+			later    = &feed.ReadLater{
+				Item:   item,
+				ItemID: item.ID,
+			}
 		)
 
-		if err = rows.Scan(&t.ID, &desc, &parent); err != nil {
+		if err = rows.Scan(
+			&later.ID,
+			&note,
+			&stamp,
+			&deadline,
+			&later.Read); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
 		}
 
-		if parent != nil {
-			t.Parent = *parent
+		if deadline != nil {
+			later.Deadline = time.Unix(*deadline, 0)
 		}
-		if desc != nil {
-			t.Description = *desc
+
+		if note != nil {
+			later.Note = *note
 		}
 
-		return t, nil
+		later.Timestamp = time.Unix(stamp, 0)
+
+		return later, nil
 	}
 
 	return nil, nil
-} // func (db *Database) TagGetByName(id int64) (*tag.Tag, error)
+} // func (db *Database) ReadLaterGetByItem(itemID int64) (*feed.ReadLater, error)
 
-// TagGetByItem returns a (possibly empty) slice of all Tags attached to an
-// Item.
-func (db *Database) TagGetByItem(itemID int64) ([]tag.Tag, error) {
-	const qid query.ID = query.TagGetByItem
+// ReadLaterGetAll returns all ReadLater notes.
+func (db *Database) ReadLaterGetAll() ([]feed.ReadLater, error) {
+	const qid query.ID = query.ReadLaterGetAll
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -3086,190 +8883,205 @@ func (db *Database) TagGetByItem(itemID int64) ([]tag.Tag, error) {
 	var rows *sql.Rows
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(itemID); err != nil {
+	if rows, err = stmt.Query(); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		db.log.Printf("[ERROR] Cannot load Tags for Item #%d: %s\n",
-			itemID,
-			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
-	var tags = make([]tag.Tag, 0, 4)
+
+	var items = make([]feed.ReadLater, 0, 32)
+	// var items = make(map[int64]feed.ReadLater, 32)
 
 	for rows.Next() {
 		var (
-			t      tag.Tag
-			desc   *string
-			parent *int64
+			lstamp,  istamp, iupdated int64
+			deadline *int64
+			note     *string
+			rating   *float64
+			later    feed.ReadLater
+			item     = new(feed.Item)
+			read     *bool
 		)
 
-		if err = rows.Scan(&t.ID, &t.Name, &desc, &parent); err != nil {
+		if err = rows.Scan(
+			&later.ID,
+			&later.ItemID,
+			&note,
+			&lstamp,
+			&deadline,
+			&read,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&istamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&iupdated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
+		} else if item.Tags, err = db.TagGetByItem(later.ItemID); err != nil {
+			db.log.Printf("[ERROR] Cannot load tags for Item %d: %s\n",
+				later.ItemID,
+				err.Error())
+			return nil, err
+		} else if item.Enclosures, err = db.EnclosureGetByItem(later.ItemID); err != nil {
+			db.log.Printf("[ERROR] Cannot load enclosures for Item %d: %s\n",
+				later.ItemID,
+				err.Error())
+			return nil, err
+		} else if item.Categories, err = db.CategoryGetByItem(later.ItemID); err != nil {
+			db.log.Printf("[ERROR] Cannot load categories for Item %d: %s\n",
+				later.ItemID,
+				err.Error())
+			return nil, err
 		}
 
-		if parent != nil {
-			t.Parent = *parent
-		}
-		if desc != nil {
-			t.Description = *desc
+		if read != nil {
+			later.Read = *read
 		}
 
-		tags = append(tags, t)
-	}
-
-	return tags, nil
-} // func (db *Database) TagGetByItem(itemID int64) ([]tag.Tag, error)
-
-// TagNameUpdate renames the given Tag to the new name.
-func (db *Database) TagNameUpdate(t *tag.Tag, name string) error {
-	const qid = query.TagNameUpdate
-	var (
-		err    error
-		msg    string
-		stmt   *sql.Stmt
-		tx     *sql.Tx
-		status bool
-	)
+		if deadline != nil {
+			later.Deadline = time.Unix(*deadline, 0)
+		}
 
-	if stmt, err = db.getQuery(qid); err != nil {
-		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid.String(),
-			err.Error())
-		return err
-	} else if db.tx != nil {
-		tx = db.tx
-	} else {
-	BEGIN_AD_HOC:
-		if tx, err = db.db.Begin(); err != nil {
-			if worthARetry(err) {
-				waitForRetry()
-				goto BEGIN_AD_HOC
-			} else {
-				msg = fmt.Sprintf("Error starting transaction: %s\n",
-					err.Error())
-				db.log.Printf("[ERROR] %s\n", msg)
-				return errors.New(msg)
-			}
+		if note != nil {
+			later.Note = *note
+		}
 
+		if rating != nil {
+			item.Rating = *rating
+			item.ManuallyRated = true
 		} else {
-			defer func() {
-				var err2 error
-				if status {
-					if err2 = tx.Commit(); err2 != nil {
-						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
-							err2.Error())
-					}
-				} else if err2 = tx.Rollback(); err2 != nil {
-					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
-						err2.Error())
-				}
-			}()
+			item.Rating = math.NaN()
 		}
-	}
-
-	stmt = tx.Stmt(stmt)
 
-EXEC_QUERY:
-	if _, err = stmt.Exec(name, t.ID); err != nil {
-		if worthARetry(err) {
-			waitForRetry()
-			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("Cannot rename Tag %s (%d) to %s: %s",
-				t.Name,
-				t.ID,
-				name,
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
+		later.Item = item
+		later.Timestamp = time.Unix(lstamp, 0)
+		item.ID = later.ItemID
+		item.Timestamp = time.Unix(istamp, 0)
+		if iupdated > 0 {
+			item.Updated = time.Unix(iupdated, 0)
 		}
+
+		items = append(items, later)
+		// items[later.ItemID] = later
 	}
 
-	status = true
-	t.Name = name
-	return nil
-} // func (db *Database) TagNameUpdate(t *tag.Tag, name string) error
+	return items, nil
+} // func (db *Database) ReadLaterGetAll() ([]feed.ReadLater, error)
 
-// TagDescriptionUpdate changes the given Tag's Description.
-func (db *Database) TagDescriptionUpdate(t *tag.Tag, desc string) error {
-	const qid = query.TagDescriptionUpdate
+// ReadLaterGetUnread returns all ReadLater items that are not marked a read.
+func (db *Database) ReadLaterGetUnread() (map[int64]feed.ReadLater, error) {
+	const qid query.ID = query.ReadLaterGetUnread
 	var (
-		err    error
-		msg    string
-		stmt   *sql.Stmt
-		tx     *sql.Tx
-		status bool
+		err  error
+		stmt *sql.Stmt
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid.String(),
+			qid,
 			err.Error())
-		return err
+		return nil, err
 	} else if db.tx != nil {
-		tx = db.tx
-	} else {
-	BEGIN_AD_HOC:
-		if tx, err = db.db.Begin(); err != nil {
-			if worthARetry(err) {
-				waitForRetry()
-				goto BEGIN_AD_HOC
-			} else {
-				msg = fmt.Sprintf("Error starting transaction: %s\n",
-					err.Error())
-				db.log.Printf("[ERROR] %s\n", msg)
-				return errors.New(msg)
-			}
-
-		} else {
-			defer func() {
-				var err2 error
-				if status {
-					if err2 = tx.Commit(); err2 != nil {
-						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
-							err2.Error())
-					}
-				} else if err2 = tx.Rollback(); err2 != nil {
-					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
-						err2.Error())
-				}
-			}()
-		}
+		stmt = db.tx.Stmt(stmt)
 	}
 
-	stmt = tx.Stmt(stmt)
+	var rows *sql.Rows
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(desc, t.ID); err != nil {
+	if rows, err = stmt.Query(); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("cannot update description of Tag %s (%d) to %q: %s",
-				t.Name,
-				t.ID,
-				desc,
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	// var items = make([]feed.ReadLater, 0, 32)
+	var items = make(map[int64]feed.ReadLater, 32)
+
+	if rows.Next() {
+		var (
+			lstamp,  istamp, iupdated int64
+			deadline *int64
+			note     *string
+			rating   *float64
+			later    = feed.ReadLater{Read: false}
+			item     = new(feed.Item)
+		)
+
+		if err = rows.Scan(
+			&later.ID,
+			&later.ItemID,
+			&note,
+			&lstamp,
+			&deadline,
+			&item.FeedID,
+			&item.URL,
+			&item.Title,
+			&item.Description,
+			&istamp,
+			&item.Read,
+			&rating,
+			&item.Author,
+			&item.AuthorURI,
+			&iupdated,
+			&item.DupGroup,
+			&item.OriginalURL,
+			&item.Content); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
+			return nil, err
+		}
+
+		if deadline != nil {
+			later.Deadline = time.Unix(*deadline, 0)
+		}
+
+		if note != nil {
+			later.Note = *note
+		}
+
+		if rating != nil {
+			item.Rating = *rating
+		} else {
+			item.Rating = math.NaN()
 		}
+
+		later.Timestamp = time.Unix(lstamp, 0)
+		item.Timestamp = time.Unix(istamp, 0)
+		if iupdated > 0 {
+			item.Updated = time.Unix(iupdated, 0)
+		}
+		item.ID = later.ItemID
+		later.Item = item
+
+		// items = append(items, later)
+		items[later.ItemID] = later
 	}
 
-	status = true
-	t.Description = desc
-	return nil
-} // func (db *Database) TagDescriptionUpdate(t *tag.Tag, desc string) error
+	return items, nil
+} // func (db *Database) ReadLaterGetUnread() ([]feed.ReadLater, error)
 
-// TagParentSet updates the given Tag's Parent field.
-func (db *Database) TagParentSet(t *tag.Tag, parent int64) error {
-	const qid = query.TagParentSet
+// ReadLaterMarkRead marks a ReadLater note as read.
+func (db *Database) ReadLaterMarkRead(itemID int64) error {
+	const qid query.ID = query.ReadLaterMarkRead
 	var (
 		err    error
 		msg    string
@@ -3317,15 +9129,13 @@ func (db *Database) TagParentSet(t *tag.Tag, parent int64) error {
 	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(parent, t.ID); err != nil {
+	if _, err = stmt.Exec(itemID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("cannot set Parent of Tag %s (%d) to %d: %s",
-				t.Name,
-				t.ID,
-				parent,
+			err = fmt.Errorf("cannot mark ReadLater Note %d as Read: %s",
+				itemID,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
@@ -3333,13 +9143,12 @@ EXEC_QUERY:
 	}
 
 	status = true
-	t.Parent = parent
 	return nil
-} // func (db *Database) TagParentSet(t *tag.Tag, parent int64) error
+} // func (db *Database) ReadLaterMarkRead(itemID int64) error
 
-// TagParentClear clears the given Tag's Parent field.
-func (db *Database) TagParentClear(t *tag.Tag) error {
-	const qid = query.TagParentClear
+// ReadLaterMarkUnread marks a ReadLater note as read.
+func (db *Database) ReadLaterMarkUnread(itemID int64) error {
+	const qid query.ID = query.ReadLaterMarkUnread
 	var (
 		err    error
 		msg    string
@@ -3387,14 +9196,13 @@ func (db *Database) TagParentClear(t *tag.Tag) error {
 	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(t.ID); err != nil {
+	if _, err = stmt.Exec(itemID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("cannot clear Parent of Tag %s (%d): %s",
-				t.Name,
-				t.ID,
+			err = fmt.Errorf("cannot mark ReadLater Note %d as Read: %s",
+				itemID,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
@@ -3402,13 +9210,12 @@ EXEC_QUERY:
 	}
 
 	status = true
-	t.Parent = 0
 	return nil
-} // func (db *Database) TagParentClear(t *tag.Tag) error
+} // func (db *Database) ReadLaterMarkUnread(itemID int64) error
 
-// TagUpdate updates a Tag.
-func (db *Database) TagUpdate(t *tag.Tag) error {
-	const qid query.ID = query.TagUpdate
+// ReadLaterDelete deletes a ReadLater note from the database.
+func (db *Database) ReadLaterDelete(id int64) error {
+	const qid query.ID = query.ReadLaterDelete
 	var (
 		err    error
 		msg    string
@@ -3455,28 +9262,14 @@ func (db *Database) TagUpdate(t *tag.Tag) error {
 
 	stmt = tx.Stmt(stmt)
 
-	var (
-		parent      *int64
-		description *string
-	)
-
-	if t.Parent != 0 {
-		parent = &t.Parent
-	}
-
-	if t.Description != "" {
-		description = &t.Description
-	}
-
 EXEC_QUERY:
-	if _, err = stmt.Exec(t.Name, parent, description, t.ID); err != nil {
+	if _, err = stmt.Exec(id); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("cannot update Tag %s (%d): %s",
-				t.Name,
-				t.ID,
+			err = fmt.Errorf("cannot delete ReadLater note %d: %s",
+				id,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
@@ -3485,11 +9278,11 @@ EXEC_QUERY:
 
 	status = true
 	return nil
-} // func (db *Database) TagUpdate(t *tag.Tag) error
+} // func (db *Database) ReadLaterDelete(id int64) error
 
-// TagLinkCreate attaches the given Tag to the given Item.
-func (db *Database) TagLinkCreate(itemID, tagID int64) error {
-	const qid query.ID = query.TagLinkCreate
+// ReadLaterSetDeadline updates the deadline of a ReadLater note.
+func (db *Database) ReadLaterSetDeadline(l *feed.ReadLater, deadline time.Time) error {
+	const qid query.ID = query.ReadLaterSetDeadine
 	var (
 		err    error
 		msg    string
@@ -3537,14 +9330,14 @@ func (db *Database) TagLinkCreate(itemID, tagID int64) error {
 	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(tagID, itemID); err != nil {
+	if _, err = stmt.Exec(deadline.Unix(), l.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("cannot attach Tag %d to Item %d: %s",
-				tagID,
-				itemID,
+			err = fmt.Errorf("cannot set deadline of ReadLater note %d to %s: %s",
+				l.ID,
+				l.Timestamp.Format(common.TimestampFormat),
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
@@ -3552,12 +9345,13 @@ EXEC_QUERY:
 	}
 
 	status = true
+	l.Deadline = deadline
 	return nil
-} // func (db *Database) TagLinkCreate(itemID, tagID int64) error
+} // func (db *Database) ReadLaterSetDeadline(l *feed.ReadLater, deadline time.Time) error
 
-// TagLinkDelete detaches a Tag from an Item.
-func (db *Database) TagLinkDelete(itemID, tagID int64) error {
-	const qid query.ID = query.TagLinkDelete
+// ReadLaterSetNote updates the Note on a ReadLater item.
+func (db *Database) ReadLaterSetNote(l *feed.ReadLater, note string) error {
+	const qid query.ID = query.ReadLaterSetNote
 	var (
 		err    error
 		msg    string
@@ -3605,14 +9399,13 @@ func (db *Database) TagLinkDelete(itemID, tagID int64) error {
 	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(tagID, itemID); err != nil {
+	if _, err = stmt.Exec(note, l.ID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		} else {
-			err = fmt.Errorf("cannot attach Tag %d to Item %d: %s",
-				tagID,
-				itemID,
+			err = fmt.Errorf("cannot set Note of ReadLater note %d: %s",
+				l.ID,
 				err.Error())
 			db.log.Printf("[ERROR] %s\n", err.Error())
 			return err
@@ -3620,63 +9413,14 @@ EXEC_QUERY:
 	}
 
 	status = true
-	return nil
-} // func (db *Database) TagLinkDelete(itemID, tagID int64) error
-
-// TagLinkGetByItem returns a slice of *the IDs* of all the Tags attached
-// to a given Item.
-func (db *Database) TagLinkGetByItem(itemID int64) ([]int64, error) {
-	const qid query.ID = query.TagLinkGetByItem
-	var (
-		err  error
-		stmt *sql.Stmt
-	)
-
-	if stmt, err = db.getQuery(qid); err != nil {
-		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
-			err.Error())
-		return nil, err
-	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
-	}
-
-	var rows *sql.Rows
-
-EXEC_QUERY:
-	if rows, err = stmt.Query(itemID); err != nil {
-		if worthARetry(err) {
-			waitForRetry()
-			goto EXEC_QUERY
-		}
-
-		db.log.Printf("[ERROR] Cannot load Tags for Item #%d: %s\n",
-			itemID,
-			err.Error())
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-	var tags = make([]int64, 0, 4)
-
-	for rows.Next() {
-		var tagID int64
-
-		if err = rows.Scan(&tagID); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return nil, err
-		}
-
-		tags = append(tags, tagID)
-	}
-
-	return tags, nil
-} // func (db *Database) TagLinkGetByItem(itemID int64) ([]int64, error)
+	l.Note = note
+	return nil
+} // func (db *Database) ReadLaterSetNote(l *feed.ReadLater, note string) error
 
-// ReadLaterAdd adds a ReadLater note to the database.
-func (db *Database) ReadLaterAdd(item *feed.Item, note string, deadline time.Time) (*feed.ReadLater, error) {
-	const qid query.ID = query.ReadLaterAdd
+// RetentionSet stores the given Retention policy, replacing the one that was
+// set for the same Feed before, if any.
+func (db *Database) RetentionSet(r *feed.Retention) error {
+	const qid = query.RetentionSet
 	var (
 		err    error
 		msg    string
@@ -3685,11 +9429,14 @@ func (db *Database) ReadLaterAdd(item *feed.Item, note string, deadline time.Tim
 		status bool
 	)
 
-	if stmt, err = db.getQuery(qid); err != nil {
+	if r.MaxAge < feed.Day {
+		return fmt.Errorf("Retention period must be at least one day, not %s",
+			r.MaxAge)
+	} else if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
 		tx = db.tx
 	} else {
@@ -3702,7 +9449,7 @@ func (db *Database) ReadLaterAdd(item *feed.Item, note string, deadline time.Tim
 				msg = fmt.Sprintf("Error starting transaction: %s\n",
 					err.Error())
 				db.log.Printf("[ERROR] %s\n", msg)
-				return nil, errors.New(msg)
+				return errors.New(msg)
 			}
 
 		} else {
@@ -3722,116 +9469,95 @@ func (db *Database) ReadLaterAdd(item *feed.Item, note string, deadline time.Tim
 	}
 
 	stmt = tx.Stmt(stmt)
-	var (
-		now = time.Now()
-		res sql.Result
-	)
 
 EXEC_QUERY:
-	if res, err = stmt.Exec(item.ID, note, now.Unix(), deadline.Unix()); err != nil {
+	if _, err = stmt.Exec(r.FeedID, int64(r.MaxAge.Seconds()), r.ReadOnly); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("Cannot add Item %s (%s) to database: %s",
-				item.Title,
-				item.URL,
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return nil, err
-		}
-	} else {
-		var later = &feed.ReadLater{
-			Item:     item,
-			ItemID:   item.ID,
-			Note:     note,
-			Deadline: deadline,
-		}
-
-		if later.ID, err = res.LastInsertId(); err != nil {
-			db.log.Printf("[ERROR] Cannot get ID of new Item %q: %s\n",
-				item.Title,
-				err.Error())
-			return nil, err
 		}
 
-		status = true
-		return later, nil
+		db.log.Printf("[ERROR] Cannot set Retention policy for Feed %d: %s\n",
+			r.FeedID,
+			err.Error())
+		return err
 	}
-} // func (db *Database) ReadLaterAdd(item *feed.Item, note string, deadline time.Time) (*feed.ReadLater, error)
 
-// ReadLaterGetByItem returns the ReadLater note for the given Item.
-func (db *Database) ReadLaterGetByItem(item *feed.Item) (*feed.ReadLater, error) {
-	const qid query.ID = query.ReadLaterGetByItem
+	status = true
+	return nil
+} // func (db *Database) RetentionSet(r *feed.Retention) error
+
+// RetentionDelete removes the Retention policy of the given Feed. A Feed ID
+// of 0 removes the global policy.
+func (db *Database) RetentionDelete(feedID int64) error {
+	const qid = query.RetentionDelete
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
 	)
 
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
+			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
+
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
+		}
 	}
 
-	var rows *sql.Rows
+	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if rows, err = stmt.Query(item.ID); err != nil {
+	if _, err = stmt.Exec(feedID); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
 		}
 
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	if rows.Next() {
-		var (
-			stamp    int64
-			deadline *int64
-			note     *string
-			later    = &feed.ReadLater{
-				Item:   item,
-				ItemID: item.ID,
-			}
-		)
-
-		if err = rows.Scan(
-			&later.ID,
-			&note,
-			&stamp,
-			&deadline,
-			&later.Read); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return nil, err
-		}
-
-		if deadline != nil {
-			later.Deadline = time.Unix(*deadline, 0)
-		}
-
-		if note != nil {
-			later.Note = *note
-		}
-
-		later.Timestamp = time.Unix(stamp, 0)
-
-		return later, nil
+		db.log.Printf("[ERROR] Cannot delete Retention policy for Feed %d: %s\n",
+			feedID,
+			err.Error())
+		return err
 	}
 
-	return nil, nil
-} // func (db *Database) ReadLaterGetByItem(itemID int64) (*feed.ReadLater, error)
+	status = true
+	return nil
+} // func (db *Database) RetentionDelete(feedID int64) error
 
-// ReadLaterGetAll returns all ReadLater notes.
-func (db *Database) ReadLaterGetAll() ([]feed.ReadLater, error) {
-	const qid query.ID = query.ReadLaterGetAll
+// RetentionGetAll returns all Retention policies, the global one, if any,
+// first.
+func (db *Database) RetentionGetAll() ([]feed.Retention, error) {
+	const qid = query.RetentionGetAll
 	var (
 		err  error
 		stmt *sql.Stmt
@@ -3855,170 +9581,152 @@ EXEC_QUERY:
 			goto EXEC_QUERY
 		}
 
+		db.log.Printf("[ERROR] Cannot load Retention policies: %s\n",
+			err.Error())
 		return nil, err
 	}
 
 	defer rows.Close() // nolint: errcheck,gosec
 
-	var items = make([]feed.ReadLater, 0, 32)
-	// var items = make(map[int64]feed.ReadLater, 32)
+	var list []feed.Retention
 
 	for rows.Next() {
 		var (
-			lstamp, istamp int64
-			deadline       *int64
-			note           *string
-			rating         *float64
-			later          feed.ReadLater
-			item           = new(feed.Item)
-			read           *bool
+			secs int64
+			r    feed.Retention
 		)
 
-		if err = rows.Scan(
-			&later.ID,
-			&later.ItemID,
-			&note,
-			&lstamp,
-			&deadline,
-			&read,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&istamp,
-			&item.Read,
-			&rating); err != nil {
+		if err = rows.Scan(&r.FeedID, &secs, &r.ReadOnly); err != nil {
 			db.log.Printf("[ERROR] Cannot scan row: %s\n",
 				err.Error())
 			return nil, err
-		} else if item.Tags, err = db.TagGetByItem(later.ItemID); err != nil {
-			db.log.Printf("[ERROR] Cannot load tags for Item %d: %s\n",
-				later.ItemID,
-				err.Error())
-			return nil, err
 		}
 
-		if read != nil {
-			later.Read = *read
-		}
+		r.MaxAge = time.Second * time.Duration(secs)
+		list = append(list, r)
+	}
 
-		if deadline != nil {
-			later.Deadline = time.Unix(*deadline, 0)
-		}
+	return list, nil
+} // func (db *Database) RetentionGetAll() ([]feed.Retention, error)
 
-		if note != nil {
-			later.Note = *note
-		}
+// ruleArgs returns the values of the Rule's columns, in the order the
+// queries expect them.
+func ruleArgs(r *feed.Rule) []interface{} {
+	var (
+		feedID, tagID *int64
+		rating        *float64
+	)
 
-		if rating != nil {
-			item.Rating = *rating
-			item.ManuallyRated = true
-		} else {
-			item.Rating = math.NaN()
-		}
+	if r.FeedID != 0 {
+		feedID = &r.FeedID
+	}
 
-		later.Item = item
-		later.Timestamp = time.Unix(lstamp, 0)
-		item.ID = later.ItemID
-		item.Timestamp = time.Unix(istamp, 0)
+	if r.TagID != 0 {
+		tagID = &r.TagID
+	}
 
-		items = append(items, later)
-		// items[later.ItemID] = later
+	if r.Rate {
+		rating = &r.Rating
 	}
 
-	return items, nil
-} // func (db *Database) ReadLaterGetAll() ([]feed.ReadLater, error)
+	return []interface{}{
+		r.Name,
+		r.Active,
+		feedID,
+		r.Title,
+		r.Description,
+		r.Author,
+		r.Language,
+		r.Domain,
+		tagID,
+		rating,
+		r.MarkRead,
+		r.ReadLater,
+		r.Archive,
+		r.Drop,
+	}
+} // func ruleArgs(r *feed.Rule) []interface{}
 
-// ReadLaterGetUnread returns all ReadLater items that are not marked a read.
-func (db *Database) ReadLaterGetUnread() (map[int64]feed.ReadLater, error) {
-	const qid query.ID = query.ReadLaterGetUnread
+// RuleAdd adds a new Rule to the database.
+func (db *Database) RuleAdd(r *feed.Rule) error {
+	const qid = query.RuleAdd
 	var (
-		err  error
-		stmt *sql.Stmt
+		err    error
+		msg    string
+		stmt   *sql.Stmt
+		tx     *sql.Tx
+		status bool
 	)
 
+	if err = r.Validate(); err != nil {
+		return err
+	}
+
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
-			qid,
+			qid.String(),
 			err.Error())
-		return nil, err
+		return err
 	} else if db.tx != nil {
-		stmt = db.tx.Stmt(stmt)
-	}
-
-	var rows *sql.Rows
-
-EXEC_QUERY:
-	if rows, err = stmt.Query(); err != nil {
-		if worthARetry(err) {
-			waitForRetry()
-			goto EXEC_QUERY
-		}
-
-		return nil, err
-	}
-
-	defer rows.Close() // nolint: errcheck,gosec
-
-	// var items = make([]feed.ReadLater, 0, 32)
-	var items = make(map[int64]feed.ReadLater, 32)
-
-	if rows.Next() {
-		var (
-			lstamp, istamp int64
-			deadline       *int64
-			note           *string
-			rating         *float64
-			later          = feed.ReadLater{Read: false}
-			item           = new(feed.Item)
-		)
-
-		if err = rows.Scan(
-			&later.ID,
-			&later.ItemID,
-			&note,
-			&lstamp,
-			&deadline,
-			&item.FeedID,
-			&item.URL,
-			&item.Title,
-			&item.Description,
-			&istamp,
-			&rating); err != nil {
-			db.log.Printf("[ERROR] Cannot scan row: %s\n",
-				err.Error())
-			return nil, err
-		}
+		tx = db.tx
+	} else {
+	BEGIN_AD_HOC:
+		if tx, err = db.db.Begin(); err != nil {
+			if worthARetry(err) {
+				waitForRetry()
+				goto BEGIN_AD_HOC
+			} else {
+				msg = fmt.Sprintf("Error starting transaction: %s\n",
+					err.Error())
+				db.log.Printf("[ERROR] %s\n", msg)
+				return errors.New(msg)
+			}
 
-		if deadline != nil {
-			later.Deadline = time.Unix(*deadline, 0)
+		} else {
+			defer func() {
+				var err2 error
+				if status {
+					if err2 = tx.Commit(); err2 != nil {
+						db.log.Printf("[ERROR] Failed to commit ad-hoc transaction: %s\n",
+							err2.Error())
+					}
+				} else if err2 = tx.Rollback(); err2 != nil {
+					db.log.Printf("[ERROR] Rollback of ad-hoc transaction failed: %s\n",
+						err2.Error())
+				}
+			}()
 		}
+	}
 
-		if note != nil {
-			later.Note = *note
-		}
+	stmt = tx.Stmt(stmt)
+	var res sql.Result
 
-		if rating != nil {
-			item.Rating = *rating
-		} else {
-			item.Rating = math.NaN()
+EXEC_QUERY:
+	if res, err = stmt.Exec(ruleArgs(r)...); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
 		}
 
-		later.Timestamp = time.Unix(lstamp, 0)
-		item.Timestamp = time.Unix(istamp, 0)
-		item.ID = later.ItemID
-		later.Item = item
-
-		// items = append(items, later)
-		items[later.ItemID] = later
+		err = fmt.Errorf("Cannot add Rule %q to database: %s",
+			r.Name,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if r.ID, err = res.LastInsertId(); err != nil {
+		db.log.Printf("[ERROR] Cannot get ID of new Rule %q: %s\n",
+			r.Name,
+			err.Error())
+		return err
 	}
 
-	return items, nil
-} // func (db *Database) ReadLaterGetUnread() ([]feed.ReadLater, error)
+	status = true
+	return nil
+} // func (db *Database) RuleAdd(r *feed.Rule) error
 
-// ReadLaterMarkRead marks a ReadLater note as read.
-func (db *Database) ReadLaterMarkRead(itemID int64) error {
-	const qid query.ID = query.ReadLaterMarkRead
+// RuleUpdate replaces the stored Rule with the same ID as r.
+func (db *Database) RuleUpdate(r *feed.Rule) error {
+	const qid = query.RuleUpdate
 	var (
 		err    error
 		msg    string
@@ -4027,6 +9735,10 @@ func (db *Database) ReadLaterMarkRead(itemID int64) error {
 		status bool
 	)
 
+	if err = r.Validate(); err != nil {
+		return err
+	}
+
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid.String(),
@@ -4064,28 +9776,41 @@ func (db *Database) ReadLaterMarkRead(itemID int64) error {
 	}
 
 	stmt = tx.Stmt(stmt)
+	var (
+		cnt int64
+		res sql.Result
+	)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(itemID); err != nil {
+	if res, err = stmt.Exec(append(ruleArgs(r), r.ID)...); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("cannot mark ReadLater Note %d as Read: %s",
-				itemID,
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
 		}
+
+		db.log.Printf("[ERROR] Cannot update Rule %q (%d): %s\n",
+			r.Name,
+			r.ID,
+			err.Error())
+		return err
+	} else if cnt, err = res.RowsAffected(); err != nil {
+		db.log.Printf("[ERROR] Cannot query number of rows affected: %s\n",
+			err.Error())
+		return err
+	} else if cnt != 1 {
+		err = fmt.Errorf("Unexpected number of rows affected: %d (expected 1)",
+			cnt)
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
 	}
 
 	status = true
 	return nil
-} // func (db *Database) ReadLaterMarkRead(itemID int64) error
+} // func (db *Database) RuleUpdate(r *feed.Rule) error
 
-// ReadLaterMarkUnread marks a ReadLater note as read.
-func (db *Database) ReadLaterMarkUnread(itemID int64) error {
-	const qid query.ID = query.ReadLaterMarkUnread
+// RuleDelete removes the Rule with the given ID.
+func (db *Database) RuleDelete(id int64) error {
+	const qid = query.RuleDelete
 	var (
 		err    error
 		msg    string
@@ -4133,26 +9858,25 @@ func (db *Database) ReadLaterMarkUnread(itemID int64) error {
 	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(itemID); err != nil {
+	if _, err = stmt.Exec(id); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("cannot mark ReadLater Note %d as Read: %s",
-				itemID,
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
 		}
+
+		db.log.Printf("[ERROR] Cannot delete Rule %d: %s\n",
+			id,
+			err.Error())
+		return err
 	}
 
 	status = true
 	return nil
-} // func (db *Database) ReadLaterMarkUnread(itemID int64) error
+} // func (db *Database) RuleDelete(id int64) error
 
-// ReadLaterDelete deletes a ReadLater note from the database.
-func (db *Database) ReadLaterDelete(id int64) error {
-	const qid query.ID = query.ReadLaterDelete
+// RuleSetActive enables or disables the Rule with the given ID.
+func (db *Database) RuleSetActive(id int64, active bool) error {
+	const qid = query.RuleSetActive
 	var (
 		err    error
 		msg    string
@@ -4200,34 +9924,120 @@ func (db *Database) ReadLaterDelete(id int64) error {
 	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(id); err != nil {
+	if _, err = stmt.Exec(active, id); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("cannot delete ReadLater note %d: %s",
-				id,
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
 		}
+
+		db.log.Printf("[ERROR] Cannot set active flag of Rule %d: %s\n",
+			id,
+			err.Error())
+		return err
 	}
 
 	status = true
 	return nil
-} // func (db *Database) ReadLaterDelete(id int64) error
+} // func (db *Database) RuleSetActive(id int64, active bool) error
 
-// ReadLaterSetDeadline updates the deadline of a ReadLater note.
-func (db *Database) ReadLaterSetDeadline(l *feed.ReadLater, deadline time.Time) error {
-	const qid query.ID = query.ReadLaterSetDeadine
+// RuleGetAll returns all Rules, in the order they were created.
+func (db *Database) RuleGetAll() ([]feed.Rule, error) {
+	return db.ruleGet(query.RuleGetAll)
+} // func (db *Database) RuleGetAll() ([]feed.Rule, error)
+
+// RuleGetActive returns the Rules that are currently active, in the order
+// they were created.
+func (db *Database) RuleGetActive() ([]feed.Rule, error) {
+	return db.ruleGet(query.RuleGetActive)
+} // func (db *Database) RuleGetActive() ([]feed.Rule, error)
+
+func (db *Database) ruleGet(qid query.ID) ([]feed.Rule, error) {
 	var (
-		err    error
-		msg    string
-		stmt   *sql.Stmt
-		tx     *sql.Tx
-		status bool
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Rules: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var list []feed.Rule
+
+	for rows.Next() {
+		var (
+			r      feed.Rule
+			rating *float64
+		)
+
+		if err = rows.Scan(
+			&r.ID,
+			&r.Name,
+			&r.Active,
+			&r.FeedID,
+			&r.Title,
+			&r.Description,
+			&r.Author,
+			&r.Language,
+			&r.Domain,
+			&r.TagID,
+			&rating,
+			&r.MarkRead,
+			&r.ReadLater,
+			&r.Archive,
+			&r.Drop); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		} else if rating != nil {
+			r.Rate = true
+			r.Rating = *rating
+		}
+
+		list = append(list, r)
+	}
+
+	return list, nil
+} // func (db *Database) ruleGet(qid query.ID) ([]feed.Rule, error)
+
+// MuteAdd adds a new Mute to the database. If the Mute has no creation
+// time, it is set to the current time.
+func (db *Database) MuteAdd(m *feed.Mute) error {
+	const qid = query.MuteAdd
+	var (
+		err             error
+		msg             string
+		stmt            *sql.Stmt
+		tx              *sql.Tx
+		status          bool
+		feedID, groupID *int64
+		expires         *int64
 	)
 
+	if err = m.Validate(); err != nil {
+		return err
+	}
+
 	if stmt, err = db.getQuery(qid); err != nil {
 		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
 			qid.String(),
@@ -4264,31 +10074,52 @@ func (db *Database) ReadLaterSetDeadline(l *feed.ReadLater, deadline time.Time)
 		}
 	}
 
+	if m.Created.IsZero() {
+		m.Created = time.Now()
+	}
+
+	if m.FeedID != 0 {
+		feedID = &m.FeedID
+	}
+
+	if m.GroupID != 0 {
+		groupID = &m.GroupID
+	}
+
+	if !m.Expires.IsZero() {
+		var stamp = m.Expires.Unix()
+		expires = &stamp
+	}
+
 	stmt = tx.Stmt(stmt)
+	var res sql.Result
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(deadline.Unix(), l.ID); err != nil {
+	if res, err = stmt.Exec(m.Pattern, m.Regex, feedID, groupID, m.Created.Unix(), expires); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("cannot set deadline of ReadLater note %d to %s: %s",
-				l.ID,
-				l.Timestamp.Format(common.TimestampFormat),
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
 		}
+
+		err = fmt.Errorf("Cannot add Mute %s to database: %s",
+			m,
+			err.Error())
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	} else if m.ID, err = res.LastInsertId(); err != nil {
+		db.log.Printf("[ERROR] Cannot get ID of new Mute %s: %s\n",
+			m,
+			err.Error())
+		return err
 	}
 
 	status = true
-	l.Deadline = deadline
 	return nil
-} // func (db *Database) ReadLaterSetDeadline(l *feed.ReadLater, deadline time.Time) error
+} // func (db *Database) MuteAdd(m *feed.Mute) error
 
-// ReadLaterSetNote updates the Note on a ReadLater item.
-func (db *Database) ReadLaterSetNote(l *feed.ReadLater, note string) error {
-	const qid query.ID = query.ReadLaterSetNote
+// MuteDelete removes the Mute with the given ID.
+func (db *Database) MuteDelete(id int64) error {
+	const qid = query.MuteDelete
 	var (
 		err    error
 		msg    string
@@ -4336,20 +10167,92 @@ func (db *Database) ReadLaterSetNote(l *feed.ReadLater, note string) error {
 	stmt = tx.Stmt(stmt)
 
 EXEC_QUERY:
-	if _, err = stmt.Exec(note, l.ID); err != nil {
+	if _, err = stmt.Exec(id); err != nil {
 		if worthARetry(err) {
 			waitForRetry()
 			goto EXEC_QUERY
-		} else {
-			err = fmt.Errorf("cannot set Note of ReadLater note %d: %s",
-				l.ID,
-				err.Error())
-			db.log.Printf("[ERROR] %s\n", err.Error())
-			return err
 		}
+
+		db.log.Printf("[ERROR] Cannot delete Mute %d: %s\n",
+			id,
+			err.Error())
+		return err
 	}
 
 	status = true
-	l.Note = note
 	return nil
-} // func (db *Database) ReadLaterSetNote(l *feed.ReadLater, note string) error
+} // func (db *Database) MuteDelete(id int64) error
+
+// MuteGetAll returns all Mutes, including expired ones, the most recent
+// first.
+func (db *Database) MuteGetAll() ([]feed.Mute, error) {
+	return db.muteGet(query.MuteGetAll)
+} // func (db *Database) MuteGetAll() ([]feed.Mute, error)
+
+// MuteGetActive returns the Mutes that have not expired, yet.
+func (db *Database) MuteGetActive() ([]feed.Mute, error) {
+	return db.muteGet(query.MuteGetActive, time.Now().Unix())
+} // func (db *Database) MuteGetActive() ([]feed.Mute, error)
+
+func (db *Database) muteGet(qid query.ID, args ...interface{}) ([]feed.Mute, error) {
+	var (
+		err  error
+		stmt *sql.Stmt
+	)
+
+	if stmt, err = db.getQuery(qid); err != nil {
+		db.log.Printf("[ERROR] Cannot prepare query %s: %s\n",
+			qid,
+			err.Error())
+		return nil, err
+	} else if db.tx != nil {
+		stmt = db.tx.Stmt(stmt)
+	}
+
+	var rows *sql.Rows
+
+EXEC_QUERY:
+	if rows, err = stmt.Query(args...); err != nil {
+		if worthARetry(err) {
+			waitForRetry()
+			goto EXEC_QUERY
+		}
+
+		db.log.Printf("[ERROR] Cannot load Mutes: %s\n",
+			err.Error())
+		return nil, err
+	}
+
+	defer rows.Close() // nolint: errcheck,gosec
+
+	var list []feed.Mute
+
+	for rows.Next() {
+		var (
+			m                feed.Mute
+			created, expires int64
+		)
+
+		if err = rows.Scan(
+			&m.ID,
+			&m.Pattern,
+			&m.Regex,
+			&m.FeedID,
+			&m.GroupID,
+			&created,
+			&expires); err != nil {
+			db.log.Printf("[ERROR] Cannot scan row: %s\n",
+				err.Error())
+			return nil, err
+		}
+
+		m.Created = time.Unix(created, 0)
+		if expires != 0 {
+			m.Expires = time.Unix(expires, 0)
+		}
+
+		list = append(list, m)
+	}
+
+	return list, nil
+} // func (db *Database) muteGet(qid query.ID, args ...interface{}) ([]feed.Mute, error)
@@ -6,7 +6,18 @@
 
 package database
 
-import "github.com/blicero/ticker/query"
+import (
+	"strings"
+
+	"github.com/blicero/ticker/query"
+)
+
+// idBatchSize is the number of IDs that queries taking a list of IDs accept
+// at once.
+const idBatchSize = 32
+
+// idPlaceholders is the parameter list for queries that take a list of IDs.
+var idPlaceholders = strings.TrimSuffix(strings.Repeat("?, ", idBatchSize), ", ")
 
 var dbQueries = map[query.ID]string{
 	query.FeedAdd: `
@@ -21,7 +32,24 @@ SELECT
      homepage,
      refresh_interval,
      refresh_timestamp,
-     active
+     active,
+     etag,
+     last_modified,
+     fail_count,
+     fail_since,
+     last_error,
+     last_success,
+     http_status,
+     adaptive,
+     interval_min,
+     interval_max,
+     adaptive_interval,
+     auth,
+     COALESCE((SELECT expires FROM websub
+               WHERE websub.feed_id = feed.id AND websub.state = 1), 0),
+     COALESCE(group_id, 0),
+     scraper,
+     full_text
 FROM feed
 `,
 	query.FeedGetDue: `
@@ -32,9 +60,48 @@ SELECT
      homepage,
      refresh_interval,
      refresh_timestamp,
-     active
-FROM feed
-WHERE active = 1 AND refresh_timestamp + refresh_interval < ?
+     active,
+     etag,
+     last_modified,
+     fail_count,
+     fail_since,
+     last_error,
+     last_success,
+     http_status,
+     adaptive,
+     interval_min,
+     interval_max,
+     adaptive_interval,
+     auth,
+     push_until,
+     COALESCE(group_id, 0),
+     scraper,
+     full_text
+FROM (SELECT *,
+             CASE WHEN push_until > ?
+                  THEN MAX(base_interval, ?)
+                  ELSE base_interval
+             END AS effective_interval
+      FROM (SELECT *,
+                   CASE WHEN adaptive <> 0 AND adaptive_interval > 0
+                        THEN MIN(MAX(adaptive_interval, bound_min), bound_max)
+                        ELSE refresh_interval
+                   END AS base_interval,
+                   COALESCE((SELECT expires FROM websub
+                             WHERE websub.feed_id = f.id AND websub.state = 1), 0)
+                     AS push_until
+            FROM (SELECT *,
+                         MAX(bound_min,
+                             CASE WHEN interval_max > 0 THEN interval_max ELSE ? END)
+                           AS bound_max
+                  FROM (SELECT *,
+                               CASE WHEN interval_min > 0 THEN interval_min ELSE ? END
+                                 AS bound_min
+                        FROM feed)) f))
+WHERE active = 1
+  AND refresh_timestamp +
+      MIN(effective_interval * (1 << MIN(fail_count, ?)),
+          MAX(effective_interval, ?)) < ?
 `,
 	query.FeedGetByID: `
 SELECT
@@ -43,15 +110,140 @@ SELECT
      homepage,
      refresh_interval,
      refresh_timestamp,
-     active
+     active,
+     etag,
+     last_modified,
+     fail_count,
+     fail_since,
+     last_error,
+     last_success,
+     http_status,
+     adaptive,
+     interval_min,
+     interval_max,
+     adaptive_interval,
+     auth,
+     COALESCE((SELECT expires FROM websub
+               WHERE websub.feed_id = feed.id AND websub.state = 1), 0),
+     COALESCE(group_id, 0),
+     scraper,
+     full_text
 FROM feed
 WHERE id = ?
+`,
+	query.FeedGetByURL: `
+SELECT id FROM feed WHERE url = ?
+UNION ALL
+SELECT feed_id FROM feed_url_history WHERE url = ?
+LIMIT 1
 `,
 	query.FeedSetActive: "UPDATE feed SET active = ? WHERE id = ?",
 	query.FeedSetTimestamp: `
 UPDATE feed
 SET refresh_timestamp = ?
 WHERE id = ?
+`,
+	query.FeedSetCacheInfo: `
+UPDATE feed
+SET etag = ?,
+    last_modified = ?
+WHERE id = ?
+`,
+	query.FeedSetSuccess: `
+UPDATE feed
+SET fail_count = 0,
+    fail_since = 0,
+    last_error = '',
+    last_success = ?,
+    http_status = ?,
+    fetch_cnt = fetch_cnt + 1
+WHERE id = ?
+`,
+	query.FeedSetFailure: `
+UPDATE feed
+SET fail_count = fail_count + 1,
+    fail_since = CASE WHEN fail_count = 0 THEN ? ELSE fail_since END,
+    last_error = ?,
+    http_status = ?,
+    fetch_cnt = fetch_cnt + 1,
+    error_cnt = error_cnt + 1
+WHERE id = ?
+`,
+	query.FeedSetAdaptive: `
+UPDATE feed
+SET adaptive = ?,
+    interval_min = ?,
+    interval_max = ?,
+    adaptive_interval = ?
+WHERE id = ?
+`,
+	query.FeedSetAdaptiveInterval: "UPDATE feed SET adaptive_interval = ? WHERE id = ?",
+	query.FeedSetAuth:             "UPDATE feed SET auth = ? WHERE id = ?",
+	query.FeedSetScraper:          "UPDATE feed SET scraper = ? WHERE id = ?",
+	query.FeedSetFullText:         "UPDATE feed SET full_text = ? WHERE id = ?",
+	query.FeedGetItemStamps: `
+SELECT timestamp
+FROM item
+WHERE feed_id = ?
+ORDER BY timestamp DESC
+LIMIT ?
+`,
+	query.FeedGetUnread: `
+SELECT
+    feed_id,
+    COUNT(id) AS cnt
+FROM item
+WHERE read = 0
+GROUP BY feed_id
+`,
+	query.FeedStatsSummary: `
+SELECT
+    f.id,
+    COUNT(i.id),
+    COALESCE(SUM(i.read = 0), 0),
+    COALESCE(SUM(i.rating = 1.0), 0),
+    COALESCE(SUM(i.rating = 0.0), 0),
+    COALESCE(SUM(LENGTH(CASE WHEN i.content <> '' THEN i.content ELSE i.description END)), 0),
+    COALESCE(MIN(i.timestamp), 0),
+    COALESCE(MAX(i.timestamp), 0),
+    f.fetch_cnt,
+    f.error_cnt,
+    f.last_success
+FROM feed f
+LEFT OUTER JOIN item i ON i.feed_id = f.id
+WHERE ? = 0 OR f.id = ?
+GROUP BY f.id
+`,
+	query.FeedStatsPerDay: `
+SELECT
+    date(timestamp, 'unixepoch', 'localtime') AS day,
+    COUNT(id)
+FROM item
+WHERE (? = 0 OR feed_id = ?) AND timestamp >= ?
+GROUP BY day
+ORDER BY day
+`,
+	query.FeedStatsPerWeek: `
+SELECT
+    date(timestamp, 'unixepoch', 'localtime', 'weekday 0', '-6 days') AS week,
+    COUNT(id)
+FROM item
+WHERE (? = 0 OR feed_id = ?) AND timestamp >= ?
+GROUP BY week
+ORDER BY week
+`,
+	query.FeedStatsTags: `
+SELECT
+    t.id,
+    t.name,
+    COUNT(l.item_id) AS cnt
+FROM tag_link l
+INNER JOIN tag t ON l.tag_id = t.id
+INNER JOIN item i ON l.item_id = i.id
+WHERE ? = 0 OR i.feed_id = ?
+GROUP BY t.id
+ORDER BY cnt DESC, t.name
+LIMIT ?
 `,
 	query.FeedDelete: "DELETE FROM feed WHERE id = ?",
 	query.FeedModify: `
@@ -62,11 +254,131 @@ UPDATE feed SET
     refresh_interval	= ?
 WHERE id = ?
 `,
+	query.FeedURLHistoryAdd: `
+INSERT INTO feed_url_history (feed_id, url, moved)
+VALUES (?, ?, ?)
+ON CONFLICT (feed_id, url) DO UPDATE
+    SET moved = excluded.moved
+`,
+	query.FeedURLHistoryGet: `
+SELECT
+    url,
+    moved
+FROM feed_url_history
+WHERE feed_id = ?
+ORDER BY moved DESC
+`,
+	query.FeedSetGroup:   "UPDATE feed SET group_id = ? WHERE id = ?",
+	query.GroupAdd:       "INSERT INTO feed_group (name, parent) VALUES (?, ?)",
+	query.GroupDelete:    "DELETE FROM feed_group WHERE id = ?",
+	query.GroupRename:    "UPDATE feed_group SET name = ? WHERE id = ?",
+	query.GroupSetParent: "UPDATE feed_group SET parent = ? WHERE id = ?",
+	query.GroupGetAll: `
+WITH RECURSIVE children(id, name, parent, lvl, full_name) AS (
+    SELECT
+        id,
+        name,
+        0 AS parent,
+        0 AS lvl,
+        name AS full_name
+    FROM feed_group WHERE parent IS NULL
+    UNION ALL
+    SELECT
+        g.id,
+        g.name,
+        g.parent,
+        lvl + 1 AS lvl,
+        full_name || '/' || g.name AS full_name
+    FROM feed_group g, children
+    WHERE g.parent = children.id
+)
+
+SELECT
+    id,
+    name,
+    parent,
+    lvl,
+    full_name
+FROM children
+ORDER BY full_name
+`,
+	query.GroupGetUnread: `
+WITH RECURSIVE sub(root, id) AS (
+    SELECT id, id FROM feed_group
+    UNION ALL
+    SELECT sub.root, g.id FROM feed_group g INNER JOIN sub ON g.parent = sub.id
+)
+
+SELECT
+    sub.root,
+    COUNT(i.id) AS cnt
+FROM sub
+INNER JOIN feed f ON f.group_id = sub.id
+INNER JOIN item i ON i.feed_id = f.id
+WHERE i.read = 0
+GROUP BY sub.root
+`,
+	query.GroupSetActive: `
+WITH RECURSIVE sub(id) AS (
+    SELECT id FROM feed_group WHERE id = ?
+    UNION ALL
+    SELECT g.id FROM feed_group g INNER JOIN sub ON g.parent = sub.id
+)
+
+UPDATE feed SET active = ? WHERE group_id IN (SELECT id FROM sub)
+`,
+	query.GroupSetInterval: `
+WITH RECURSIVE sub(id) AS (
+    SELECT id FROM feed_group WHERE id = ?
+    UNION ALL
+    SELECT g.id FROM feed_group g INNER JOIN sub ON g.parent = sub.id
+)
+
+UPDATE feed SET refresh_interval = ? WHERE group_id IN (SELECT id FROM sub)
+`,
+	query.WebSubAdd: `
+INSERT OR REPLACE INTO websub (feed_id, hub, topic, secret, state, lease, expires, requested)
+                       VALUES (      ?,   ?,     ?,      ?,     ?,     ?,       ?,         ?)
+`,
+	query.WebSubGetByFeed: `
+SELECT
+    hub,
+    topic,
+    secret,
+    state,
+    lease,
+    expires,
+    requested
+FROM websub
+WHERE feed_id = ?
+`,
+	query.WebSubGetExpiring: `
+SELECT
+    feed_id,
+    hub,
+    topic,
+    secret,
+    state,
+    lease,
+    expires,
+    requested
+FROM websub
+WHERE state = 1 AND expires < ? AND requested < ?
+`,
+	query.WebSubSetState: `
+UPDATE websub
+SET state = ?,
+    lease = ?,
+    expires = ?
+WHERE feed_id = ?
+`,
+	query.WebSubSetRequested: "UPDATE websub SET requested = ? WHERE feed_id = ?",
+	query.WebSubDelete:       "DELETE FROM websub WHERE feed_id = ?",
 	query.ItemAdd: `
-INSERT INTO item (feed_id, link, title, description, timestamp)
-VALUES           (      ?,    ?,     ?,           ?,         ?)
+INSERT INTO item (feed_id, link, title, description, timestamp, author, author_uri, guid, content_hash, fingerprint, original_link)
+VALUES           (      ?,    ?,     ?,           ?,         ?,      ?,          ?,    ?,            ?,           ?,             ?)
 `,
-	query.ItemInsertFTS: "INSERT INTO item_index (link, body) VALUES (?, ?)",
+	query.ItemInsertFTS: "INSERT INTO item_index (docid, link, body) VALUES (?, ?, ?)",
 	query.ItemGetRecent: `
 SELECT
     id,
@@ -76,7 +388,13 @@ SELECT
     description,
     timestamp,
     read,
-    rating
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
 FROM item
 ORDER BY timestamp DESC
 LIMIT ?
@@ -91,7 +409,13 @@ SELECT
     description,
     timestamp,
     read,
-    rating
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
 FROM item
 WHERE rating IS NOT NULL
 `,
@@ -103,7 +427,13 @@ SELECT
     description,
     timestamp,
     read,
-    rating
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
 FROM item
 WHERE id = ?
 `,
@@ -115,7 +445,13 @@ SELECT
     description,
     timestamp,
     read,
-    rating
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
 FROM item
 WHERE link = ?
 `,
@@ -127,9 +463,36 @@ SELECT
     description,
     timestamp,
     read,
-    rating
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
+FROM item
+WHERE feed_id = ?
+ORDER BY timestamp DESC
+LIMIT ?
+`,
+	query.ItemGetByFeedUnread: `
+SELECT
+    id,
+    link,
+    title,
+    description,
+    timestamp,
+    read,
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
 FROM item
 WHERE feed_id = ?
+  AND read = 0
 ORDER BY timestamp DESC
 LIMIT ?
 `,
@@ -142,7 +505,13 @@ SELECT
     description,
     timestamp,
     read,
-    rating
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
 FROM item
 ORDER BY timestamp DESC
 LIMIT ? OFFSET ?
@@ -156,9 +525,15 @@ SELECT
     i.description,
     i.timestamp,
     i.read,
-    i.rating
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
 FROM item_index x
-INNER JOIN item i ON x.link = i.link
+INNER JOIN item i ON x.docid = i.id
 WHERE item_index MATCH ?
 ORDER BY i.timestamp DESC, i.title ASC
 `,
@@ -172,9 +547,15 @@ SELECT
     i.description,
     i.timestamp,
     i.read,
-    i.rating
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
 FROM item_index x
-INNER JOIN item i ON x.link = i.link
+INNER JOIN item i ON x.docid = i.id
 WHERE item_index MATCH ?
 ORDER BY i.timestamp DESC, i.title ASC
 )
@@ -187,7 +568,13 @@ SELECT DISTINCT
         i.description,
         i.timestamp,
         i.read,
-        i.rating
+        i.rating,
+        i.author,
+        i.author_uri,
+        i.updated,
+        i.dup_group,
+        i.original_link,
+        i.content
 FROM tag_link l
 INNER JOIN items1 i ON l.item_id = i.id
 WHERE i.timestamp BETWEEN ? AND ?
@@ -195,8 +582,9 @@ ORDER BY i.timestamp DESC
 `,
 	query.ItemGetContent: `
 SELECT
+    id,
     link,
-    title || ' ' || description AS body
+    title || ' ' || CASE content WHEN '' THEN description ELSE content END AS body
 FROM item
 `,
 	// TODO As Tags can form a hierarchy, I would really like this query to
@@ -215,10 +603,38 @@ SELECT
     i.description,
     i.timestamp,
     i.read,
-    i.rating
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
+FROM tag_link t
+INNER JOIN item i ON t.item_id = i.id
+WHERE t.tag_id = ?
+ORDER BY i.timestamp DESC
+`,
+	query.ItemGetByTagUnread: `
+SELECT
+    i.id,
+    i.feed_id,
+    i.link,
+    i.title,
+    i.description,
+    i.timestamp,
+    i.read,
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
 FROM tag_link t
 INNER JOIN item i ON t.item_id = i.id
 WHERE t.tag_id = ?
+  AND i.read = 0
 ORDER BY i.timestamp DESC
 `,
 	query.ItemGetByTagRecursive: `
@@ -247,12 +663,156 @@ SELECT
     i.description,
     i.timestamp,
     i.read,
-    i.rating
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
+FROM children c
+INNER JOIN tag_link l ON c.id = l.tag_id
+INNER JOIN item i ON l.item_id = i.id
+INNER JOIN feed f ON i.feed_id = f.id
+ORDER BY i.timestamp DESC
+`,
+	query.ItemGetByTagRecursiveUnread: `
+WITH RECURSIVE children(id, name, description, parent) AS (
+    SELECT
+        id,
+        name,
+        description,
+        parent
+    FROM tag WHERE id = ?
+    UNION ALL
+    SELECT
+        tag.id,
+        tag.name,
+        tag.description,
+        tag.parent
+    FROM tag, children
+    WHERE tag.parent = children.id
+)
+
+SELECT
+    i.id,
+    i.feed_id,
+    i.link,
+    i.title,
+    i.description,
+    i.timestamp,
+    i.read,
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
 FROM children c
 INNER JOIN tag_link l ON c.id = l.tag_id
 INNER JOIN item i ON l.item_id = i.id
 INNER JOIN feed f ON i.feed_id = f.id
+WHERE i.read = 0
 ORDER BY i.timestamp DESC
+`,
+	query.ItemGetByAuthor: `
+SELECT
+    id,
+    feed_id,
+    link,
+    title,
+    description,
+    timestamp,
+    read,
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
+FROM item
+WHERE author LIKE '%' || ? || '%'
+ORDER BY timestamp DESC
+`,
+	query.ItemGetByCategory: `
+SELECT
+    i.id,
+    i.feed_id,
+    i.link,
+    i.title,
+    i.description,
+    i.timestamp,
+    i.read,
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
+FROM item_category c
+INNER JOIN item i ON c.item_id = i.id
+WHERE c.name = ? COLLATE NOCASE
+ORDER BY i.timestamp DESC
+`,
+	query.ItemGetByGroup: `
+WITH RECURSIVE sub(id) AS (
+    SELECT id FROM feed_group WHERE id = ?
+    UNION ALL
+    SELECT g.id FROM feed_group g INNER JOIN sub ON g.parent = sub.id
+)
+
+SELECT
+    i.id,
+    i.feed_id,
+    i.link,
+    i.title,
+    i.description,
+    i.timestamp,
+    i.read,
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
+FROM item i
+INNER JOIN feed f ON i.feed_id = f.id
+WHERE f.group_id IN (SELECT id FROM sub)
+ORDER BY i.timestamp DESC
+LIMIT ?
+`,
+	query.ItemGetByGroupUnread: `
+WITH RECURSIVE sub(id) AS (
+    SELECT id FROM feed_group WHERE id = ?
+    UNION ALL
+    SELECT g.id FROM feed_group g INNER JOIN sub ON g.parent = sub.id
+)
+
+SELECT
+    i.id,
+    i.feed_id,
+    i.link,
+    i.title,
+    i.description,
+    i.timestamp,
+    i.read,
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
+FROM item i
+INNER JOIN feed f ON i.feed_id = f.id
+WHERE f.group_id IN (SELECT id FROM sub)
+  AND i.read = 0
+ORDER BY i.timestamp DESC
+LIMIT ?
 `,
 	query.ItemGetPrefetch: `
 SELECT id,
@@ -262,14 +822,136 @@ SELECT id,
        description,
        timestamp,
        read,
-       rating
+       rating,
+       author,
+       author_uri,
+       updated,
+       dup_group,
+       original_link,
+       content
 FROM item
 WHERE prefetch <> 1
 ORDER BY timestamp DESC
 LIMIT ?
+`,
+	query.ItemGetContentPending: `
+SELECT i.id,
+       i.feed_id,
+       i.link,
+       i.title,
+       i.description,
+       i.timestamp,
+       i.read,
+       i.rating,
+       i.author,
+       i.author_uri,
+       i.updated,
+       i.dup_group,
+       i.original_link,
+       i.content
+FROM item i
+INNER JOIN feed f ON i.feed_id = f.id
+WHERE f.full_text <> 0
+  AND i.content_fetched = 0
+  AND i.timestamp >= ?
+ORDER BY i.timestamp DESC
+LIMIT ?
 `,
 	query.ItemGetTotalCnt: "SELECT COUNT(id) FROM item",
 	query.ItemPrefetchSet: "UPDATE item SET description = ?, prefetch = 1 WHERE id = ?",
+	query.ItemContentSet:  "UPDATE item SET content = ?, content_fetched = 1 WHERE id = ?",
+	query.ItemMarkRead:    "UPDATE item SET read = 1 WHERE id = ?",
+	query.ItemMarkUnread:  "UPDATE item SET read = 0 WHERE id = ?",
+	query.ItemMarkReadBatch: `
+UPDATE item
+SET read = 1
+WHERE id IN (` + idPlaceholders + `) AND read = 0
+`,
+	query.ItemMarkReadByFeed: `
+UPDATE item
+SET read = 1
+WHERE feed_id = ? AND read = 0
+`,
+	query.ItemMarkReadByTag: `
+WITH RECURSIVE children(id) AS (
+    SELECT id FROM tag WHERE id = ?
+    UNION ALL
+    SELECT tag.id FROM tag, children WHERE tag.parent = children.id
+)
+
+UPDATE item
+SET read = 1
+WHERE read = 0
+  AND id IN (SELECT l.item_id
+             FROM tag_link l
+             INNER JOIN children c ON l.tag_id = c.id)
+`,
+	query.ItemGetRecentUnread: `
+SELECT
+    id,
+    feed_id,
+    link,
+    title,
+    description,
+    timestamp,
+    read,
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
+FROM item
+WHERE read = 0
+ORDER BY timestamp DESC
+LIMIT ?
+`,
+	query.ItemGetAllUnread: `
+SELECT
+    id,
+    feed_id,
+    link,
+    title,
+    description,
+    timestamp,
+    read,
+    rating,
+    author,
+    author_uri,
+    updated,
+    dup_group,
+    original_link,
+    content
+FROM item
+WHERE read = 0
+ORDER BY timestamp DESC
+LIMIT ? OFFSET ?
+`,
+	query.ItemGetUnreadCnt:       "SELECT COUNT(id) FROM item WHERE read = 0",
+	query.ItemGetUnreadCntByFeed: "SELECT COUNT(id) FROM item WHERE feed_id = ? AND read = 0",
+	query.ItemGetUnreadCntByTag: `
+SELECT
+    COUNT(i.id)
+FROM tag_link t
+INNER JOIN item i ON t.item_id = i.id
+WHERE t.tag_id = ?
+  AND i.read = 0
+`,
+	query.ItemGetUnreadCntByTagRecursive: `
+WITH RECURSIVE children(id) AS (
+    SELECT id FROM tag WHERE id = ?
+    UNION ALL
+    SELECT tag.id FROM tag, children WHERE tag.parent = children.id
+)
+
+SELECT
+    COUNT(DISTINCT i.id)
+FROM children c
+INNER JOIN tag_link l ON c.id = l.tag_id
+INNER JOIN item i ON l.item_id = i.id
+WHERE i.read = 0
+`,
 	query.ItemRatingSet:   "UPDATE item SET rating = ? WHERE id = ?",
 	query.ItemRatingClear: "UPDATE item SET rating = NULL WHERE id = ?",
 	query.ItemHasDuplicate: `
@@ -277,7 +959,178 @@ SELECT
     COUNT(id) AS cnt
 FROM item
 WHERE link = ?
-   OR (feed_id = ? AND title = ?)
+   OR (title = ? AND feed_id IN (
+         SELECT ?
+         UNION
+         SELECT f.id
+         FROM feed f
+         INNER JOIN feed_url_history h ON f.url = h.url
+         WHERE h.feed_id = ?
+         UNION
+         SELECT h.feed_id
+         FROM feed f
+         INNER JOIN feed_url_history h ON f.url = h.url
+         WHERE f.id = ?))
+`,
+	query.ItemLookupGUID: "SELECT id, content_hash FROM item WHERE feed_id = ? AND guid = ?",
+	query.ItemHashSet:    "UPDATE item SET content_hash = ? WHERE id = ?",
+	query.ItemUpdate: `
+UPDATE item
+SET link = ?,
+    title = ?,
+    description = ?,
+    author = ?,
+    author_uri = ?,
+    content_hash = ?,
+    fingerprint = ?,
+    original_link = ?,
+    updated = ?,
+    prefetch = 0,
+    content_fetched = 0
+WHERE id = ?
+`,
+	query.ItemGetFingerprints: `
+SELECT
+    id,
+    fingerprint,
+    dup_group
+FROM item
+WHERE feed_id <> ?
+  AND fingerprint <> 0
+  AND timestamp BETWEEN ? AND ?
+`,
+	query.ItemDupGroupSet:       "UPDATE item SET dup_group = ? WHERE id = ? OR id = ?",
+	query.ItemGetDuplicateFeeds: "SELECT DISTINCT feed_id FROM item WHERE dup_group = ? ORDER BY feed_id",
+	query.ItemGetDuplicateFeedsByGroups: `
+SELECT DISTINCT
+    dup_group,
+    feed_id
+FROM item
+WHERE dup_group IN (` + idPlaceholders + `)
+ORDER BY dup_group, feed_id
+`,
+	query.ItemGetExpired: `
+SELECT
+    i.id,
+    i.link,
+    i.title,
+    i.description,
+    i.timestamp
+FROM item i
+WHERE i.feed_id = ?
+  AND i.timestamp < ?
+  AND i.rating IS NULL
+  AND (i.read <> 0 OR ? = 0)
+  AND NOT EXISTS (SELECT 1 FROM tag_link t WHERE t.item_id = i.id)
+  AND NOT EXISTS (SELECT 1 FROM read_later l WHERE l.item_id = i.id)
+ORDER BY i.timestamp
+`,
+	query.ItemDelete: "DELETE FROM item WHERE id = ?",
+	query.ItemCacheRefs: `
+SELECT item_id, body FROM (
+    SELECT id AS item_id, description AS body
+    FROM item
+    WHERE INSTR(description, ?) > 0
+    UNION ALL
+    SELECT id, content
+    FROM item
+    WHERE INSTR(content, ?) > 0
+    UNION ALL
+    SELECT item_id, description
+    FROM item_revision
+    WHERE INSTR(description, ?) > 0
+)
+ORDER BY item_id
+`,
+	query.RevisionAdd: `
+INSERT INTO item_revision (item_id, link, title, description, timestamp)
+SELECT
+    id,
+    link,
+    title,
+    description,
+    ?
+FROM item
+WHERE id = ?
+`,
+	query.RevisionGetByItem: `
+SELECT
+    id,
+    link,
+    title,
+    description,
+    timestamp
+FROM item_revision
+WHERE item_id = ?
+ORDER BY timestamp DESC, id DESC
+`,
+	query.EnclosureAdd: `
+INSERT OR IGNORE INTO enclosure (item_id, url, mime_type, length, duration)
+                         VALUES (      ?,   ?,         ?,      ?,        ?)
+`,
+	query.EnclosureGetByItem: `
+SELECT
+    id,
+    url,
+    mime_type,
+    length,
+    duration
+FROM enclosure
+WHERE item_id = ?
+ORDER BY id
+`,
+	query.EnclosureGetByID: `
+SELECT
+    item_id,
+    url,
+    mime_type,
+    length,
+    duration
+FROM enclosure
+WHERE id = ?
+`,
+	query.CategoryAdd:       "INSERT OR IGNORE INTO item_category (item_id, name) VALUES (?, ?)",
+	query.CategoryGetByItem: "SELECT name FROM item_category WHERE item_id = ? ORDER BY id",
+	query.CategoryGetByFeed: `
+SELECT
+    c.name,
+    COUNT(c.item_id) AS cnt
+FROM item_category c
+INNER JOIN item i ON c.item_id = i.id
+WHERE i.feed_id = ?
+GROUP BY c.name COLLATE NOCASE
+ORDER BY c.name COLLATE NOCASE
+`,
+	query.CategoryTagAdd: `
+INSERT OR IGNORE INTO category_tag (feed_id, category, tag_id)
+                            VALUES (      ?,        ?,      ?)
+`,
+	query.CategoryTagApply: `
+INSERT OR IGNORE INTO tag_link (tag_id, item_id)
+SELECT
+    m.tag_id,
+    ?
+FROM category_tag m
+WHERE m.feed_id = ? AND m.category = ? COLLATE NOCASE
+`,
+	query.CategoryTagApplyFeed: `
+INSERT OR IGNORE INTO tag_link (tag_id, item_id)
+SELECT
+    ?,
+    c.item_id
+FROM item_category c
+INNER JOIN item i ON c.item_id = i.id
+WHERE i.feed_id = ? AND c.name = ? COLLATE NOCASE
+`,
+	query.CategoryTagDelete: "DELETE FROM category_tag WHERE id = ?",
+	query.CategoryTagGetByFeed: `
+SELECT
+    id,
+    category,
+    tag_id
+FROM category_tag
+WHERE feed_id = ?
+ORDER BY category COLLATE NOCASE
 `,
 	query.FTSClear:     "DELETE FROM item_index",
 	query.TagCreate:    "INSERT INTO tag (name, description, parent) VALUES (?, ?, ?)",
@@ -411,7 +1264,13 @@ SELECT
     i.description,
     i.timestamp,
     i.read,
-    i.rating
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
 FROM read_later l
 INNER JOIN item i ON i.id = l.item_id
 ORDER BY l.deadline DESC
@@ -429,7 +1288,13 @@ SELECT
     i.description,
     i.timestamp,
     i.read,
-    i.rating
+    i.rating,
+    i.author,
+    i.author_uri,
+    i.updated,
+    i.dup_group,
+    i.original_link,
+    i.content
 FROM read_later l
 INNER JOIN item i ON l.item_id = i.id
 WHERE l.read <> 1
@@ -449,4 +1314,110 @@ WHERE item_id = ?
 	query.ReadLaterDeleteRead: "DELETE FROM read_later WHERE COALESCE(read, 0) <> 0",
 	query.ReadLaterSetDeadine: "UPDATE read_later SET deadline = ? WHERE item_id = ?",
 	query.ReadLaterSetNote:    "UPDATE read_later SET note = ? WHERE item_id = ?",
+	query.RetentionSet: `
+INSERT INTO retention (feed_id, max_age, read_only)
+               VALUES (      ?,       ?,         ?)
+ON CONFLICT (feed_id) DO UPDATE
+SET max_age = excluded.max_age,
+    read_only = excluded.read_only
+`,
+	query.RetentionDelete: "DELETE FROM retention WHERE feed_id = ?",
+	query.RetentionGetAll: "SELECT feed_id, max_age, read_only FROM retention ORDER BY feed_id",
+	query.RuleAdd: `
+INSERT INTO rule (name, active, feed_id, title, description, author, language, domain,
+                  tag_id, rating, mark_read, read_later, archive, drop_item)
+          VALUES (   ?,      ?,       ?,     ?,           ?,      ?,        ?,      ?,
+                       ?,      ?,         ?,          ?,       ?,         ?)
+`,
+	query.RuleUpdate: `
+UPDATE rule
+SET name = ?,
+    active = ?,
+    feed_id = ?,
+    title = ?,
+    description = ?,
+    author = ?,
+    language = ?,
+    domain = ?,
+    tag_id = ?,
+    rating = ?,
+    mark_read = ?,
+    read_later = ?,
+    archive = ?,
+    drop_item = ?
+WHERE id = ?
+`,
+	query.RuleDelete:    "DELETE FROM rule WHERE id = ?",
+	query.RuleSetActive: "UPDATE rule SET active = ? WHERE id = ?",
+	query.RuleGetAll: `
+SELECT
+    id,
+    name,
+    active,
+    COALESCE(feed_id, 0),
+    title,
+    description,
+    author,
+    language,
+    domain,
+    COALESCE(tag_id, 0),
+    rating,
+    mark_read,
+    read_later,
+    archive,
+    drop_item
+FROM rule
+ORDER BY id
+`,
+	query.RuleGetActive: `
+SELECT
+    id,
+    name,
+    active,
+    COALESCE(feed_id, 0),
+    title,
+    description,
+    author,
+    language,
+    domain,
+    COALESCE(tag_id, 0),
+    rating,
+    mark_read,
+    read_later,
+    archive,
+    drop_item
+FROM rule
+WHERE active <> 0
+ORDER BY id
+`,
+	query.MuteAdd: `
+INSERT INTO mute (pattern, regex, feed_id, group_id, created, expires)
+          VALUES (      ?,     ?,       ?,        ?,       ?,       ?)
+`,
+	query.MuteDelete: "DELETE FROM mute WHERE id = ?",
+	query.MuteGetAll: `
+SELECT
+    id,
+    pattern,
+    regex,
+    COALESCE(feed_id, 0),
+    COALESCE(group_id, 0),
+    created,
+    COALESCE(expires, 0)
+FROM mute
+ORDER BY created DESC
+`,
+	query.MuteGetActive: `
+SELECT
+    id,
+    pattern,
+    regex,
+    COALESCE(feed_id, 0),
+    COALESCE(group_id, 0),
+    created,
+    COALESCE(expires, 0)
+FROM mute
+WHERE expires IS NULL OR expires > ?
+ORDER BY id
+`,
 }
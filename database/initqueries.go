@@ -6,6 +6,8 @@
 
 package database
 
+// initQueries creates the current schema in a fresh database. Databases
+// created by an older version are brought up to date by migrations.
 var initQueries = []string{
 	`
 CREATE TABLE feed (
@@ -16,11 +18,44 @@ CREATE TABLE feed (
     refresh_interval    INTEGER NOT NULL,
     refresh_timestamp   INTEGER NOT NULL DEFAULT 0,
     active              INTEGER NOT NULL DEFAULT 1,
+    etag                TEXT NOT NULL DEFAULT '',
+    last_modified       TEXT NOT NULL DEFAULT '',
+    fail_count          INTEGER NOT NULL DEFAULT 0,
+    fail_since          INTEGER NOT NULL DEFAULT 0,
+    last_error          TEXT NOT NULL DEFAULT '',
+    last_success        INTEGER NOT NULL DEFAULT 0,
+    http_status         INTEGER NOT NULL DEFAULT 0,
+    adaptive            INTEGER NOT NULL DEFAULT 0,
+    interval_min        INTEGER NOT NULL DEFAULT 0,
+    interval_max        INTEGER NOT NULL DEFAULT 0,
+    adaptive_interval   INTEGER NOT NULL DEFAULT 0,
+    auth                TEXT NOT NULL DEFAULT '',
+    group_id            INTEGER,
+    scraper             TEXT NOT NULL DEFAULT '',
+    full_text           INTEGER NOT NULL DEFAULT 0,
+    fetch_cnt           INTEGER NOT NULL DEFAULT 0,
+    error_cnt           INTEGER NOT NULL DEFAULT 0,
 
-    CONSTRAINT interval_positive CHECK (refresh_interval > 0)
+    CONSTRAINT interval_positive CHECK (refresh_interval > 0),
+    FOREIGN KEY (group_id) REFERENCES feed_group (id)
+        ON DELETE SET NULL
+        ON UPDATE RESTRICT
 )
 `,
 
+	`
+CREATE TABLE feed_group (
+    id          INTEGER PRIMARY KEY,
+    name        TEXT NOT NULL,
+    parent      INTEGER,
+    FOREIGN KEY (parent) REFERENCES feed_group (id)
+        ON DELETE RESTRICT
+        ON UPDATE RESTRICT
+)
+`,
+
+	"CREATE INDEX feed_group_idx ON feed (group_id)",
+
 	`
 CREATE TABLE item (
     id			INTEGER PRIMARY KEY,
@@ -32,6 +67,16 @@ CREATE TABLE item (
     read                INTEGER NOT NULL DEFAULT 0,
     rating              REAL,
     prefetch            INTEGER NOT NULL DEFAULT 0,
+    author              TEXT NOT NULL DEFAULT '',
+    author_uri          TEXT NOT NULL DEFAULT '',
+    guid                TEXT NOT NULL DEFAULT '',
+    content_hash        TEXT NOT NULL DEFAULT '',
+    updated             INTEGER NOT NULL DEFAULT 0,
+    fingerprint         INTEGER NOT NULL DEFAULT 0,
+    dup_group           INTEGER NOT NULL DEFAULT 0,
+    original_link       TEXT NOT NULL DEFAULT '',
+    content             TEXT NOT NULL DEFAULT '',
+    content_fetched     INTEGER NOT NULL DEFAULT 0,
     
     CHECK (rating IS NULL OR (rating BETWEEN 0.0 AND 1.0)),
     CONSTRAINT feed_link_uniq UNIQUE (feed_id, link),
@@ -47,7 +92,20 @@ CREATE TABLE item (
 CREATE TRIGGER tr_item_fts_insert
 AFTER INSERT ON item
 BEGIN
-    INSERT INTO item_index (link, body) VALUES (new.link, new.title || ' ' || new.description);
+    INSERT INTO item_index (docid, link, body)
+    VALUES (new.id,
+            new.link,
+            new.title || ' ' || CASE new.content WHEN '' THEN new.description ELSE new.content END);
+END;
+`,
+	`
+CREATE TRIGGER tr_item_fts_update
+AFTER UPDATE OF link, title, description, content ON item
+BEGIN
+    UPDATE item_index
+    SET link = new.link,
+        body = new.title || ' ' || CASE new.content WHEN '' THEN new.description ELSE new.content END
+    WHERE docid = old.id;
 END;
 `,
 	`
@@ -55,7 +113,7 @@ CREATE TRIGGER tr_item_fts_delete
 AFTER DELETE ON item
 BEGIN
     DELETE FROM item_index
-    WHERE item_index.link = old.link;
+    WHERE docid = old.id;
 END;
 `,
 
@@ -99,4 +157,161 @@ CREATE TABLE read_later (
 	ON UPDATE RESTRICT
 )
 `,
+
+	`
+CREATE TABLE websub (
+    feed_id     INTEGER PRIMARY KEY,
+    hub         TEXT NOT NULL,
+    topic       TEXT NOT NULL,
+    secret      TEXT NOT NULL,
+    state       INTEGER NOT NULL DEFAULT 0,
+    lease       INTEGER NOT NULL DEFAULT 0,
+    expires     INTEGER NOT NULL DEFAULT 0,
+    requested   INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+	`
+CREATE TABLE enclosure (
+    id          INTEGER PRIMARY KEY,
+    item_id     INTEGER NOT NULL,
+    url         TEXT NOT NULL,
+    mime_type   TEXT NOT NULL DEFAULT '',
+    length      INTEGER NOT NULL DEFAULT 0,
+    duration    INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT enclosure_item_url_uniq UNIQUE (item_id, url),
+    FOREIGN KEY (item_id) REFERENCES item (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+	`
+CREATE TABLE item_category (
+    id          INTEGER PRIMARY KEY,
+    item_id     INTEGER NOT NULL,
+    name        TEXT NOT NULL,
+    CONSTRAINT item_category_uniq UNIQUE (item_id, name),
+    FOREIGN KEY (item_id) REFERENCES item (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+	`
+CREATE TABLE category_tag (
+    id          INTEGER PRIMARY KEY,
+    feed_id     INTEGER NOT NULL,
+    category    TEXT NOT NULL,
+    tag_id      INTEGER NOT NULL,
+    CONSTRAINT category_tag_uniq UNIQUE (feed_id, category, tag_id),
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT,
+    FOREIGN KEY (tag_id) REFERENCES tag (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+	`
+CREATE TABLE item_revision (
+    id          INTEGER PRIMARY KEY,
+    item_id     INTEGER NOT NULL,
+    link        TEXT NOT NULL,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL,
+    timestamp   INTEGER NOT NULL,
+    FOREIGN KEY (item_id) REFERENCES item (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+	`
+CREATE TABLE retention (
+    id          INTEGER PRIMARY KEY,
+    feed_id     INTEGER UNIQUE NOT NULL DEFAULT 0,
+    max_age     INTEGER NOT NULL,
+    read_only   INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT retention_age_positive CHECK (max_age > 0)
+)
+`,
+
+	`
+CREATE TRIGGER tr_feed_delete_retention
+AFTER DELETE ON feed
+BEGIN
+    DELETE FROM retention WHERE feed_id = old.id;
+END;
+`,
+
+	`
+CREATE TABLE rule (
+    id          INTEGER PRIMARY KEY,
+    name        TEXT UNIQUE NOT NULL,
+    active      INTEGER NOT NULL DEFAULT 1,
+    feed_id     INTEGER,
+    title       TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    author      TEXT NOT NULL DEFAULT '',
+    language    TEXT NOT NULL DEFAULT '',
+    domain      TEXT NOT NULL DEFAULT '',
+    tag_id      INTEGER,
+    rating      REAL,
+    mark_read   INTEGER NOT NULL DEFAULT 0,
+    read_later  INTEGER NOT NULL DEFAULT 0,
+    archive     INTEGER NOT NULL DEFAULT 0,
+    drop_item   INTEGER NOT NULL DEFAULT 0,
+    CHECK (rating IS NULL OR (rating BETWEEN 0.0 AND 1.0)),
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT,
+    FOREIGN KEY (tag_id) REFERENCES tag (id)
+        ON DELETE SET NULL
+        ON UPDATE RESTRICT
+)
+`,
+	`
+CREATE TABLE mute (
+    id          INTEGER PRIMARY KEY,
+    pattern     TEXT NOT NULL,
+    regex       INTEGER NOT NULL DEFAULT 0,
+    feed_id     INTEGER,
+    group_id    INTEGER,
+    created     INTEGER NOT NULL,
+    expires     INTEGER,
+    CHECK (feed_id IS NULL OR group_id IS NULL),
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT,
+    FOREIGN KEY (group_id) REFERENCES feed_group (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+	"CREATE INDEX mute_expires_idx ON mute (expires)",
+
+	`
+CREATE TABLE feed_url_history (
+    id          INTEGER PRIMARY KEY,
+    feed_id     INTEGER NOT NULL,
+    url         TEXT NOT NULL,
+    moved       INTEGER NOT NULL,
+    CONSTRAINT feed_url_history_uniq UNIQUE (feed_id, url),
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+	"CREATE INDEX feed_url_history_url_idx ON feed_url_history (url)",
+
+	"CREATE INDEX item_read_idx ON item (read, timestamp)",
+	"CREATE INDEX item_timestamp_idx ON item (timestamp)",
+	"CREATE INDEX item_dup_group_idx ON item (dup_group)",
 }
@@ -0,0 +1,374 @@
+// /home/krylon/go/src/ticker/database/migrations.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:42:26 krylon>
+
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations holds the steps that bring a database created by an older
+// version of the application up to date. The version of the schema is kept
+// in SQLite's user_version pragma, a database at version n needs the steps
+// in migrations[n:]. A fresh database gets the current schema from
+// initQueries and starts out at version len(migrations).
+//
+// Any change to the schema must go into initQueries as well as into a new
+// step appended to this list. Never change a step that has been released.
+var migrations = [][]string{
+	// Version 1: Everything added since the initial schema.
+	{
+		"ALTER TABLE feed ADD COLUMN etag TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE feed ADD COLUMN last_modified TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE feed ADD COLUMN fail_count INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN fail_since INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN last_error TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE feed ADD COLUMN last_success INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN http_status INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN adaptive INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN interval_min INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN interval_max INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN adaptive_interval INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN auth TEXT NOT NULL DEFAULT ''",
+
+		`
+CREATE TABLE IF NOT EXISTS feed_group (
+    id          INTEGER PRIMARY KEY,
+    name        TEXT NOT NULL,
+    parent      INTEGER,
+    FOREIGN KEY (parent) REFERENCES feed_group (id)
+        ON DELETE RESTRICT
+        ON UPDATE RESTRICT
+)
+`,
+
+		`
+ALTER TABLE feed ADD COLUMN group_id INTEGER
+    REFERENCES feed_group (id)
+        ON DELETE SET NULL
+        ON UPDATE RESTRICT
+`,
+		"CREATE INDEX IF NOT EXISTS feed_group_idx ON feed (group_id)",
+		"ALTER TABLE feed ADD COLUMN scraper TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE feed ADD COLUMN full_text INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN fetch_cnt INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE feed ADD COLUMN error_cnt INTEGER NOT NULL DEFAULT 0",
+
+		"ALTER TABLE item ADD COLUMN author TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE item ADD COLUMN author_uri TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE item ADD COLUMN guid TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE item ADD COLUMN content_hash TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE item ADD COLUMN updated INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE item ADD COLUMN fingerprint INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE item ADD COLUMN dup_group INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE item ADD COLUMN original_link TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE item ADD COLUMN content TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE item ADD COLUMN content_fetched INTEGER NOT NULL DEFAULT 0",
+
+		"DROP TRIGGER IF EXISTS tr_item_fts_insert",
+
+		`
+CREATE TRIGGER tr_item_fts_insert
+AFTER INSERT ON item
+BEGIN
+    INSERT INTO item_index (link, body)
+    VALUES (new.link,
+            new.title || ' ' || CASE new.content WHEN '' THEN new.description ELSE new.content END);
+END;
+`,
+
+		`
+CREATE TRIGGER IF NOT EXISTS tr_item_fts_update
+AFTER UPDATE OF link, title, description, content ON item
+BEGIN
+    UPDATE item_index
+    SET link = new.link,
+        body = new.title || ' ' || CASE new.content WHEN '' THEN new.description ELSE new.content END
+    WHERE item_index.link = old.link;
+END;
+`,
+
+		`
+CREATE TABLE IF NOT EXISTS websub (
+    feed_id     INTEGER PRIMARY KEY,
+    hub         TEXT NOT NULL,
+    topic       TEXT NOT NULL,
+    secret      TEXT NOT NULL,
+    state       INTEGER NOT NULL DEFAULT 0,
+    lease       INTEGER NOT NULL DEFAULT 0,
+    expires     INTEGER NOT NULL DEFAULT 0,
+    requested   INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+		`
+CREATE TABLE IF NOT EXISTS enclosure (
+    id          INTEGER PRIMARY KEY,
+    item_id     INTEGER NOT NULL,
+    url         TEXT NOT NULL,
+    mime_type   TEXT NOT NULL DEFAULT '',
+    length      INTEGER NOT NULL DEFAULT 0,
+    duration    INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT enclosure_item_url_uniq UNIQUE (item_id, url),
+    FOREIGN KEY (item_id) REFERENCES item (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+		`
+CREATE TABLE IF NOT EXISTS item_category (
+    id          INTEGER PRIMARY KEY,
+    item_id     INTEGER NOT NULL,
+    name        TEXT NOT NULL,
+    CONSTRAINT item_category_uniq UNIQUE (item_id, name),
+    FOREIGN KEY (item_id) REFERENCES item (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+		`
+CREATE TABLE IF NOT EXISTS category_tag (
+    id          INTEGER PRIMARY KEY,
+    feed_id     INTEGER NOT NULL,
+    category    TEXT NOT NULL,
+    tag_id      INTEGER NOT NULL,
+    CONSTRAINT category_tag_uniq UNIQUE (feed_id, category, tag_id),
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT,
+    FOREIGN KEY (tag_id) REFERENCES tag (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+		`
+CREATE TABLE IF NOT EXISTS item_revision (
+    id          INTEGER PRIMARY KEY,
+    item_id     INTEGER NOT NULL,
+    link        TEXT NOT NULL,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL,
+    timestamp   INTEGER NOT NULL,
+    FOREIGN KEY (item_id) REFERENCES item (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+		`
+CREATE TABLE IF NOT EXISTS retention (
+    id          INTEGER PRIMARY KEY,
+    feed_id     INTEGER UNIQUE NOT NULL DEFAULT 0,
+    max_age     INTEGER NOT NULL,
+    read_only   INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT retention_age_positive CHECK (max_age > 0)
+)
+`,
+
+		`
+CREATE TRIGGER IF NOT EXISTS tr_feed_delete_retention
+AFTER DELETE ON feed
+BEGIN
+    DELETE FROM retention WHERE feed_id = old.id;
+END;
+`,
+
+		`
+CREATE TABLE IF NOT EXISTS rule (
+    id          INTEGER PRIMARY KEY,
+    name        TEXT UNIQUE NOT NULL,
+    active      INTEGER NOT NULL DEFAULT 1,
+    feed_id     INTEGER,
+    title       TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    author      TEXT NOT NULL DEFAULT '',
+    language    TEXT NOT NULL DEFAULT '',
+    domain      TEXT NOT NULL DEFAULT '',
+    tag_id      INTEGER,
+    rating      REAL,
+    mark_read   INTEGER NOT NULL DEFAULT 0,
+    read_later  INTEGER NOT NULL DEFAULT 0,
+    archive     INTEGER NOT NULL DEFAULT 0,
+    drop_item   INTEGER NOT NULL DEFAULT 0,
+    CHECK (rating IS NULL OR (rating BETWEEN 0.0 AND 1.0)),
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT,
+    FOREIGN KEY (tag_id) REFERENCES tag (id)
+        ON DELETE SET NULL
+        ON UPDATE RESTRICT
+)
+`,
+
+		`
+CREATE TABLE IF NOT EXISTS mute (
+    id          INTEGER PRIMARY KEY,
+    pattern     TEXT NOT NULL,
+    regex       INTEGER NOT NULL DEFAULT 0,
+    feed_id     INTEGER,
+    group_id    INTEGER,
+    created     INTEGER NOT NULL,
+    expires     INTEGER,
+    CHECK (feed_id IS NULL OR group_id IS NULL),
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT,
+    FOREIGN KEY (group_id) REFERENCES feed_group (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+		"CREATE INDEX IF NOT EXISTS mute_expires_idx ON mute (expires)",
+
+		`
+CREATE TABLE IF NOT EXISTS feed_url_history (
+    id          INTEGER PRIMARY KEY,
+    feed_id     INTEGER NOT NULL,
+    url         TEXT NOT NULL,
+    moved       INTEGER NOT NULL,
+    CONSTRAINT feed_url_history_uniq UNIQUE (feed_id, url),
+    FOREIGN KEY (feed_id) REFERENCES feed (id)
+        ON DELETE CASCADE
+        ON UPDATE RESTRICT
+)
+`,
+
+		"CREATE INDEX IF NOT EXISTS feed_url_history_url_idx ON feed_url_history (url)",
+		"CREATE INDEX IF NOT EXISTS item_read_idx ON item (read, timestamp)",
+	},
+
+	// Version 2: Indices for finding duplicate Items.
+	{
+		"CREATE INDEX IF NOT EXISTS item_timestamp_idx ON item (timestamp)",
+		"CREATE INDEX IF NOT EXISTS item_dup_group_idx ON item (dup_group)",
+	},
+
+	// Version 3: Key the full-text index by the ID of the Item, since
+	// several Feeds may carry the same link. Items stored before we kept
+	// their GUIDs get their link as GUID, which is what the Feed parser
+	// falls back to for Items without one.
+	{
+		"DROP TRIGGER IF EXISTS tr_item_fts_insert",
+		"DROP TRIGGER IF EXISTS tr_item_fts_update",
+		"DROP TRIGGER IF EXISTS tr_item_fts_delete",
+		"DELETE FROM item_index",
+
+		`
+INSERT INTO item_index (docid, link, body)
+SELECT
+    id,
+    link,
+    title || ' ' || CASE content WHEN '' THEN description ELSE content END
+FROM item
+`,
+
+		`
+CREATE TRIGGER tr_item_fts_insert
+AFTER INSERT ON item
+BEGIN
+    INSERT INTO item_index (docid, link, body)
+    VALUES (new.id,
+            new.link,
+            new.title || ' ' || CASE new.content WHEN '' THEN new.description ELSE new.content END);
+END;
+`,
+
+		`
+CREATE TRIGGER tr_item_fts_update
+AFTER UPDATE OF link, title, description, content ON item
+BEGIN
+    UPDATE item_index
+    SET link = new.link,
+        body = new.title || ' ' || CASE new.content WHEN '' THEN new.description ELSE new.content END
+    WHERE docid = old.id;
+END;
+`,
+
+		`
+CREATE TRIGGER tr_item_fts_delete
+AFTER DELETE ON item
+BEGIN
+    DELETE FROM item_index
+    WHERE docid = old.id;
+END;
+`,
+
+		`
+UPDATE item
+SET guid = CASE original_link WHEN '' THEN link ELSE original_link END
+WHERE guid = ''
+`,
+	},
+}
+
+// migrate brings the schema of an existing database up to date, running
+// each pending step in a transaction of its own.
+func (db *Database) migrate() error {
+	var (
+		err     error
+		version int
+	)
+
+	if err = db.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		db.log.Printf("[ERROR] Cannot query schema version of %s: %s\n",
+			db.path,
+			err.Error())
+		return err
+	} else if version > len(migrations) {
+		err = fmt.Errorf("Schema version %d of %s is newer than what I know about (%d)",
+			version,
+			db.path,
+			len(migrations))
+		db.log.Printf("[ERROR] %s\n", err.Error())
+		return err
+	}
+
+	for ; version < len(migrations); version++ {
+		var tx *sql.Tx
+
+		db.log.Printf("[INFO] Migrate %s from schema version %d to %d\n",
+			db.path,
+			version,
+			version+1)
+
+		if tx, err = db.db.Begin(); err != nil {
+			db.log.Printf("[ERROR] Cannot begin transaction: %s\n",
+				err.Error())
+			return err
+		}
+
+		var steps = make([]string, 0, len(migrations[version])+1)
+		steps = append(steps, migrations[version]...)
+		steps = append(steps, fmt.Sprintf("PRAGMA user_version = %d", version+1))
+
+		for _, q := range steps {
+			if _, err = tx.Exec(q); err != nil {
+				db.log.Printf("[ERROR] Cannot execute migration query: %s\n%s\n",
+					err.Error(),
+					q)
+				tx.Rollback() // nolint: errcheck
+				return err
+			}
+		}
+
+		if err = tx.Commit(); err != nil {
+			db.log.Printf("[ERROR] Failed to commit migration to version %d: %s\n",
+				version+1,
+				err.Error())
+			return err
+		}
+	}
+
+	return nil
+} // func (db *Database) migrate() error
@@ -7,6 +7,7 @@
 package download
 
 import (
+	"os"
 	"testing"
 	"github.com/blicero/ticker/blacklist"
 	"github.com/blicero/ticker/feed"
@@ -40,3 +41,38 @@ func TestDownload(t *testing.T) {
 
 	dl.processPage(&item, blacklist.DefaultList())
 } // func TestDownload(t *testing.T)
+
+func TestDownloadEnclosure(t *testing.T) {
+	var (
+		enc = feed.Enclosure{
+			ID:     23,
+			ItemID: 42,
+			URL:    urlRoot + "/img001.jpg",
+			Type:   "image/jpeg",
+		}
+		missing = feed.Enclosure{
+			ID:     24,
+			ItemID: 42,
+			URL:    urlRoot + "/nothing.jpg",
+			Type:   "image/jpeg",
+		}
+	)
+
+	dl.processEnclosure(&enc)
+
+	if !enc.IsDownloaded() {
+		t.Errorf("Enclosure %s was not saved to %s",
+			enc.URL,
+			enc.LocalPath())
+	}
+
+	dl.processEnclosure(&missing)
+
+	if missing.IsDownloaded() {
+		t.Errorf("Missing Enclosure %s should not have been saved",
+			missing.URL)
+	} else if _, err := os.Stat(missing.LocalPath() + ".part"); err == nil {
+		t.Errorf("Partial download of %s was not removed",
+			missing.URL)
+	}
+} // func TestDownloadEnclosure(t *testing.T)
@@ -34,6 +34,14 @@ import (
 
 const wkInterval = time.Millisecond * 2500
 
+// EnclosureTimeout is how long we allow the download of a single Enclosure
+// to take. Podcast episodes and videos may be large, so this is much longer
+// than the timeout for other requests.
+var EnclosureTimeout = time.Hour
+
+// MaxEnclosureSize is the maximum size of an Enclosure we download.
+var MaxEnclosureSize int64 = 4 << 30
+
 var mimePat = regexp.MustCompile(`^([^/]+)/(\w+)`)
 
 // Agent is the nexus of download activity.
@@ -41,8 +49,11 @@ type Agent struct {
 	log       *log.Logger
 	lock      sync.RWMutex
 	active    bool
-	PageQ     chan *feed.Item
-	workerCnt int
+	PageQ      chan *feed.Item
+	EnclosureQ chan *feed.Enclosure
+	workerCnt  int
+	client     *http.Client
+	encClient  *http.Client
 }
 
 // NewAgent creates an Agent with the given number of workers.
@@ -54,12 +65,23 @@ func NewAgent(cnt int) (*Agent, error) {
 	)
 
 	ag = &Agent{
-		PageQ:     make(chan *feed.Item, cnt),
-		workerCnt: cnt,
+		PageQ:      make(chan *feed.Item, cnt),
+		EnclosureQ: make(chan *feed.Enclosure, cnt),
+		workerCnt:  cnt,
+		client:     common.HTTPClient(),
 	}
 
+	var cfg = common.HTTPSettings()
+
+	cfg.Timeout = EnclosureTimeout
+	cfg.MaxResponseSize = MaxEnclosureSize
+
 	if ag.log, err = common.GetLogger(logdomain.Download); err != nil {
 		return nil, err
+	} else if ag.encClient, err = common.NewHTTPClient(cfg); err != nil {
+		ag.log.Printf("[ERROR] Cannot create HTTP client for Enclosures: %s\n",
+			err.Error())
+		return nil, err
 	}
 
 	return ag, nil
@@ -116,6 +138,8 @@ func (ag *Agent) worker(idx int) {
 			continue
 		case item := <-ag.PageQ:
 			ag.processPage(item, bl)
+		case enc := <-ag.EnclosureQ:
+			ag.processEnclosure(enc)
 		}
 	}
 } // func (ag *Agent) worker(idx int)
@@ -136,7 +160,7 @@ func (ag *Agent) processPage(i *feed.Item, bl blacklist.Blacklist) {
 			i.Title,
 			err.Error())
 		return
-	} else if resp, err = http.Get(i.URL); err != nil {
+	} else if resp, err = ag.client.Get(i.URL); err != nil {
 		ag.log.Printf("[ERROR] Error fetching Item %d (%s) from %q: %s\n",
 			i.ID,
 			i.Title,
@@ -362,6 +386,86 @@ func (ag *Agent) processPage(i *feed.Item, bl blacklist.Blacklist) {
 	}
 } // func (ag *Agent) processPage(i *feed.Item)
 
+// processEnclosure downloads an Enclosure to the local archive. The data is
+// written to a temporary file first, so an Enclosure only counts as
+// downloaded once it is complete.
+func (ag *Agent) processEnclosure(e *feed.Enclosure) {
+	var (
+		err       error
+		resp      *http.Response
+		fh        *os.File
+		localPath = e.LocalPath()
+		tmpPath   = localPath + ".part"
+	)
+
+	if e.IsDownloaded() {
+		ag.log.Printf("[DEBUG] Enclosure %d (%s) has already been downloaded\n",
+			e.ID,
+			e.URL)
+		return
+	} else if info, serr := os.Stat(tmpPath); serr == nil && time.Since(info.ModTime()) < EnclosureTimeout {
+		ag.log.Printf("[INFO] Enclosure %d (%s) is already being downloaded\n",
+			e.ID,
+			e.URL)
+		return
+	}
+
+	if fh, err = os.Create(tmpPath); err != nil {
+		ag.log.Printf("[ERROR] Cannot create file %s: %s\n",
+			tmpPath,
+			err.Error())
+		return
+	}
+
+	defer fh.Close() // nolint: errcheck
+
+	if resp, err = ag.encClient.Get(e.URL); err != nil {
+		ag.log.Printf("[ERROR] Error fetching Enclosure %d (%s): %s\n",
+			e.ID,
+			e.URL,
+			err.Error())
+		os.Remove(tmpPath) // nolint: errcheck
+		return
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode != 200 {
+		ag.log.Printf("[ERROR] Error fetching Enclosure %d (%s): %s\n",
+			e.ID,
+			e.URL,
+			resp.Status)
+		os.Remove(tmpPath) // nolint: errcheck
+		return
+	} else if _, err = io.Copy(fh, resp.Body); err != nil {
+		ag.log.Printf("[ERROR] Failed to save Enclosure %d (%s) to %s: %s\n",
+			e.ID,
+			e.URL,
+			tmpPath,
+			err.Error())
+		os.Remove(tmpPath) // nolint: errcheck
+		return
+	} else if err = fh.Close(); err != nil {
+		ag.log.Printf("[ERROR] Cannot close %s: %s\n",
+			tmpPath,
+			err.Error())
+		os.Remove(tmpPath) // nolint: errcheck
+		return
+	} else if err = os.Rename(tmpPath, localPath); err != nil {
+		ag.log.Printf("[ERROR] Cannot rename %s to %s: %s\n",
+			tmpPath,
+			localPath,
+			err.Error())
+		os.Remove(tmpPath) // nolint: errcheck
+		return
+	}
+
+	ag.log.Printf("[INFO] Saved Enclosure %d (%s) to %s\n",
+		e.ID,
+		e.URL,
+		localPath)
+} // func (ag *Agent) processEnclosure(e *feed.Enclosure)
+
 func (ag *Agent) fetchImage(addr *url.URL, folder string) (string, error) {
 	var (
 		err                      error
@@ -372,7 +476,7 @@ func (ag *Agent) fetchImage(addr *url.URL, folder string) (string, error) {
 
 	aStr = addr.String()
 
-	if resp, err = http.Get(aStr); err != nil {
+	if resp, err = ag.client.Get(aStr); err != nil {
 		ag.log.Printf("[ERROR] Failed to get %q: %s\n",
 			aStr,
 			err.Error())
@@ -450,7 +554,7 @@ func (ag *Agent) fetchScript(href *url.URL, folder string) (string, error) {
 
 	defer fh.Close() // nolint: errcheck
 
-	if resp, err = http.Get(astr); err != nil {
+	if resp, err = ag.client.Get(astr); err != nil {
 		ag.log.Printf("[ERROR] Cannot retrieve %q: %s\n",
 			astr,
 			err.Error())
@@ -0,0 +1,189 @@
+// /home/krylon/go/src/ticker/feed/00_feed_srv_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:54:35 krylon>
+
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+const (
+	testETag         = `"ticker-test-0001"`
+	testLastModified = "Sat, 17 Oct 2026 12:00:00 GMT"
+	testUserAgent    = "TickerTest/1.0"
+	testSlowDelay    = time.Second
+)
+
+// testAuth holds the credentials the test server demands for
+// /private.xml.
+var testAuth = Auth{
+	Username: "krylon",
+	Password: "geheim",
+	Headers:  map[string]string{"X-Api-Key": "0815"},
+	Cookies:  map[string]string{"session": "abcdef"},
+	Params:   map[string]string{"token": "4711"},
+}
+
+const testRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Ticker Test Feed</title>
+    <link>http://www.example.com/</link>
+    <description>A feed for testing</description>
+    <item>
+      <title>First Item</title>
+      <link>http://www.example.com/item/1</link>
+      <guid>http://www.example.com/item/1</guid>
+      <description>The first test item</description>
+      <pubDate>Sat, 17 Oct 2026 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>Second Item</title>
+      <link>http://www.example.com/item/2</link>
+      <guid>http://www.example.com/item/2</guid>
+      <description>The second test item</description>
+      <pubDate>Sat, 17 Oct 2026 11:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>
+`
+
+const testJSONFeed = `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "Ticker Test JSON Feed",
+  "home_page_url": "http://www.example.com/",
+  "feed_url": "http://www.example.com/feed.json",
+  "items": [
+    {
+      "id": "http://www.example.com/post/1",
+      "url": "http://www.example.com/post/1",
+      "title": "First Post",
+      "content_html": "<p>The first test post</p>",
+      "date_published": "2026-10-17T10:00:00Z",
+      "authors": [
+        { "name": "Jane Doe", "url": "http://www.example.com/~jane" }
+      ],
+      "tags": [ "Science", "Open Source" ]
+    },
+    {
+      "id": 2,
+      "external_url": "http://www.example.org/elsewhere",
+      "title": "Second Post",
+      "content_text": "The second test post",
+      "date_published": "2026-10-17T11:00:00+02:00",
+      "attachments": [
+        {
+          "url": "http://www.example.com/episode2.mp3",
+          "mime_type": "audio/mpeg",
+          "size_in_bytes": 1048576,
+          "duration_in_seconds": 1800
+        }
+      ]
+    }
+  ]
+}
+`
+
+const testHTML = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>Ticker Test Site</title>
+    <link rel="stylesheet" href="/style.css" />
+    <link rel="alternate" type="application/feed+json" title="JSON" href="feed.json" />
+    <link rel="alternate" type="text/html" hreflang="de" href="/de/" />
+  </head>
+  <body><p>Nothing to see here</p></body>
+</html>
+`
+
+func handleFeedRequest(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/index.html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testHTML)) // nolint: errcheck
+		return
+	case "/news.html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testScrapeHTML)) // nolint: errcheck
+		return
+	case "/article.html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testArticleHTML)) // nolint: errcheck
+		return
+	case "/feed.json":
+		w.Header().Set("Content-Type", "application/feed+json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testJSONFeed)) // nolint: errcheck
+		return
+	case "/plain.json":
+		// Some servers do not know the JSON Feed content type.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testJSONFeed)) // nolint: errcheck
+		return
+	case "/private.xml":
+		var (
+			user, pass, _ = r.BasicAuth()
+			cookie, err   = r.Cookie("session")
+		)
+
+		if user != testAuth.Username ||
+			pass != testAuth.Password ||
+			r.Header.Get("X-Api-Key") != testAuth.Headers["X-Api-Key"] ||
+			err != nil || cookie.Value != testAuth.Cookies["session"] ||
+			r.URL.Query().Get("token") != testAuth.Params["token"] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	case "/agent.xml":
+		if r.Header.Get("User-Agent") != testUserAgent {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	case "/slow.xml":
+		time.Sleep(testSlowDelay)
+	case "/moved.xml":
+		http.Redirect(w, r, "/feed.xml", http.StatusMovedPermanently)
+		return
+	case "/moved-private.xml":
+		http.Redirect(w, r, "/private.xml?"+r.URL.RawQuery, http.StatusPermanentRedirect)
+		return
+	case "/temporary.xml":
+		http.Redirect(w, r, "/feed.xml", http.StatusFound)
+		return
+	case "/chain.xml":
+		http.Redirect(w, r, "/temporary.xml", http.StatusPermanentRedirect)
+		return
+	case "/feed.xml":
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Header.Get("If-None-Match") == testETag ||
+		r.Header.Get("If-Modified-Since") == testLastModified {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml")
+	w.Header().Set("ETag", testETag)
+	w.Header().Set("Last-Modified", testLastModified)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(testRSS)) // nolint: errcheck
+} // func handleFeedRequest(w http.ResponseWriter, r *http.Request)
+
+func startServer() *httptest.Server {
+	var srv = httptest.NewUnstartedServer(http.HandlerFunc(handleFeedRequest))
+	srv.EnableHTTP2 = false
+	srv.Start()
+	return srv
+} // func startServer() *httptest.Server
@@ -0,0 +1,67 @@
+// /home/krylon/go/src/ticker/feed/02_feed_cond_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:16:27 krylon>
+
+package feed
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFeedFetchConditional(t *testing.T) {
+	var (
+		err   error
+		items []Item
+		srv   = startServer()
+		f     = Feed{
+			ID:       1,
+			Name:     "Test Feed",
+			URL:      srv.URL + "/feed.xml",
+			Interval: time.Minute,
+			Active:   true,
+			log:      flog,
+		}
+	)
+
+	defer srv.Close()
+
+	if items, err = f.Fetch(); err != nil {
+		t.Fatalf("Error fetching Feed %s: %s",
+			f.Name,
+			err.Error())
+	} else if len(items) != 2 {
+		t.Fatalf("Unexpected number of Items: %d (expected 2)",
+			len(items))
+	} else if f.ETag != testETag {
+		t.Errorf("Unexpected ETag: %q (expected %q)",
+			f.ETag,
+			testETag)
+	} else if f.LastModified != testLastModified {
+		t.Errorf("Unexpected Last-Modified: %q (expected %q)",
+			f.LastModified,
+			testLastModified)
+	}
+
+	// Pretend we are a fresh Feed loaded from the database, the cache
+	// headers should be enough to tell the server we have seen it.
+	var f2 = Feed{
+		ID:           f.ID,
+		Name:         f.Name,
+		URL:          f.URL,
+		Interval:     f.Interval,
+		Active:       true,
+		ETag:         f.ETag,
+		LastModified: f.LastModified,
+		log:          flog,
+	}
+
+	if items, err = f2.Fetch(); !errors.Is(err, ErrNotModified) {
+		t.Errorf("Expected ErrNotModified, got %v (%d Items)",
+			err,
+			len(items))
+	}
+} // func TestFeedFetchConditional(t *testing.T)
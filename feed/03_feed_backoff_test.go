@@ -0,0 +1,118 @@
+// /home/krylon/go/src/ticker/feed/03_feed_backoff_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 03:08:12 krylon>
+
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/common"
+)
+
+func TestFeedRetryInterval(t *testing.T) {
+	type testCase struct {
+		f        Feed
+		interval time.Duration
+		dead     bool
+	}
+
+	var health = common.HealthSettings()
+
+	var cases = []testCase{
+		testCase{
+			f: Feed{
+				Name:     "healthy",
+				Interval: time.Minute * 15,
+			},
+			interval: time.Minute * 15,
+		},
+		testCase{
+			f: Feed{
+				Name:      "failed once",
+				Interval:  time.Minute * 15,
+				FailCount: 1,
+				FailSince: time.Now().Add(-time.Minute * 15),
+			},
+			interval: time.Minute * 30,
+		},
+		testCase{
+			f: Feed{
+				Name:      "failed thrice",
+				Interval:  time.Minute * 15,
+				FailCount: 3,
+				FailSince: time.Now().Add(-time.Hour),
+			},
+			interval: time.Hour * 2,
+		},
+		testCase{
+			f: Feed{
+				Name:      "failed often",
+				Interval:  time.Minute * 15,
+				FailCount: 200,
+				FailSince: time.Now().Add(-health.FailureLimit - time.Hour),
+			},
+			interval: health.BackoffCeiling,
+			dead:     true,
+		},
+		testCase{
+			f: Feed{
+				Name:      "slow",
+				Interval:  health.BackoffCeiling * 2,
+				FailCount: 2,
+				FailSince: time.Now().Add(-time.Hour),
+			},
+			interval: health.BackoffCeiling * 2,
+		},
+	}
+
+	for _, c := range cases {
+		var ival = c.f.RetryInterval()
+
+		if ival != c.interval {
+			t.Errorf("Unexpected retry interval for Feed %s: %s (expected %s)",
+				c.f.Name,
+				ival,
+				c.interval)
+		} else if c.f.IsDead() != c.dead {
+			t.Errorf("Unexpected result from IsDead for Feed %s: %t (expected %t)",
+				c.f.Name,
+				c.f.IsDead(),
+				c.dead)
+		}
+	}
+} // func TestFeedRetryInterval(t *testing.T)
+
+func TestFeedHealthSettings(t *testing.T) {
+	var (
+		saved = common.HealthSettings()
+		f     = Feed{
+			Name:      "configured",
+			Interval:  time.Minute * 15,
+			FailCount: 10,
+			FailSince: time.Now().Add(-time.Hour * 3),
+		}
+	)
+
+	defer common.ConfigureHealth(saved) // nolint: errcheck
+
+	if err := common.ConfigureHealth(common.HealthConfig{}); err == nil {
+		t.Error("ConfigureHealth accepted an empty configuration")
+	} else if err = common.ConfigureHealth(common.HealthConfig{
+		BackoffCeiling: time.Hour,
+		FailureLimit:   time.Hour * 2,
+	}); err != nil {
+		t.Fatalf("Cannot configure Feed health: %s", err.Error())
+	} else if ival := f.RetryInterval(); ival != time.Hour {
+		t.Errorf("Unexpected retry interval: %s (expected %s)",
+			ival,
+			time.Hour)
+	} else if !f.IsDead() {
+		t.Errorf("Feed %s should be dead after failing for %s",
+			f.Name,
+			time.Since(f.FailSince).Round(time.Minute))
+	}
+} // func TestFeedHealthSettings(t *testing.T)
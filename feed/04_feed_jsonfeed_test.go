@@ -0,0 +1,87 @@
+// /home/krylon/go/src/ticker/feed/04_feed_jsonfeed_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:33:38 krylon>
+
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SlyMarbo/rss"
+)
+
+func TestFeedFetchJSON(t *testing.T) {
+	var srv = startServer()
+
+	defer srv.Close()
+
+	for _, path := range []string{"/feed.json", "/plain.json"} {
+		var (
+			err   error
+			items []Item
+			f     = Feed{
+				ID:       1,
+				Name:     "JSON Feed " + path,
+				URL:      srv.URL + path,
+				Interval: time.Minute,
+				Active:   true,
+				log:      flog,
+			}
+		)
+
+		if items, err = f.Fetch(); err != nil {
+			t.Errorf("Error fetching Feed %s: %s",
+				f.Name,
+				err.Error())
+			continue
+		} else if len(items) != 2 {
+			t.Errorf("Unexpected number of Items in %s: %d (expected 2)",
+				f.Name,
+				len(items))
+			continue
+		}
+
+		var first, second = items[0], items[1]
+
+		if first.URL != "http://www.example.com/post/1" {
+			t.Errorf("Unexpected URL for first Item: %s", first.URL)
+		} else if first.Description != "<p>The first test post</p>" {
+			t.Errorf("Unexpected Description for first Item: %q", first.Description)
+		} else if !first.Timestamp.Equal(time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected Timestamp for first Item: %s", first.Timestamp)
+		} else if second.URL != "http://www.example.org/elsewhere" {
+			t.Errorf("Unexpected URL for second Item: %s", second.URL)
+		} else if second.Description != "The second test post" {
+			t.Errorf("Unexpected Description for second Item: %q", second.Description)
+		}
+	}
+} // func TestFeedFetchJSON(t *testing.T)
+
+func TestParseJSONFeed(t *testing.T) {
+	var (
+		err error
+		fd  *rss.Feed
+	)
+
+	if fd, err = parseJSONFeed([]byte(testJSONFeed)); err != nil {
+		t.Fatalf("Cannot parse JSON Feed: %s", err.Error())
+	} else if fd.Title != "Ticker Test JSON Feed" {
+		t.Errorf("Unexpected title: %q", fd.Title)
+	} else if len(fd.Items) != 2 {
+		t.Fatalf("Unexpected number of Items: %d (expected 2)", len(fd.Items))
+	} else if fd.Items[1].ID != "2" {
+		t.Errorf("Unexpected ID for second Item: %q", fd.Items[1].ID)
+	} else if len(fd.Items[1].Enclosures) != 1 {
+		t.Errorf("Unexpected number of attachments: %d (expected 1)",
+			len(fd.Items[1].Enclosures))
+	} else if e := fd.Items[1].Enclosures[0]; e.Type != "audio/mpeg" || e.Length != 1048576 {
+		t.Errorf("Unexpected attachment: %#v", e)
+	}
+
+	if _, err = parseJSONFeed([]byte(`{"version": "1.0", "items": []}`)); err == nil {
+		t.Error("parseJSONFeed accepted a Feed with an invalid version")
+	}
+} // func TestParseJSONFeed(t *testing.T)
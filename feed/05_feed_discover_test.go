@@ -0,0 +1,53 @@
+// /home/krylon/go/src/ticker/feed/05_feed_discover_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:37:01 krylon>
+
+package feed
+
+import "testing"
+
+func TestDiscover(t *testing.T) {
+	var (
+		err        error
+		candidates []Candidate
+		srv        = startServer()
+	)
+
+	defer srv.Close()
+
+	if candidates, err = Discover(srv.URL + "/index.html"); err != nil {
+		t.Fatalf("Error discovering Feeds: %s", err.Error())
+	} else if len(candidates) != 2 {
+		t.Fatalf("Unexpected number of candidates: %d (expected 2)\n%#v",
+			len(candidates),
+			candidates)
+	}
+
+	var (
+		jc = candidates[0]
+		rc = candidates[1]
+	)
+
+	if jc.URL != srv.URL+"/feed.json" {
+		t.Errorf("Unexpected URL for announced Feed: %s", jc.URL)
+	} else if jc.Name != "Ticker Test JSON Feed" {
+		t.Errorf("Unexpected Name for announced Feed: %q", jc.Name)
+	} else if jc.Homepage != "http://www.example.com/" {
+		t.Errorf("Unexpected Homepage for announced Feed: %q", jc.Homepage)
+	}
+
+	if rc.URL != srv.URL+"/feed.xml" {
+		t.Errorf("Unexpected URL for probed Feed: %s", rc.URL)
+	} else if rc.Name != "Ticker Test Feed" {
+		t.Errorf("Unexpected Name for probed Feed: %q", rc.Name)
+	}
+
+	// Pointing Discover at a Feed directly should give us just that Feed.
+	if candidates, err = Discover(srv.URL + "/feed.xml"); err != nil {
+		t.Fatalf("Error discovering Feed: %s", err.Error())
+	} else if len(candidates) != 1 || candidates[0].URL != srv.URL+"/feed.xml" {
+		t.Errorf("Unexpected result for direct Feed URL: %#v", candidates)
+	}
+} // func TestDiscover(t *testing.T)
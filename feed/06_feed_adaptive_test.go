@@ -0,0 +1,94 @@
+// /home/krylon/go/src/ticker/feed/06_feed_adaptive_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:42:28 krylon>
+
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func makeStamps(gaps ...time.Duration) []time.Time {
+	var (
+		stamp  = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		stamps = []time.Time{stamp}
+	)
+
+	for _, g := range gaps {
+		stamp = stamp.Add(-g)
+		stamps = append(stamps, stamp)
+	}
+
+	return stamps
+} // func makeStamps(gaps ...time.Duration) []time.Time
+
+func TestFeedComputeInterval(t *testing.T) {
+	type testCase struct {
+		f      Feed
+		stamps []time.Time
+		ival   time.Duration
+	}
+
+	var cases = []testCase{
+		testCase{
+			f:      Feed{Name: "too few"},
+			stamps: makeStamps(time.Hour),
+			ival:   0,
+		},
+		testCase{
+			f:      Feed{Name: "hourly"},
+			stamps: makeStamps(time.Hour, time.Hour, time.Hour*5, time.Hour),
+			ival:   time.Hour,
+		},
+		testCase{
+			f:      Feed{Name: "busy"},
+			stamps: makeStamps(time.Second*30, time.Minute, time.Minute),
+			ival:   AdaptiveMinDefault,
+		},
+		testCase{
+			f: Feed{
+				Name:        "dormant",
+				IntervalMax: time.Hour * 12,
+			},
+			stamps: makeStamps(time.Hour*24*30, time.Hour*24*60),
+			ival:   time.Hour * 12,
+		},
+	}
+
+	for _, c := range cases {
+		var ival = c.f.ComputeInterval(c.stamps)
+
+		if ival != c.ival {
+			t.Errorf("Unexpected interval for Feed %s: %s (expected %s)",
+				c.f.Name,
+				ival,
+				c.ival)
+		}
+	}
+} // func TestFeedComputeInterval(t *testing.T)
+
+func TestFeedEffectiveInterval(t *testing.T) {
+	var f = Feed{
+		Name:             "Test",
+		Interval:         time.Minute * 15,
+		AdaptiveInterval: time.Hour * 2,
+		IntervalMax:      time.Hour,
+	}
+
+	if ival := f.EffectiveInterval(); ival != f.Interval {
+		t.Errorf("Feed not in adaptive mode should use its Interval, not %s", ival)
+	}
+
+	f.Adaptive = true
+
+	if ival := f.EffectiveInterval(); ival != f.IntervalMax {
+		t.Errorf("Adaptive interval was not clamped: %s (expected %s)",
+			ival,
+			f.IntervalMax)
+	} else if next := f.Next(); !next.Equal(f.LastUpdate.Add(f.IntervalMax)) {
+		t.Errorf("Next does not respect adaptive interval: %s", next)
+	}
+} // func TestFeedEffectiveInterval(t *testing.T)
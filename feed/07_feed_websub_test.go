@@ -0,0 +1,153 @@
+// /home/krylon/go/src/ticker/feed/07_feed_websub_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:50:03 krylon>
+
+package feed
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testRSSHub = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+  <channel>
+    <title>Ticker Test Feed</title>
+    <link>http://www.example.com/</link>
+    <atom:link rel="hub" href="https://hub.example.com/" />
+    <atom:link rel="self" href="http://www.example.com/feed.xml" />
+    <item>
+      <title>First Item</title>
+      <link>http://www.example.com/item/1</link>
+      <atom:link rel="hub" href="https://wrong.example.com/" />
+    </item>
+  </channel>
+</rss>
+`
+
+const testAtomHub = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Ticker Test Feed</title>
+  <link rel="self" href="http://www.example.com/atom.xml" />
+  <link rel="alternate" href="http://www.example.com/" />
+  <link rel="hub" href="https://hub.example.com/" />
+</feed>
+`
+
+const testJSONFeedHub = `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "Ticker Test JSON Feed",
+  "feed_url": "http://www.example.com/feed.json",
+  "hubs": [
+    { "type": "rssCloud", "url": "https://cloud.example.com/" },
+    { "type": "WebSub", "url": "https://hub.example.com/" }
+  ],
+  "items": []
+}
+`
+
+func TestFeedFindHub(t *testing.T) {
+	type testCase struct {
+		name   string
+		header http.Header
+		ctype  string
+		body   string
+		hub    string
+		topic  string
+	}
+
+	var cases = []testCase{
+		testCase{
+			name:  "RSS",
+			ctype: "application/rss+xml",
+			body:  testRSSHub,
+			hub:   "https://hub.example.com/",
+			topic: "http://www.example.com/feed.xml",
+		},
+		testCase{
+			name:  "Atom",
+			ctype: "application/atom+xml",
+			body:  testAtomHub,
+			hub:   "https://hub.example.com/",
+			topic: "http://www.example.com/atom.xml",
+		},
+		testCase{
+			name:  "JSON Feed",
+			ctype: "application/feed+json",
+			body:  testJSONFeedHub,
+			hub:   "https://hub.example.com/",
+			topic: "http://www.example.com/feed.json",
+		},
+		testCase{
+			name: "Link header",
+			header: http.Header{
+				"Link": []string{
+					`<https://hub.example.com/>; rel="hub", <http://www.example.com/feed.xml>; rel="self"`,
+				},
+			},
+			ctype: "application/rss+xml",
+			body:  testRSS,
+			hub:   "https://hub.example.com/",
+			topic: "http://www.example.com/feed.xml",
+		},
+		testCase{
+			name:  "No hub",
+			ctype: "application/rss+xml",
+			body:  testRSS,
+		},
+	}
+
+	for _, c := range cases {
+		var header = c.header
+
+		if header == nil {
+			header = make(http.Header)
+		}
+
+		var hub, topic = findHub(header, c.ctype, []byte(c.body))
+
+		if hub != c.hub {
+			t.Errorf("Unexpected hub for %s: %q (expected %q)",
+				c.name,
+				hub,
+				c.hub)
+		} else if topic != c.topic {
+			t.Errorf("Unexpected topic for %s: %q (expected %q)",
+				c.name,
+				topic,
+				c.topic)
+		}
+	}
+} // func TestFeedFindHub(t *testing.T)
+
+func TestFeedPushInterval(t *testing.T) {
+	var f = Feed{
+		Name:     "Test",
+		Interval: time.Minute * 15,
+	}
+
+	if ival := f.EffectiveInterval(); ival != f.Interval {
+		t.Errorf("Unexpected interval for Feed without push: %s (expected %s)",
+			ival,
+			f.Interval)
+	}
+
+	f.PushUntil = time.Now().Add(time.Hour)
+
+	if ival := f.EffectiveInterval(); ival != PushInterval {
+		t.Errorf("Unexpected interval for pushed Feed: %s (expected %s)",
+			ival,
+			PushInterval)
+	}
+
+	f.PushUntil = time.Now().Add(-time.Minute)
+
+	if ival := f.EffectiveInterval(); ival != f.Interval {
+		t.Errorf("Unexpected interval for Feed with expired push lease: %s (expected %s)",
+			ival,
+			f.Interval)
+	}
+} // func TestFeedPushInterval(t *testing.T)
@@ -0,0 +1,114 @@
+// /home/krylon/go/src/ticker/feed/08_feed_auth_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:53:39 krylon>
+
+package feed
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeedFetchAuth(t *testing.T) {
+	var srv = startServer()
+	defer srv.Close()
+
+	var (
+		err error
+		f   *Feed
+		u   = srv.URL + "/private.xml"
+	)
+
+	if f, err = New(42, "Private Feed", u, srv.URL, time.Minute*15, true); err != nil {
+		t.Fatalf("Cannot create Feed: %s", err.Error())
+	} else if _, err = f.fetch(); err == nil {
+		t.Fatal("Fetching a private Feed without credentials should fail")
+	} else if f.HTTPStatus != http.StatusUnauthorized {
+		t.Errorf("Unexpected HTTP status: %d (expected %d)",
+			f.HTTPStatus,
+			http.StatusUnauthorized)
+	}
+
+	var auth = testAuth
+	f.Auth = &auth
+
+	if _, err = f.fetch(); err != nil {
+		t.Errorf("Cannot fetch private Feed with credentials: %s", err.Error())
+	}
+
+	// Nobody should be listening on port 1.
+	f.URL = "http://127.0.0.1:1/private.xml"
+
+	if _, err = f.fetch(); err == nil {
+		t.Error("Fetching from a closed port should fail")
+	} else if strings.Contains(err.Error(), testAuth.Params["token"]) {
+		t.Errorf("Error message gives away secret parameter: %s", err.Error())
+	}
+} // func TestFeedFetchAuth(t *testing.T)
+
+func TestFeedAuthString(t *testing.T) {
+	var (
+		a   *Auth
+		str string
+	)
+
+	if str = a.String(); str != "none" {
+		t.Errorf("Unexpected description of nil Auth: %q", str)
+	}
+
+	a = &testAuth
+
+	if str = a.String(); str != "Basic, 1 Header, 1 Cookie, 1 Parameter" {
+		t.Errorf("Unexpected description of Auth: %q", str)
+	}
+
+	for _, secret := range []string{a.Password, a.Headers["X-Api-Key"], a.Cookies["session"], a.Params["token"]} {
+		if strings.Contains(str, secret) {
+			t.Errorf("Description of Auth gives away secret %q", secret)
+		}
+	}
+} // func TestFeedAuthString(t *testing.T)
+
+func TestFeedAuthMerge(t *testing.T) {
+	var (
+		a = &Auth{Username: "alice", Password: "old", Token: "abc"}
+		m = a.Merge(&Auth{Password: "new", Headers: map[string]string{"X-Foo": "bar"}})
+	)
+
+	if m.Username != "alice" || m.Password != "new" || m.Token != "abc" || m.Headers["X-Foo"] != "bar" {
+		t.Errorf("Unexpected result of merge: %#v", m)
+	} else if a.Password != "old" {
+		t.Error("Merge modified the receiver")
+	}
+} // func TestFeedAuthMerge(t *testing.T)
+
+func TestFeedParseAuthPairs(t *testing.T) {
+	var (
+		err   error
+		pairs map[string]string
+	)
+
+	if pairs, err = ParseAuthPairs("X-Api-Key: 0815\n\n  Accept: text/xml  \n", ":"); err != nil {
+		t.Errorf("Cannot parse headers: %s", err.Error())
+	} else if len(pairs) != 2 || pairs["X-Api-Key"] != "0815" || pairs["Accept"] != "text/xml" {
+		t.Errorf("Unexpected headers: %v", pairs)
+	}
+
+	if pairs, err = ParseAuthPairs("session=a=b", "="); err != nil {
+		t.Errorf("Cannot parse cookies: %s", err.Error())
+	} else if pairs["session"] != "a=b" {
+		t.Errorf("Unexpected cookies: %v", pairs)
+	}
+
+	if _, err = ParseAuthPairs("no separator here", "="); err == nil {
+		t.Error("Line without separator should be rejected")
+	}
+
+	if pairs, err = ParseAuthPairs("   \n", "="); err != nil || pairs != nil {
+		t.Errorf("Blank input should give nil map, not %v (%v)", pairs, err)
+	}
+} // func TestFeedParseAuthPairs(t *testing.T)
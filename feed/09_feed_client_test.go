@@ -0,0 +1,80 @@
+// /home/krylon/go/src/ticker/feed/09_feed_client_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:01:53 krylon>
+
+package feed
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/common"
+)
+
+func TestFeedFetchClient(t *testing.T) {
+	var srv = startServer()
+	defer srv.Close()
+	defer common.ConfigureHTTP(common.DefaultHTTPConfig) // nolint: errcheck
+
+	var (
+		err error
+		f   *Feed
+		cfg = common.DefaultHTTPConfig
+	)
+
+	if f, err = New(42, "Test Feed", srv.URL+"/agent.xml", srv.URL, time.Minute*15, true); err != nil {
+		t.Fatalf("Cannot create Feed: %s", err.Error())
+	} else if _, err = f.fetch(); err == nil {
+		t.Error("Fetching with the wrong User-Agent should fail")
+	} else if f.HTTPStatus != http.StatusForbidden {
+		t.Errorf("Unexpected HTTP status: %d (expected %d)",
+			f.HTTPStatus,
+			http.StatusForbidden)
+	}
+
+	cfg.UserAgent = testUserAgent
+
+	if err = common.ConfigureHTTP(cfg); err != nil {
+		t.Fatalf("Cannot configure HTTP client: %s", err.Error())
+	} else if _, err = f.fetch(); err != nil {
+		t.Errorf("Cannot fetch Feed with our User-Agent: %s", err.Error())
+	}
+
+	cfg.MaxResponseSize = 64
+	f.URL = srv.URL + "/feed.xml"
+	f.ETag, f.LastModified = "", ""
+
+	if err = common.ConfigureHTTP(cfg); err != nil {
+		t.Fatalf("Cannot configure HTTP client: %s", err.Error())
+	} else if _, err = f.fetch(); !errors.Is(err, common.ErrResponseTooLarge) {
+		t.Errorf("Fetching an oversized Feed should fail with %q, not %v",
+			common.ErrResponseTooLarge,
+			err)
+	}
+
+	cfg.MaxResponseSize = 0
+	cfg.Timeout = testSlowDelay / 4
+	f.URL = srv.URL + "/slow.xml"
+
+	var start = time.Now()
+
+	if err = common.ConfigureHTTP(cfg); err != nil {
+		t.Fatalf("Cannot configure HTTP client: %s", err.Error())
+	} else if _, err = f.fetch(); err == nil {
+		t.Error("Fetching from a hanging server should time out")
+	} else if d := time.Since(start); d >= testSlowDelay {
+		t.Errorf("Request took %s, timeout was %s", d, cfg.Timeout)
+	}
+
+	for _, p := range []string{"ftp://localhost:21", "::nonsense"} {
+		cfg.Proxy = p
+
+		if err = common.ConfigureHTTP(cfg); err == nil {
+			t.Errorf("Invalid proxy %q should have been rejected", p)
+		}
+	}
+} // func TestFeedFetchClient(t *testing.T)
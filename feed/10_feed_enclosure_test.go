@@ -0,0 +1,216 @@
+// /home/krylon/go/src/ticker/feed/10_feed_enclosure_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:08:04 krylon>
+
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+const testPodcast = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+  <channel>
+    <title>Ticker Test Podcast</title>
+    <link>http://www.example.com/podcast/</link>
+    <description>A podcast for testing</description>
+    <itunes:duration>99:99:99</itunes:duration>
+    <item>
+      <title>Episode 1</title>
+      <link>http://www.example.com/podcast/1</link>
+      <guid>urn:ticker:podcast:1</guid>
+      <description>The first episode</description>
+      <pubDate>Sat, 17 Oct 2026 10:00:00 GMT</pubDate>
+      <enclosure url="http://www.example.com/podcast/1.mp3" type="audio/mpeg" length="12345678" />
+      <itunes:duration>1:02:03</itunes:duration>
+    </item>
+    <item>
+      <title>Episode 2</title>
+      <link>http://www.example.com/podcast/2</link>
+      <description>The second episode</description>
+      <pubDate>Sat, 17 Oct 2026 11:00:00 GMT</pubDate>
+      <enclosure url="http://www.example.com/podcast/2.ogg" type="" length="0" />
+      <itunes:duration>95</itunes:duration>
+    </item>
+    <item>
+      <title>Show notes</title>
+      <link>http://www.example.com/podcast/notes</link>
+      <description>No media here</description>
+      <pubDate>Sat, 17 Oct 2026 12:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>
+`
+
+const testPodcastAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Ticker Test Vlog</title>
+  <id>urn:ticker:vlog</id>
+  <updated>2026-10-17T10:00:00Z</updated>
+  <entry>
+    <title>Video 1</title>
+    <id>urn:ticker:vlog:1</id>
+    <updated>2026-10-17T10:00:00Z</updated>
+    <link rel="alternate" type="text/html" href="http://www.example.com/vlog/1" />
+    <link rel="enclosure" type="video/mp4" length="4711" href="http://www.example.com/vlog/1.mp4" />
+    <link rel="replies" type="text/html" href="http://www.example.com/vlog/1#comments" />
+  </entry>
+</feed>
+`
+
+func TestParseDuration(t *testing.T) {
+	type testCase struct {
+		s     string
+		d     time.Duration
+		isErr bool
+	}
+
+	var cases = []testCase{
+		{s: "95", d: time.Second * 95},
+		{s: "05:30", d: time.Minute*5 + time.Second*30},
+		{s: " 1:02:03 ", d: time.Hour + time.Minute*2 + time.Second*3},
+		{s: "12.5", d: time.Millisecond * 12500},
+		{s: "", isErr: true},
+		{s: "1:2:3:4", isErr: true},
+		{s: "eine Stunde", isErr: true},
+		{s: "-5", isErr: true},
+	}
+
+	for _, c := range cases {
+		var d, err = ParseDuration(c.s)
+
+		if c.isErr {
+			if err == nil {
+				t.Errorf("ParseDuration(%q) should have failed, returned %s",
+					c.s,
+					d)
+			}
+		} else if err != nil {
+			t.Errorf("ParseDuration(%q) failed: %s", c.s, err.Error())
+		} else if d != c.d {
+			t.Errorf("ParseDuration(%q) = %s (expected %s)", c.s, d, c.d)
+		}
+	}
+} // func TestParseDuration(t *testing.T)
+
+func TestFeedEnclosures(t *testing.T) {
+	type testCase struct {
+		ctype string
+		body  string
+		encs  [][]Enclosure
+	}
+
+	var cases = []testCase{
+		{
+			ctype: "application/rss+xml",
+			body:  testPodcast,
+			encs: [][]Enclosure{
+				{
+					{
+						URL:      "http://www.example.com/podcast/1.mp3",
+						Type:     "audio/mpeg",
+						Length:   12345678,
+						Duration: time.Hour + time.Minute*2 + time.Second*3,
+					},
+				},
+				{
+					{
+						URL:      "http://www.example.com/podcast/2.ogg",
+						Type:     "audio/ogg",
+						Duration: time.Second * 95,
+					},
+				},
+				nil,
+			},
+		},
+		{
+			ctype: "application/atom+xml",
+			body:  testPodcastAtom,
+			encs: [][]Enclosure{
+				{
+					{
+						URL:    "http://www.example.com/vlog/1.mp4",
+						Type:   "video/mp4",
+						Length: 4711,
+					},
+				},
+			},
+		},
+		{
+			ctype: "application/feed+json",
+			body:  testJSONFeed,
+			encs: [][]Enclosure{
+				nil,
+				{
+					{
+						URL:      "http://www.example.com/episode2.mp3",
+						Type:     "audio/mpeg",
+						Length:   1048576,
+						Duration: time.Minute * 30,
+					},
+				},
+			},
+		},
+	}
+
+	var f, err = New(42, "Test Podcast", "http://www.example.com/podcast.xml", "", time.Hour, true)
+
+	if err != nil {
+		t.Fatalf("Cannot create Feed: %s", err.Error())
+	}
+
+	for _, c := range cases {
+		var items []Item
+
+		if items, err = f.Parse(c.ctype, []byte(c.body)); err != nil {
+			t.Errorf("Cannot parse %s: %s", c.ctype, err.Error())
+			continue
+		} else if len(items) != len(c.encs) {
+			t.Errorf("Unexpected number of Items in %s: %d (expected %d)",
+				c.ctype,
+				len(items),
+				len(c.encs))
+			continue
+		}
+
+		for idx, item := range items {
+			if len(item.Enclosures) != len(c.encs[idx]) {
+				t.Errorf("Item %q in %s has %d Enclosures (expected %d)",
+					item.Title,
+					c.ctype,
+					len(item.Enclosures),
+					len(c.encs[idx]))
+				continue
+			}
+
+			for eidx, e := range item.Enclosures {
+				if e != c.encs[idx][eidx] {
+					t.Errorf("Unexpected Enclosure in Item %q:\n%#v\n%#v",
+						item.Title,
+						e,
+						c.encs[idx][eidx])
+				}
+			}
+		}
+	}
+} // func TestFeedEnclosures(t *testing.T)
+
+func TestEnclosureDurationString(t *testing.T) {
+	var cases = map[time.Duration]string{
+		0:                                 "",
+		time.Second * 95:                  "1:35",
+		time.Hour + time.Minute*2 + 3e9:   "1:02:03",
+		time.Hour*10 + time.Millisecond*1: "10:00:00",
+	}
+
+	for d, s := range cases {
+		var e = Enclosure{Duration: d}
+
+		if str := e.DurationString(); str != s {
+			t.Errorf("DurationString of %s = %q (expected %q)", d, str, s)
+		}
+	}
+} // func TestEnclosureDurationString(t *testing.T)
@@ -0,0 +1,187 @@
+// /home/krylon/go/src/ticker/feed/11_feed_meta_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:47:50 krylon>
+
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+const testMetaRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel>
+    <title>Ticker Test Blog</title>
+    <link>http://www.example.com/blog/</link>
+    <description>A blog for testing</description>
+    <category>Channel category</category>
+    <item>
+      <title>Post 1</title>
+      <link>http://www.example.com/blog/1</link>
+      <guid>urn:ticker:blog:1</guid>
+      <description>The first post</description>
+      <pubDate>Sat, 17 Oct 2026 10:00:00 GMT</pubDate>
+      <author>jane@example.com (Jane Doe)</author>
+      <category>Science</category>
+      <category domain="http://www.example.com/tags">Open Source</category>
+      <category>science</category>
+    </item>
+    <item>
+      <title>Post 2</title>
+      <link>http://www.example.com/blog/2</link>
+      <description>The second post</description>
+      <pubDate>Sat, 17 Oct 2026 11:00:00 GMT</pubDate>
+      <dc:creator>John Roe</dc:creator>
+      <dc:subject>Politics</dc:subject>
+    </item>
+    <item>
+      <title>Post 3</title>
+      <link>http://www.example.com/blog/3</link>
+      <description>Anonymous and uncategorized</description>
+      <pubDate>Sat, 17 Oct 2026 12:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>
+`
+
+const testMetaAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Ticker Test Atom</title>
+  <id>urn:ticker:atom</id>
+  <updated>2026-10-17T10:00:00Z</updated>
+  <author><name>Feed Author</name></author>
+  <entry>
+    <title>Entry 1</title>
+    <id>urn:ticker:atom:1</id>
+    <updated>2026-10-17T10:00:00Z</updated>
+    <link rel="alternate" type="text/html" href="http://www.example.com/atom/1" />
+    <author>
+      <name>Jane Doe</name>
+      <uri>http://www.example.com/~jane</uri>
+    </author>
+    <category term="Go" label="The Go Programming Language" />
+    <category term="Databases" />
+  </entry>
+</feed>
+`
+
+func TestFeedMeta(t *testing.T) {
+	type itemMetaCase struct {
+		author     string
+		authorURI  string
+		categories []string
+	}
+
+	type testCase struct {
+		ctype string
+		body  string
+		items []itemMetaCase
+	}
+
+	var cases = []testCase{
+		{
+			ctype: "application/rss+xml",
+			body:  testMetaRSS,
+			items: []itemMetaCase{
+				{
+					author:     "Jane Doe",
+					authorURI:  "mailto:jane@example.com",
+					categories: []string{"Science", "Open Source"},
+				},
+				{
+					author:     "John Roe",
+					categories: []string{"Politics"},
+				},
+				{},
+			},
+		},
+		{
+			ctype: "application/atom+xml",
+			body:  testMetaAtom,
+			items: []itemMetaCase{
+				{
+					author:     "Jane Doe",
+					authorURI:  "http://www.example.com/~jane",
+					categories: []string{"Go", "Databases"},
+				},
+			},
+		},
+		{
+			ctype: "application/feed+json",
+			body:  testJSONFeed,
+			items: []itemMetaCase{
+				{
+					author:     "Jane Doe",
+					authorURI:  "http://www.example.com/~jane",
+					categories: []string{"Science", "Open Source"},
+				},
+				{},
+			},
+		},
+	}
+
+	var f, err = New(42, "Test Blog", "http://www.example.com/blog.xml", "", time.Hour, true)
+
+	if err != nil {
+		t.Fatalf("Cannot create Feed: %s", err.Error())
+	}
+
+	for _, c := range cases {
+		var items []Item
+
+		if items, err = f.Parse(c.ctype, []byte(c.body)); err != nil {
+			t.Errorf("Cannot parse %s: %s", c.ctype, err.Error())
+			continue
+		} else if len(items) != len(c.items) {
+			t.Errorf("Unexpected number of Items in %s: %d (expected %d)",
+				c.ctype,
+				len(items),
+				len(c.items))
+			continue
+		}
+
+		for idx, item := range items {
+			var exp = c.items[idx]
+
+			if item.Author != exp.author || item.AuthorURI != exp.authorURI {
+				t.Errorf("Unexpected author of Item %q: %q <%s> (expected %q <%s>)",
+					item.Title,
+					item.Author,
+					item.AuthorURI,
+					exp.author,
+					exp.authorURI)
+			}
+
+			if len(item.Categories) != len(exp.categories) {
+				t.Errorf("Unexpected categories of Item %q: %v (expected %v)",
+					item.Title,
+					item.Categories,
+					exp.categories)
+				continue
+			}
+
+			for cidx, cat := range exp.categories {
+				if item.Categories[cidx] != cat {
+					t.Errorf("Unexpected category #%d of Item %q: %q (expected %q)",
+						cidx,
+						item.Title,
+						item.Categories[cidx],
+						cat)
+				} else if !item.HasCategory(cat) {
+					t.Errorf("Item %q should be in category %q",
+						item.Title,
+						cat)
+				}
+			}
+
+			if exp.author != "" && !item.HasAuthor(exp.author[:4]) {
+				t.Errorf("Item %q should match author %q",
+					item.Title,
+					exp.author[:4])
+			}
+		}
+	}
+} // func TestFeedMeta(t *testing.T)
@@ -0,0 +1,145 @@
+// /home/krylon/go/src/ticker/feed/12_feed_scrape_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:18:42 krylon>
+
+package feed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testScrapeHTML = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>Ticker Test News</title>
+    <base href="/news/" />
+  </head>
+  <body>
+    <div class="post">
+      <h2><a href="one.html">  First
+        Story </a></h2>
+      <time datetime="2026-10-17T10:00:00Z">Yesterday</time>
+      <p class="teaser">Something <b>happened</b>.</p>
+    </div>
+    <div class="post">
+      <h2><a href="/news/two.html#comments">Second Story</a></h2>
+      <span class="date">16.10.2026</span>
+      <p class="teaser">Something else happened.</p>
+    </div>
+    <div class="post">
+      <h2>No link here</h2>
+    </div>
+    <div class="ad">
+      <a href="https://ads.example.com/">Buy stuff</a>
+    </div>
+  </body>
+</html>
+`
+
+func TestScrape(t *testing.T) {
+	var (
+		err   error
+		f     *Feed
+		items []Item
+		srv   = startServer()
+		s     = &Scraper{
+			Item:    "div.post",
+			Title:   "h2",
+			Date:    "time, .date",
+			Summary: "p.teaser",
+		}
+	)
+
+	defer srv.Close()
+
+	if f, err = New(0, "Scraped", srv.URL+"/news.html", srv.URL, time.Hour, true); err != nil {
+		t.Fatalf("Cannot create Feed: %s", err.Error())
+	}
+
+	f.Scraper = s
+
+	if items, err = f.Fetch(); err != nil {
+		t.Fatalf("Cannot scrape %s: %s", f.URL, err.Error())
+	} else if len(items) != 2 {
+		t.Fatalf("Unexpected number of Items: %d (expected 2)", len(items))
+	}
+
+	type expect struct {
+		title, url, summary string
+		stamp               time.Time
+	}
+
+	var cases = []expect{
+		{
+			title:   "First Story",
+			url:     srv.URL + "/news/one.html",
+			summary: "Something <b>happened</b>.",
+			stamp:   time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			title:   "Second Story",
+			url:     srv.URL + "/news/two.html",
+			summary: "Something else happened.",
+			stamp:   time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local),
+		},
+	}
+
+	for idx, c := range cases {
+		var i = items[idx]
+
+		if i.Title != c.title {
+			t.Errorf("Unexpected title of Item %d: %q (expected %q)",
+				idx,
+				i.Title,
+				c.title)
+		} else if i.URL != c.url || i.GUID != c.url {
+			t.Errorf("Unexpected link of Item %d: %s (expected %s)",
+				idx,
+				i.URL,
+				c.url)
+		} else if i.Description != c.summary {
+			t.Errorf("Unexpected summary of Item %d: %q (expected %q)",
+				idx,
+				i.Description,
+				c.summary)
+		} else if !i.Timestamp.Equal(c.stamp) {
+			t.Errorf("Unexpected timestamp of Item %d: %s (expected %s)",
+				idx,
+				i.Timestamp,
+				c.stamp)
+		}
+	}
+} // func TestScrape(t *testing.T)
+
+func TestScrapePreview(t *testing.T) {
+	var (
+		err   error
+		items []Item
+		srv   = startServer()
+	)
+
+	defer srv.Close()
+
+	if items, err = (&Scraper{Item: "div.post"}).Preview(srv.URL+"/news.html", nil); err != nil {
+		t.Fatalf("Cannot preview Scraper: %s", err.Error())
+	} else if len(items) != 2 {
+		t.Fatalf("Unexpected number of Items: %d (expected 2)", len(items))
+	} else if items[0].Title != "First Story" {
+		t.Errorf("Title should default to the link text: %q", items[0].Title)
+	}
+
+	if _, err = (&Scraper{Item: "div..post"}).Preview(srv.URL+"/news.html", nil); err == nil {
+		t.Error("Preview should reject an invalid selector")
+	} else if !strings.Contains(err.Error(), "Item selector") {
+		t.Errorf("Unexpected error for invalid selector: %s", err.Error())
+	}
+
+	if _, err = (&Scraper{Item: "article"}).Preview(srv.URL+"/news.html", nil); !errors.Is(err, ErrNoItems) {
+		t.Errorf("Expected ErrNoItems, got %v", err)
+	}
+} // func TestScrapePreview(t *testing.T)
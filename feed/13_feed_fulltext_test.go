@@ -0,0 +1,130 @@
+// /home/krylon/go/src/ticker/feed/13_feed_fulltext_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:25:31 krylon>
+
+package feed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testArticleHTML = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>A long story - Ticker Test News</title>
+    <script>var tracking = "evil";</script>
+  </head>
+  <body>
+    <nav><a href="/">Home</a> <a href="/news/">News</a></nav>
+    <div id="header" class="header">Ticker Test News</div>
+    <div class="sidebar">
+      <p>Subscribe to our newsletter, it is really, really great, we promise!</p>
+    </div>
+    <div class="article-body">
+      <h1>A long story</h1>
+      <p>
+        Once upon a time, in a land far, far away, there lived a programmer
+        who wanted to read the news without clicking on every single link.
+      </p>
+      <p onclick="alert('gotcha')">
+        So she wrote a program that fetched the pages, threw away the menus,
+        the ads, and the comments, and kept only the text of the article.
+      </p>
+      <p>
+        The program looked at every paragraph, counted its commas, and gave
+        points to the element containing it. <a href="/more.html">Read more</a>
+        about how it worked, or <a href="javascript:alert(1)">don't</a>.
+      </p>
+      <img src="/img/story.png" alt="A picture" />
+    </div>
+    <div class="comments">
+      <p>First! This is a comment, and it is not part of the article at all.</p>
+    </div>
+    <footer><p>Copyright 2026, Ticker Test News, all rights reserved.</p></footer>
+  </body>
+</html>
+`
+
+func TestExtractContent(t *testing.T) {
+	var (
+		err     error
+		content string
+	)
+
+	if content, err = ExtractContent("https://news.example.com/2026/story.html", []byte(testArticleHTML)); err != nil {
+		t.Fatalf("Cannot extract content: %s", err.Error())
+	}
+
+	for _, s := range []string{
+		"Once upon a time",
+		"kept only the text",
+		`href="https://news.example.com/more.html"`,
+		`src="https://news.example.com/img/story.png"`,
+		`target="_blank"`,
+	} {
+		if !strings.Contains(content, s) {
+			t.Errorf("Extracted content does not contain %q:\n%s", s, content)
+		}
+	}
+
+	for _, s := range []string{
+		"tracking",
+		"newsletter",
+		"First!",
+		"Copyright",
+		"onclick",
+		"javascript:",
+	} {
+		if strings.Contains(content, s) {
+			t.Errorf("Extracted content should not contain %q:\n%s", s, content)
+		}
+	}
+
+	if _, err = ExtractContent("https://news.example.com/", []byte(testHTML)); !errors.Is(err, ErrNoContent) {
+		t.Errorf("Expected ErrNoContent from a page without an article, got %v", err)
+	}
+} // func TestExtractContent(t *testing.T)
+
+func TestFetchContent(t *testing.T) {
+	var (
+		err     error
+		f       *Feed
+		content string
+		srv     = startServer()
+		i       = Item{
+			Title:       "A long story",
+			Description: "Once upon a time...",
+		}
+	)
+
+	defer srv.Close()
+
+	if f, err = New(0, "Teaser", srv.URL+"/feed.xml", srv.URL, time.Hour, true); err != nil {
+		t.Fatalf("Cannot create Feed: %s", err.Error())
+	}
+
+	i.URL = srv.URL + "/article.html"
+
+	if content, err = f.FetchContent(&i); err != nil {
+		t.Fatalf("Cannot fetch content of %s: %s", i.URL, err.Error())
+	} else if !strings.Contains(content, "Once upon a time") {
+		t.Errorf("Unexpected content:\n%s", content)
+	}
+
+	i.Content = content
+
+	if txt := i.Plaintext(); !strings.Contains(txt, "kept only the text") {
+		t.Errorf("Plaintext does not use the full text: %q", txt)
+	}
+
+	i.URL = srv.URL + "/feed.xml"
+
+	if _, err = f.FetchContent(&i); err == nil {
+		t.Error("FetchContent should refuse a page that is not HTML")
+	}
+} // func TestFetchContent(t *testing.T)
@@ -0,0 +1,65 @@
+// /home/krylon/go/src/ticker/feed/14_feed_rule_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:34:35 krylon>
+
+package feed
+
+import "testing"
+
+func TestRuleMatch(t *testing.T) {
+	var item = Item{
+		FeedID:      42,
+		URL:         "https://blog.example.com/2026/10/go-generics",
+		Title:       "What's new in Go 1.99",
+		Description: "Generics, iterators, and a lot of other things.",
+		Author:      "Gopher",
+	}
+
+	type testCase struct {
+		rule  Rule
+		match bool
+	}
+
+	var cases = []testCase{
+		{Rule{Name: "title", Title: `\bgo\b`, MarkRead: true}, true},
+		{Rule{Name: "title miss", Title: "rust", MarkRead: true}, false},
+		{Rule{Name: "description", Description: "ITERATORS", MarkRead: true}, true},
+		{Rule{Name: "author", Author: "^gopher$", MarkRead: true}, true},
+		{Rule{Name: "domain", Domain: "example.com", MarkRead: true}, true},
+		{Rule{Name: "subdomain", Domain: "blog.example.com", MarkRead: true}, true},
+		{Rule{Name: "domain miss", Domain: "ample.com", MarkRead: true}, false},
+		{Rule{Name: "feed", FeedID: 42, MarkRead: true}, true},
+		{Rule{Name: "feed miss", FeedID: 23, MarkRead: true}, false},
+		{Rule{Name: "all", FeedID: 42, Title: "go", Domain: "example.com", MarkRead: true}, true},
+		{Rule{Name: "one fails", FeedID: 42, Title: "go", Domain: "example.org", MarkRead: true}, false},
+	}
+
+	for _, c := range cases {
+		if err := c.rule.Validate(); err != nil {
+			t.Errorf("Rule %s is invalid: %s", c.rule.Name, err.Error())
+		} else if m := c.rule.Match(&item); m != c.match {
+			t.Errorf("Rule %s: Match returned %t, expected %t",
+				c.rule.Name,
+				m,
+				c.match)
+		}
+	}
+} // func TestRuleMatch(t *testing.T)
+
+func TestRuleValidate(t *testing.T) {
+	var cases = []Rule{
+		{Title: "foo", MarkRead: true},
+		{Name: "No conditions", Drop: true},
+		{Name: "No actions", Title: "foo"},
+		{Name: "Bad pattern", Author: "[a-", Drop: true},
+		{Name: "Bad rating", Title: "foo", Rate: true, Rating: 2},
+	}
+
+	for _, r := range cases {
+		if err := r.Validate(); err == nil {
+			t.Errorf("Invalid Rule %q passed validation", r.Name)
+		}
+	}
+} // func TestRuleValidate(t *testing.T)
@@ -0,0 +1,70 @@
+// /home/krylon/go/src/ticker/feed/15_feed_mute_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:38:51 krylon>
+
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMuteMatch(t *testing.T) {
+	var item = Item{
+		Title:       "Election results are in",
+		Description: "<p>The Müller-Lüdenscheidt party won the <b>general election</b> by a landslide.</p>",
+	}
+
+	type testCase struct {
+		mute  Mute
+		match bool
+	}
+
+	var cases = []testCase{
+		{Mute{Pattern: "election"}, true},
+		{Mute{Pattern: "ELECTION"}, true},
+		{Mute{Pattern: "elect"}, false},
+		{Mute{Pattern: "general election"}, true},
+		{Mute{Pattern: "general  election"}, true},
+		{Mute{Pattern: "election general"}, false},
+		{Mute{Pattern: "Müller"}, true},
+		{Mute{Pattern: "Lüdenscheidt"}, true},
+		{Mute{Pattern: "Lüden"}, false},
+		{Mute{Pattern: `land\w+`, Regex: true}, true},
+		{Mute{Pattern: `^Election`, Regex: true}, true},
+		{Mute{Pattern: `football`, Regex: true}, false},
+	}
+
+	for _, c := range cases {
+		if err := c.mute.Validate(); err != nil {
+			t.Errorf("Mute %s is invalid: %s", &c.mute, err.Error())
+		} else if m := c.mute.Match(&item); m != c.match {
+			t.Errorf("Mute %s: Match returned %t, expected %t",
+				&c.mute,
+				m,
+				c.match)
+		}
+	}
+} // func TestMuteMatch(t *testing.T)
+
+func TestMuteValidate(t *testing.T) {
+	var cases = []Mute{
+		{Pattern: "  "},
+		{Pattern: "foo(", Regex: true},
+		{Pattern: "foo", FeedID: 1, GroupID: 2},
+	}
+
+	for _, m := range cases {
+		if err := m.Validate(); err == nil {
+			t.Errorf("Invalid Mute %s passed validation", &m)
+		}
+	}
+
+	var m = Mute{Pattern: "foo", Expires: time.Now().Add(-time.Minute)}
+
+	if !m.IsExpired() {
+		t.Errorf("Mute %s should have expired", &m)
+	}
+} // func TestMuteValidate(t *testing.T)
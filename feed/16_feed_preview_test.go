@@ -0,0 +1,101 @@
+// /home/krylon/go/src/ticker/feed/16_feed_preview_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:50:31 krylon>
+
+package feed
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFetchPreview(t *testing.T) {
+	var (
+		err error
+		p   *Preview
+		srv = startServer()
+	)
+
+	defer srv.Close()
+
+	type testCase struct {
+		path   string
+		format string
+		title  string
+		cnt    int
+	}
+
+	var cases = []testCase{
+		{"/feed.xml", "RSS 2.0", "Ticker Test Feed", 2},
+		{"/feed.json", "JSON Feed", "Ticker Test JSON Feed", 2},
+	}
+
+	for _, c := range cases {
+		if p, err = FetchPreview(srv.URL+c.path, nil); err != nil {
+			t.Errorf("Error previewing %s: %s", c.path, err.Error())
+			continue
+		} else if p.Format != c.format {
+			t.Errorf("Unexpected format for %s: %q (expected %q)",
+				c.path,
+				p.Format,
+				c.format)
+		} else if p.Title != c.title {
+			t.Errorf("Unexpected title for %s: %q", c.path, p.Title)
+		} else if p.ItemCnt != c.cnt || len(p.Items) != c.cnt {
+			t.Errorf("Unexpected number of Items for %s: %d/%d (expected %d)",
+				c.path,
+				p.ItemCnt,
+				len(p.Items),
+				c.cnt)
+		} else if p.Homepage != "http://www.example.com/" {
+			t.Errorf("Unexpected homepage for %s: %q", c.path, p.Homepage)
+		}
+	}
+
+	// An HTML page is not a Feed, but we still want to know why.
+	if p, err = FetchPreview(srv.URL+"/index.html", nil); err == nil {
+		t.Error("Previewing an HTML page should have failed")
+	} else if p == nil {
+		t.Error("FetchPreview returned no Preview for HTML page")
+	} else if len(p.Warnings) == 0 ||
+		!strings.Contains(p.Warnings[0], "HTML") {
+		t.Errorf("Expected a warning about HTML, got %q", p.Warnings)
+	}
+} // func TestFetchPreview(t *testing.T)
+
+func TestPreviewCharset(t *testing.T) {
+	type testCase struct {
+		ctype   string
+		body    string
+		charset string
+		warn    bool
+	}
+
+	var cases = []testCase{
+		{"application/rss+xml", `<?xml version="1.0" encoding="UTF-8"?><rss/>`, "utf-8", false},
+		{"application/rss+xml; charset=utf-8", `<?xml version="1.0"?><rss/>`, "utf-8", false},
+		{"application/rss+xml; charset=ISO-8859-1", `<?xml version="1.0" encoding="UTF-8"?><rss/>`, "utf-8", true},
+		{"application/rss+xml; charset=ISO-8859-1", `<?xml version="1.0"?><rss/>`, "iso-8859-1", true},
+		{"application/rss+xml", "<?xml version=\"1.0\"?><rss>\xfc</rss>", "utf-8", true},
+	}
+
+	for _, c := range cases {
+		var p = &Preview{ContentType: c.ctype}
+
+		p.checkCharset([]byte(c.body))
+
+		if p.Charset != c.charset {
+			t.Errorf("Unexpected charset for %q: %q (expected %q)",
+				c.body,
+				p.Charset,
+				c.charset)
+		} else if w := len(p.Warnings) > 0; w != c.warn {
+			t.Errorf("Unexpected warnings for %s / %q: %q",
+				c.ctype,
+				c.body,
+				p.Warnings)
+		}
+	}
+} // func TestPreviewCharset(t *testing.T)
@@ -0,0 +1,59 @@
+// /home/krylon/go/src/ticker/feed/17_feed_redirect_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:54:35 krylon>
+
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFeedPermanentRedirect(t *testing.T) {
+	var srv = startServer()
+
+	defer srv.Close()
+
+	type testCase struct {
+		path  string
+		auth  *Auth
+		moved string
+	}
+
+	var cases = []testCase{
+		{"/feed.xml", nil, ""},
+		{"/moved.xml", nil, "/feed.xml"},
+		{"/temporary.xml", nil, ""},
+		{"/chain.xml", nil, "/temporary.xml"},
+		{"/moved-private.xml", &testAuth, "/private.xml"},
+	}
+
+	for _, c := range cases {
+		var (
+			err error
+			f   = &Feed{
+				Name:     c.path,
+				URL:      srv.URL + c.path,
+				Interval: time.Minute,
+				Active:   true,
+				Auth:     c.auth,
+			}
+			expect string
+		)
+
+		if c.moved != "" {
+			expect = srv.URL + c.moved
+		}
+
+		if _, err = f.fetch(); err != nil {
+			t.Errorf("Error fetching %s: %s", c.path, err.Error())
+		} else if f.MovedTo != expect {
+			t.Errorf("Unexpected MovedTo for %s: %q (expected %q)",
+				c.path,
+				f.MovedTo,
+				expect)
+		}
+	}
+} // func TestFeedPermanentRedirect(t *testing.T)
@@ -0,0 +1,112 @@
+// /home/krylon/go/src/ticker/feed/adaptive.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:50:03 krylon>
+
+package feed
+
+import (
+	"sort"
+	"time"
+)
+
+// AdaptiveMinDefault is the shortest refresh interval for a Feed in adaptive
+// mode that does not set its own lower bound.
+var AdaptiveMinDefault = time.Minute * 5
+
+// AdaptiveMaxDefault is the longest refresh interval for a Feed in adaptive
+// mode that does not set its own upper bound.
+var AdaptiveMaxDefault = time.Hour * 24
+
+// AdaptiveSampleSize is the number of recent Items we look at to estimate
+// how often a Feed is updated.
+const AdaptiveSampleSize = 20
+
+// adaptiveMinSamples is the minimum number of Item timestamps we need before
+// we trust our estimate.
+const adaptiveMinSamples = 3
+
+// Bounds returns the lower and upper limit for the refresh interval of a Feed
+// in adaptive mode.
+func (f *Feed) Bounds() (time.Duration, time.Duration) {
+	var min, max = f.IntervalMin, f.IntervalMax
+
+	if min <= 0 {
+		min = AdaptiveMinDefault
+	}
+
+	if max <= 0 {
+		max = AdaptiveMaxDefault
+	}
+
+	if max < min {
+		max = min
+	}
+
+	return min, max
+} // func (f *Feed) Bounds() (time.Duration, time.Duration)
+
+// Clamp restricts the given interval to the Feed's bounds.
+func (f *Feed) Clamp(ival time.Duration) time.Duration {
+	var min, max = f.Bounds()
+
+	if ival < min {
+		return min
+	} else if ival > max {
+		return max
+	}
+
+	return ival
+} // func (f *Feed) Clamp(ival time.Duration) time.Duration
+
+// EffectiveInterval returns the interval at which the Feed is refreshed.
+// If the Feed is in adaptive mode and we have enough data to compute an
+// interval, that interval is used, otherwise the Feed's Interval.
+// If the Feed pushes its Items to us, we poll it no more often than
+// PushInterval.
+func (f *Feed) EffectiveInterval() time.Duration {
+	var ival = f.Interval
+
+	if f.Adaptive && f.AdaptiveInterval > 0 {
+		ival = f.Clamp(f.AdaptiveInterval)
+	}
+
+	if f.IsPushed() && ival < PushInterval {
+		ival = PushInterval
+	}
+
+	return ival
+} // func (f *Feed) EffectiveInterval() time.Duration
+
+// ComputeInterval estimates a refresh interval from the timestamps of
+// recently published Items, using the median spacing between them, so
+// that a single burst or a single long pause do not throw us off.
+// If there are not enough timestamps, ComputeInterval returns 0.
+func (f *Feed) ComputeInterval(stamps []time.Time) time.Duration {
+	if len(stamps) < adaptiveMinSamples {
+		return 0
+	}
+
+	var (
+		sorted = make([]time.Time, len(stamps))
+		gaps   = make([]time.Duration, 0, len(stamps)-1)
+	)
+
+	copy(sorted, stamps)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	for i := 1; i < len(sorted); i++ {
+		gaps = append(gaps, sorted[i].Sub(sorted[i-1]))
+	}
+
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+
+	var median = gaps[len(gaps)/2]
+
+	if len(gaps)%2 == 0 {
+		median = (gaps[len(gaps)/2-1] + median) / 2
+	}
+
+	return f.Clamp(median)
+} // func (f *Feed) ComputeInterval(stamps []time.Time) time.Duration
@@ -0,0 +1,194 @@
+// /home/krylon/go/src/ticker/feed/auth.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:53:39 krylon>
+
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Auth holds the credentials and additional request parameters we need to
+// fetch a Feed that is not publicly accessible. Since these are secrets,
+// Auth's String method only describes what kind of credentials are set,
+// so they do not end up in log files or web pages by accident.
+type Auth struct {
+	Username string            `json:",omitempty"`
+	Password string            `json:",omitempty"`
+	Token    string            `json:",omitempty"`
+	Headers  map[string]string `json:",omitempty"`
+	Cookies  map[string]string `json:",omitempty"`
+	Params   map[string]string `json:",omitempty"`
+}
+
+// IsEmpty returns true if no credentials or parameters are set.
+func (a *Auth) IsEmpty() bool {
+	return a == nil ||
+		(a.Username == "" &&
+			a.Password == "" &&
+			a.Token == "" &&
+			len(a.Headers) == 0 &&
+			len(a.Cookies) == 0 &&
+			len(a.Params) == 0)
+} // func (a *Auth) IsEmpty() bool
+
+func (a *Auth) String() string {
+	if a.IsEmpty() {
+		return "none"
+	}
+
+	var parts = make([]string, 0, 5)
+
+	if a.Username != "" || a.Password != "" {
+		parts = append(parts, "Basic")
+	}
+
+	if a.Token != "" {
+		parts = append(parts, "Bearer")
+	}
+
+	for _, m := range []struct {
+		name  string
+		items map[string]string
+	}{
+		{"Header", a.Headers},
+		{"Cookie", a.Cookies},
+		{"Parameter", a.Params},
+	} {
+		switch len(m.items) {
+		case 0:
+		case 1:
+			parts = append(parts, "1 "+m.name)
+		default:
+			parts = append(parts, fmt.Sprintf("%d %ss", len(m.items), m.name))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+} // func (a *Auth) String() string
+
+// Merge returns a copy of the receiver with all values that are set in u
+// replaced. This way, a form can leave secrets blank to keep them as they
+// are.
+func (a *Auth) Merge(u *Auth) *Auth {
+	var m Auth
+
+	if a != nil {
+		m = *a
+	}
+
+	if u == nil {
+		return &m
+	}
+
+	if u.Username != "" {
+		m.Username = u.Username
+	}
+
+	if u.Password != "" {
+		m.Password = u.Password
+	}
+
+	if u.Token != "" {
+		m.Token = u.Token
+	}
+
+	if len(u.Headers) > 0 {
+		m.Headers = u.Headers
+	}
+
+	if len(u.Cookies) > 0 {
+		m.Cookies = u.Cookies
+	}
+
+	if len(u.Params) > 0 {
+		m.Params = u.Params
+	}
+
+	return &m
+} // func (a *Auth) Merge(u *Auth) *Auth
+
+// apply adds the credentials to a request. Parameters are added to the
+// query string. If both a username and a token are set, the token takes
+// precedence, and a header set explicitly trumps both.
+func (a *Auth) apply(req *http.Request) {
+	if a.IsEmpty() {
+		return
+	}
+
+	if len(a.Params) > 0 {
+		var q = req.URL.Query()
+
+		for k, v := range a.Params {
+			q.Set(k, v)
+		}
+
+		req.URL.RawQuery = q.Encode()
+	}
+
+	if a.Username != "" || a.Password != "" {
+		req.SetBasicAuth(a.Username, a.Password)
+	}
+
+	if a.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.Token)
+	}
+
+	for k, v := range a.Headers {
+		req.Header.Set(k, v)
+	}
+
+	var names = make([]string, 0, len(a.Cookies))
+
+	for k := range a.Cookies {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	for _, k := range names {
+		req.AddCookie(&http.Cookie{Name: k, Value: a.Cookies[k]})
+	}
+} // func (a *Auth) apply(req *http.Request)
+
+// ParseAuthPairs parses a block of text with one name/value pair per line,
+// separated by sep, e.g. "X-Api-Key: 1234" for headers or "session=abcd"
+// for cookies. Blank lines are ignored.
+func ParseAuthPairs(text, sep string) (map[string]string, error) {
+	var pairs = make(map[string]string)
+
+	for idx, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		var kv = strings.SplitN(line, sep, 2)
+
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("Line %d: missing %q between name and value",
+				idx+1,
+				sep)
+		}
+
+		var name = strings.TrimSpace(kv[0])
+
+		if name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("Line %d: invalid name %q",
+				idx+1,
+				name)
+		}
+
+		pairs[name] = strings.TrimSpace(kv[1])
+	}
+
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	return pairs, nil
+} // func ParseAuthPairs(text, sep string) (map[string]string, error)
@@ -0,0 +1,23 @@
+// /home/krylon/go/src/ticker/feed/category.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:47:50 krylon>
+
+package feed
+
+// Category is a category a Feed uses for its Items, along with the number
+// of Items we have seen in it.
+type Category struct {
+	Name  string
+	Count int64
+}
+
+// CategoryTag maps a Feed's category to one of our Tags. New Items in that
+// category get the Tag attached automatically.
+type CategoryTag struct {
+	ID       int64
+	FeedID   int64
+	Category string
+	TagID    int64
+}
@@ -0,0 +1,220 @@
+// /home/krylon/go/src/ticker/feed/discover.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:01:53 krylon>
+
+package feed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SlyMarbo/rss"
+	"github.com/blicero/ticker/common"
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Candidate is a Feed we found while looking at a web site.
+type Candidate struct {
+	URL      string
+	Type     string
+	Name     string
+	Homepage string
+}
+
+// discoverTimeout limits how long we wait for any single request during
+// discovery, since a user is waiting for the result.
+const discoverTimeout = time.Second * 20
+
+// discoverMaxSize is the maximum number of bytes we read from any response
+// during discovery.
+const discoverMaxSize = 4 << 20
+
+// feedTypes are the MIME types announced in <link rel="alternate"> elements
+// that we recognize as feeds.
+var feedTypes = map[string]bool{
+	"application/rss+xml":   true,
+	"application/atom+xml":  true,
+	"application/feed+json": true,
+}
+
+// commonPaths are the locations where many sites put their feeds, even if
+// they do not announce them.
+var commonPaths = []string{
+	"/feed",
+	"/feed/",
+	"/rss",
+	"/rss.xml",
+	"/atom.xml",
+	"/feed.xml",
+	"/index.xml",
+	"/feed.json",
+}
+
+// Discover looks for Feeds at the given URL. If the URL points to a Feed
+// itself, that Feed is the only Candidate. Otherwise, the page is searched
+// for <link rel="alternate"> elements, and a few common locations are
+// probed. Only Candidates that can actually be parsed are returned.
+func Discover(pageURL string) ([]Candidate, error) {
+	var (
+		err        error
+		base       *url.URL
+		body       []byte
+		ctype      string
+		doc        *html.Node
+		seen       = make(map[string]bool)
+		candidates []Candidate
+	)
+
+	if base, err = url.Parse(pageURL); err != nil {
+		return nil, err
+	} else if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("Unsupported URL scheme %q", base.Scheme)
+	} else if body, ctype, err = discoverGet(pageURL); err != nil {
+		return nil, err
+	}
+
+	// Servers do not always send a useful Content-Type, so if it does not
+	// say HTML, we see if it is a Feed, and if it is not, we try to
+	// parse it as HTML anyway.
+	if !isHTML(ctype) {
+		if c, err := candidateFromBody(pageURL, ctype, body); err == nil {
+			return []Candidate{*c}, nil
+		}
+	}
+
+	if doc, err = html.Parse(bytes.NewReader(body)); err != nil {
+		return nil, err
+	}
+
+	if b := dom.QuerySelector(doc, "base[href]"); b != nil {
+		if u, err := base.Parse(dom.GetAttribute(b, "href")); err == nil {
+			base = u
+		}
+	}
+
+	var links []*url.URL
+
+	for _, l := range dom.QuerySelectorAll(doc, "link[rel~=alternate][href]") {
+		var mtype, _, _ = mime.ParseMediaType(dom.GetAttribute(l, "type"))
+
+		if !feedTypes[mtype] {
+			continue
+		} else if u, err := base.Parse(dom.GetAttribute(l, "href")); err == nil {
+			links = append(links, u)
+		}
+	}
+
+	for _, p := range commonPaths {
+		links = append(links, base.ResolveReference(&url.URL{Path: p}))
+	}
+
+	for _, u := range links {
+		var (
+			c     *Candidate
+			addr  = u.String()
+			fbody []byte
+			fct   string
+		)
+
+		if seen[addr] {
+			continue
+		}
+
+		seen[addr] = true
+
+		if fbody, fct, err = discoverGet(addr); err != nil || isHTML(fct) {
+			continue
+		} else if c, err = candidateFromBody(addr, fct, fbody); err != nil {
+			continue
+		}
+
+		if c.Homepage == addr {
+			c.Homepage = pageURL
+		}
+
+		candidates = append(candidates, *c)
+	}
+
+	return candidates, nil
+} // func Discover(pageURL string) ([]Candidate, error)
+
+// discoverGet fetches the given URL and returns the body and the
+// Content-Type of the response.
+func discoverGet(addr string) ([]byte, string, error) {
+	var (
+		err         error
+		req         *http.Request
+		res         *http.Response
+		body        []byte
+		ctx, cancel = context.WithTimeout(context.Background(), discoverTimeout)
+	)
+
+	defer cancel()
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, addr, nil); err != nil {
+		return nil, "", err
+	} else if res, err = common.HTTPClient().Do(req); err != nil {
+		return nil, "", err
+	}
+
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("Unexpected HTTP status fetching %s: %s",
+			addr,
+			res.Status)
+	} else if body, err = io.ReadAll(io.LimitReader(res.Body, discoverMaxSize)); err != nil {
+		return nil, "", err
+	}
+
+	return body, res.Header.Get("Content-Type"), nil
+} // func discoverGet(addr string) ([]byte, string, error)
+
+// candidateFromBody attempts to parse the body of a response as a Feed.
+func candidateFromBody(addr, ctype string, body []byte) (*Candidate, error) {
+	var (
+		err   error
+		fd    *rss.Feed
+		mtype string
+		c     = &Candidate{URL: addr}
+	)
+
+	if fd, err = parse(ctype, body); err != nil {
+		return nil, err
+	} else if fd.Title == "" && len(fd.Items) == 0 {
+		return nil, fmt.Errorf("%s does not look like a Feed", addr)
+	}
+
+	if mtype, _, err = mime.ParseMediaType(ctype); err == nil {
+		c.Type = mtype
+	}
+
+	c.Name = strings.TrimSpace(fd.Title)
+	c.Homepage = fd.Link
+
+	if c.Homepage == "" {
+		c.Homepage = addr
+	}
+
+	if c.Name == "" {
+		c.Name = c.Homepage
+	}
+
+	return c, nil
+} // func candidateFromBody(addr, ctype string, body []byte) (*Candidate, error)
+
+func isHTML(ctype string) bool {
+	var mtype, _, err = mime.ParseMediaType(ctype)
+
+	return err == nil && (mtype == "text/html" || mtype == "application/xhtml+xml")
+} // func isHTML(ctype string) bool
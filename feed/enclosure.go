@@ -0,0 +1,188 @@
+// /home/krylon/go/src/ticker/feed/enclosure.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:47:50 krylon>
+
+package feed
+
+import (
+	"fmt"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SlyMarbo/rss"
+	"github.com/blicero/ticker/common"
+)
+
+// Enclosure is a file attached to an Item, e.g. the audio file of a podcast
+// episode or a video.
+type Enclosure struct {
+	ID       int64
+	ItemID   int64
+	URL      string
+	Type     string
+	Length   int64
+	Duration time.Duration
+}
+
+// Kind returns the general kind of media, i.e. the part of the MIME type
+// before the slash, e.g. "audio" or "video".
+func (e *Enclosure) Kind() string {
+	var kind, _, _ = strings.Cut(e.Type, "/")
+	return kind
+} // func (e *Enclosure) Kind() string
+
+// IsAudio returns true if the Enclosure is an audio file.
+func (e *Enclosure) IsAudio() bool {
+	return e.Kind() == "audio"
+} // func (e *Enclosure) IsAudio() bool
+
+// IsVideo returns true if the Enclosure is a video file.
+func (e *Enclosure) IsVideo() bool {
+	return e.Kind() == "video"
+} // func (e *Enclosure) IsVideo() bool
+
+// Filename returns the last part of the Enclosure's URL path.
+func (e *Enclosure) Filename() string {
+	var (
+		err error
+		u   *url.URL
+	)
+
+	if u, err = url.Parse(e.URL); err != nil || path.Base(u.Path) == "/" || path.Base(u.Path) == "." {
+		return fmt.Sprintf("enclosure%d", e.ID)
+	}
+
+	return path.Base(u.Path)
+} // func (e *Enclosure) Filename() string
+
+// LocalPath returns the path the Enclosure is stored at once it has been
+// downloaded.
+func (e *Enclosure) LocalPath() string {
+	return filepath.Join(
+		common.EnclosureDir,
+		strconv.FormatInt(e.ID, 10)+path.Ext(e.Filename()))
+} // func (e *Enclosure) LocalPath() string
+
+// IsDownloaded returns true if the Enclosure has been downloaded to the
+// local archive.
+func (e *Enclosure) IsDownloaded() bool {
+	var info, err = os.Stat(e.LocalPath())
+
+	return err == nil && info.Mode().IsRegular()
+} // func (e *Enclosure) IsDownloaded() bool
+
+// DurationString formats the Enclosure's duration the way media players
+// usually do, e.g. 1:02:03. If the duration is unknown, it returns an empty
+// string.
+func (e *Enclosure) DurationString() string {
+	if e.Duration <= 0 {
+		return ""
+	}
+
+	var (
+		secs = int64(e.Duration.Seconds())
+		h    = secs / 3600
+		m    = (secs % 3600) / 60
+		s    = secs % 60
+	)
+
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+
+	return fmt.Sprintf("%d:%02d", m, s)
+} // func (e *Enclosure) DurationString() string
+
+// ParseDuration parses the duration of an enclosure as given in the
+// itunes:duration element, which may be a number of seconds, or a
+// timestamp like HH:MM:SS or MM:SS.
+func ParseDuration(s string) (time.Duration, error) {
+	var (
+		secs   float64
+		pieces = strings.Split(strings.TrimSpace(s), ":")
+	)
+
+	if len(pieces) > 3 {
+		return 0, fmt.Errorf("Invalid duration %q", s)
+	}
+
+	for _, p := range pieces {
+		var (
+			err error
+			n   float64
+		)
+
+		if n, err = strconv.ParseFloat(p, 64); err != nil || n < 0 {
+			return 0, fmt.Errorf("Invalid duration %q", s)
+		}
+
+		secs = secs*60 + n
+	}
+
+	return time.Duration(secs * float64(time.Second)), nil
+} // func ParseDuration(s string) (time.Duration, error)
+
+// nonMediaTypes are the MIME types of links the RSS library mistakes for
+// enclosures, e.g. <link rel="replies"> in Atom entries.
+var nonMediaTypes = map[string]bool{
+	"text/html":             true,
+	"application/xhtml+xml": true,
+	"application/atom+xml":  true,
+	"application/rss+xml":   true,
+	"application/feed+json": true,
+}
+
+// enclosures converts the enclosures of a parsed Item to our own type,
+// dropping those that are not media files.
+func enclosures(item *rss.Item, meta *itemMeta) []Enclosure {
+	var list = make([]Enclosure, 0, len(item.Enclosures))
+
+	for _, e := range item.Enclosures {
+		if e == nil || e.URL == "" {
+			continue
+		}
+
+		var ctype = strings.ToLower(strings.TrimSpace(e.Type))
+
+		if ctype == "" {
+			if u, err := url.Parse(e.URL); err == nil {
+				ctype = mime.TypeByExtension(path.Ext(u.Path))
+			}
+		}
+
+		if mtype, _, err := mime.ParseMediaType(ctype); err == nil {
+			ctype = mtype
+		}
+
+		switch kind, _, _ := strings.Cut(ctype, "/"); kind {
+		case "audio", "video", "image":
+		case "application":
+			if nonMediaTypes[ctype] {
+				continue
+			}
+		default:
+			continue
+		}
+
+		list = append(list, Enclosure{
+			URL:      e.URL,
+			Type:     ctype,
+			Length:   int64(e.Length),
+			Duration: meta.enclosureDuration(e.URL),
+		})
+	}
+
+	if len(list) == 0 {
+		return nil
+	}
+
+	return list
+} // func enclosures(item *rss.Item, meta *itemMeta) []Enclosure
@@ -11,7 +11,10 @@ package feed
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/url"
 	"github.com/blicero/ticker/common"
 	"github.com/blicero/ticker/logdomain"
 	"time"
@@ -22,17 +25,46 @@ import (
 // ErrInactive indicates that a Feed is not active.
 var ErrInactive = errors.New("feed is not active")
 
-// Feed represents an RSS feed.
+// BackoffSteps is the maximum number of times the refresh interval of a
+// failing Feed is doubled.
+const BackoffSteps = 16
+
+// ErrNotModified indicates that the server reported the Feed has not changed
+// since we last fetched it.
+var ErrNotModified = errors.New("feed has not been modified")
+
+// Feed represents an RSS feed. MovedTo is not stored, fetching the Feed
+// sets it to the URL the server permanently redirected us to, if any.
 type Feed struct {
-	ID         int64
-	Name       string
-	URL        string
-	Homepage   string
-	Interval   time.Duration
-	LastUpdate time.Time
-	Active     bool
-	rfeed      *rss.Feed
-	log        *log.Logger
+	ID               int64
+	Name             string
+	URL              string
+	Homepage         string
+	Interval         time.Duration
+	LastUpdate       time.Time
+	Active           bool
+	ETag             string
+	LastModified     string
+	FailCount        int64
+	FailSince        time.Time
+	LastError        string
+	LastSuccess      time.Time
+	HTTPStatus       int
+	Adaptive         bool
+	IntervalMin      time.Duration
+	IntervalMax      time.Duration
+	AdaptiveInterval time.Duration
+	Hub              string
+	Topic            string
+	PushUntil        time.Time
+	Auth             *Auth
+	GroupID          int64
+	Scraper          *Scraper
+	FullText         bool
+	MovedTo          string
+	rfeed            *rss.Feed
+	meta             map[string]*itemMeta
+	log              *log.Logger
 }
 
 // New creates a new Feed.
@@ -71,9 +103,54 @@ func (f *Feed) IsDue() bool {
 
 // Next returns the Timestamp when the Feed is next due for a refresh.
 func (f *Feed) Next() time.Time {
-	return f.LastUpdate.Add(f.Interval)
+	return f.LastUpdate.Add(f.RetryInterval())
 } // func (f *Feed) Next() time.Time
 
+// IsFailing returns true if the most recent attempt to refresh the Feed failed.
+func (f *Feed) IsFailing() bool {
+	return f.FailCount > 0
+} // func (f *Feed) IsFailing() bool
+
+// RetryInterval returns the amount of time to wait after the last refresh
+// before the Feed is checked again. For a healthy Feed, this is just its
+// EffectiveInterval, for a failing Feed, that interval is doubled after each
+// consecutive failure, up to the configured backoff ceiling.
+func (f *Feed) RetryInterval() time.Duration {
+	var base = f.EffectiveInterval()
+
+	if f.FailCount == 0 {
+		return base
+	}
+
+	var (
+		steps    = f.FailCount
+		ceiling  = common.HealthSettings().BackoffCeiling
+		interval time.Duration
+	)
+
+	if steps > BackoffSteps {
+		steps = BackoffSteps
+	}
+
+	if base > ceiling {
+		ceiling = base
+	}
+
+	if interval = base * time.Duration(1<<steps); interval > ceiling {
+		interval = ceiling
+	}
+
+	return interval
+} // func (f *Feed) RetryInterval() time.Duration
+
+// IsDead returns true if the Feed has been failing for longer than the
+// configured failure limit.
+func (f *Feed) IsDead() bool {
+	return f.IsFailing() &&
+		!f.FailSince.IsZero() &&
+		time.Since(f.FailSince) > common.HealthSettings().FailureLimit
+} // func (f *Feed) IsDead() bool
+
 // FetchRaw fetches a Feed.
 func (f *Feed) FetchRaw() (*rss.Feed, error) {
 	var (
@@ -85,31 +162,120 @@ func (f *Feed) FetchRaw() (*rss.Feed, error) {
 		return nil, ErrInactive
 	}
 
-	if f.rfeed != nil {
+	if f.rfeed != nil && !f.IsDue() {
 		fd = f.rfeed
-	} else if fd, err = rss.Fetch(f.URL); err != nil {
-		f.log.Printf("[ERROR] Error fetching %s (%s): %s\n",
-			f.Name,
-			f.URL,
-			err.Error())
+	} else if fd, err = f.fetch(); err != nil {
+		if !errors.Is(err, ErrNotModified) {
+			f.log.Printf("[ERROR] Error fetching %s (%s): %s\n",
+				f.Name,
+				f.URL,
+				err.Error())
+		}
 		return nil, err
 	} else {
 		f.rfeed = fd
 		f.LastUpdate = time.Now()
 	}
 
-	if f.IsDue() {
-		if err = fd.Update(); err != nil {
-			f.log.Printf("[ERROR] Cannot update %s (%s): %s\n",
-				f.Name,
-				f.URL,
-				err.Error())
-			return nil, err
+	return fd, nil
+} // func (f *Feed) FetchRaw() (*rss.Feed, error)
+
+// fetch performs a conditional GET request for the Feed's URL, sending
+// the ETag and Last-Modified values we got from the previous response,
+// if any. If the server tells us the Feed has not changed, fetch returns
+// ErrNotModified.
+func (f *Feed) fetch() (*rss.Feed, error) {
+	var (
+		err  error
+		req  *http.Request
+		res  *http.Response
+		body []byte
+		fd   *rss.Feed
+	)
+
+	if req, err = http.NewRequest(http.MethodGet, f.URL, nil); err != nil {
+		return nil, err
+	}
+
+	if f.ETag != "" {
+		req.Header.Set("If-None-Match", f.ETag)
+	}
+
+	if f.LastModified != "" {
+		req.Header.Set("If-Modified-Since", f.LastModified)
+	}
+
+	f.Auth.apply(req)
+
+	if res, err = common.HTTPClient().Do(req); err != nil {
+		// The request URL may contain secret parameters, and the error
+		// ends up in the Feed's LastError, so we replace it.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			uerr.URL = f.URL
 		}
+
+		f.HTTPStatus = 0
+		return nil, err
+	}
+
+	defer res.Body.Close() // nolint: errcheck
+
+	f.HTTPStatus = res.StatusCode
+
+	if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusNotModified {
+		f.MovedTo = f.permanentRedirect(res)
+	}
+
+	if res.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	} else if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected HTTP status fetching %s: %s",
+			f.URL,
+			res.Status)
+	} else if body, err = io.ReadAll(res.Body); err != nil {
+		return nil, err
+	} else if f.Scraper != nil {
+		fd, err = f.Scraper.scrape(f.URL, body)
+	} else {
+		fd, err = parse(res.Header.Get("Content-Type"), body)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fd.Link == "" {
+		fd.Link = f.URL
+	}
+
+	fd.UpdateURL = f.URL
+	f.ETag = res.Header.Get("ETag")
+	f.LastModified = res.Header.Get("Last-Modified")
+
+	// A scraped page is not a Feed, so it has no hub or Item metadata.
+	if f.Scraper != nil {
+		return fd, nil
+	}
+
+	f.Hub, f.Topic = findHub(res.Header, res.Header.Get("Content-Type"), body)
+	f.meta = findMeta(res.Header.Get("Content-Type"), body)
+
+	if f.Hub != "" && f.Topic == "" {
+		f.Topic = f.URL
 	}
 
 	return fd, nil
-} // func (f *Feed) FetchRaw() (*rss.Feed, error)
+} // func (f *Feed) fetch() (*rss.Feed, error)
+
+// parse parses the body of a response as JSON Feed, RSS or Atom.
+func parse(contentType string, body []byte) (*rss.Feed, error) {
+	if isJSONFeed(contentType, body) {
+		return parseJSONFeed(body)
+	}
+
+	return rss.Parse(body)
+} // func parse(contentType string, body []byte) (*rss.Feed, error)
 
 // Fetch fetches a Feed.
 func (f *Feed) Fetch() ([]Item, error) {
@@ -118,33 +284,30 @@ func (f *Feed) Fetch() ([]Item, error) {
 		fd  *rss.Feed
 	)
 
-	if !f.Active {
-		return nil, ErrInactive
+	if fd, err = f.FetchRaw(); err != nil {
+		return nil, err
 	}
 
-	if f.rfeed != nil {
-		fd = f.rfeed
-	} else if fd, err = rss.Fetch(f.URL); err != nil {
-		f.log.Printf("[ERROR] Error fetching %s (%s): %s\n",
-			f.Name,
-			f.URL,
-			err.Error())
+	return f.items(fd, f.meta), nil
+} // func (f *Feed) Fetch() ([]Item, error)
+
+// Parse converts a Feed document we received by other means than fetching
+// it ourselves, e.g. pushed to us by a WebSub hub, into Items.
+func (f *Feed) Parse(contentType string, body []byte) ([]Item, error) {
+	var (
+		err error
+		fd  *rss.Feed
+	)
+
+	if fd, err = parse(contentType, body); err != nil {
 		return nil, err
-	} else {
-		f.rfeed = fd
-		f.LastUpdate = time.Now()
 	}
 
-	if f.IsDue() {
-		if err = fd.Update(); err != nil {
-			f.log.Printf("[ERROR] Cannot update %s (%s): %s\n",
-				f.Name,
-				f.URL,
-				err.Error())
-			return nil, err
-		}
-	}
+	return f.items(fd, findMeta(contentType, body)), nil
+} // func (f *Feed) Parse(contentType string, body []byte) ([]Item, error)
 
+// items converts the Items of a parsed Feed to our own Item type.
+func (f *Feed) items(fd *rss.Feed, meta map[string]*itemMeta) []Item {
 	var (
 		now   = time.Now()
 		items = make([]Item, len(fd.Items))
@@ -159,15 +322,26 @@ func (f *Feed) Fetch() ([]Item, error) {
 			item.Content = item.Summary
 		}
 
+		var m = meta[item.ID]
+
 		items[idx] = Item{
 			FeedID:      f.ID,
 			URL:         item.Link,
+			GUID:        item.ID,
 			Title:       item.Title,
 			Description: item.Content,
 			Timestamp:   item.Date,
+			Enclosures:  enclosures(item, m),
+		}
+
+		if m != nil {
+			items[idx].Author = m.author
+			items[idx].AuthorURI = m.authorURI
+			items[idx].Categories = m.categories
+		} else if item.Category != "" {
+			items[idx].Categories = []string{item.Category}
 		}
 	}
 
-	return items, nil
-	//return fd, nil
-} // func (f *Feed) Fetch() ([]Item, error)
+	return items
+} // func (f *Feed) items(fd *rss.Feed, meta map[string]*itemMeta) []Item
@@ -0,0 +1,261 @@
+// /home/krylon/go/src/ticker/feed/fulltext.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:25:31 krylon>
+
+package feed
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/blicero/ticker/common"
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// ErrNoContent indicates that we could not find the main content of a page.
+var ErrNoContent = errors.New("no main content found on the page")
+
+// minContentLength is the number of characters the text we extract from an
+// article page must have at least. Anything shorter is most likely not the
+// article, but a cookie banner or some such.
+const minContentLength = 250
+
+// maxPageSize is the largest article page we are willing to download.
+const maxPageSize = 4 << 20 // 4 MiB
+
+// Elements we never want in the extracted content.
+var junkTags = []string{
+	"script", "style", "noscript", "template", "iframe", "frame", "object",
+	"embed", "form", "input", "button", "select", "textarea", "nav",
+	"aside", "footer", "link", "meta", "svg", "canvas", "video", "audio",
+}
+
+// The class names and IDs of an element give us a hint if it is part of the
+// content or of the stuff around it.
+var (
+	unlikelyPat = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|cookie|disqus|extra|foot|header|menu|modal|nav|newsletter|popup|promo|related|remark|rss|share|shoutbox|sidebar|social|sponsor|subscribe|teaser|widget`)
+	likelyPat   = regexp.MustCompile(`(?i)article|body|column|content|entry|main|page|post|story|text`)
+)
+
+// FetchContent loads the page an Item links to and extracts its main
+// content. The Feed's credentials are only sent along if the page lives on
+// the same host as the Feed.
+func (f *Feed) FetchContent(i *Item) (string, error) {
+	var (
+		err       error
+		req       *http.Request
+		res       *http.Response
+		body      []byte
+		page, src *url.URL
+	)
+
+	if page, err = url.Parse(i.URL); err != nil {
+		return "", err
+	} else if req, err = http.NewRequest(http.MethodGet, i.URL, nil); err != nil {
+		return "", err
+	}
+
+	if src, err = url.Parse(f.URL); err == nil && strings.EqualFold(src.Host, page.Host) {
+		f.Auth.apply(req)
+	}
+
+	if res, err = common.HTTPClient().Do(req); err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Unexpected HTTP status fetching %s: %s",
+			i.URL,
+			res.Status)
+	} else if ctype := res.Header.Get("Content-Type"); ctype != "" && !strings.Contains(ctype, "html") {
+		return "", fmt.Errorf("%s is not an HTML page, but %s",
+			i.URL,
+			ctype)
+	} else if body, err = io.ReadAll(io.LimitReader(res.Body, maxPageSize)); err != nil {
+		return "", err
+	}
+
+	return ExtractContent(res.Request.URL.String(), body)
+} // func (f *Feed) FetchContent(i *Item) (string, error)
+
+// ExtractContent finds the main content of an HTML page, the way "reader
+// modes" in web browsers do, and returns it as HTML. Paragraphs of text
+// earn points for the elements that contain them, long paragraphs and
+// commas earn more. The element with the highest score, after a penalty
+// for text that consists of links, is considered the content.
+//
+// Links and images are made absolute and open in a new window, scripts and
+// event handlers are removed.
+func ExtractContent(pageURL string, body []byte) (string, error) {
+	var (
+		err    error
+		base   *url.URL
+		doc    *html.Node
+		top    *html.Node
+		best   float64
+		scores = make(map[*html.Node]float64)
+	)
+
+	if base, err = url.Parse(pageURL); err != nil {
+		return "", err
+	} else if doc, err = html.Parse(bytes.NewReader(body)); err != nil {
+		return "", err
+	}
+
+	if b := dom.QuerySelector(doc, "base[href]"); b != nil {
+		if u, err := base.Parse(dom.GetAttribute(b, "href")); err == nil {
+			base = u
+		}
+	}
+
+	dom.RemoveNodes(dom.GetAllNodesWithTag(doc, junkTags...), nil)
+	dom.RemoveNodes(dom.QuerySelectorAll(doc, "body *"), isUnlikely)
+
+	for _, p := range dom.GetAllNodesWithTag(doc, "p", "pre", "td", "blockquote") {
+		var (
+			text  = collapseSpace(dom.TextContent(p))
+			score float64
+		)
+
+		if len(text) < 25 || p.Parent == nil {
+			continue
+		}
+
+		score = 1 + float64(strings.Count(text, ","))
+		if l := float64(len(text)) / 100; l < 3 {
+			score += l
+		} else {
+			score += 3
+		}
+
+		for lvl, n := 0, p.Parent; lvl < 3 && n != nil && n.Type == html.ElementNode; lvl, n = lvl+1, n.Parent {
+			if _, ok := scores[n]; !ok {
+				scores[n] = initialScore(n)
+			}
+
+			scores[n] += score / float64(lvl+1)
+		}
+	}
+
+	for n, s := range scores {
+		if s *= 1 - linkDensity(n); s > best {
+			best = s
+			top = n
+		}
+	}
+
+	if top == nil || len(collapseSpace(dom.TextContent(top))) < minContentLength {
+		return "", ErrNoContent
+	}
+
+	cleanContent(top, base)
+
+	return strings.TrimSpace(dom.InnerHTML(top)), nil
+} // func ExtractContent(pageURL string, body []byte) (string, error)
+
+// isUnlikely returns true if the class or ID of an element suggest it is
+// not part of the content.
+func isUnlikely(n *html.Node) bool {
+	switch dom.TagName(n) {
+	case "body", "article", "main", "a":
+		return false
+	}
+
+	var hint = dom.ClassName(n) + " " + dom.ID(n)
+
+	return unlikelyPat.MatchString(hint) && !likelyPat.MatchString(hint)
+} // func isUnlikely(n *html.Node) bool
+
+// initialScore returns the score an element starts out with, based on its
+// tag and class names.
+func initialScore(n *html.Node) float64 {
+	var score float64
+
+	switch dom.TagName(n) {
+	case "article", "main":
+		score = 10
+	case "div", "section":
+		score = 5
+	case "pre", "td", "blockquote":
+		score = 3
+	case "ol", "ul", "dl", "dd", "dt", "li":
+		score = -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th", "header":
+		score = -5
+	}
+
+	var hint = dom.ClassName(n) + " " + dom.ID(n)
+
+	if likelyPat.MatchString(hint) {
+		score += 25
+	}
+
+	if unlikelyPat.MatchString(hint) {
+		score -= 25
+	}
+
+	return score
+} // func initialScore(n *html.Node) float64
+
+// linkDensity returns the fraction of an element's text that is part of
+// links.
+func linkDensity(n *html.Node) float64 {
+	var (
+		total = len(collapseSpace(dom.TextContent(n)))
+		links int
+	)
+
+	if total == 0 {
+		return 0
+	}
+
+	for _, a := range dom.GetElementsByTagName(n, "a") {
+		links += len(collapseSpace(dom.TextContent(a)))
+	}
+
+	return float64(links) / float64(total)
+} // func linkDensity(n *html.Node) float64
+
+// cleanContent prepares the extracted content to be embedded in our pages.
+func cleanContent(top *html.Node, base *url.URL) {
+	for _, n := range dom.QuerySelectorAll(top, "*") {
+		var attrs = n.Attr[:0]
+
+		for _, a := range n.Attr {
+			var key = strings.ToLower(a.Key)
+
+			if strings.HasPrefix(key, "on") || key == "style" || key == "class" || key == "id" {
+				continue
+			} else if key == "href" || key == "src" {
+				var u, err = base.Parse(strings.TrimSpace(a.Val))
+
+				if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+					continue
+				}
+
+				a.Val = u.String()
+			} else if key == "srcset" {
+				continue
+			}
+
+			attrs = append(attrs, a)
+		}
+
+		n.Attr = attrs
+
+		if dom.TagName(n) == "a" {
+			dom.SetAttribute(n, "target", "_blank")
+		}
+	}
+} // func cleanContent(top *html.Node, base *url.URL)
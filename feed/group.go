@@ -0,0 +1,18 @@
+// /home/krylon/go/src/ticker/feed/group.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:14:30 krylon>
+
+package feed
+
+// Group is a folder to organize Feeds in. Groups can contain other Groups,
+// so they form a hierarchy, much like Tags. Path is the Group's name,
+// prefixed with the names of its ancestors, separated by slashes.
+type Group struct {
+	ID     int64
+	Name   string
+	Parent int64
+	Level  int
+	Path   string
+}
@@ -7,6 +7,8 @@
 package feed
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -27,18 +29,44 @@ import (
 var whitespace *regexp.Regexp = regexp.MustCompile(`[\s\t\n\r]+`)
 
 // Item represents a single news item from an RSS Feed.
+//
+// Items from different Feeds that carry the same story share a DupGroup,
+// the ID of the first Item of the group. AlsoIn lists the other Feeds that
+// carried the story.
+//
+// URL is the canonical form of the link the Feed gave us, OriginalURL the
+// link itself, if the two differ.
+//
+// Content is the main content of the page the Item links to, for Feeds
+// that only carry a teaser and have us fetch the full text.
+//
+// Muted is the Mute that hides the Item, if any. Like AlsoIn, it is only
+// filled in for display.
 type Item struct {
 	ID            int64
 	FeedID        int64
 	URL           string
+	OriginalURL   string
+	GUID          string
 	Title         string
 	Description   string
+	Content       string
 	Timestamp     time.Time
+	Updated       time.Time
 	Read          bool
 	Rating        float64
 	ManuallyRated bool
+	Author        string
+	AuthorURI     string
+	Categories    []string
 	Tags          []tag.Tag
+	Enclosures    []Enclosure
+	Fingerprint   uint64
+	DupGroup      int64
+	AlsoIn        []int64
+	Muted         *Mute
 	tagMap        map[string]bool
+	lang          string
 }
 
 func (i *Item) String() string {
@@ -69,17 +97,28 @@ func (i *Item) RatingString() string {
 	return fmt.Sprintf("%.2f", i.Rating)
 } // func (i *Item) RatingString() string
 
+// Body returns the full text of the Item, if we have it, the description
+// otherwise.
+func (i *Item) Body() string {
+	if i.Content != "" {
+		return i.Content
+	}
+
+	return i.Description
+} // func (i *Item) Body() string
+
 // Plaintext returns the complete text of the Item, cleansed of any HTML.
 func (i *Item) Plaintext() string {
 	var tmp = make([]string, 2)
 	var err error
+	var body = i.Body()
 
 	if tmp[0], err = html2text.FromString(i.Title); err != nil {
 		tmp[0] = i.Title
 	}
 
-	if tmp[1], err = html2text.FromString(i.Description); err != nil {
-		tmp[1] = i.Description
+	if tmp[1], err = html2text.FromString(body); err != nil {
+		tmp[1] = body
 	}
 
 	if tmp[1] == "Comments" { // Hacker News
@@ -119,6 +158,40 @@ func (i *Item) HasTagNamed(name string) bool {
 	return i.tagMap[name]
 } // func (i *Item) HasTagNamed(name string) bool
 
+// IsUpdated returns true if the Item has changed upstream since we first
+// saw it.
+func (i *Item) IsUpdated() bool {
+	return !i.Updated.IsZero()
+} // func (i *Item) IsUpdated() bool
+
+// ContentHash returns a checksum of the Item's link, title and description,
+// so we can tell if the Item has changed upstream. It has to be computed
+// before we modify the description, e.g. when prefetching images.
+func (i *Item) ContentHash() string {
+	var sum = sha256.Sum256([]byte(i.URL + "\x00" + i.Title + "\x00" + i.Description))
+
+	return hex.EncodeToString(sum[:])
+} // func (i *Item) ContentHash() string
+
+// HasAuthor returns true if the Item's author contains the given name,
+// ignoring case.
+func (i *Item) HasAuthor(name string) bool {
+	return name != "" &&
+		strings.Contains(strings.ToLower(i.Author), strings.ToLower(name))
+} // func (i *Item) HasAuthor(name string) bool
+
+// HasCategory returns true if the Feed placed the Item in the given
+// category, ignoring case.
+func (i *Item) HasCategory(name string) bool {
+	for _, c := range i.Categories {
+		if strings.EqualFold(c, name) {
+			return true
+		}
+	}
+
+	return false
+} // func (i *Item) HasCategory(name string) bool
+
 // IsDownloaded returns true if the Item's linked URL has been downloaded
 // to the local archive.
 func (i *Item) IsDownloaded() (result bool) {
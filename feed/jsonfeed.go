@@ -0,0 +1,195 @@
+// /home/krylon/go/src/ticker/feed/jsonfeed.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:47:50 krylon>
+
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SlyMarbo/rss"
+)
+
+// JSON Feed is documented at https://www.jsonfeed.org/version/1.1/
+// We convert JSON Feeds into the same structure the rss package uses for
+// RSS and Atom, so the rest of the application does not need to care what
+// format a Feed is published in.
+
+const (
+	jsonFeedMimeType      = "application/feed+json"
+	jsonFeedVersionPrefix = "https://jsonfeed.org/version/"
+)
+
+// jsonFeedID is the id of a JSON Feed item. The spec says it should be a
+// string, but some publishers use numbers, so we accept both.
+type jsonFeedID string
+
+func (id *jsonFeedID) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if len(data) > 0 && data[0] != '"' {
+		*id = jsonFeedID(data)
+		return nil
+	} else if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*id = jsonFeedID(s)
+	return nil
+} // func (id *jsonFeedID) UnmarshalJSON(data []byte) error
+
+type jsonFeedAttachment struct {
+	URL               string  `json:"url"`
+	MimeType          string  `json:"mime_type"`
+	Title             string  `json:"title"`
+	SizeInBytes       uint    `json:"size_in_bytes"`
+	DurationInSeconds float64 `json:"duration_in_seconds"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type jsonFeedItem struct {
+	ID            jsonFeedID           `json:"id"`
+	URL           string               `json:"url"`
+	ExternalURL   string               `json:"external_url"`
+	Title         string               `json:"title"`
+	ContentHTML   string               `json:"content_html"`
+	ContentText   string               `json:"content_text"`
+	Summary       string               `json:"summary"`
+	DatePublished string               `json:"date_published"`
+	DateModified  string               `json:"date_modified"`
+	Tags          []string             `json:"tags"`
+	Author        *jsonFeedAuthor      `json:"author"`
+	Authors       []jsonFeedAuthor     `json:"authors"`
+	Attachments   []jsonFeedAttachment `json:"attachments"`
+}
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description"`
+	Icon        string         `json:"icon"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+// isJSONFeed returns true if the response looks like a JSON Feed, either
+// because the server says so in the Content-Type header, or because the
+// body is a JSON object pointing to the JSON Feed spec.
+func isJSONFeed(contentType string, body []byte) bool {
+	if mtype, _, err := mime.ParseMediaType(contentType); err == nil && mtype == jsonFeedMimeType {
+		return true
+	}
+
+	var trimmed = bytes.TrimSpace(body)
+
+	if !bytes.HasPrefix(trimmed, []byte("{")) {
+		return false
+	}
+
+	// Some JSON encoders escape slashes, so we look for both spellings.
+	return bytes.Contains(trimmed, []byte(jsonFeedVersionPrefix)) ||
+		bytes.Contains(trimmed, []byte(strings.ReplaceAll(jsonFeedVersionPrefix, "/", `\/`)))
+} // func isJSONFeed(contentType string, body []byte) bool
+
+// parseJSONFeed parses a JSON Feed and converts it to an rss.Feed.
+func parseJSONFeed(body []byte) (*rss.Feed, error) {
+	var (
+		err error
+		jf  jsonFeed
+		fd  *rss.Feed
+	)
+
+	if err = json.Unmarshal(body, &jf); err != nil {
+		return nil, fmt.Errorf("Cannot parse JSON Feed: %w", err)
+	} else if !strings.HasPrefix(jf.Version, jsonFeedVersionPrefix) {
+		return nil, fmt.Errorf("Unsupported JSON Feed version %q", jf.Version)
+	}
+
+	fd = &rss.Feed{
+		Title:       jf.Title,
+		Description: jf.Description,
+		Link:        jf.HomePageURL,
+		UpdateURL:   jf.FeedURL,
+		Items:       make([]*rss.Item, 0, len(jf.Items)),
+		ItemMap:     make(map[string]struct{}, len(jf.Items)),
+	}
+
+	if jf.Icon != "" {
+		fd.Image = &rss.Image{URL: jf.Icon}
+	}
+
+	for _, ji := range jf.Items {
+		var item = &rss.Item{
+			ID:      string(ji.ID),
+			Title:   ji.Title,
+			Summary: ji.Summary,
+			Content: ji.ContentHTML,
+			Link:    ji.URL,
+		}
+
+		if item.Content == "" && ji.ContentText != "" {
+			item.Content = strings.ReplaceAll(
+				html.EscapeString(ji.ContentText),
+				"\n",
+				"<br />\n")
+		}
+
+		if item.Link == "" {
+			item.Link = ji.ExternalURL
+		}
+
+		if item.Link == "" && strings.HasPrefix(item.ID, "http") {
+			item.Link = item.ID
+		}
+
+		if len(ji.Tags) > 0 {
+			item.Category = ji.Tags[0]
+		}
+
+		if item.Date, err = parseJSONFeedDate(ji.DatePublished, ji.DateModified); err == nil {
+			item.DateValid = true
+		}
+
+		for _, a := range ji.Attachments {
+			item.Enclosures = append(item.Enclosures, &rss.Enclosure{
+				URL:    a.URL,
+				Type:   a.MimeType,
+				Length: a.SizeInBytes,
+			})
+		}
+
+		fd.Items = append(fd.Items, item)
+		fd.ItemMap[item.ID] = struct{}{}
+	}
+
+	return fd, nil
+} // func parseJSONFeed(body []byte) (*rss.Feed, error)
+
+// parseJSONFeedDate returns the first of the given timestamps that can be
+// parsed. JSON Feed requires RFC 3339 timestamps.
+func parseJSONFeedDate(stamps ...string) (time.Time, error) {
+	for _, s := range stamps {
+		if s == "" {
+			continue
+		} else if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("No valid timestamp in %s",
+		strconv.Quote(strings.Join(stamps, ", ")))
+} // func parseJSONFeedDate(stamps ...string) (time.Time, error)
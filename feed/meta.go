@@ -0,0 +1,257 @@
+// /home/krylon/go/src/ticker/feed/meta.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:47:50 krylon>
+
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	itunesNS = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+	dcNS     = "http://purl.org/dc/elements/1.1/"
+)
+
+// itemMeta holds the metadata of an Item the RSS library does not know
+// about.
+type itemMeta struct {
+	author     string
+	authorURI  string
+	categories []string
+	// duration applies to all of an Item's enclosures, durations to
+	// individual enclosures, identified by their URL.
+	duration  time.Duration
+	durations map[string]time.Duration
+}
+
+func (m *itemMeta) setAuthor(name, uri string) {
+	if m.author == "" && m.authorURI == "" {
+		m.author = strings.TrimSpace(name)
+		m.authorURI = strings.TrimSpace(uri)
+	}
+} // func (m *itemMeta) setAuthor(name, uri string)
+
+func (m *itemMeta) addCategory(name string) {
+	if name = strings.TrimSpace(name); name == "" {
+		return
+	}
+
+	for _, c := range m.categories {
+		if strings.EqualFold(c, name) {
+			return
+		}
+	}
+
+	m.categories = append(m.categories, name)
+} // func (m *itemMeta) addCategory(name string)
+
+// enclosureDuration returns the duration of the enclosure with the given
+// URL, if known.
+func (m *itemMeta) enclosureDuration(u string) time.Duration {
+	if m == nil {
+		return 0
+	} else if d, ok := m.durations[u]; ok {
+		return d
+	}
+
+	return m.duration
+} // func (m *itemMeta) enclosureDuration(u string) time.Duration
+
+// rssAuthorPat matches the RSS 2.0 convention of giving an author as
+// "email (Name)".
+var rssAuthorPat = regexp.MustCompile(`^\s*(\S+@\S+)\s*\((.+)\)\s*$`)
+
+// parseRSSAuthor splits the content of an RSS <author> element into name
+// and URI.
+func parseRSSAuthor(s string) (name, uri string) {
+	if m := rssAuthorPat.FindStringSubmatch(s); m != nil {
+		return m[2], "mailto:" + m[1]
+	}
+
+	return s, ""
+} // func parseRSSAuthor(s string) (name, uri string)
+
+// findMeta extracts the metadata of a Feed's Items the RSS library does not
+// give us. The keys are the Item IDs as the RSS library computes them.
+func findMeta(contentType string, body []byte) map[string]*itemMeta {
+	if isJSONFeed(contentType, body) {
+		return findMetaJSON(body)
+	}
+
+	return findMetaXML(body)
+} // func findMeta(contentType string, body []byte) map[string]*itemMeta
+
+func findMetaJSON(body []byte) map[string]*itemMeta {
+	var (
+		jf   jsonFeed
+		meta = make(map[string]*itemMeta)
+	)
+
+	if err := json.Unmarshal(body, &jf); err != nil {
+		return meta
+	}
+
+	for _, item := range jf.Items {
+		var m = &itemMeta{durations: make(map[string]time.Duration)}
+
+		// JSON Feed 1.1 replaced author with authors.
+		for _, a := range item.Authors {
+			m.setAuthor(a.Name, a.URL)
+		}
+
+		if item.Author != nil {
+			m.setAuthor(item.Author.Name, item.Author.URL)
+		}
+
+		for _, t := range item.Tags {
+			m.addCategory(t)
+		}
+
+		for _, a := range item.Attachments {
+			if a.DurationInSeconds > 0 {
+				m.durations[a.URL] = time.Duration(a.DurationInSeconds * float64(time.Second))
+			}
+		}
+
+		meta[string(item.ID)] = m
+	}
+
+	return meta
+} // func findMetaJSON(body []byte) map[string]*itemMeta
+
+// findMetaXML looks for authors, categories and <itunes:duration> elements
+// in the Items of an RSS or Atom Feed.
+func findMetaXML(body []byte) map[string]*itemMeta {
+	var (
+		dec                 = xml.NewDecoder(bytes.NewReader(body))
+		meta                = make(map[string]*itemMeta)
+		stack               []xml.Name
+		text                strings.Builder
+		m                   *itemMeta
+		depth               int
+		guid, link, id      string
+		atomName, atomURI   string
+		creator, itunesAuth string
+	)
+
+	dec.Strict = false
+	dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+
+	for {
+		var tok, err = dec.Token()
+
+		if err != nil {
+			return meta
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name)
+			text.Reset()
+
+			if m == nil && (t.Name.Local == "item" || t.Name.Local == "entry") {
+				m = &itemMeta{}
+				depth = len(stack)
+				guid, link, id = "", "", ""
+				atomName, atomURI, creator, itunesAuth = "", "", "", ""
+			} else if m != nil && len(stack) == depth+1 && t.Name.Local == "category" {
+				// Atom puts the category in attributes.
+				for _, a := range t.Attr {
+					if a.Name.Local == "term" {
+						m.addCategory(a.Value)
+					}
+				}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			var (
+				level = len(stack) - depth
+				value = text.String()
+			)
+
+			text.Reset()
+
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+
+			if m == nil {
+				continue
+			} else if level == 0 {
+				// End of the Item
+				var key = guid
+
+				if t.Name.Local == "entry" {
+					key = id
+				} else if key == "" {
+					key = link
+				}
+
+				m.setAuthor(atomName, atomURI)
+				m.setAuthor(creator, "")
+				m.setAuthor(itunesAuth, "")
+
+				if key != "" {
+					meta[key] = m
+				}
+
+				m = nil
+				continue
+			}
+
+			switch {
+			case level == 1 && t.Name.Local == "guid":
+				guid = value
+			case level == 1 && t.Name.Local == "link":
+				link = value
+			case level == 1 && t.Name.Local == "id":
+				id = value
+			case level == 1 && t.Name.Local == "category":
+				m.addCategory(value)
+			case level == 1 && t.Name.Local == "subject" && isNS(t.Name, dcNS, "dc"):
+				m.addCategory(value)
+			case level == 1 && t.Name.Local == "creator" && isNS(t.Name, dcNS, "dc"):
+				if creator == "" {
+					creator = value
+				}
+			case level == 1 && t.Name.Local == "author" && isNS(t.Name, itunesNS, "itunes"):
+				itunesAuth = value
+			case level == 1 && t.Name.Local == "author":
+				// RSS 2.0 has the author as text, Atom has child
+				// elements, which we handle below.
+				if value = strings.TrimSpace(value); value != "" {
+					var name, uri = parseRSSAuthor(value)
+					m.setAuthor(name, uri)
+				}
+			case level == 2 && stack[len(stack)-1].Local == "author" && t.Name.Local == "name":
+				if atomName == "" {
+					atomName = value
+				}
+			case level == 2 && stack[len(stack)-1].Local == "author" && t.Name.Local == "uri":
+				if atomURI == "" {
+					atomURI = value
+				}
+			case level == 1 && t.Name.Local == "duration" && isNS(t.Name, itunesNS, "itunes"):
+				if d, err := ParseDuration(value); err == nil && d > 0 {
+					m.duration = d
+				}
+			}
+		}
+	}
+} // func findMetaXML(body []byte) map[string]*itemMeta
+
+// isNS returns true if the name is in the given namespace. Since we do not
+// parse strictly, an undeclared prefix ends up as the namespace.
+func isNS(n xml.Name, ns, prefix string) bool {
+	return n.Space == ns || n.Space == prefix
+} // func isNS(n xml.Name, ns, prefix string) bool
@@ -0,0 +1,108 @@
+// /home/krylon/go/src/ticker/feed/mute.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:38:51 krylon>
+
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Mute hides Items that contain a word, a phrase, or match a regular
+// expression, without deleting them. Words and phrases must appear as a
+// whole and ignore case. A Mute applies to all Feeds, unless FeedID or
+// GroupID restrict it to a single Feed or to the Feeds in a Group and its
+// subgroups. A Mute with a zero Expires never expires.
+type Mute struct {
+	ID      int64
+	Pattern string
+	Regex   bool
+	FeedID  int64
+	GroupID int64
+	Created time.Time
+	Expires time.Time
+	re      *regexp.Regexp
+}
+
+// The boundaries of words and phrases. Unlike \b, these work for letters
+// outside of ASCII, too.
+const (
+	wordStart = `(?:^|[^\pL\pN_])`
+	wordEnd   = `(?:$|[^\pL\pN_])`
+)
+
+// IsGlobal returns true if the Mute applies to all Feeds.
+func (m *Mute) IsGlobal() bool {
+	return m.FeedID == 0 && m.GroupID == 0
+} // func (m *Mute) IsGlobal() bool
+
+// IsExpired returns true if the Mute has an expiry date that lies in the
+// past.
+func (m *Mute) IsExpired() bool {
+	return !m.Expires.IsZero() && m.Expires.Before(time.Now())
+} // func (m *Mute) IsExpired() bool
+
+// Validate checks if the Mute has a pattern, a valid regular expression,
+// and at most one scope.
+func (m *Mute) Validate() error {
+	if strings.TrimSpace(m.Pattern) == "" {
+		return errors.New("Mute has no pattern")
+	} else if m.FeedID != 0 && m.GroupID != 0 {
+		return errors.New("Mute cannot apply to a Feed and a Group at once")
+	}
+
+	return m.compile()
+} // func (m *Mute) Validate() error
+
+func (m *Mute) compile() error {
+	var (
+		err error
+		src string
+	)
+
+	if m.Regex {
+		src = "(?i)" + m.Pattern
+	} else {
+		var words = strings.Fields(m.Pattern)
+
+		for i, w := range words {
+			words[i] = regexp.QuoteMeta(w)
+		}
+
+		src = "(?i)" + wordStart + strings.Join(words, `\s+`) + wordEnd
+	}
+
+	if m.re, err = regexp.Compile(src); err != nil {
+		return fmt.Errorf("Invalid pattern %q: %w",
+			m.Pattern,
+			err)
+	}
+
+	return nil
+} // func (m *Mute) compile() error
+
+// Match returns true if the Item's title or text contain the Mute's
+// pattern. It does not check if the Mute applies to the Item's Feed.
+func (m *Mute) Match(i *Item) bool {
+	if m.re == nil {
+		if err := m.compile(); err != nil {
+			return false
+		}
+	}
+
+	return m.re.MatchString(i.Plaintext())
+} // func (m *Mute) Match(i *Item) bool
+
+func (m *Mute) String() string {
+	if m.Regex {
+		return "/" + m.Pattern + "/"
+	}
+
+	return fmt.Sprintf("%q", m.Pattern)
+} // func (m *Mute) String() string
@@ -0,0 +1,314 @@
+// /home/krylon/go/src/ticker/feed/preview.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:50:31 krylon>
+
+package feed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/SlyMarbo/rss"
+	"github.com/blicero/ticker/common"
+	"github.com/jaytaylor/html2text"
+)
+
+// PreviewItemCnt is the number of Items a Preview shows.
+const PreviewItemCnt = 10
+
+// previewExcerptLength is the maximum length of the excerpts of the Items'
+// descriptions in a Preview.
+const previewExcerptLength = 300
+
+var xmlEncodingPat = regexp.MustCompile(`^\s*<\?xml[^>]*encoding\s*=\s*["']([^"']+)["']`)
+
+// Preview describes what we found when fetching a Feed once, without
+// subscribing to it. Interval is the refresh interval we would suggest,
+// based on the Items' timestamps, or 0 if there are too few of them.
+//
+// The descriptions of the Items are reduced to short excerpts of plain
+// text, so they can be displayed safely.
+type Preview struct {
+	URL         string
+	FinalURL    string
+	Title       string
+	Homepage    string
+	Description string
+	Format      string
+	ContentType string
+	Charset     string
+	ItemCnt     int
+	Oldest      time.Time
+	Newest      time.Time
+	Interval    time.Duration
+	Items       []Item
+	Warnings    []string
+}
+
+// FetchPreview fetches the Feed at addr and parses it, without storing
+// anything. Problems that do not keep us from reading the Feed are
+// reported as Warnings. If the Feed cannot be parsed, FetchPreview returns
+// the Preview along with the error, so the Warnings may shed some light on
+// what went wrong.
+func FetchPreview(addr string, a *Auth) (*Preview, error) {
+	var (
+		err         error
+		req         *http.Request
+		res         *http.Response
+		body        []byte
+		fd          *rss.Feed
+		ctx, cancel = context.WithTimeout(context.Background(), discoverTimeout)
+		p           = &Preview{URL: addr, FinalURL: addr}
+	)
+
+	defer cancel()
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, addr, nil); err != nil {
+		return nil, err
+	}
+
+	a.apply(req)
+
+	if res, err = common.HTTPClient().Do(req); err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected HTTP status fetching %s: %s",
+			addr,
+			res.Status)
+	} else if body, err = io.ReadAll(io.LimitReader(res.Body, discoverMaxSize+1)); err != nil {
+		return nil, err
+	}
+
+	if len(body) > discoverMaxSize {
+		body = body[:discoverMaxSize]
+		p.warn("The Feed is larger than %d MiB, we only looked at the beginning",
+			discoverMaxSize>>20)
+	}
+
+	if u := res.Request.URL.String(); u != addr {
+		p.FinalURL = u
+		p.warn("%s redirects to %s", addr, u)
+	}
+
+	p.ContentType = res.Header.Get("Content-Type")
+	p.Format = detectFormat(p.ContentType, body)
+	p.checkContentType()
+	p.checkCharset(body)
+
+	if fd, err = parse(p.ContentType, body); err != nil {
+		return p, err
+	}
+
+	p.fill(fd, findMeta(p.ContentType, body))
+
+	return p, nil
+} // func FetchPreview(addr string, a *Auth) (*Preview, error)
+
+func (p *Preview) warn(format string, args ...interface{}) {
+	p.Warnings = append(p.Warnings, fmt.Sprintf(format, args...))
+} // func (p *Preview) warn(format string, args ...interface{})
+
+// detectFormat tells what kind of Feed the body contains, going by the same
+// criteria the parser uses.
+func detectFormat(ctype string, body []byte) string {
+	if isJSONFeed(ctype, body) {
+		return "JSON Feed"
+	} else if bytes.Contains(body, []byte("<rss")) {
+		return "RSS 2.0"
+	} else if bytes.Contains(body, []byte(`xmlns="http://purl.org/rss/1.0/"`)) {
+		return "RSS 1.0"
+	} else if bytes.Contains(body, []byte("<feed")) {
+		return "Atom"
+	}
+
+	return "unknown"
+} // func detectFormat(ctype string, body []byte) string
+
+// checkContentType warns about Content-Types that do not fit a Feed.
+func (p *Preview) checkContentType() {
+	var mtype, _, err = mime.ParseMediaType(p.ContentType)
+
+	if p.ContentType == "" {
+		p.warn("The server did not send a Content-Type")
+	} else if err != nil {
+		p.warn("Cannot parse Content-Type %q: %s", p.ContentType, err.Error())
+	} else if isHTML(p.ContentType) {
+		p.warn("The server claims to send an HTML page (%s), not a Feed", mtype)
+	} else if !strings.Contains(mtype, "xml") && !strings.Contains(mtype, "json") {
+		p.warn("Unusual Content-Type for a Feed: %s", mtype)
+	}
+} // func (p *Preview) checkContentType()
+
+// checkCharset compares the charset announced in the Content-Type with the
+// one declared in the document and checks if the document is valid UTF-8,
+// if it claims to be. The parser only goes by the XML declaration, so if
+// the two disagree, the text of the Items may be garbled.
+func (p *Preview) checkCharset(body []byte) {
+	var header, decl string
+
+	if _, params, err := mime.ParseMediaType(p.ContentType); err == nil {
+		header = strings.ToLower(params["charset"])
+	}
+
+	if m := xmlEncodingPat.FindSubmatch(body); m != nil {
+		decl = strings.ToLower(string(m[1]))
+	}
+
+	switch {
+	case decl != "":
+		p.Charset = decl
+	case header != "":
+		p.Charset = header
+	default:
+		p.Charset = "utf-8"
+	}
+
+	if header != "" && decl != "" && header != decl {
+		p.warn("The server announces charset %s, but the document declares %s",
+			header,
+			decl)
+	} else if header != "" && decl == "" && !isUTF8(header) && p.Format != "JSON Feed" {
+		p.warn("The server announces charset %s, but the document does not declare it, so it will be read as UTF-8",
+			header)
+	}
+
+	if isUTF8(p.Charset) && !utf8.Valid(body) {
+		p.warn("The document is supposed to be UTF-8, but contains invalid characters")
+	}
+} // func (p *Preview) checkCharset(body []byte)
+
+func isUTF8(charset string) bool {
+	return charset == "utf-8" || charset == "utf8" || charset == "us-ascii"
+} // func isUTF8(charset string) bool
+
+// fill takes the Feed's metadata and Items from the parsed document.
+func (p *Preview) fill(fd *rss.Feed, meta map[string]*itemMeta) {
+	var (
+		f      = &Feed{URL: p.URL}
+		stamps = make([]time.Time, 0, len(fd.Items))
+		now    = time.Now()
+		guids  = make(map[string]bool, len(fd.Items))
+	)
+
+	p.Title = strings.TrimSpace(fd.Title)
+	p.Homepage = fd.Link
+	p.ItemCnt = len(fd.Items)
+
+	if d, err := html2text.FromString(fd.Description); err == nil {
+		p.Description = d
+	}
+
+	if p.Homepage == "" {
+		p.Homepage = p.URL
+		p.warn("The Feed does not link to a homepage")
+	}
+
+	if p.Title == "" {
+		p.warn("The Feed has no title")
+	}
+
+	if p.ItemCnt == 0 {
+		p.warn("The Feed contains no Items")
+		return
+	}
+
+	var undated, future, untitled, unlinked, dups int
+
+	for _, item := range fd.Items {
+		switch {
+		case item.Date.IsZero():
+			undated++
+		case item.Date.After(now):
+			future++
+		default:
+			stamps = append(stamps, item.Date)
+		}
+
+		if strings.TrimSpace(item.Title) == "" {
+			untitled++
+		}
+
+		if item.Link == "" {
+			unlinked++
+		}
+
+		if item.ID != "" {
+			if guids[item.ID] {
+				dups++
+			}
+			guids[item.ID] = true
+		}
+	}
+
+	for _, w := range []struct {
+		cnt int
+		msg string
+	}{
+		{undated, "%d of %d Items have no date, they get the time we first see them"},
+		{future, "%d of %d Items are dated in the future, they get the time we first see them"},
+		{untitled, "%d of %d Items have no title"},
+		{unlinked, "%d of %d Items have no link"},
+		{dups, "%d of %d Items share their ID with another Item, so they will overwrite each other"},
+	} {
+		if w.cnt > 0 {
+			p.warn(w.msg, w.cnt, p.ItemCnt)
+		}
+	}
+
+	for _, s := range stamps {
+		if p.Oldest.IsZero() || s.Before(p.Oldest) {
+			p.Oldest = s
+		}
+
+		if s.After(p.Newest) {
+			p.Newest = s
+		}
+	}
+
+	p.Interval = f.ComputeInterval(stamps)
+
+	var items = f.items(fd, meta)
+
+	if len(items) > PreviewItemCnt {
+		items = items[:PreviewItemCnt]
+	}
+
+	for idx := range items {
+		items[idx].Description = excerpt(items[idx].Description)
+	}
+
+	p.Items = items
+} // func (p *Preview) fill(fd *rss.Feed, meta map[string]*itemMeta)
+
+// excerpt turns an HTML snippet into plain text and shortens it to
+// previewExcerptLength characters.
+func excerpt(s string) string {
+	var text, err = html2text.FromString(s, html2text.Options{OmitLinks: true})
+
+	if err != nil {
+		text = s
+	}
+
+	text = collapseSpace(text)
+
+	if utf8.RuneCountInString(text) > previewExcerptLength {
+		var runes = []rune(text)
+		text = strings.TrimSpace(string(runes[:previewExcerptLength])) + "…"
+	}
+
+	return text
+} // func excerpt(s string) string
@@ -0,0 +1,84 @@
+// /home/krylon/go/src/ticker/feed/redirect.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:54:35 krylon>
+
+package feed
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Move records that a Feed used to live at URL until the given time.
+type Move struct {
+	URL   string
+	Moved time.Time
+}
+
+// permanentRedirect returns the URL a response says the Feed has moved to
+// for good, or an empty string if it has not moved. The HTTP client follows
+// redirects on its own, so we retrace its steps: Only the chain of 301 and
+// 308 responses at the start counts, once the server redirects us
+// temporarily, the URLs after that are not meant to be kept.
+func (f *Feed) permanentRedirect(res *http.Response) string {
+	var (
+		chain []*http.Request
+		moved string
+	)
+
+	for req := res.Request; req != nil; {
+		chain = append(chain, req)
+
+		if req.Response == nil {
+			break
+		}
+
+		req = req.Response.Request
+	}
+
+	// The chain runs backwards, from the last request to the first.
+	for idx := len(chain) - 2; idx >= 0; idx-- {
+		var status = chain[idx].Response.StatusCode
+
+		if status != http.StatusMovedPermanently &&
+			status != http.StatusPermanentRedirect {
+			break
+		}
+
+		moved = f.stripAuthParams(chain[idx].URL.String())
+	}
+
+	if moved == f.URL {
+		return ""
+	}
+
+	return moved
+} // func (f *Feed) permanentRedirect(res *http.Response) string
+
+// stripAuthParams removes the query parameters we add to requests for the
+// Feed's credentials from a URL, so they do not end up in the Feed's URL if
+// the server passes them on when redirecting us.
+func (f *Feed) stripAuthParams(addr string) string {
+	if f.Auth.IsEmpty() || len(f.Auth.Params) == 0 {
+		return addr
+	}
+
+	var u, err = url.Parse(addr)
+
+	if err != nil {
+		return addr
+	}
+
+	var q = u.Query()
+
+	for k := range f.Auth.Params {
+		q.Del(k)
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u.String()
+} // func (f *Feed) stripAuthParams(addr string) string
@@ -0,0 +1,50 @@
+// /home/krylon/go/src/ticker/feed/retention.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:05:37 krylon>
+
+package feed
+
+import (
+	"fmt"
+	"time"
+)
+
+// Day is the unit in which we express the age limits of Retention policies.
+const Day = time.Hour * 24
+
+// Retention is a policy for deleting old Items. A Retention with a FeedID
+// of 0 applies to all Feeds that have no policy of their own.
+//
+// Items that are tagged, rated, on the read-later list or archived are
+// never deleted.
+type Retention struct {
+	FeedID int64
+	MaxAge time.Duration
+	// ReadOnly restricts the policy to Items that have been read.
+	ReadOnly bool
+}
+
+// IsGlobal returns true if the Retention applies to all Feeds without a
+// policy of their own.
+func (r *Retention) IsGlobal() bool {
+	return r.FeedID == 0
+} // func (r *Retention) IsGlobal() bool
+
+// Days returns the maximum age of Items in days.
+func (r *Retention) Days() int64 {
+	return int64(r.MaxAge / Day)
+} // func (r *Retention) Days() int64
+
+func (r *Retention) String() string {
+	var kind = "untagged, unrated"
+
+	if r.ReadOnly {
+		kind = "read, " + kind
+	}
+
+	return fmt.Sprintf("Delete %s Items older than %d days",
+		kind,
+		r.Days())
+} // func (r *Retention) String() string
@@ -0,0 +1,20 @@
+// /home/krylon/go/src/ticker/feed/revision.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:51:30 krylon>
+
+package feed
+
+import "time"
+
+// Revision is a previous version of an Item that has been changed upstream.
+// Timestamp is the time the Revision was replaced by a newer one.
+type Revision struct {
+	ID          int64
+	ItemID      int64
+	URL         string
+	Title       string
+	Description string
+	Timestamp   time.Time
+}
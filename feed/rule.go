@@ -0,0 +1,216 @@
+// /home/krylon/go/src/ticker/feed/rule.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:34:35 krylon>
+
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/endeveit/guesslanguage"
+)
+
+// Rule describes an action to perform automatically on new Items that
+// match certain conditions. Conditions that are empty match any Item, the
+// regular expressions are matched case-insensitively. Language is a two
+// letter code as used by the Classifier, Domain matches the host of the
+// Item's link and its subdomains.
+//
+// If Drop is set, matching Items are not stored at all, so the other
+// actions make no sense.
+type Rule struct {
+	ID     int64
+	Name   string
+	Active bool
+	// Conditions
+	FeedID      int64
+	Title       string
+	Description string
+	Author      string
+	Language    string
+	Domain      string
+	// Actions
+	TagID     int64
+	Rate      bool
+	Rating    float64
+	MarkRead  bool
+	ReadLater bool
+	Archive   bool
+	Drop      bool
+	title     *regexp.Regexp
+	desc      *regexp.Regexp
+	author    *regexp.Regexp
+}
+
+// HasCondition returns true if the Rule has at least one condition.
+func (r *Rule) HasCondition() bool {
+	return r.FeedID != 0 ||
+		r.Title != "" ||
+		r.Description != "" ||
+		r.Author != "" ||
+		r.Language != "" ||
+		r.Domain != ""
+} // func (r *Rule) HasCondition() bool
+
+// HasAction returns true if the Rule does anything to the Items it matches.
+func (r *Rule) HasAction() bool {
+	return r.TagID != 0 ||
+		r.Rate ||
+		r.MarkRead ||
+		r.ReadLater ||
+		r.Archive ||
+		r.Drop
+} // func (r *Rule) HasAction() bool
+
+// Validate checks if the Rule is complete and its regular expressions are
+// valid. A Rule without conditions would apply to every single Item, so we
+// do not allow that.
+func (r *Rule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("Rule has no name")
+	} else if !r.HasCondition() {
+		return errors.New("Rule has no conditions")
+	} else if !r.HasAction() {
+		return errors.New("Rule has no actions")
+	} else if r.Rate && (r.Rating < 0 || r.Rating > 1) {
+		return fmt.Errorf("Rating must be between 0 and 1, not %f", r.Rating)
+	}
+
+	return r.compile()
+} // func (r *Rule) Validate() error
+
+func (r *Rule) compile() error {
+	var err error
+
+	for _, pat := range []struct {
+		name string
+		src  string
+		dst  **regexp.Regexp
+	}{
+		{"Title", r.Title, &r.title},
+		{"Description", r.Description, &r.desc},
+		{"Author", r.Author, &r.author},
+	} {
+		if pat.src == "" {
+			*pat.dst = nil
+		} else if *pat.dst, err = regexp.Compile("(?i)" + pat.src); err != nil {
+			return fmt.Errorf("Invalid %s pattern %q: %w",
+				pat.name,
+				pat.src,
+				err)
+		}
+	}
+
+	return nil
+} // func (r *Rule) compile() error
+
+// Match returns true if the Item meets all of the Rule's conditions.
+func (r *Rule) Match(i *Item) bool {
+	if (r.Title != "" && r.title == nil) ||
+		(r.Description != "" && r.desc == nil) ||
+		(r.Author != "" && r.author == nil) {
+		if err := r.compile(); err != nil {
+			return false
+		}
+	}
+
+	if r.FeedID != 0 && r.FeedID != i.FeedID {
+		return false
+	} else if r.title != nil && !r.title.MatchString(i.Title) {
+		return false
+	} else if r.desc != nil && !r.desc.MatchString(i.Body()) {
+		return false
+	} else if r.author != nil && !r.author.MatchString(i.Author) {
+		return false
+	} else if r.Domain != "" && !matchDomain(i.URL, r.Domain) {
+		return false
+	} else if r.Language != "" && !strings.EqualFold(r.Language, i.Language()) {
+		return false
+	}
+
+	return true
+} // func (r *Rule) Match(i *Item) bool
+
+// Actions returns a short description of what the Rule does.
+func (r *Rule) Actions() string {
+	var acts []string
+
+	if r.Drop {
+		return "drop"
+	}
+
+	if r.TagID != 0 {
+		acts = append(acts, "tag")
+	}
+
+	if r.Rate {
+		if r.Rating > 0 {
+			acts = append(acts, "rate interesting")
+		} else {
+			acts = append(acts, "rate boring")
+		}
+	}
+
+	if r.MarkRead {
+		acts = append(acts, "mark read")
+	}
+
+	if r.ReadLater {
+		acts = append(acts, "read later")
+	}
+
+	if r.Archive {
+		acts = append(acts, "archive")
+	}
+
+	return strings.Join(acts, ", ")
+} // func (r *Rule) Actions() string
+
+// Language guesses the language the Item is written in. If the guess fails,
+// it returns an empty string.
+func (i *Item) Language() (lang string) {
+	if i.lang != "" {
+		return i.lang
+	}
+
+	// guesslanguage has been known to panic on some input.
+	defer func() {
+		if x := recover(); x != nil {
+			lang = ""
+		}
+	}()
+
+	var err error
+
+	if lang, err = guesslanguage.Guess(i.Plaintext()); err != nil {
+		return ""
+	}
+
+	i.lang = lang
+	return lang
+} // func (i *Item) Language() string
+
+// matchDomain returns true if the host of the given link is domain or one
+// of its subdomains.
+func matchDomain(link, domain string) bool {
+	var (
+		err  error
+		u    *url.URL
+		host string
+	)
+
+	if u, err = url.Parse(link); err != nil {
+		return false
+	}
+
+	host = strings.ToLower(u.Hostname())
+	domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+
+	return host == domain || strings.HasSuffix(host, "."+domain)
+} // func matchDomain(link, domain string) bool
@@ -0,0 +1,278 @@
+// /home/krylon/go/src/ticker/feed/scrape.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:18:42 krylon>
+
+package feed
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SlyMarbo/rss"
+	"github.com/andybalholm/cascadia"
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Scraper describes how to extract Items from a web page that does not offer
+// a Feed. All fields are CSS selectors. Item selects the elements that make
+// up one Item each, the other selectors are applied within those elements.
+// Only Item is required: Without a Link selector, we use the first link in
+// the element, without a Title selector, the text of that link. Items
+// without a Date get the time we first saw them.
+type Scraper struct {
+	Item    string
+	Title   string `json:",omitempty"`
+	Link    string `json:",omitempty"`
+	Date    string `json:",omitempty"`
+	Summary string `json:",omitempty"`
+}
+
+// ErrNoItems indicates that a Scraper did not find any Items on a page.
+var ErrNoItems = errors.New("no elements on the page match the Item selector")
+
+// dateLayouts are the formats we try, in order, to parse the dates we find
+// on scraped pages.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"2. 1. 2006",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// scraper holds the compiled selectors of a Scraper.
+type scraper struct {
+	item    cascadia.Selector
+	title   cascadia.Selector
+	link    cascadia.Selector
+	date    cascadia.Selector
+	summary cascadia.Selector
+}
+
+// IsEmpty returns true if the Scraper has no Item selector.
+func (s *Scraper) IsEmpty() bool {
+	return s == nil || strings.TrimSpace(s.Item) == ""
+} // func (s *Scraper) IsEmpty() bool
+
+// Validate checks if all of the Scraper's selectors are valid.
+func (s *Scraper) Validate() error {
+	_, err := s.compile()
+	return err
+} // func (s *Scraper) Validate() error
+
+func (s *Scraper) compile() (*scraper, error) {
+	var (
+		err error
+		c   = new(scraper)
+	)
+
+	if s.IsEmpty() {
+		return nil, errors.New("Item selector is empty")
+	}
+
+	for _, sel := range []struct {
+		name string
+		src  string
+		dst  *cascadia.Selector
+	}{
+		{"Item", s.Item, &c.item},
+		{"Title", s.Title, &c.title},
+		{"Link", s.Link, &c.link},
+		{"Date", s.Date, &c.date},
+		{"Summary", s.Summary, &c.summary},
+	} {
+		if strings.TrimSpace(sel.src) == "" {
+			continue
+		} else if *sel.dst, err = cascadia.Compile(sel.src); err != nil {
+			return nil, fmt.Errorf("Invalid %s selector %q: %w",
+				sel.name,
+				sel.src,
+				err)
+		}
+	}
+
+	return c, nil
+} // func (s *Scraper) compile() (*scraper, error)
+
+// scrape extracts the Items from the page at pageURL, whose content is
+// body. The result looks like a parsed Feed, so scraped Items go through
+// the same processing as regular ones.
+func (s *Scraper) scrape(pageURL string, body []byte) (*rss.Feed, error) {
+	var (
+		err  error
+		c    *scraper
+		base *url.URL
+		doc  *html.Node
+		now  = time.Now()
+		fd   = &rss.Feed{Link: pageURL}
+	)
+
+	if c, err = s.compile(); err != nil {
+		return nil, err
+	} else if base, err = url.Parse(pageURL); err != nil {
+		return nil, err
+	} else if doc, err = html.Parse(bytes.NewReader(body)); err != nil {
+		return nil, err
+	}
+
+	if b := dom.QuerySelector(doc, "base[href]"); b != nil {
+		if u, err := base.Parse(dom.GetAttribute(b, "href")); err == nil {
+			base = u
+		}
+	}
+
+	if t := dom.QuerySelector(doc, "title"); t != nil {
+		fd.Title = collapseSpace(dom.TextContent(t))
+	}
+
+	var seen = make(map[string]bool)
+
+	for _, n := range c.item.MatchAll(doc) {
+		var (
+			a    *html.Node
+			href string
+			item = &rss.Item{Date: now}
+		)
+
+		if c.link != nil {
+			a = c.link.MatchFirst(n)
+		} else {
+			a = firstLink(n)
+		}
+
+		if a == nil {
+			continue
+		} else if href = dom.GetAttribute(a, "href"); href == "" {
+			// The Link selector may point at an element containing the
+			// actual link.
+			if a = firstLink(a); a == nil {
+				continue
+			}
+			href = dom.GetAttribute(a, "href")
+		}
+
+		var u *url.URL
+
+		if u, err = base.Parse(strings.TrimSpace(href)); err != nil {
+			continue
+		}
+
+		u.Fragment = ""
+		item.Link = u.String()
+
+		if seen[item.Link] {
+			continue
+		}
+
+		seen[item.Link] = true
+		item.ID = item.Link
+
+		if c.title != nil {
+			if t := c.title.MatchFirst(n); t != nil {
+				item.Title = collapseSpace(dom.TextContent(t))
+			}
+		}
+
+		if item.Title == "" {
+			item.Title = collapseSpace(dom.TextContent(a))
+		}
+
+		if c.summary != nil {
+			if sum := c.summary.MatchFirst(n); sum != nil {
+				item.Summary = strings.TrimSpace(dom.InnerHTML(sum))
+			}
+		}
+
+		if c.date != nil {
+			if d := c.date.MatchFirst(n); d != nil {
+				if stamp, ok := parseScrapedDate(d); ok {
+					item.Date = stamp
+				}
+			}
+		}
+
+		fd.Items = append(fd.Items, item)
+	}
+
+	if len(fd.Items) == 0 {
+		return nil, ErrNoItems
+	}
+
+	return fd, nil
+} // func (s *Scraper) scrape(pageURL string, body []byte) (*rss.Feed, error)
+
+// Preview fetches the page at pageURL and returns the Items the Scraper
+// finds on it, so the user can check the selectors before subscribing.
+func (s *Scraper) Preview(pageURL string, a *Auth) ([]Item, error) {
+	var (
+		err error
+		f   *Feed
+	)
+
+	if err = s.Validate(); err != nil {
+		return nil, err
+	} else if f, err = New(0, pageURL, pageURL, pageURL, time.Hour, true); err != nil {
+		return nil, err
+	}
+
+	f.Auth = a
+	f.Scraper = s
+
+	return f.Fetch()
+} // func (s *Scraper) Preview(pageURL string, a *Auth) ([]Item, error)
+
+// firstLink returns the first element with an href attribute at or below
+// n, or nil if there is none.
+func firstLink(n *html.Node) *html.Node {
+	if n.Type == html.ElementNode && dom.HasAttribute(n, "href") {
+		return n
+	}
+
+	return dom.QuerySelector(n, "[href]")
+} // func firstLink(n *html.Node) *html.Node
+
+// parseScrapedDate tries to get a timestamp from an element. The datetime
+// attribute of <time> elements is preferred over the element's text.
+func parseScrapedDate(n *html.Node) (time.Time, bool) {
+	var candidates = []string{
+		dom.GetAttribute(n, "datetime"),
+		dom.GetAttribute(n, "content"),
+		dom.GetAttribute(n, "title"),
+		collapseSpace(dom.TextContent(n)),
+	}
+
+	for _, s := range candidates {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+
+		for _, layout := range dateLayouts {
+			if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return t, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+} // func parseScrapedDate(n *html.Node) (time.Time, bool)
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+} // func collapseSpace(s string) string
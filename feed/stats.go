@@ -0,0 +1,124 @@
+// /home/krylon/go/src/ticker/feed/stats.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 03:05:09 krylon>
+
+package feed
+
+import "time"
+
+// Count is the number of Items in the period beginning at Start.
+type Count struct {
+	Start time.Time
+	Cnt   int64
+}
+
+// TagCount is the number of Items a Tag is attached to.
+type TagCount struct {
+	TagID int64
+	Name  string
+	Cnt   int64
+}
+
+// Stats summarizes the Items of a Feed, or of all Feeds if FeedID is 0,
+// and how reliably we have been able to fetch it.
+//
+// Interesting and Boring are the Items the user rated manually, TextLength
+// is the total length of the Items' content or description, in characters.
+// PerDay, PerWeek and Tags are only filled in when asking for the
+// statistics of a single Feed or all Feeds combined, not when asking for
+// an overview of all Feeds. FetchCnt and ErrorCnt only count the times we
+// fetched the Feed ourselves, Items a WebSub hub pushed to us do not count.
+type Stats struct {
+	FeedID      int64
+	ItemCnt     int64
+	UnreadCnt   int64
+	Interesting int64
+	Boring      int64
+	TextLength  int64
+	Oldest      time.Time
+	Newest      time.Time
+	FetchCnt    int64
+	ErrorCnt    int64
+	LastSuccess time.Time
+	PerDay      []Count
+	PerWeek     []Count
+	Tags        []TagCount
+}
+
+// Add adds the counters of other to s, so the statistics of several Feeds
+// can be combined.
+func (s *Stats) Add(other *Stats) {
+	s.ItemCnt += other.ItemCnt
+	s.UnreadCnt += other.UnreadCnt
+	s.Interesting += other.Interesting
+	s.Boring += other.Boring
+	s.TextLength += other.TextLength
+	s.FetchCnt += other.FetchCnt
+	s.ErrorCnt += other.ErrorCnt
+
+	if !other.Oldest.IsZero() && (s.Oldest.IsZero() || other.Oldest.Before(s.Oldest)) {
+		s.Oldest = other.Oldest
+	}
+
+	if other.Newest.After(s.Newest) {
+		s.Newest = other.Newest
+	}
+
+	if other.LastSuccess.After(s.LastSuccess) {
+		s.LastSuccess = other.LastSuccess
+	}
+} // func (s *Stats) Add(other *Stats)
+
+// AvgLength returns the average length of the Items.
+func (s *Stats) AvgLength() int64 {
+	if s.ItemCnt == 0 {
+		return 0
+	}
+
+	return s.TextLength / s.ItemCnt
+} // func (s *Stats) AvgLength() int64
+
+// RatedCnt returns the number of Items the user has rated.
+func (s *Stats) RatedCnt() int64 {
+	return s.Interesting + s.Boring
+} // func (s *Stats) RatedCnt() int64
+
+// InterestingShare returns the percentage of rated Items that were rated
+// as interesting.
+func (s *Stats) InterestingShare() float64 {
+	if s.RatedCnt() == 0 {
+		return 0
+	}
+
+	return float64(s.Interesting) * 100 / float64(s.RatedCnt())
+} // func (s *Stats) InterestingShare() float64
+
+// ErrorRate returns the percentage of attempts to fetch the Feed that
+// failed.
+func (s *Stats) ErrorRate() float64 {
+	if s.FetchCnt == 0 {
+		return 0
+	}
+
+	return float64(s.ErrorCnt) * 100 / float64(s.FetchCnt)
+} // func (s *Stats) ErrorRate() float64
+
+// ItemsPerWeek returns the average number of Items per week over the time
+// span the Items cover.
+func (s *Stats) ItemsPerWeek() float64 {
+	const week = 7 * 24 * time.Hour
+
+	if s.ItemCnt == 0 {
+		return 0
+	}
+
+	var span = s.Newest.Sub(s.Oldest)
+
+	if span < week {
+		return float64(s.ItemCnt)
+	}
+
+	return float64(s.ItemCnt) / (float64(span) / float64(week))
+} // func (s *Stats) ItemsPerWeek() float64
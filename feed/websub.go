@@ -0,0 +1,152 @@
+// /home/krylon/go/src/ticker/feed/websub.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:50:03 krylon>
+
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PushInterval is the refresh interval for Feeds that push new Items to us
+// via WebSub. We still poll them once in a while, in case the hub misses
+// something.
+var PushInterval = time.Hour * 12
+
+// IsPushed returns true if the Feed has an active WebSub subscription.
+func (f *Feed) IsPushed() bool {
+	return f.PushUntil.After(time.Now())
+} // func (f *Feed) IsPushed() bool
+
+// findHub looks for the WebSub hub and the topic URL a Feed announces,
+// either in the Link headers of the response or in the Feed itself. If the
+// Feed does not name its topic, the topic is empty, and the caller should
+// use the URL it fetched the Feed from.
+func findHub(header http.Header, contentType string, body []byte) (hub, topic string) {
+	for _, l := range header.Values("Link") {
+		for _, link := range strings.Split(l, ",") {
+			var href, rels = parseLinkHeader(link)
+
+			for _, rel := range rels {
+				if rel == "hub" && hub == "" {
+					hub = href
+				} else if rel == "self" && topic == "" {
+					topic = href
+				}
+			}
+		}
+	}
+
+	if hub != "" {
+		return hub, topic
+	} else if isJSONFeed(contentType, body) {
+		return findHubJSON(body)
+	}
+
+	return findHubXML(body)
+} // func findHub(header http.Header, contentType string, body []byte) (hub, topic string)
+
+// parseLinkHeader splits a single link of a Link header, such as
+// `<https://example.org/hub>; rel="hub"`, into the URL and its relations.
+func parseLinkHeader(link string) (string, []string) {
+	var (
+		href   string
+		rels   []string
+		pieces = strings.Split(link, ";")
+	)
+
+	href = strings.TrimSpace(pieces[0])
+
+	if !strings.HasPrefix(href, "<") || !strings.HasSuffix(href, ">") {
+		return "", nil
+	}
+
+	href = strings.Trim(href, "<>")
+
+	for _, p := range pieces[1:] {
+		var kv = strings.SplitN(strings.TrimSpace(p), "=", 2)
+
+		if len(kv) == 2 && strings.EqualFold(kv[0], "rel") {
+			rels = append(rels, strings.Fields(strings.ToLower(strings.Trim(kv[1], `"`)))...)
+		}
+	}
+
+	return href, rels
+} // func parseLinkHeader(link string) (string, []string)
+
+// findHubXML looks for <link rel="hub"> and <link rel="self"> elements at
+// the channel level of an RSS or Atom Feed.
+func findHubXML(body []byte) (hub, topic string) {
+	var dec = xml.NewDecoder(bytes.NewReader(body))
+
+	dec.Strict = false
+	dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+
+	for {
+		var tok, err = dec.Token()
+
+		if err != nil {
+			return hub, topic
+		}
+
+		var elt, ok = tok.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		switch elt.Name.Local {
+		case "item", "entry":
+			// Links inside Items belong to the Items, not the Feed.
+			return hub, topic
+		case "link":
+			var href, rel string
+
+			for _, a := range elt.Attr {
+				switch a.Name.Local {
+				case "href":
+					href = a.Value
+				case "rel":
+					rel = strings.ToLower(a.Value)
+				}
+			}
+
+			if rel == "hub" && hub == "" {
+				hub = href
+			} else if rel == "self" && topic == "" {
+				topic = href
+			}
+		}
+	}
+} // func findHubXML(body []byte) (hub, topic string)
+
+// findHubJSON looks for a WebSub hub in the hubs array of a JSON Feed.
+func findHubJSON(body []byte) (hub, topic string) {
+	var jf struct {
+		FeedURL string `json:"feed_url"`
+		Hubs    []struct {
+			Type string `json:"type"`
+			URL  string `json:"url"`
+		} `json:"hubs"`
+	}
+
+	if err := json.Unmarshal(body, &jf); err != nil {
+		return "", ""
+	}
+
+	for _, h := range jf.Hubs {
+		if strings.EqualFold(h.Type, "WebSub") && h.URL != "" {
+			return h.URL, jf.FeedURL
+		}
+	}
+
+	return "", ""
+} // func findHubJSON(body []byte) (hub, topic string)
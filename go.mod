@@ -4,6 +4,7 @@ go 1.18
 
 require (
 	github.com/SlyMarbo/rss v1.0.1
+	github.com/andybalholm/cascadia v1.2.0
 	github.com/anmitsu/go-shlex v0.0.0-20200514113438-38f4b401e2be
 	github.com/blicero/krylib v0.0.0-20230308180103-2ef208d8985d
 	github.com/blicero/shield v0.0.0-20221011200435-788bfada6e93
@@ -21,7 +22,6 @@ require (
 )
 
 require (
-	github.com/andybalholm/cascadia v1.2.0 // indirect
 	github.com/axgle/mahonia v0.0.0-20180208002826-3358181d7394 // indirect
 	github.com/garyburd/redigo v1.6.4 // indirect
 	github.com/golang/snappy v0.0.0-20180518054509-2e65f85255db // indirect
@@ -13,9 +13,14 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/blicero/ticker/canon"
 	"github.com/blicero/ticker/common"
+	"github.com/blicero/ticker/database"
+	"github.com/blicero/ticker/feed"
+	"github.com/blicero/ticker/opml"
 	"github.com/blicero/ticker/reader"
 	"github.com/blicero/ticker/web"
+	"github.com/blicero/ticker/websub"
 )
 
 func main() {
@@ -25,11 +30,16 @@ func main() {
 		common.BuildStamp)
 
 	var (
-		err     error
-		baseDir string
-		rdr     *reader.Reader
-		srv     *web.Server
-		msgq    = make(chan string, 5)
+		err        error
+		baseDir    string
+		importPath string
+		exportPath string
+		canonPath  string
+		rdr        *reader.Reader
+		srv        *web.Server
+		msgq       = make(chan string, 5)
+		httpCfg    = common.DefaultHTTPConfig
+		healthCfg  = common.DefaultHealthConfig
 	)
 
 	flag.StringVar(
@@ -39,6 +49,111 @@ func main() {
 		"The directory to store application-specific data in.",
 	)
 
+	flag.IntVar(
+		&reader.Workers,
+		"workers",
+		reader.Workers,
+		"The maximum number of Feeds to fetch concurrently.",
+	)
+
+	flag.IntVar(
+		&reader.PerHostLimit,
+		"perhost",
+		reader.PerHostLimit,
+		"The maximum number of concurrent requests to a single host.",
+	)
+
+	flag.StringVar(
+		&websub.CallbackBase,
+		"callback",
+		"",
+		"The public base URL WebSub hubs can reach us at, e.g. https://ticker.example.org. If empty, we do not subscribe to hubs.",
+	)
+
+	flag.DurationVar(
+		&httpCfg.Timeout,
+		"timeout",
+		httpCfg.Timeout,
+		"The maximum time a single HTTP request may take.",
+	)
+
+	flag.StringVar(
+		&httpCfg.UserAgent,
+		"useragent",
+		httpCfg.UserAgent,
+		"The User-Agent to send with HTTP requests.",
+	)
+
+	flag.StringVar(
+		&httpCfg.Proxy,
+		"proxy",
+		"",
+		"The URL of a HTTP or SOCKS5 proxy, e.g. socks5://localhost:1080. If empty, the proxy from the environment is used.",
+	)
+
+	flag.Int64Var(
+		&httpCfg.MaxResponseSize,
+		"maxsize",
+		httpCfg.MaxResponseSize,
+		"The maximum size of HTTP responses in bytes, 0 means no limit.",
+	)
+
+	flag.StringVar(
+		&httpCfg.TLSMinVersion,
+		"tlsmin",
+		httpCfg.TLSMinVersion,
+		"The oldest TLS version to accept (1.0, 1.1, 1.2 or 1.3).",
+	)
+
+	flag.BoolVar(
+		&httpCfg.TLSInsecure,
+		"insecure",
+		false,
+		"Do not verify TLS certificates. Use with care.",
+	)
+
+	flag.StringVar(
+		&httpCfg.TLSCAFile,
+		"cafile",
+		"",
+		"A PEM file with additional CA certificates to trust.",
+	)
+
+	flag.DurationVar(
+		&healthCfg.BackoffCeiling,
+		"backoffmax",
+		healthCfg.BackoffCeiling,
+		"The longest time to wait before trying again to refresh a Feed that keeps failing.",
+	)
+
+	flag.DurationVar(
+		&healthCfg.FailureLimit,
+		"failurelimit",
+		healthCfg.FailureLimit,
+		"How long a Feed may keep failing before it is deactivated.",
+	)
+
+	flag.StringVar(
+		&canonPath,
+		"canonrules",
+		"",
+		"A JSON file with the rules to canonicalize the links of Items. If empty, the built-in rules are used.",
+	)
+
+	flag.StringVar(
+		&importPath,
+		"import",
+		"",
+		"Import subscriptions from the given OPML file and exit.",
+	)
+
+	flag.StringVar(
+		&exportPath,
+		"export",
+		"",
+		"Export subscriptions to the given OPML file and exit.",
+	)
+
 	flag.Parse()
 
 	if baseDir != common.BaseDir {
@@ -54,6 +169,53 @@ func main() {
 			err.Error(),
 		)
 		os.Exit(1)
+	} else if err = common.ConfigureHTTP(httpCfg); err != nil {
+		fmt.Fprintf(
+			os.Stderr,
+			"Invalid HTTP configuration: %s\n",
+			err.Error(),
+		)
+		os.Exit(1)
+	} else if err = common.ConfigureHealth(healthCfg); err != nil {
+		fmt.Fprintf(
+			os.Stderr,
+			"Invalid Feed health configuration: %s\n",
+			err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	if canonPath != "" {
+		var rules canon.Rules
+
+		if rules, err = canon.Load(canonPath); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"Cannot load URL rules: %s\n",
+				err.Error(),
+			)
+			os.Exit(1)
+		} else if err = canon.Configure(rules); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"Invalid URL rules in %s: %s\n",
+				canonPath,
+				err.Error(),
+			)
+			os.Exit(1)
+		}
+	}
+
+	if importPath != "" || exportPath != "" {
+		if err = runOPML(importPath, exportPath); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"OPML import/export failed: %s\n",
+				err.Error())
+			os.Exit(1)
+		}
+
+		os.Exit(0)
 	}
 
 	if rdr, err = reader.New(msgq); err != nil {
@@ -70,6 +232,9 @@ func main() {
 		os.Exit(1)
 	}
 
+	srv.SetReader(rdr)
+	rdr.SetArchiveQueue(srv.ArchiveQueue())
+
 	go forwardMsg(msgq, srv)
 	go rdr.Loop()
 	go srv.ListenAndServe()
@@ -93,3 +258,74 @@ func forwardMsg(q <-chan string, srv *web.Server) {
 		srv.SendMessage(m)
 	}
 } // func forwardMsg(q <-chan string, srv *web.Server)
+
+// runOPML imports and/or exports the list of subscriptions.
+func runOPML(importPath, exportPath string) error {
+	var (
+		err error
+		db  *database.Database
+	)
+
+	if db, err = database.Open(common.DbPath); err != nil {
+		return err
+	}
+
+	defer db.Close() // nolint: errcheck
+
+	if importPath != "" {
+		var (
+			fh   *os.File
+			subs []opml.Subscription
+			rep  *opml.Report
+		)
+
+		if fh, err = os.Open(importPath); err != nil {
+			return err
+		}
+
+		defer fh.Close() // nolint: errcheck
+
+		if subs, err = opml.Parse(fh); err != nil {
+			return err
+		} else if rep, err = opml.Import(db, subs); err != nil {
+			return err
+		}
+
+		for _, f := range rep.Duplicates {
+			fmt.Printf("Duplicate: %s (%s)\n", f.Name, f.URL)
+		}
+
+		for _, x := range rep.Invalid {
+			fmt.Printf("Invalid:   %s (%s): %s\n", x.Feed.Name, x.Feed.URL, x.Reason)
+		}
+
+		fmt.Printf("Imported %s: %s\n", importPath, rep)
+	}
+
+	if exportPath != "" {
+		var (
+			out    *os.File
+			feeds  []feed.Feed
+			groups []feed.Group
+		)
+
+		if feeds, err = db.FeedGetAll(); err != nil {
+			return err
+		} else if groups, err = db.GroupGetAll(); err != nil {
+			return err
+		} else if out, err = os.Create(exportPath); err != nil {
+			return err
+		}
+
+		if err = opml.Export(out, feeds, groups); err != nil {
+			out.Close() // nolint: errcheck
+			return err
+		} else if err = out.Close(); err != nil {
+			return fmt.Errorf("Cannot close %s: %w", exportPath, err)
+		}
+
+		fmt.Printf("Exported %d Feeds to %s\n", len(feeds), exportPath)
+	}
+
+	return nil
+} // func runOPML(importPath, exportPath string) error
@@ -0,0 +1,203 @@
+// /home/krylon/go/src/ticker/opml/01_opml_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:14:30 krylon>
+
+package opml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/common"
+	"github.com/blicero/ticker/database"
+	"github.com/blicero/ticker/feed"
+)
+
+const testOPML = `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Test</title></head>
+  <body>
+    <outline text="News">
+      <outline text="Tagesschau" type="rss"
+               xmlUrl="http://www.tagesschau.de/xml/rss2"
+               htmlUrl="http://www.tagesschau.de/" />
+      <outline text="Tagesschau again" type="rss"
+               xmlUrl="http://www.tagesschau.de/xml/rss2" />
+    </outline>
+    <outline text="Slow Blog" type="rss"
+             xmlUrl="https://blog.example.com/feed"
+             interval="3600" active="false" />
+    <outline text="Broken" type="rss" xmlUrl="ftp://example.com/feed" />
+  </body>
+</opml>
+`
+
+func TestParse(t *testing.T) {
+	var (
+		err   error
+		feeds []Subscription
+	)
+
+	if feeds, err = Parse(strings.NewReader(testOPML)); err != nil {
+		t.Fatalf("Cannot parse OPML: %s", err.Error())
+	} else if len(feeds) != 4 {
+		t.Fatalf("Unexpected number of Feeds: %d (expected 4)", len(feeds))
+	} else if feeds[0].Interval != DefaultInterval {
+		t.Errorf("Unexpected default interval: %s", feeds[0].Interval)
+	} else if feeds[2].Interval != time.Hour || feeds[2].Active {
+		t.Errorf("Settings of Feed %s were not parsed: %s / %t",
+			feeds[2].Name,
+			feeds[2].Interval,
+			feeds[2].Active)
+	} else if len(feeds[0].Folders) != 1 || feeds[0].Folders[0] != "News" {
+		t.Errorf("Unexpected folders for Feed %s: %v",
+			feeds[0].Name,
+			feeds[0].Folders)
+	} else if len(feeds[2].Folders) != 0 {
+		t.Errorf("Feed %s should not be in any folder: %v",
+			feeds[2].Name,
+			feeds[2].Folders)
+	}
+} // func TestParse(t *testing.T)
+
+func TestImportExport(t *testing.T) {
+	var (
+		err    error
+		db     *database.Database
+		subs   []Subscription
+		feeds  []feed.Feed
+		groups []feed.Group
+		rep    *Report
+		buf    bytes.Buffer
+	)
+
+	if db, err = database.Open(common.DbPath); err != nil {
+		t.Fatalf("Cannot open database: %s", err.Error())
+	}
+
+	defer db.Close() // nolint: errcheck
+
+	if subs, err = Parse(strings.NewReader(testOPML)); err != nil {
+		t.Fatalf("Cannot parse OPML: %s", err.Error())
+	} else if rep, err = Import(db, subs); err != nil {
+		t.Fatalf("Cannot import Feeds: %s", err.Error())
+	} else if len(rep.Added) != 2 || len(rep.Duplicates) != 1 || len(rep.Invalid) != 1 || len(rep.Groups) != 1 {
+		t.Fatalf("Unexpected import result: %s", rep)
+	} else if rep.Added[0].GroupID != rep.Groups[0].ID {
+		t.Errorf("Feed %s was not put in Group %s",
+			rep.Added[0].Name,
+			rep.Groups[0].Name)
+	} else if rep, err = Import(db, subs); err != nil {
+		t.Fatalf("Cannot import Feeds a second time: %s", err.Error())
+	} else if len(rep.Added) != 0 || len(rep.Duplicates) != 3 {
+		t.Fatalf("Unexpected result of repeated import: %s", rep)
+	}
+
+	if feeds, err = db.FeedGetAll(); err != nil {
+		t.Fatalf("Cannot load Feeds: %s", err.Error())
+	} else if groups, err = db.GroupGetAll(); err != nil {
+		t.Fatalf("Cannot load Groups: %s", err.Error())
+	} else if err = Export(&buf, feeds, groups); err != nil {
+		t.Fatalf("Cannot export Feeds: %s", err.Error())
+	}
+
+	var exported []Subscription
+
+	if exported, err = Parse(&buf); err != nil {
+		t.Fatalf("Cannot parse exported OPML: %s", err.Error())
+	} else if len(exported) != len(feeds) {
+		t.Fatalf("Unexpected number of exported Feeds: %d (expected %d)",
+			len(exported),
+			len(feeds))
+	}
+
+	var byURL = make(map[string]Subscription, len(exported))
+
+	for _, e := range exported {
+		byURL[e.URL] = e
+	}
+
+	for _, f := range feeds {
+		var e = byURL[f.URL]
+
+		if e.Name != f.Name || e.URL != f.URL || e.Homepage != f.Homepage ||
+			e.Interval != f.Interval || e.Active != f.Active {
+			t.Errorf("Exported Feed does not match:\nExpected: %s\nGot:      %s",
+				&f,
+				&e.Feed)
+		}
+	}
+} // func TestImportExport(t *testing.T)
+
+const testNestedOPML = `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Test</title></head>
+  <body>
+    <outline text="Tech">
+      <outline text="Go">
+        <outline text="Go Blog" type="rss"
+                 xmlUrl="https://go.dev/blog/feed.atom" />
+      </outline>
+      <outline text="LWN" type="rss"
+               xmlUrl="https://lwn.net/headlines/rss" />
+    </outline>
+    <outline text="Empty" />
+  </body>
+</opml>
+`
+
+func TestImportExportFolders(t *testing.T) {
+	var (
+		err    error
+		db     *database.Database
+		subs   []Subscription
+		feeds  []feed.Feed
+		groups []feed.Group
+		rep    *Report
+		buf    bytes.Buffer
+	)
+
+	if db, err = database.Open(common.DbPath); err != nil {
+		t.Fatalf("Cannot open database: %s", err.Error())
+	}
+
+	defer db.Close() // nolint: errcheck
+
+	if subs, err = Parse(strings.NewReader(testNestedOPML)); err != nil {
+		t.Fatalf("Cannot parse OPML: %s", err.Error())
+	} else if rep, err = Import(db, subs); err != nil {
+		t.Fatalf("Cannot import Feeds: %s", err.Error())
+	} else if len(rep.Added) != 2 || len(rep.Groups) != 2 {
+		t.Fatalf("Unexpected import result: %s", rep)
+	} else if feeds, err = db.FeedGetAll(); err != nil {
+		t.Fatalf("Cannot load Feeds: %s", err.Error())
+	} else if groups, err = db.GroupGetAll(); err != nil {
+		t.Fatalf("Cannot load Groups: %s", err.Error())
+	} else if err = Export(&buf, feeds, groups); err != nil {
+		t.Fatalf("Cannot export Feeds: %s", err.Error())
+	} else if subs, err = Parse(&buf); err != nil {
+		t.Fatalf("Cannot parse exported OPML: %s", err.Error())
+	}
+
+	var expected = map[string]string{
+		"https://go.dev/blog/feed.atom": "Tech/Go",
+		"https://lwn.net/headlines/rss": "Tech",
+	}
+
+	for _, s := range subs {
+		var path, ok = expected[s.URL]
+
+		if !ok {
+			continue
+		} else if p := strings.Join(s.Folders, "/"); p != path {
+			t.Errorf("Feed %s was exported in folder %q (expected %q)",
+				s.Name,
+				p,
+				path)
+		}
+	}
+} // func TestImportExportFolders(t *testing.T)
@@ -0,0 +1,369 @@
+// /home/krylon/go/src/ticker/opml/opml.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:54:35 krylon>
+
+// Package opml implements import and export of subscriptions in the OPML 2.0
+// format.
+package opml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blicero/ticker/common"
+	"github.com/blicero/ticker/database"
+	"github.com/blicero/ticker/feed"
+)
+
+// DefaultInterval is the refresh interval for imported Feeds that do not
+// specify one.
+const DefaultInterval = time.Minute * 15
+
+// Document is the root element of an OPML file.
+type Document struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    Head     `xml:"head"`
+	Body    Body     `xml:"body"`
+}
+
+// Head contains the metadata of an OPML file.
+type Head struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// Body contains the outlines of an OPML file.
+type Body struct {
+	Outlines []Outline `xml:"outline"`
+}
+
+// Outline is either a subscription or a folder containing further Outlines.
+// Interval (in seconds) and Active are not part of the OPML spec, we use
+// them to carry over our own settings, other readers will ignore them.
+type Outline struct {
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr,omitempty"`
+	Type     string    `xml:"type,attr,omitempty"`
+	XMLURL   string    `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string    `xml:"htmlUrl,attr,omitempty"`
+	Interval string    `xml:"interval,attr,omitempty"`
+	Active   string    `xml:"active,attr,omitempty"`
+	Outlines []Outline `xml:"outline"`
+}
+
+// Feed converts the Outline to a Feed.
+func (o *Outline) Feed() feed.Feed {
+	var f = feed.Feed{
+		Name:     o.Title,
+		URL:      strings.TrimSpace(o.XMLURL),
+		Homepage: strings.TrimSpace(o.HTMLURL),
+		Interval: DefaultInterval,
+		Active:   true,
+	}
+
+	if f.Name == "" {
+		f.Name = o.Text
+	}
+
+	if f.Name == "" {
+		f.Name = f.URL
+	}
+
+	if f.Homepage == "" {
+		f.Homepage = f.URL
+	}
+
+	if secs, err := strconv.ParseInt(o.Interval, 10, 64); err == nil && secs > 0 {
+		f.Interval = time.Second * time.Duration(secs)
+	}
+
+	if active, err := strconv.ParseBool(o.Active); err == nil {
+		f.Active = active
+	}
+
+	return f
+} // func (o *Outline) Feed() feed.Feed
+
+// Subscription is a Feed found in an OPML document, along with the names of
+// the folders it was placed in, outermost first.
+type Subscription struct {
+	feed.Feed
+	Folders []string
+}
+
+// Export writes the given Feeds to w as an OPML document. Groups become
+// folders, Feeds that belong to a Group are placed in its folder.
+func Export(w io.Writer, feeds []feed.Feed, groups []feed.Group) error {
+	var (
+		err   error
+		known = make(map[int64]bool, len(groups))
+		doc   = Document{
+			Version: "2.0",
+			Head: Head{
+				Title:       fmt.Sprintf("%s subscriptions", common.AppName),
+				DateCreated: time.Now().Format(time.RFC1123Z),
+			},
+		}
+	)
+
+	for _, g := range groups {
+		known[g.ID] = true
+	}
+
+	doc.Body.Outlines = outlines(0, feeds, groups, known)
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	var enc = xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err = enc.Encode(&doc); err != nil {
+		return err
+	} else if _, err = io.WriteString(w, "\n"); err != nil {
+		return err
+	}
+
+	return nil
+} // func Export(w io.Writer, feeds []feed.Feed, groups []feed.Group) error
+
+// outlines returns the Outlines for the Groups and Feeds directly below the
+// Group with the given ID. Feeds in Groups we don't know about end up at
+// the top level.
+func outlines(parent int64, feeds []feed.Feed, groups []feed.Group, known map[int64]bool) []Outline {
+	var list []Outline
+
+	for _, g := range groups {
+		if g.Parent == parent {
+			list = append(list, Outline{
+				Text:     g.Name,
+				Title:    g.Name,
+				Outlines: outlines(g.ID, feeds, groups, known),
+			})
+		}
+	}
+
+	for _, f := range feeds {
+		if f.GroupID == parent || (parent == 0 && !known[f.GroupID]) {
+			list = append(list, Outline{
+				Text:     f.Name,
+				Title:    f.Name,
+				Type:     "rss",
+				XMLURL:   f.URL,
+				HTMLURL:  f.Homepage,
+				Interval: strconv.FormatInt(int64(f.Interval.Seconds()), 10),
+				Active:   strconv.FormatBool(f.Active),
+			})
+		}
+	}
+
+	return list
+} // func outlines(parent int64, feeds []feed.Feed, groups []feed.Group, known map[int64]bool) []Outline
+
+// Parse reads an OPML document and returns the subscriptions it contains.
+func Parse(r io.Reader) ([]Subscription, error) {
+	var (
+		err  error
+		doc  Document
+		subs []Subscription
+	)
+
+	if err = xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("Cannot parse OPML document: %w", err)
+	}
+
+	subs = collect(subs, nil, doc.Body.Outlines)
+
+	return subs, nil
+} // func Parse(r io.Reader) ([]Subscription, error)
+
+func collect(subs []Subscription, folders []string, outlines []Outline) []Subscription {
+	for _, o := range outlines {
+		if o.XMLURL != "" {
+			subs = append(subs, Subscription{
+				Feed:    o.Feed(),
+				Folders: folders,
+			})
+			subs = collect(subs, folders, o.Outlines)
+			continue
+		}
+
+		var name = o.Title
+
+		if name == "" {
+			name = o.Text
+		}
+
+		// Make a copy, so sibling folders don't share the backing array.
+		var sub = make([]string, len(folders), len(folders)+1)
+		copy(sub, folders)
+		subs = collect(subs, append(sub, name), o.Outlines)
+	}
+
+	return subs
+} // func collect(subs []Subscription, folders []string, outlines []Outline) []Subscription
+
+// Rejected is a Feed that could not be imported, along with the reason.
+type Rejected struct {
+	Feed   feed.Feed
+	Reason string
+}
+
+// Report summarizes the outcome of an import.
+type Report struct {
+	Added      []feed.Feed
+	Duplicates []feed.Feed
+	Invalid    []Rejected
+	Groups     []feed.Group
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf("%d Feeds added, %d duplicates skipped, %d invalid, %d Groups created",
+		len(r.Added),
+		len(r.Duplicates),
+		len(r.Invalid),
+		len(r.Groups))
+} // func (r *Report) String() string
+
+// validateURL checks if a Feed URL is something we can subscribe to.
+func validateURL(s string) error {
+	var (
+		err error
+		u   *url.URL
+	)
+
+	if s == "" {
+		return fmt.Errorf("URL is empty")
+	} else if u, err = url.Parse(s); err != nil {
+		return err
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("Unsupported URL scheme %q", u.Scheme)
+	} else if u.Host == "" {
+		return fmt.Errorf("URL %q has no host", s)
+	}
+
+	return nil
+} // func validateURL(s string) error
+
+// groupKey identifies a Group by its name and parent, the way folders in
+// an OPML document do.
+type groupKey struct {
+	parent int64
+	name   string
+}
+
+// Import adds the given Feeds to the database in a single transaction.
+// Feeds whose URL we are already subscribed to, or used to be subscribed to
+// before the Feed moved, or that appear more than once, are reported as
+// duplicates, Feeds with invalid URLs are reported as
+// invalid. New Feeds are put in the Groups matching their folders, Groups
+// that do not exist, yet, are created. If adding a Feed fails, the whole
+// import is rolled back.
+func Import(db *database.Database, subs []Subscription) (*Report, error) {
+	var (
+		err      error
+		existing []feed.Feed
+		groups   []feed.Group
+		seen     map[string]bool
+		groupIDs map[groupKey]int64
+		rep      = new(Report)
+	)
+
+	if existing, err = db.FeedGetAll(); err != nil {
+		return nil, err
+	} else if groups, err = db.GroupGetAll(); err != nil {
+		return nil, err
+	}
+
+	seen = make(map[string]bool, len(existing)+len(subs))
+	groupIDs = make(map[groupKey]int64, len(groups))
+
+	for _, f := range existing {
+		seen[f.URL] = true
+	}
+
+	for _, g := range groups {
+		groupIDs[groupKey{g.Parent, g.Name}] = g.ID
+	}
+
+	if err = db.Begin(); err != nil {
+		return nil, err
+	}
+
+	for _, s := range subs {
+		var (
+			f       = s.Feed
+			groupID int64
+			moved   *feed.Feed
+		)
+
+		if err = validateURL(f.URL); err != nil {
+			rep.Invalid = append(rep.Invalid, Rejected{Feed: f, Reason: err.Error()})
+			continue
+		} else if seen[f.URL] {
+			rep.Duplicates = append(rep.Duplicates, f)
+			continue
+		} else if moved, err = db.FeedGetByURL(f.URL); err != nil {
+			db.Rollback() // nolint: errcheck
+			return nil, err
+		} else if moved != nil {
+			rep.Duplicates = append(rep.Duplicates, f)
+			continue
+		}
+
+		for _, name := range s.Folders {
+			var (
+				ok  bool
+				key = groupKey{groupID, name}
+				g   *feed.Group
+			)
+
+			if groupID, ok = groupIDs[key]; ok {
+				continue
+			} else if g, err = db.GroupAdd(name, key.parent); err != nil {
+				db.Rollback() // nolint: errcheck
+				return nil, err
+			}
+
+			groupID = g.ID
+			groupIDs[key] = g.ID
+			rep.Groups = append(rep.Groups, *g)
+		}
+
+		if err = db.FeedAdd(&f); err != nil {
+			db.Rollback() // nolint: errcheck
+			return nil, err
+		} else if !f.Active {
+			if err = db.FeedSetActive(f.ID, false); err != nil {
+				db.Rollback() // nolint: errcheck
+				return nil, err
+			}
+		}
+
+		if groupID != 0 {
+			if err = db.FeedSetGroup(f.ID, groupID); err != nil {
+				db.Rollback() // nolint: errcheck
+				return nil, err
+			}
+			f.GroupID = groupID
+		}
+
+		seen[f.URL] = true
+		rep.Added = append(rep.Added, f)
+	}
+
+	if err = db.Commit(); err != nil {
+		return nil, err
+	}
+
+	return rep, nil
+} // func Import(db *database.Database, subs []Subscription) (*Report, error)
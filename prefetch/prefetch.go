@@ -65,6 +65,7 @@ type Prefetcher struct {
 	resQ    chan processedItem
 	cnt     int
 	running bool
+	client  *http.Client
 }
 
 // Create creates a new instance of the Prefetcher, prepared to use up to cnt
@@ -75,7 +76,10 @@ func Create(cnt int) (*Prefetcher, error) {
 		pre *Prefetcher
 	)
 
-	pre = &Prefetcher{cnt: cnt}
+	pre = &Prefetcher{
+		cnt:    cnt,
+		client: common.HTTPClient(),
+	}
 
 	if pre.log, err = common.GetLogger(logdomain.Prefetch); err != nil {
 		return nil, err
@@ -331,7 +335,7 @@ func (p *Prefetcher) fetchImage(href string) (string, error) {
 
 	if _, err = p.getImageSize(href); err != nil {
 		return "", err
-	} else if resp, err = http.Get(href); err != nil {
+	} else if resp, err = p.client.Get(href); err != nil {
 		p.log.Printf("[ERROR] Failed to fetch %q: %s\n",
 			href,
 			err.Error())
@@ -403,13 +407,14 @@ func (p *Prefetcher) getImageSize(href string) (int64, error) {
 		cnt    int64
 	)
 
-	if res, err = http.Head(href); err != nil {
+	if res, err = p.client.Head(href); err != nil {
 		p.log.Printf("[ERROR] Cannot get HTTP headers for %q: %s\n",
 			href,
 			err.Error())
 		return 0, err
 	}
 
+	res.Body.Close() // nolint: errcheck
 	lenStr = res.Header.Get("Content-Length")
 
 	if lenStr == "" {
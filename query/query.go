@@ -16,10 +16,42 @@ const (
 	FeedGetAll
 	FeedGetDue
 	FeedGetByID
+	FeedGetByURL
 	FeedSetActive
 	FeedSetTimestamp
+	FeedSetCacheInfo
+	FeedSetSuccess
+	FeedSetFailure
+	FeedSetAdaptive
+	FeedSetAdaptiveInterval
+	FeedSetAuth
+	FeedSetScraper
+	FeedSetFullText
+	FeedGetItemStamps
+	FeedGetUnread
+	FeedStatsSummary
+	FeedStatsPerDay
+	FeedStatsPerWeek
+	FeedStatsTags
 	FeedDelete
 	FeedModify
+	FeedURLHistoryAdd
+	FeedURLHistoryGet
+	FeedSetGroup
+	GroupAdd
+	GroupDelete
+	GroupRename
+	GroupSetParent
+	GroupGetAll
+	GroupGetUnread
+	GroupSetActive
+	GroupSetInterval
+	WebSubAdd
+	WebSubGetByFeed
+	WebSubGetExpiring
+	WebSubSetState
+	WebSubSetRequested
+	WebSubDelete
 	ItemAdd
 	ItemInsertFTS
 	ItemGetRecent
@@ -27,18 +59,61 @@ const (
 	ItemGetByID
 	ItemGetByURL
 	ItemGetByFeed
+	ItemGetByFeedUnread
 	ItemGetAll
 	ItemGetFTS
 	ItemGetSearchExtended
 	ItemGetContent
 	ItemGetByTag
+	ItemGetByTagUnread
 	ItemGetByTagRecursive
+	ItemGetByTagRecursiveUnread
+	ItemGetByAuthor
+	ItemGetByCategory
+	ItemGetByGroup
+	ItemGetByGroupUnread
 	ItemGetPrefetch
+	ItemGetContentPending
 	ItemGetTotalCnt
 	ItemRatingSet
 	ItemRatingClear
 	ItemHasDuplicate
+	ItemLookupGUID
+	ItemHashSet
+	ItemUpdate
+	ItemGetFingerprints
+	ItemDupGroupSet
+	ItemGetDuplicateFeeds
+	ItemGetDuplicateFeedsByGroups
+	ItemGetExpired
+	ItemDelete
+	ItemCacheRefs
 	ItemPrefetchSet
+	ItemContentSet
+	ItemMarkRead
+	ItemMarkUnread
+	ItemMarkReadBatch
+	ItemMarkReadByFeed
+	ItemMarkReadByTag
+	ItemGetRecentUnread
+	ItemGetAllUnread
+	ItemGetUnreadCnt
+	ItemGetUnreadCntByFeed
+	ItemGetUnreadCntByTag
+	ItemGetUnreadCntByTagRecursive
+	RevisionAdd
+	RevisionGetByItem
+	EnclosureAdd
+	EnclosureGetByItem
+	EnclosureGetByID
+	CategoryAdd
+	CategoryGetByItem
+	CategoryGetByFeed
+	CategoryTagAdd
+	CategoryTagApply
+	CategoryTagApplyFeed
+	CategoryTagDelete
+	CategoryTagGetByFeed
 	FTSClear
 	TagCreate
 	TagDelete
@@ -68,4 +143,17 @@ const (
 	ReadLaterDeleteRead
 	ReadLaterSetDeadine
 	ReadLaterSetNote
+	RetentionSet
+	RetentionDelete
+	RetentionGetAll
+	RuleAdd
+	RuleUpdate
+	RuleDelete
+	RuleSetActive
+	RuleGetAll
+	RuleGetActive
+	MuteAdd
+	MuteDelete
+	MuteGetAll
+	MuteGetActive
 )
@@ -0,0 +1,122 @@
+// /home/krylon/go/src/ticker/reader/03_reader_hostlimit_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:58:39 krylon>
+
+package reader
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostQueue(t *testing.T) {
+	const (
+		limit   = 2
+		workers = 8
+		jobCnt  = 16
+	)
+
+	var (
+		wg    sync.WaitGroup
+		cur   int32
+		peak  int32
+		cnt   int32
+		hosts = make([]string, jobCnt)
+	)
+
+	for i := range hosts {
+		hosts[i] = "www.example.com"
+	}
+
+	var jobQ = newHostQueue(limit, hosts)
+
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for idx := jobQ.next(); idx >= 0; idx = jobQ.next() {
+				var n = atomic.AddInt32(&cur, 1)
+				for {
+					var p = atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond * 10)
+				atomic.AddInt32(&cur, -1)
+				atomic.AddInt32(&cnt, 1)
+				jobQ.done(idx)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if peak > limit {
+		t.Errorf("Too many concurrent requests to one host: %d (limit %d)",
+			peak,
+			limit)
+	} else if cnt != jobCnt {
+		t.Errorf("Unexpected number of jobs done: %d (expected %d)",
+			cnt,
+			jobCnt)
+	} else if len(jobQ.busy) != 0 {
+		t.Errorf("Queue still has %d busy hosts after all jobs finished",
+			len(jobQ.busy))
+	}
+} // func TestHostQueue(t *testing.T)
+
+// TestHostQueueIdleHost checks that a job for an idle host is handed out
+// while all slots for a busy host are taken, even if all workers are
+// available and the jobs for the busy host come first.
+func TestHostQueueIdleHost(t *testing.T) {
+	const (
+		workers  = 4
+		busyHost = "busy.example.com"
+		idleHost = "idle.example.com"
+	)
+
+	var (
+		wg      sync.WaitGroup
+		hosts   = []string{busyHost, busyHost, busyHost, busyHost, idleHost}
+		jobQ    = newHostQueue(1, hosts)
+		release = make(chan struct{})
+		idleQ   = make(chan struct{}, 1)
+	)
+
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for idx := jobQ.next(); idx >= 0; idx = jobQ.next() {
+				// The jobs for the busy host do not finish before
+				// the one for the idle host has been handed out.
+				if hosts[idx] == idleHost {
+					idleQ <- struct{}{}
+				} else {
+					<-release
+				}
+				jobQ.done(idx)
+			}
+		}()
+	}
+
+	select {
+	case <-idleQ:
+	case <-time.After(time.Second * 5):
+		t.Errorf("Job for %s was not handed out while %s was busy",
+			idleHost,
+			busyHost)
+	}
+
+	close(release)
+	wg.Wait()
+} // func TestHostQueueIdleHost(t *testing.T)
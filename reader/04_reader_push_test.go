@@ -0,0 +1,158 @@
+// /home/krylon/go/src/ticker/reader/04_reader_push_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 03:05:09 krylon>
+
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestReaderPush(t *testing.T) {
+	if rdr == nil {
+		t.Log("Reader has not been initialized. Bail.\n")
+		t.SkipNow()
+	}
+
+	var (
+		err   error
+		items []feed.Item
+		db    = rdr.pool.Get()
+		f     = &feed.Feed{
+			Name:     "Pushed Feed",
+			URL:      "http://push.example.com/feed.xml",
+			Homepage: "http://push.example.com/",
+			Interval: time.Minute * 30,
+			Active:   true,
+		}
+	)
+
+	defer rdr.pool.Put(db)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	}
+
+	for i := 0; i < pushQueueSize; i++ {
+		// Every Item is pushed twice, so we can check duplicates are
+		// skipped.
+		var item = feed.Item{
+			FeedID:      f.ID,
+			URL:         fmt.Sprintf("http://push.example.com/item/%d", i/2),
+			Title:       fmt.Sprintf("Item %d", i/2),
+			Description: "Pushed to us",
+			Timestamp:   time.Now().Add(time.Minute * time.Duration(-i)),
+		}
+
+		if err = rdr.Push(*f, []feed.Item{item}); err != nil {
+			t.Fatalf("Cannot push Item %d: %s", i, err.Error())
+		}
+	}
+
+	if err = rdr.Push(*f, nil); !errors.Is(err, ErrPushQueueFull) {
+		t.Errorf("Push to full queue returned unexpected error: %v", err)
+	}
+
+	for i := 0; i < pushQueueSize; i++ {
+		var res = <-rdr.pushQ
+
+		if err = rdr.storePushed(&res); err != nil {
+			t.Fatalf("Cannot store pushed Items: %s", err.Error())
+		}
+	}
+
+	if items, err = db.ItemGetByFeed(f.ID, -1); err != nil {
+		t.Fatalf("Cannot get Items for Feed %s: %s", f.Name, err.Error())
+	} else if len(items) != pushQueueSize/2 {
+		t.Errorf("Unexpected number of Items stored: %d (expected %d)",
+			len(items),
+			pushQueueSize/2)
+	}
+} // func TestReaderPush(t *testing.T)
+
+// TestReaderPushStats checks that storing pushed Items does not count as a
+// fetch of their Feed.
+func TestReaderPushStats(t *testing.T) {
+	if rdr == nil {
+		t.Log("Reader has not been initialized. Bail.\n")
+		t.SkipNow()
+	}
+
+	var (
+		err           error
+		before, after *feed.Stats
+		stored        *feed.Feed
+		items         []feed.Item
+		db            = rdr.pool.Get()
+		f             = &feed.Feed{
+			Name:     "Pushed Stats Feed",
+			URL:      "http://pushstats.example.com/feed.xml",
+			Homepage: "http://pushstats.example.com/",
+			Interval: time.Minute * 30,
+			Active:   true,
+		}
+		res = fetchResult{
+			pushed: true,
+			items: []feed.Item{
+				{
+					URL:         "http://pushstats.example.com/item/1",
+					Title:       "Pushed Item",
+					Description: "Pushed to us",
+					Timestamp:   time.Now(),
+				},
+			},
+		}
+	)
+
+	defer rdr.pool.Put(db)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	} else if before, err = db.FeedGetStats(f.ID, 0, 0, 0); err != nil {
+		t.Fatalf("Cannot get statistics for Feed %s: %s", f.Name, err.Error())
+	}
+
+	res.f = *f
+	res.items[0].FeedID = f.ID
+
+	if err = rdr.storePushed(&res); err != nil {
+		t.Fatalf("Cannot store pushed Items: %s", err.Error())
+	} else if items, err = db.ItemGetByFeed(f.ID, -1); err != nil {
+		t.Fatalf("Cannot get Items for Feed %s: %s", f.Name, err.Error())
+	} else if len(items) != 1 {
+		t.Errorf("Unexpected number of Items stored: %d (expected 1)",
+			len(items))
+	}
+
+	if after, err = db.FeedGetStats(f.ID, 0, 0, 0); err != nil {
+		t.Fatalf("Cannot get statistics for Feed %s: %s", f.Name, err.Error())
+	} else if after.FetchCnt != before.FetchCnt || after.ErrorCnt != before.ErrorCnt {
+		t.Errorf("Push changed the fetch counters of Feed %s: %d/%d (expected %d/%d)",
+			f.Name,
+			after.FetchCnt,
+			after.ErrorCnt,
+			before.FetchCnt,
+			before.ErrorCnt)
+	} else if !after.LastSuccess.Equal(before.LastSuccess) {
+		t.Errorf("Push changed the last successful fetch of Feed %s: %s (expected %s)",
+			f.Name,
+			after.LastSuccess,
+			before.LastSuccess)
+	}
+
+	if stored, err = db.FeedGetByID(f.ID); err != nil {
+		t.Fatalf("Cannot get Feed %s by ID (%d): %s", f.Name, f.ID, err.Error())
+	} else if !stored.LastUpdate.Equal(f.LastUpdate) {
+		t.Errorf("Push changed the refresh timestamp of Feed %s: %s (expected %s)",
+			f.Name,
+			stored.LastUpdate,
+			f.LastUpdate)
+	}
+} // func TestReaderPushStats(t *testing.T)
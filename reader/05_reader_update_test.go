@@ -0,0 +1,90 @@
+// /home/krylon/go/src/ticker/reader/05_reader_update_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 01:01:02 krylon>
+
+package reader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestReaderUpdate(t *testing.T) {
+	if rdr == nil {
+		t.Log("Reader has not been initialized. Bail.\n")
+		t.SkipNow()
+	}
+
+	var (
+		err   error
+		items []feed.Item
+		revs  []feed.Revision
+		db    = rdr.pool.Get()
+		f     = &feed.Feed{
+			Name:     "Edited Feed",
+			URL:      "http://edit.example.com/feed.xml",
+			Homepage: "http://edit.example.com/",
+			Interval: time.Minute * 30,
+			Active:   true,
+		}
+		orig = feed.Item{
+			URL:         "https://edit.example.com/2026/10/first-draft",
+			GUID:        "urn:ticker:edit:1",
+			Title:       "Frist post",
+			Description: "This is the first version.",
+			Timestamp:   time.Now().Add(-time.Hour),
+		}
+	)
+
+	defer rdr.pool.Put(db)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	}
+
+	orig.FeedID = f.ID
+
+	// The author fixes a typo, which also changes the link, and then the
+	// Feed is fetched once more without any changes.
+	var edit = orig
+	edit.URL = "https://edit.example.com/2026/10/first-post"
+	edit.Title = "First post"
+
+	for _, i := range []feed.Item{orig, edit, edit} {
+		var res = fetchResult{f: *f, items: []feed.Item{i}}
+
+		if err = rdr.store(db, &res); err != nil {
+			t.Fatalf("Cannot store Item %q: %s", i.Title, err.Error())
+		}
+	}
+
+	if items, err = db.ItemGetByFeed(f.ID, -1); err != nil {
+		t.Fatalf("Cannot get Items for Feed %s: %s", f.Name, err.Error())
+	} else if len(items) != 1 {
+		t.Fatalf("Unexpected number of Items stored: %d (expected 1)",
+			len(items))
+	} else if items[0].Title != edit.Title || items[0].URL != edit.URL {
+		t.Errorf("Item was not updated: %q (%s)",
+			items[0].Title,
+			items[0].URL)
+	} else if !items[0].IsUpdated() {
+		t.Error("Item is not marked as updated")
+	}
+
+	if revs, err = db.RevisionGetByItem(items[0].ID); err != nil {
+		t.Fatalf("Cannot load Revisions of Item %d: %s",
+			items[0].ID,
+			err.Error())
+	} else if len(revs) != 1 {
+		t.Fatalf("Unexpected number of Revisions: %d (expected 1)",
+			len(revs))
+	} else if revs[0].Title != orig.Title || revs[0].URL != orig.URL {
+		t.Errorf("Revision does not hold the original version: %q (%s)",
+			revs[0].Title,
+			revs[0].URL)
+	}
+} // func TestReaderUpdate(t *testing.T)
@@ -0,0 +1,78 @@
+// /home/krylon/go/src/ticker/reader/06_reader_canon_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:38:21 krylon>
+
+package reader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+func TestReaderCanonicalURL(t *testing.T) {
+	if rdr == nil {
+		t.Log("Reader has not been initialized. Bail.\n")
+		t.SkipNow()
+	}
+
+	const canonical = "http://track.example.com/2026/10/story"
+
+	var (
+		err   error
+		items []feed.Item
+		db    = rdr.pool.Get()
+		f     = &feed.Feed{
+			Name:     "Tracking Feed",
+			URL:      "http://track.example.com/feed.xml",
+			Homepage: "http://track.example.com/",
+			Interval: time.Minute * 30,
+			Active:   true,
+		}
+		tracked = feed.Item{
+			URL:         "http://track.example.com/2026/10/story?utm_source=rss&utm_medium=feed",
+			Title:       "A tracked story",
+			Description: "The link of this Item carries tracking parameters.",
+			Timestamp:   time.Now(),
+		}
+	)
+
+	defer rdr.pool.Put(db)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	}
+
+	tracked.FeedID = f.ID
+
+	// The second time around, the Feed gives us a different variant of
+	// the same link, which must not lead to a second Item.
+	var variant = tracked
+	variant.URL = "http://TRACK.example.com:80/2026/10/story?fbclid=xyz&utm_campaign=fall"
+
+	for _, i := range []feed.Item{tracked, variant} {
+		var res = fetchResult{f: *f, items: []feed.Item{i}}
+
+		if err = rdr.store(db, &res); err != nil {
+			t.Fatalf("Cannot store Item %q: %s", i.Title, err.Error())
+		}
+	}
+
+	if items, err = db.ItemGetByFeed(f.ID, -1); err != nil {
+		t.Fatalf("Cannot get Items for Feed %s: %s", f.Name, err.Error())
+	} else if len(items) != 1 {
+		t.Fatalf("Unexpected number of Items stored: %d (expected 1)",
+			len(items))
+	} else if items[0].URL != canonical {
+		t.Errorf("Unexpected URL: %s (expected %s)",
+			items[0].URL,
+			canonical)
+	} else if items[0].OriginalURL != tracked.URL {
+		t.Errorf("Unexpected original URL: %s (expected %s)",
+			items[0].OriginalURL,
+			tracked.URL)
+	}
+} // func TestReaderCanonicalURL(t *testing.T)
@@ -0,0 +1,187 @@
+// /home/krylon/go/src/ticker/reader/07_reader_rules_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:44:07 krylon>
+
+package reader
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+	"github.com/blicero/ticker/tag"
+)
+
+func TestReaderRules(t *testing.T) {
+	if rdr == nil {
+		t.Log("Reader has not been initialized. Bail.\n")
+		t.SkipNow()
+	}
+
+	var (
+		err   error
+		tg    *tag.Tag
+		items []feed.Item
+		db    = rdr.pool.Get()
+		f     = &feed.Feed{
+			Name:     "Rules Feed",
+			URL:      "http://rules.example.com/feed.xml",
+			Homepage: "http://rules.example.com/",
+			Interval: time.Minute * 30,
+			Active:   true,
+		}
+		res = fetchResult{
+			items: []feed.Item{
+				{
+					URL:         "http://rules.example.com/2026/10/release",
+					Title:       "Release notes for version 2.0",
+					Description: "Lots of new features.",
+					Timestamp:   time.Now(),
+				},
+				{
+					URL:         "http://rules.example.com/2026/10/sponsored",
+					Title:       "Sponsored: Buy our stuff",
+					Description: "Advertising.",
+					Timestamp:   time.Now(),
+				},
+				{
+					URL:         "http://rules.example.com/2026/10/other",
+					Title:       "Something else entirely",
+					Description: "Nothing to see here.",
+					Timestamp:   time.Now(),
+				},
+			},
+		}
+	)
+
+	defer rdr.pool.Put(db)
+
+	if err = db.FeedAdd(f); err != nil {
+		t.Fatalf("Cannot add Feed %s: %s", f.Name, err.Error())
+	} else if tg, err = db.TagCreate("release", "Release announcements", 0); err != nil {
+		t.Fatalf("Cannot create Tag: %s", err.Error())
+	}
+
+	var rules = []feed.Rule{
+		{
+			Name:     "Releases",
+			Active:   true,
+			FeedID:   f.ID,
+			Title:    "release",
+			TagID:    tg.ID,
+			MarkRead: true,
+		},
+		{
+			Name:   "Ads",
+			Active: true,
+			Title:  "^sponsored:",
+			Drop:   true,
+		},
+	}
+
+	for idx := range rules {
+		if err = db.RuleAdd(&rules[idx]); err != nil {
+			t.Fatalf("Cannot add Rule %s: %s", rules[idx].Name, err.Error())
+		}
+	}
+
+	defer func() {
+		for _, r := range rules {
+			db.RuleDelete(r.ID) // nolint: errcheck
+		}
+	}()
+
+	res.f = *f
+
+	for idx := range res.items {
+		res.items[idx].FeedID = f.ID
+	}
+
+	if err = rdr.store(db, &res); err != nil {
+		t.Fatalf("Cannot store Items: %s", err.Error())
+	} else if items, err = db.ItemGetByFeed(f.ID, -1); err != nil {
+		t.Fatalf("Cannot get Items for Feed %s: %s", f.Name, err.Error())
+	} else if len(items) != 2 {
+		t.Fatalf("Unexpected number of Items stored: %d (expected 2)",
+			len(items))
+	}
+
+	for _, i := range items {
+		var tags []int64
+
+		if tags, err = db.TagLinkGetByItem(i.ID); err != nil {
+			t.Fatalf("Cannot get Tags of Item %q: %s", i.Title, err.Error())
+		}
+
+		switch i.Title {
+		case res.items[0].Title:
+			if !i.Read {
+				t.Errorf("Item %q was not marked as read", i.Title)
+			}
+			if len(tags) != 1 || tags[0] != tg.ID {
+				t.Errorf("Item %q was not tagged: %v", i.Title, tags)
+			}
+		case res.items[2].Title:
+			if i.Read || len(tags) != 0 {
+				t.Errorf("No Rule should have matched Item %q", i.Title)
+			}
+		default:
+			t.Errorf("Unexpected Item %q was stored", i.Title)
+		}
+	}
+} // func TestReaderRules(t *testing.T)
+
+// When the download Agent does not keep up, Items that Rules want archived
+// pile up in the Reader's queue until it is full, the rest are dropped.
+func TestReaderArchiveQueue(t *testing.T) {
+	var (
+		err   error
+		r     *Reader
+		agent = make(chan *feed.Item)
+		cnt   = archiveQueueSize + archiveWorkers + 10
+	)
+
+	if r, err = New(nil); err != nil {
+		t.Fatalf("Cannot create Reader: %s", err.Error())
+	}
+
+	r.SetArchiveQueue(agent)
+
+	for i := 0; i < cnt; i++ {
+		r.archive(&feed.Item{ID: int64(i + 1), Title: fmt.Sprintf("Item #%d", i+1)})
+
+		// Let the workers pick up the first Items, so they are
+		// stuck waiting for the Agent before the queue fills up.
+		for i < archiveWorkers && len(r.archiveQ) > 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	if len(r.archiveQ) != archiveQueueSize {
+		t.Errorf("Archive queue holds %d Items (expected %d)",
+			len(r.archiveQ),
+			archiveQueueSize)
+	}
+
+	var received int
+
+	for received < archiveQueueSize+archiveWorkers {
+		select {
+		case <-agent:
+			received++
+		case <-time.After(time.Second):
+			t.Fatalf("Agent received only %d Items (expected %d)",
+				received,
+				archiveQueueSize+archiveWorkers)
+		}
+	}
+
+	select {
+	case item := <-agent:
+		t.Errorf("Item %q should have been dropped", item.Title)
+	case <-time.After(time.Millisecond * 100):
+	}
+} // func TestReaderArchiveQueue(t *testing.T)
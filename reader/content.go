@@ -0,0 +1,149 @@
+// /home/krylon/go/src/ticker/reader/content.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:58:39 krylon>
+
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/blicero/ticker/feed"
+)
+
+// contentDelay is how often the Reader looks for Items whose full content
+// it has to fetch.
+const contentDelay = time.Minute
+
+// contentBatchSize is the maximum number of article pages the Reader
+// fetches in one go.
+const contentBatchSize = 32
+
+// contentMaxAge is the age beyond which we do not bother to fetch the
+// content of Items any more, so enabling full text for a Feed does not
+// cause us to load the pages for its entire history.
+const contentMaxAge = time.Hour * 24 * 7
+
+// contentResult carries the content extracted from an Item's page.
+type contentResult struct {
+	item    feed.Item
+	content string
+	err     error
+}
+
+// fetchContent fetches the pages of new Items whose Feeds only carry a
+// teaser and extracts the main content from them. Pages are fetched
+// concurrently, observing the limit on requests per host, the results are
+// stored sequentially.
+func (r *Reader) fetchContent() {
+	var (
+		err   error
+		items []feed.Item
+		wg    sync.WaitGroup
+		hosts []string
+		jobQ  *hostQueue
+		resQ  chan contentResult
+		wcnt  = Workers
+		feeds = make(map[int64]*feed.Feed)
+		db    = r.pool.Get()
+	)
+
+	defer r.pool.Put(db)
+
+	if items, err = db.ItemGetContentPending(time.Now().Add(-contentMaxAge), contentBatchSize); err != nil {
+		var msg = fmt.Sprintf("Cannot get Items to fetch content for: %s",
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	} else if len(items) == 0 {
+		return
+	}
+
+	for _, i := range items {
+		if _, ok := feeds[i.FeedID]; ok {
+			continue
+		} else if feeds[i.FeedID], err = db.FeedGetByID(i.FeedID); err != nil {
+			r.log.Printf("[ERROR] Cannot load Feed %d: %s\n",
+				i.FeedID,
+				err.Error())
+			return
+		}
+	}
+
+	if wcnt > len(items) {
+		wcnt = len(items)
+	} else if wcnt < 1 {
+		wcnt = 1
+	}
+
+	hosts = make([]string, len(items))
+	for idx := range items {
+		hosts[idx] = urlHost(items[idx].URL)
+	}
+
+	jobQ = newHostQueue(PerHostLimit, hosts)
+	resQ = make(chan contentResult, wcnt)
+
+	wg.Add(wcnt)
+	for i := 0; i < wcnt; i++ {
+		go r.contentWorker(feeds, items, jobQ, resQ, &wg)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resQ)
+	}()
+
+	for res := range resQ {
+		if res.err != nil {
+			// We do not try again, most of the time the extraction
+			// fails because the page simply does not look like an
+			// article.
+			r.log.Printf("[INFO] Cannot get content of Item %q (%s): %s\n",
+				res.item.Title,
+				res.item.URL,
+				res.err.Error())
+		}
+
+		if err = db.ItemContentSet(&res.item, res.content); err != nil {
+			r.log.Printf("[ERROR] Cannot store content of Item %q: %s\n",
+				res.item.Title,
+				err.Error())
+		}
+	}
+} // func (r *Reader) fetchContent()
+
+// contentWorker fetches the pages of the Items jobQ hands out and passes the
+// extracted content on to resQ.
+func (r *Reader) contentWorker(feeds map[int64]*feed.Feed, items []feed.Item, jobQ *hostQueue, resQ chan<- contentResult, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for idx := jobQ.next(); idx >= 0; idx = jobQ.next() {
+		var (
+			i   = items[idx]
+			res = contentResult{item: i}
+			f   = feeds[i.FeedID]
+		)
+
+		if f == nil {
+			jobQ.done(idx)
+			res.err = errors.New("Feed was not found in database")
+			resQ <- res
+			continue
+		}
+
+		r.log.Printf("[TRACE] Fetch content of Item %q (%s)\n",
+			i.Title,
+			i.URL)
+
+		res.content, res.err = f.FetchContent(&i)
+		jobQ.done(idx)
+
+		resQ <- res
+	}
+} // func (r *Reader) contentWorker(...)
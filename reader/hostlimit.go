@@ -0,0 +1,117 @@
+// /home/krylon/go/src/ticker/reader/hostlimit.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:58:39 krylon>
+
+package reader
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/blicero/ticker/feed"
+)
+
+// hostQueue hands out jobs to a pool of workers, so that no host receives
+// more than a given number of concurrent requests. Jobs are referred to by
+// their index. A worker only gets a job whose host has a free slot, so jobs
+// for an idle host do not have to wait behind those for a busy one.
+type hostQueue struct {
+	max     int
+	left    int
+	lock    sync.Mutex
+	cond    *sync.Cond
+	busy    map[string]int
+	pending map[string][]int
+	order   []string
+	hosts   []string
+}
+
+// newHostQueue creates a queue for a list of jobs, hosts holds the name of
+// the host each job goes to.
+func newHostQueue(max int, hosts []string) *hostQueue {
+	if max < 1 {
+		max = 1
+	}
+
+	var q = &hostQueue{
+		max:     max,
+		left:    len(hosts),
+		busy:    make(map[string]int),
+		pending: make(map[string][]int),
+		hosts:   hosts,
+	}
+
+	q.cond = sync.NewCond(&q.lock)
+
+	for idx, h := range hosts {
+		if _, ok := q.pending[h]; !ok {
+			q.order = append(q.order, h)
+		}
+		q.pending[h] = append(q.pending[h], idx)
+	}
+
+	return q
+} // func newHostQueue(max int, hosts []string) *hostQueue
+
+// next blocks until there is a job whose host has a free slot and returns
+// its index. Once all jobs have been handed out, it returns -1.
+func (q *hostQueue) next() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for q.left > 0 {
+		for _, h := range q.order {
+			if len(q.pending[h]) == 0 || q.busy[h] >= q.max {
+				continue
+			}
+
+			var idx = q.pending[h][0]
+			q.pending[h] = q.pending[h][1:]
+			q.busy[h]++
+			if q.left--; q.left == 0 {
+				// Let the idle workers know there is nothing
+				// left to do.
+				q.cond.Broadcast()
+			}
+			return idx
+		}
+
+		q.cond.Wait()
+	}
+
+	return -1
+} // func (q *hostQueue) next() int
+
+// done frees the slot taken by the job with the given index.
+func (q *hostQueue) done(idx int) {
+	var host = q.hosts[idx]
+
+	q.lock.Lock()
+	if q.busy[host]--; q.busy[host] <= 0 {
+		delete(q.busy, host)
+	}
+	q.lock.Unlock()
+	q.cond.Broadcast()
+} // func (q *hostQueue) done(idx int)
+
+// feedHost returns the name of the host serving the given Feed.
+func feedHost(f *feed.Feed) string {
+	return urlHost(f.URL)
+} // func feedHost(f *feed.Feed) string
+
+// urlHost returns the name of the host in the given URL.
+func urlHost(s string) string {
+	var (
+		err error
+		u   *url.URL
+	)
+
+	if u, err = url.Parse(s); err != nil || u.Host == "" {
+		return s
+	}
+
+	return strings.ToLower(u.Hostname())
+} // func urlHost(s string) string
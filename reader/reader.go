@@ -8,14 +8,18 @@
 package reader
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"sync"
+	"github.com/blicero/ticker/canon"
 	"github.com/blicero/ticker/common"
 	"github.com/blicero/ticker/database"
 	"github.com/blicero/ticker/feed"
 	"github.com/blicero/ticker/logdomain"
+	"github.com/blicero/ticker/retention"
+	"github.com/blicero/ticker/websub"
 	"time"
 )
 
@@ -23,14 +27,51 @@ import (
 //       should probably set this to a higher value.
 const checkDelay = time.Second * 5
 
+// dbPoolSize is the number of database connections the Reader keeps around.
+const dbPoolSize = 2
+
+// renewDelay is how often the Reader checks for WebSub Subscriptions that
+// need to be renewed.
+const renewDelay = time.Minute * 10
+
+// retentionDelay is how often the Reader deletes Items that have expired
+// according to their Feed's Retention policy.
+const retentionDelay = time.Hour * 6
+
+// pushQueueSize is the number of pushed Feeds that may wait to be stored.
+const pushQueueSize = 32
+
+// ErrPushQueueFull indicates that the Reader cannot accept any more pushed
+// content at the moment.
+var ErrPushQueueFull = errors.New("push queue is full")
+
+// Workers is the maximum number of Feeds the Reader fetches concurrently.
+var Workers = 8
+
+// PerHostLimit is the maximum number of concurrent requests the Reader sends
+// to any single host.
+var PerHostLimit = 1
+
+// fetchResult carries the outcome of fetching a Feed from a fetch worker
+// to the goroutine that stores the Items in the database.
+type fetchResult struct {
+	f      feed.Feed
+	items  []feed.Item
+	err    error
+	pushed bool
+}
+
 // Reader regularly checks the subscribed Feeds and stores any new Items in
 // the database.
 type Reader struct {
-	db       *database.Database
+	pool     *database.Pool
 	log      *log.Logger
 	active   bool
 	lock     sync.RWMutex
 	msgQueue chan<- string
+	pushQ    chan fetchResult
+	archiveQ chan *feed.Item
+	agentQ   chan<- *feed.Item
 	StopQ    chan int
 }
 
@@ -41,6 +82,8 @@ func New(q chan<- string) (*Reader, error) {
 		msg string
 		r   = &Reader{
 			msgQueue: q,
+			pushQ:    make(chan fetchResult, pushQueueSize),
+			archiveQ: make(chan *feed.Item, archiveQueueSize),
 			StopQ:    make(chan int),
 		}
 	)
@@ -52,8 +95,8 @@ func New(q chan<- string) (*Reader, error) {
 		r.sndMsg(msg)
 		fmt.Fprintln(os.Stderr, msg)
 		return nil, err
-	} else if r.db, err = database.Open(common.DbPath); err != nil {
-		msg = fmt.Sprintf("Cannot open database at %s: %s",
+	} else if r.pool, err = database.NewPool(dbPoolSize); err != nil {
+		msg = fmt.Sprintf("Cannot open database pool at %s: %s",
 			common.DbPath,
 			err.Error())
 		r.log.Printf("[ERROR] %s\n", msg)
@@ -61,6 +104,10 @@ func New(q chan<- string) (*Reader, error) {
 		return nil, err
 	}
 
+	for i := 0; i < archiveWorkers; i++ {
+		go r.archiveWorker()
+	}
+
 	return r, nil
 } // func New() (*Reader, error)
 
@@ -104,10 +151,18 @@ func (r *Reader) Loop() error {
 	r.active = true
 	r.lock.Unlock()
 
-	var ticker = time.NewTicker(checkDelay)
+	var (
+		ticker = time.NewTicker(checkDelay)
+		renew  = time.NewTicker(renewDelay)
+		expire = time.NewTicker(retentionDelay)
+		fetch  = time.NewTicker(contentDelay)
+	)
 
 	defer func() {
 		ticker.Stop()
+		renew.Stop()
+		expire.Stop()
+		fetch.Stop()
 		r.lock.Lock()
 		r.active = false
 		r.lock.Unlock()
@@ -128,6 +183,20 @@ func (r *Reader) Loop() error {
 				r.sndMsg(msg)
 				return err
 			}
+		case <-renew.C:
+			r.renewSubscriptions()
+		case <-expire.C:
+			r.expire()
+		case <-fetch.C:
+			r.fetchContent()
+		case res := <-r.pushQ:
+			if err := r.storePushed(&res); err != nil {
+				var msg = fmt.Sprintf("Failed to store pushed Items: %s",
+					err.Error())
+				r.log.Printf("[ERROR] %s\n", msg)
+				r.sndMsg(msg)
+				return err
+			}
 		case <-r.StopQ:
 			break
 		}
@@ -136,88 +205,581 @@ func (r *Reader) Loop() error {
 	return nil
 } // func (r *Reader) Loop() error
 
+// refresh fetches all Feeds that are due. The Feeds are fetched concurrently
+// by up to Workers goroutines, no more than PerHostLimit at a time from any
+// single host, while the Items are stored in the database
+// sequentially by the calling goroutine.
 func (r *Reader) refresh() error {
 	var (
 		err   error
+		fatal error
 		feeds []feed.Feed
+		wg    sync.WaitGroup
+		hosts []string
+		jobQ  *hostQueue
+		resQ  chan fetchResult
+		wcnt  = Workers
+		db    = r.pool.Get()
 	)
 
-	// r.log.Println("[TRACE] Check/Refresh Feeds")
+	defer r.pool.Put(db)
 
-	// defer func() {
-	// 	r.log.Println("[TRACE] Reader.refresh() is finished.")
-	// }()
-
-	if feeds, err = r.db.FeedGetDue(); err != nil {
+	if feeds, err = db.FeedGetDue(); err != nil {
 		var msg = fmt.Sprintf("Cannot get all Feeds: %s",
 			err.Error())
 		r.log.Printf("[ERROR] %s\n", msg)
 		r.sndMsg(msg)
 		return err
+	} else if len(feeds) == 0 {
+		return nil
+	}
+
+	if wcnt > len(feeds) {
+		wcnt = len(feeds)
+	} else if wcnt < 1 {
+		wcnt = 1
+	}
+
+	hosts = make([]string, len(feeds))
+	for idx := range feeds {
+		hosts[idx] = feedHost(&feeds[idx])
+	}
+
+	jobQ = newHostQueue(PerHostLimit, hosts)
+	resQ = make(chan fetchResult, wcnt)
+
+	wg.Add(wcnt)
+	for i := 0; i < wcnt; i++ {
+		go r.fetchWorker(feeds, jobQ, resQ, &wg)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resQ)
+	}()
+
+	for res := range resQ {
+		// If storing Items failed, we still need to drain the queue,
+		// so the workers can finish.
+		if fatal == nil {
+			fatal = r.store(db, &res)
+		}
 	}
 
-	for _, f := range feeds {
-		var items []feed.Item
+	return fatal
+} // func (r *Reader) refresh() error
 
-		r.log.Printf("[TRACE] Check Feed %s\n", f.Name)
-		r.sndMsg(fmt.Sprintf("Refresh Feed %s", f.Name))
+// fetchWorker fetches the Feeds jobQ hands out and passes the results on to
+// resQ.
+func (r *Reader) fetchWorker(feeds []feed.Feed, jobQ *hostQueue, resQ chan<- fetchResult, wg *sync.WaitGroup) {
+	defer wg.Done()
 
-		if !f.IsDue() {
+	for idx := jobQ.next(); idx >= 0; idx = jobQ.next() {
+		var res = fetchResult{f: feeds[idx]}
+
+		if !res.f.IsDue() {
 			// CANTHAPPEN!!!
 			r.log.Printf("[CANTHAPPEN] Feed %s is next due at %s\n",
-				f.Name,
-				f.Next().Format(common.TimestampFormat))
-			continue
-		} else if items, err = f.Fetch(); err != nil {
-			var msg = fmt.Sprintf("Failed to refresh Feed %s: %s",
-				f.Name,
-				err.Error())
-			r.log.Printf("[ERROR] %s\n", msg)
-			r.sndMsg(msg)
+				res.f.Name,
+				res.f.Next().Format(common.TimestampFormat))
+			jobQ.done(idx)
 			continue
 		}
 
-		r.log.Printf("[TRACE] Feed %s: Process %d Items\n",
+		r.log.Printf("[TRACE] Check Feed %s\n", res.f.Name)
+		r.sndMsg(fmt.Sprintf("Refresh Feed %s", res.f.Name))
+
+		res.items, res.err = res.f.Fetch()
+		jobQ.done(idx)
+
+		resQ <- res
+	}
+} // func (r *Reader) fetchWorker(...)
+
+// store saves the Items of a fetched Feed in the database and updates the
+// Feed's status.
+func (r *Reader) store(db *database.Database, res *fetchResult) error {
+	var (
+		err   error
+		rules []feed.Rule
+		f     = &res.f
+	)
+
+	if res.err != nil {
+		if errors.Is(res.err, feed.ErrNotModified) {
+			r.log.Printf("[TRACE] Feed %s has not been modified\n", f.Name)
+			r.recordSuccess(db, f)
+			return nil
+		}
+
+		var msg = fmt.Sprintf("Failed to refresh Feed %s: %s",
 			f.Name,
-			len(items))
+			res.err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		r.recordFailure(db, f, res.err)
+		return nil
+	}
 
-		for _, i := range items {
-			var dup bool
+	r.log.Printf("[TRACE] Feed %s: Process %d Items\n",
+		f.Name,
+		len(res.items))
 
-			if dup, err = r.db.ItemHasDuplicate(&i); err != nil {
-				var msg = fmt.Sprintf("Cannot check if Item %s is in database: %s",
-					i.URL,
+	if rules, err = db.RuleGetActive(); err != nil {
+		var msg = fmt.Sprintf("Cannot load Rules: %s",
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return err
+	}
+
+	for _, i := range res.items {
+		var (
+			dup     bool
+			drop    bool
+			id      int64
+			hash    string
+			matched []*feed.Rule
+		)
+
+		if u := canon.URL(i.URL); u != i.URL {
+			i.OriginalURL = i.URL
+			i.URL = u
+		}
+
+		// Within a Feed, the GUID identifies an Item, even if its link
+		// changes. If we know the GUID, the Item may have been edited
+		// upstream.
+		if i.GUID != "" {
+			if id, hash, err = db.ItemLookupGUID(i.FeedID, i.GUID); err != nil {
+				var msg = fmt.Sprintf("Cannot look up Item %s in database: %s",
+					i.GUID,
 					err.Error())
 				r.log.Printf("[ERROR] %s\n", msg)
 				r.sndMsg(msg)
 				return err
-			} else if dup {
+			} else if id != 0 {
+				if hash == "" {
+					// The Item predates content hashes, so we
+					// cannot tell if it has changed. We just
+					// remember what it looks like now.
+					if err = db.ItemHashSet(id, i.ContentHash()); err != nil {
+						var msg = fmt.Sprintf("Cannot update Item %q: %s",
+							i.Title,
+							err.Error())
+						r.log.Printf("[ERROR] %s\n", msg)
+						r.sndMsg(msg)
+					}
+				} else if hash != i.ContentHash() {
+					r.log.Printf("[TRACE] Update Item %s (%s)\n",
+						i.Title,
+						i.URL)
+
+					// A failed update, e.g. because the new link
+					// clashes with another Item, loses nothing, so
+					// we carry on with the other Items.
+					if err = db.ItemUpdate(id, &i); err != nil {
+						var msg = fmt.Sprintf("Cannot update Item %q: %s",
+							i.Title,
+							err.Error())
+						r.log.Printf("[ERROR] %s\n", msg)
+						r.sndMsg(msg)
+					}
+				}
+
 				continue
 			}
+		}
 
-			r.log.Printf("[TRACE] Add Item %s (%s)\n",
+		if dup, err = db.ItemHasDuplicate(&i); err != nil {
+			var msg = fmt.Sprintf("Cannot check if Item %s is in database: %s",
+				i.URL,
+				err.Error())
+			r.log.Printf("[ERROR] %s\n", msg)
+			r.sndMsg(msg)
+			return err
+		} else if dup {
+			continue
+		} else if matched, drop = matchRules(rules, &i); drop {
+			r.log.Printf("[TRACE] Rule %s drops Item %s (%s)\n",
+				matched[0].Name,
 				i.Title,
 				i.URL)
+			continue
+		}
+
+		r.log.Printf("[TRACE] Add Item %s (%s)\n",
+			i.Title,
+			i.URL)
+
+		if err = db.ItemAdd(&i); err != nil {
+			var msg = fmt.Sprintf("Cannot save Item %q to database: %s",
+				i.Title,
+				err.Error())
+			r.log.Printf("[ERROR] %s\n", msg)
+			r.sndMsg(msg)
+			return err
+		}
+
+		r.applyRules(db, matched, &i)
+	}
 
-			if err = r.db.ItemAdd(&i); err != nil {
-				var msg = fmt.Sprintf("Cannot save Item %q to database: %s",
-					i.Title,
+	// A push is not a refresh, so it must not count as a fetch, nor
+	// postpone the next time we poll the Feed ourselves.
+	if res.pushed {
+		return nil
+	}
+
+	if err = db.FeedSetCacheInfo(f); err != nil {
+		var msg = fmt.Sprintf("Cannot store cache info for Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+	}
+
+	if f.Adaptive {
+		r.adaptInterval(db, f)
+	}
+
+	if websub.CallbackBase != "" {
+		r.checkHub(db, f)
+	}
+
+	r.recordSuccess(db, f)
+	return nil
+} // func (r *Reader) store(db *database.Database, res *fetchResult) error
+
+// Push hands Items a WebSub hub delivered for the given Feed to the Reader,
+// which stores them the same way as Items it fetched itself. If too many
+// pushed Feeds are waiting to be stored already, Push returns
+// ErrPushQueueFull.
+func (r *Reader) Push(f feed.Feed, items []feed.Item) error {
+	select {
+	case r.pushQ <- fetchResult{f: f, items: items, pushed: true}:
+		return nil
+	default:
+		return ErrPushQueueFull
+	}
+} // func (r *Reader) Push(f feed.Feed, items []feed.Item) error
+
+// storePushed stores Items that were pushed to us. Unlike a refresh, this
+// leaves the Feed's refresh timestamp and fetch statistics alone.
+func (r *Reader) storePushed(res *fetchResult) error {
+	var db = r.pool.Get()
+	defer r.pool.Put(db)
+
+	r.log.Printf("[TRACE] Feed %s pushed %d Items\n",
+		res.f.Name,
+		len(res.items))
+
+	return r.store(db, res)
+} // func (r *Reader) storePushed(res *fetchResult) error
+
+// checkHub makes sure we are subscribed to the WebSub hub a Feed announces.
+// If the Feed no longer announces a hub, we forget about our Subscription.
+func (r *Reader) checkHub(db *database.Database, f *feed.Feed) {
+	var (
+		err error
+		sub *websub.Subscription
+	)
+
+	if sub, err = db.WebSubGetByFeed(f.ID); err != nil {
+		var msg = fmt.Sprintf("Cannot load WebSub Subscription for Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	} else if f.Hub == "" {
+		if sub != nil {
+			r.log.Printf("[INFO] Feed %s no longer announces a WebSub hub\n",
+				f.Name)
+			if err = db.WebSubDelete(f.ID); err != nil {
+				var msg = fmt.Sprintf("Cannot delete WebSub Subscription for Feed %s: %s",
+					f.Name,
 					err.Error())
 				r.log.Printf("[ERROR] %s\n", msg)
 				r.sndMsg(msg)
-				return err
 			}
 		}
+		return
+	} else if sub != nil && sub.Hub == f.Hub && sub.Topic == f.Topic {
+		switch sub.State {
+		case websub.Active, websub.Denied:
+			return
+		case websub.Pending:
+			if time.Since(sub.Requested) < websub.RetryDelay {
+				return
+			}
+		}
+	}
+
+	var secret string
+
+	if secret, err = websub.NewSecret(); err != nil {
+		var msg = fmt.Sprintf("Cannot generate secret for WebSub Subscription: %s",
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	}
+
+	sub = &websub.Subscription{
+		FeedID:    f.ID,
+		Hub:       f.Hub,
+		Topic:     f.Topic,
+		Secret:    secret,
+		State:     websub.Pending,
+		Requested: time.Now(),
+	}
+
+	// The hub may verify our intent before it even answers our request,
+	// so we need to store the Subscription first.
+	if err = db.WebSubAdd(sub); err != nil {
+		var msg = fmt.Sprintf("Cannot store WebSub Subscription for Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	}
+
+	r.subscribe(f.Name, sub)
+} // func (r *Reader) checkHub(db *database.Database, f *feed.Feed)
+
+// subscribe sends a subscription request to the hub.
+func (r *Reader) subscribe(name string, sub *websub.Subscription) {
+	if err := websub.Subscribe(sub, sub.Callback(websub.CallbackBase)); err != nil {
+		var msg = fmt.Sprintf("Cannot subscribe to Feed %s at %s: %s",
+			name,
+			sub.Hub,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	}
+
+	r.log.Printf("[INFO] Asked %s to push updates for Feed %s\n",
+		sub.Hub,
+		name)
+} // func (r *Reader) subscribe(name string, sub *websub.Subscription)
+
+// renewSubscriptions asks the hubs to renew any Subscriptions whose lease
+// is about to expire.
+func (r *Reader) renewSubscriptions() {
+	if websub.CallbackBase == "" {
+		return
+	}
+
+	var (
+		err  error
+		subs []websub.Subscription
+		db   = r.pool.Get()
+	)
+
+	defer r.pool.Put(db)
+
+	if subs, err = db.WebSubGetExpiring(); err != nil {
+		var msg = fmt.Sprintf("Cannot load WebSub Subscriptions: %s",
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	}
 
-		if err = r.db.FeedSetTimestamp(&f, time.Now()); err != nil {
-			var msg = fmt.Sprintf("Cannot update timestamp on Feed %s: %s",
+	for idx := range subs {
+		var (
+			f   *feed.Feed
+			sub = &subs[idx]
+		)
+
+		if f, err = db.FeedGetByID(sub.FeedID); err != nil {
+			var msg = fmt.Sprintf("Cannot load Feed %d: %s",
+				sub.FeedID,
+				err.Error())
+			r.log.Printf("[ERROR] %s\n", msg)
+			r.sndMsg(msg)
+			continue
+		} else if f == nil || !f.Active {
+			// We let the lease of inactive Feeds run out.
+			continue
+		} else if err = db.WebSubSetRequested(sub, time.Now()); err != nil {
+			var msg = fmt.Sprintf("Cannot update WebSub Subscription for Feed %s: %s",
 				f.Name,
 				err.Error())
 			r.log.Printf("[ERROR] %s\n", msg)
 			r.sndMsg(msg)
 			continue
 		}
+
+		r.subscribe(f.Name, sub)
 	}
+} // func (r *Reader) renewSubscriptions()
 
-	return nil
-} // func (r *Reader) refresh() error
+// expire deletes the Items that have expired according to the Retention
+// policies.
+func (r *Reader) expire() {
+	var (
+		err error
+		rep *retention.Report
+		db  = r.pool.Get()
+	)
+
+	defer r.pool.Put(db)
+
+	if rep, err = retention.Run(db, false); err != nil {
+		var msg = fmt.Sprintf("Cannot delete expired Items: %s",
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	} else if rep.ItemCnt == 0 {
+		return
+	}
+
+	var msg = fmt.Sprintf("Deleted %d expired Items from %d Feeds and %d cached images",
+		rep.ItemCnt,
+		len(rep.Feeds),
+		len(rep.Files))
+	r.log.Printf("[INFO] %s\n", msg)
+	r.sndMsg(msg)
+} // func (r *Reader) expire()
+
+// adaptInterval recomputes the refresh interval of a Feed in adaptive mode
+// from the timestamps of its most recent Items.
+func (r *Reader) adaptInterval(db *database.Database, f *feed.Feed) {
+	var (
+		err    error
+		stamps []time.Time
+		ival   time.Duration
+	)
+
+	if stamps, err = db.FeedGetItemStamps(f.ID, feed.AdaptiveSampleSize); err != nil {
+		var msg = fmt.Sprintf("Cannot load Item timestamps for Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	} else if ival = f.ComputeInterval(stamps); ival == 0 || ival == f.AdaptiveInterval {
+		return
+	} else if err = db.FeedSetAdaptiveInterval(f, ival); err != nil {
+		var msg = fmt.Sprintf("Cannot update refresh interval of Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	}
+
+	r.log.Printf("[DEBUG] Feed %s will be refreshed every %s\n",
+		f.Name,
+		ival)
+} // func (r *Reader) adaptInterval(db *database.Database, f *feed.Feed)
+
+// recordSuccess updates the Feed's refresh timestamp and marks it as healthy.
+// If the Feed has moved, we update its URL.
+func (r *Reader) recordSuccess(db *database.Database, f *feed.Feed) {
+	var (
+		err error
+		now = time.Now()
+	)
+
+	if f.MovedTo != "" {
+		r.followMove(db, f)
+	}
+
+	if err = db.FeedSetSuccess(f, now); err != nil {
+		var msg = fmt.Sprintf("Cannot record successful refresh of Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+	}
+
+	if err = db.FeedSetTimestamp(f, now); err != nil {
+		var msg = fmt.Sprintf("Cannot update timestamp on Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+	}
+} // func (r *Reader) recordSuccess(db *database.Database, f *feed.Feed)
+
+// followMove updates the URL of a Feed the server told us has moved
+// permanently. The old URL goes to the Feed's URL history.
+func (r *Reader) followMove(db *database.Database, f *feed.Feed) {
+	var (
+		err error
+		old = f.URL
+	)
+
+	if err = db.FeedModify(f, f.Name, f.MovedTo, f.Homepage, f.Interval); err != nil {
+		var msg = fmt.Sprintf("Feed %s has moved to %s, but I cannot update its URL: %s",
+			f.Name,
+			f.MovedTo,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	}
+
+	var msg = fmt.Sprintf("Feed %s has moved from %s to %s",
+		f.Name,
+		old,
+		f.URL)
+	r.log.Printf("[INFO] %s\n", msg)
+	r.sndMsg(msg)
+	f.MovedTo = ""
+} // func (r *Reader) followMove(db *database.Database, f *feed.Feed)
+
+// recordFailure updates the Feed's refresh timestamp and failure count, so
+// the next attempt is delayed. If the Feed has been failing for too long,
+// it is deactivated.
+func (r *Reader) recordFailure(db *database.Database, f *feed.Feed, ferr error) {
+	var (
+		err error
+		now = time.Now()
+	)
+
+	if err = db.FeedSetFailure(f, ferr, now); err != nil {
+		var msg = fmt.Sprintf("Cannot record failed refresh of Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	} else if err = db.FeedSetTimestamp(f, now); err != nil {
+		var msg = fmt.Sprintf("Cannot update timestamp on Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	}
+
+	r.log.Printf("[INFO] Feed %s has failed %d times in a row, next attempt at %s\n",
+		f.Name,
+		f.FailCount,
+		f.Next().Format(common.TimestampFormat))
+
+	if !f.IsDead() {
+		return
+	} else if err = db.FeedSetActive(f.ID, false); err != nil {
+		var msg = fmt.Sprintf("Cannot deactivate Feed %s: %s",
+			f.Name,
+			err.Error())
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+		return
+	}
+
+	f.Active = false
+
+	var msg = fmt.Sprintf("Deactivated Feed %s, it has been failing since %s",
+		f.Name,
+		f.FailSince.Format(common.TimestampFormat))
+	r.log.Printf("[INFO] %s\n", msg)
+	r.sndMsg(msg)
+} // func (r *Reader) recordFailure(db *database.Database, f *feed.Feed, ferr error)
@@ -0,0 +1,145 @@
+// /home/krylon/go/src/ticker/reader/rules.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:44:07 krylon>
+
+package reader
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/blicero/ticker/database"
+	"github.com/blicero/ticker/feed"
+)
+
+// ruleDeadline is the deadline Rules give the Items they put on the
+// read-later list.
+const ruleDeadline = time.Hour * 24 * 7
+
+// archiveQueueSize is the number of Items that may wait to be handed to the
+// download Agent, archiveWorkers the number of goroutines handing them over.
+const (
+	archiveQueueSize = 64
+	archiveWorkers   = 2
+)
+
+// SetArchiveQueue tells the Reader where to send Items that Rules want
+// archived.
+func (r *Reader) SetArchiveQueue(q chan<- *feed.Item) {
+	r.lock.Lock()
+	r.agentQ = q
+	r.lock.Unlock()
+} // func (r *Reader) SetArchiveQueue(q chan<- *feed.Item)
+
+// matchRules returns the Rules that match the given Item. If one of them
+// drops the Item, drop is true.
+func matchRules(rules []feed.Rule, i *feed.Item) (matched []*feed.Rule, drop bool) {
+	for idx := range rules {
+		var rule = &rules[idx]
+
+		if !rule.Match(i) {
+			continue
+		} else if rule.Drop {
+			return []*feed.Rule{rule}, true
+		}
+
+		matched = append(matched, rule)
+	}
+
+	return matched, false
+} // func matchRules(rules []feed.Rule, i *feed.Item) ([]*feed.Rule, bool)
+
+// applyRules performs the actions of the matching Rules on an Item that
+// has just been stored. Failures are reported, but do not keep us from
+// processing the remaining Items.
+func (r *Reader) applyRules(db *database.Database, matched []*feed.Rule, i *feed.Item) {
+	for _, rule := range matched {
+		var err error
+
+		r.log.Printf("[TRACE] Rule %s matches Item %q\n",
+			rule.Name,
+			i.Title)
+
+		if rule.TagID != 0 && !i.HasTag(rule.TagID) {
+			if err = db.TagLinkCreate(i.ID, rule.TagID); err != nil {
+				r.ruleFailed(rule, i, err)
+			}
+		}
+
+		if rule.Rate {
+			if err = db.ItemRatingSet(i, rule.Rating); err != nil {
+				r.ruleFailed(rule, i, err)
+			}
+		}
+
+		if rule.MarkRead && !i.Read {
+			if err = db.ItemMarkRead(i); err != nil {
+				r.ruleFailed(rule, i, err)
+			}
+		}
+
+		if rule.ReadLater {
+			var l *feed.ReadLater
+
+			if l, err = db.ReadLaterGetByItem(i); err != nil {
+				r.ruleFailed(rule, i, err)
+			} else if l == nil {
+				var note = fmt.Sprintf("Rule %s", rule.Name)
+
+				if _, err = db.ReadLaterAdd(i, note, time.Now().Add(ruleDeadline)); err != nil {
+					r.ruleFailed(rule, i, err)
+				}
+			}
+		}
+
+		if rule.Archive {
+			r.archive(i)
+		}
+	}
+} // func (r *Reader) applyRules(db *database.Database, matched []*feed.Rule, i *feed.Item)
+
+// archive queues an Item to be handed to the download Agent. The Agent's
+// queue is short, so we do not wait for it to accept the Item. If too many
+// Items are waiting already, we drop it, the user can still archive it by
+// hand.
+func (r *Reader) archive(i *feed.Item) {
+	var item = *i
+
+	select {
+	case r.archiveQ <- &item:
+	default:
+		var msg = fmt.Sprintf("Cannot archive Item %q: Archive queue is full",
+			i.Title)
+		r.log.Printf("[ERROR] %s\n", msg)
+		r.sndMsg(msg)
+	}
+} // func (r *Reader) archive(i *feed.Item)
+
+// archiveWorker hands the queued Items to the download Agent, waiting for
+// the Agent to accept each of them.
+func (r *Reader) archiveWorker() {
+	for item := range r.archiveQ {
+		r.lock.RLock()
+		var q = r.agentQ
+		r.lock.RUnlock()
+
+		if q == nil {
+			r.log.Printf("[ERROR] Cannot archive Item %q: No download queue\n",
+				item.Title)
+			continue
+		}
+
+		q <- item
+	}
+} // func (r *Reader) archiveWorker()
+
+func (r *Reader) ruleFailed(rule *feed.Rule, i *feed.Item, err error) {
+	var msg = fmt.Sprintf("Cannot apply Rule %s to Item %q: %s",
+		rule.Name,
+		i.Title,
+		err.Error())
+	r.log.Printf("[ERROR] %s\n", msg)
+	r.sndMsg(msg)
+} // func (r *Reader) ruleFailed(rule *feed.Rule, i *feed.Item, err error)
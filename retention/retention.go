@@ -0,0 +1,229 @@
+// /home/krylon/go/src/ticker/retention/retention.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 03:06:32 krylon>
+
+// Package retention deletes old Items according to the Retention policies
+// set for the Feeds, so the database does not grow forever.
+package retention
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/blicero/ticker/common"
+	"github.com/blicero/ticker/database"
+	"github.com/blicero/ticker/feed"
+)
+
+// Prefetched images are stored in the cache folder, Items refer to them by
+// links to /cache/<name>.
+var cachePat = regexp.MustCompile(`/cache/([0-9A-Za-z]+\.[0-9A-Za-z]+)`)
+
+// Expiry lists the Items of one Feed that its Retention policy allows us to
+// delete.
+type Expiry struct {
+	Feed   feed.Feed
+	Policy feed.Retention
+	Items  []feed.Item
+}
+
+// Report describes what a run of the retention job deleted, or would have
+// deleted, had it not been a dry run.
+type Report struct {
+	Timestamp time.Time
+	DryRun    bool
+	Feeds     []Expiry
+	ItemCnt   int
+	// Files are the prefetched images in the cache folder that are only
+	// used by the expired Items.
+	Files []string
+}
+
+// Run applies the Retention policies stored in the database. A Feed's own
+// policy takes precedence over the global one, Feeds without either keep
+// all their Items. Items whose pages have been archived or whose Enclosures
+// have been downloaded are kept, as well.
+// Deleting an Item also removes it from the full-text index.
+//
+// If dryRun is true, Run only reports what it would delete.
+func Run(db *database.Database, dryRun bool) (*Report, error) {
+	var (
+		err      error
+		feeds    []feed.Feed
+		policies []feed.Retention
+		global   *feed.Retention
+		perFeed  = make(map[int64]feed.Retention)
+		rep      = &Report{
+			Timestamp: time.Now(),
+			DryRun:    dryRun,
+		}
+	)
+
+	if policies, err = db.RetentionGetAll(); err != nil {
+		return nil, err
+	} else if len(policies) == 0 {
+		return rep, nil
+	} else if feeds, err = db.FeedGetAll(); err != nil {
+		return nil, err
+	}
+
+	for i := range policies {
+		if policies[i].IsGlobal() {
+			global = &policies[i]
+		} else {
+			perFeed[policies[i].FeedID] = policies[i]
+		}
+	}
+
+	for _, f := range feeds {
+		var (
+			items []feed.Item
+			exp   = Expiry{Feed: f}
+		)
+
+		if p, ok := perFeed[f.ID]; ok {
+			exp.Policy = p
+		} else if global != nil {
+			exp.Policy = *global
+		} else {
+			continue
+		}
+
+		if items, err = db.ItemGetExpired(f.ID, &exp.Policy, rep.Timestamp); err != nil {
+			return nil, err
+		}
+
+		for _, i := range items {
+			var keep bool
+
+			if i.IsDownloaded() {
+				continue
+			} else if keep, err = hasDownloads(db, i.ID); err != nil {
+				return nil, err
+			} else if !keep {
+				exp.Items = append(exp.Items, i)
+			}
+		}
+
+		if len(exp.Items) > 0 {
+			rep.Feeds = append(rep.Feeds, exp)
+			rep.ItemCnt += len(exp.Items)
+		}
+	}
+
+	if rep.Files, err = unusedFiles(db, rep.Feeds); err != nil {
+		return nil, err
+	} else if dryRun || rep.ItemCnt == 0 {
+		return rep, nil
+	} else if err = deleteItems(db, rep.Feeds); err != nil {
+		return nil, err
+	}
+
+	for _, name := range rep.Files {
+		var path = filepath.Join(common.CacheDir, name)
+
+		if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return rep, err
+		}
+	}
+
+	return rep, nil
+} // func Run(db *database.Database, dryRun bool) (*Report, error)
+
+// hasDownloads returns true if any of the Item's Enclosures have been
+// downloaded.
+func hasDownloads(db *database.Database, itemID int64) (bool, error) {
+	var (
+		err  error
+		encs []feed.Enclosure
+	)
+
+	if encs, err = db.EnclosureGetByItem(itemID); err != nil {
+		return false, err
+	}
+
+	for i := range encs {
+		if encs[i].IsDownloaded() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+} // func hasDownloads(db *database.Database, itemID int64) (bool, error)
+
+// unusedFiles returns the names of the cached images that no Items other
+// than the expired ones refer to.
+func unusedFiles(db *database.Database, list []Expiry) ([]string, error) {
+	var (
+		err     error
+		refs    map[string][]int64
+		expired = make(map[int64]bool)
+		files   []string
+	)
+
+	for _, exp := range list {
+		for _, i := range exp.Items {
+			expired[i.ID] = true
+		}
+	}
+
+	if len(expired) == 0 {
+		return nil, nil
+	} else if refs, err = db.ItemCacheRefs(cachePat); err != nil {
+		return nil, err
+	}
+
+	for name, ids := range refs {
+		var used bool
+
+		for _, id := range ids {
+			if !expired[id] {
+				used = true
+				break
+			}
+		}
+
+		if !used {
+			files = append(files, name)
+		}
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+} // func unusedFiles(db *database.Database, list []Expiry) ([]string, error)
+
+// deleteItems deletes the expired Items in a single transaction.
+func deleteItems(db *database.Database, list []Expiry) (err error) {
+	var status bool
+
+	if err = db.Begin(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if status {
+			err = db.Commit()
+		} else {
+			db.Rollback() // nolint: errcheck
+		}
+	}()
+
+	for _, exp := range list {
+		for _, i := range exp.Items {
+			if err = db.ItemDelete(i.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	status = true
+	return nil
+} // func deleteItems(db *database.Database, list []Expiry) error
@@ -66,6 +66,21 @@ func TestParseQueryString(t *testing.T) {
 				DateEnd:   mkdate(2020, 12, 31),
 			},
 		},
+		testCase{
+			qstr: `podcast author:"Jane Doe" category:Science category:"Open Source"`,
+			res: Query{
+				Query: []string{
+					"podcast",
+				},
+				Authors: []string{
+					"Jane Doe",
+				},
+				Categories: []string{
+					"Open Source",
+					"Science",
+				},
+			},
+		},
 	}
 
 	for _, c := range qlist {
@@ -27,12 +27,14 @@ var metaPat = regexp.MustCompile(`(?i)(\w+):(.*)\s*$`)
 // Query represents a ... you guessed it: a search query.
 // Using multiple
 type Query struct {
-	Tags      []string
-	DateBegin time.Time
-	DateEnd   time.Time
-	Query     []string
-	db        *database.Database
-	log       *log.Logger
+	Tags       []string
+	Authors    []string
+	Categories []string
+	DateBegin  time.Time
+	DateEnd    time.Time
+	Query      []string
+	db         *database.Database
+	log        *log.Logger
 }
 
 // ParseQueryStr parses a query string and returns a SearchQuery object.
@@ -75,6 +77,14 @@ func ParseQueryStr(d *database.Database, s string) (*Query, error) {
 
 			q.Tags = append(q.Tags, tword)
 
+		case "author":
+			tword = unquote(match[2])
+			q.Authors = append(q.Authors, tword)
+
+		case "category":
+			tword = unquote(match[2])
+			q.Categories = append(q.Categories, tword)
+
 		case "datemin":
 			tword = match[2]
 			if tword[0] == '"' {
@@ -111,10 +121,34 @@ func ParseQueryStr(d *database.Database, s string) (*Query, error) {
 
 	sort.Strings(q.Query)
 	sort.Strings(q.Tags)
+	sort.Strings(q.Authors)
+	sort.Strings(q.Categories)
 
 	return q, nil
 } // func ParseQueryStr(s string) (*Query, error)
 
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+} // func unquote(s string) string
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+} // func equalStrings(a, b []string) bool
+
 // Equal returns true if the given SearchQuery is structurally identical to
 // the receiver.
 func (q *Query) Equal(other *Query) bool {
@@ -153,6 +187,18 @@ End:   %s
 		}
 	}
 
+	if !equalStrings(q.Authors, other.Authors) {
+		q.log.Printf("[TRACE] Authors differ: %v != %v\n",
+			q.Authors,
+			other.Authors)
+		return false
+	} else if !equalStrings(q.Categories, other.Categories) {
+		q.log.Printf("[TRACE] Categories differ: %v != %v\n",
+			q.Categories,
+			other.Categories)
+		return false
+	}
+
 	for i, t := range q.Query {
 		if t != other.Query[i] {
 			q.log.Printf("[TRACE] Query token %d differs: %q != %q\n",
@@ -178,10 +224,30 @@ func (q *Query) Execute() ([]feed.Item, error) {
 
 	q.log.Printf("[TRACE] Run query %q\n", qstr)
 
-	if items, err = q.db.ItemGetFTS(qstr); err != nil {
-		q.log.Printf("[ERROR] Fulltext search failed: %s\n",
-			err.Error())
-		return nil, err
+	// Without any search terms, we have nothing to feed the fulltext
+	// search, so we start with the Items matching the first category or
+	// author and filter those below.
+	switch {
+	case len(q.Query) == 0 && len(q.Categories) > 0:
+		if items, err = q.db.ItemGetByCategory(q.Categories[0]); err != nil {
+			q.log.Printf("[ERROR] Cannot load Items in category %q: %s\n",
+				q.Categories[0],
+				err.Error())
+			return nil, err
+		}
+	case len(q.Query) == 0 && len(q.Authors) > 0:
+		if items, err = q.db.ItemGetByAuthor(q.Authors[0]); err != nil {
+			q.log.Printf("[ERROR] Cannot load Items by author %q: %s\n",
+				q.Authors[0],
+				err.Error())
+			return nil, err
+		}
+	default:
+		if items, err = q.db.ItemGetFTS(qstr); err != nil {
+			q.log.Printf("[ERROR] Fulltext search failed: %s\n",
+				err.Error())
+			return nil, err
+		}
 	}
 
 	results = make([]feed.Item, 0)
@@ -224,6 +290,28 @@ func (q *Query) Execute() ([]feed.Item, error) {
 			results = append(results, it)
 		}
 
+		items = results
+		results = make([]feed.Item, 0)
+	}
+
+	if len(q.Authors) > 0 || len(q.Categories) > 0 {
+	META:
+		for _, it := range items {
+			for _, author := range q.Authors {
+				if !it.HasAuthor(author) {
+					continue META
+				}
+			}
+
+			for _, cat := range q.Categories {
+				if !it.HasCategory(cat) {
+					continue META
+				}
+			}
+
+			results = append(results, it)
+		}
+
 		items = results
 	}
 
@@ -0,0 +1,88 @@
+// /home/krylon/go/src/ticker/simhash/01_simhash_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:58:15 krylon>
+
+package simhash
+
+import (
+	"strings"
+	"testing"
+)
+
+const wireStory = `Central bank raises interest rates by a quarter point.
+The central bank raised its key interest rate by a quarter percentage point
+on Tuesday, the fourth increase this year, citing persistent inflation in
+services and a labor market that remains tight. Officials signaled that
+further increases were possible if price pressures do not ease in the coming
+months, while several members of the committee argued for a pause to assess
+the effects of earlier moves on lending and investment.`
+
+func TestHash(t *testing.T) {
+	type testCase struct {
+		name string
+		text string
+		dup  bool
+	}
+
+	var (
+		orig  = Hash(wireStory)
+		cases = []testCase{
+			{
+				name: "identical",
+				text: wireStory,
+				dup:  true,
+			},
+			{
+				name: "reformatted",
+				text: "CENTRAL BANK RAISES INTEREST RATES BY A QUARTER POINT -- " + wireStory[strings.Index(wireStory, "\n")+1:],
+				dup:  true,
+			},
+			{
+				name: "edited",
+				text: strings.Replace(wireStory, "on Tuesday", "on Wednesday", 1),
+				dup:  true,
+			},
+			{
+				name: "rephrased",
+				text: strings.Replace(wireStory, "Officials signaled", "The governor said", 1),
+				dup:  true,
+			},
+			{
+				name: "different story",
+				text: `Local team wins championship after dramatic overtime.
+The home side came back from a two goal deficit in the final minutes and
+won the title in overtime, sending thousands of fans into the streets to
+celebrate late into the night. The coach praised the resilience of his
+players and dedicated the victory to the supporters.`,
+				dup: false,
+			},
+			{
+				name: "empty",
+				text: " ... ",
+				dup:  false,
+			},
+		}
+	)
+
+	if orig == 0 {
+		t.Fatal("Fingerprint of test story is 0")
+	}
+
+	for _, c := range cases {
+		var fp = Hash(c.text)
+
+		if IsDuplicate(orig, fp) != c.dup {
+			t.Errorf("Test case %q: IsDuplicate = %t (expected %t), distance = %d",
+				c.name,
+				!c.dup,
+				c.dup,
+				Distance(orig, fp))
+		}
+	}
+
+	if Hash("") != 0 {
+		t.Error("Fingerprint of empty string should be 0")
+	}
+} // func TestHash(t *testing.T)
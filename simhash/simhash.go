@@ -0,0 +1,79 @@
+// /home/krylon/go/src/ticker/simhash/simhash.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:58:15 krylon>
+
+// Package simhash computes fingerprints of texts that are similar if the
+// texts are similar. We use them to find news Items from different Feeds
+// that carry the same story, e.g. from a wire service.
+package simhash
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Threshold is the maximum number of bits by which the fingerprints of two
+// texts may differ for them to count as near-duplicates. News Items are
+// short, so a single edited phrase moves the fingerprint by a few bits,
+// while unrelated stories are typically 15 or more bits apart.
+const Threshold = 6
+
+// Window is how far apart in time two Items may be published to still count
+// as the same story.
+var Window = time.Hour * 48
+
+// Hash computes the SimHash of the given text, using the words of the text
+// as features. Texts that contain no words at all get a fingerprint of 0.
+func Hash(text string) uint64 {
+	var (
+		words = strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !(unicode.IsLetter(r) || unicode.IsDigit(r))
+		})
+		weights [64]int
+		fp      uint64
+	)
+
+	if len(words) == 0 {
+		return 0
+	}
+
+	for _, word := range words {
+		var h = fnv.New64a()
+
+		h.Write([]byte(word)) // nolint: errcheck
+
+		var sum = h.Sum64()
+
+		for b := 0; b < 64; b++ {
+			if sum&(1<<uint(b)) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+
+	for b, w := range weights {
+		if w > 0 {
+			fp |= 1 << uint(b)
+		}
+	}
+
+	return fp
+} // func Hash(text string) uint64
+
+// Distance returns the number of bits by which two fingerprints differ.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+} // func Distance(a, b uint64) int
+
+// IsDuplicate returns true if the two fingerprints are close enough for
+// their texts to be considered near-duplicates.
+func IsDuplicate(a, b uint64) bool {
+	return a != 0 && b != 0 && Distance(a, b) <= Threshold
+} // func IsDuplicate(a, b uint64) bool
@@ -0,0 +1,30 @@
+// /home/krylon/go/src/ticker/web/02_web_redirect_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:40:28 krylon>
+
+package web
+
+import "testing"
+
+func TestIsLocalPath(t *testing.T) {
+	var cases = map[string]bool{
+		"/feed/all":                 true,
+		"/feed/1?unread=1":          true,
+		"":                          false,
+		"feed/all":                  false,
+		"//evil.example/":           false,
+		"/\\evil.example/":          false,
+		"https://evil.example/":     false,
+		"/%2f%2fevil.example/":      true,
+		"javascript:alert(1)":       false,
+		"http:/evil.example/feed/1": false,
+	}
+
+	for s, local := range cases {
+		if isLocalPath(s) != local {
+			t.Errorf("isLocalPath(%q) should return %t", s, local)
+		}
+	}
+} // func TestIsLocalPath(t *testing.T)
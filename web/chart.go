@@ -0,0 +1,124 @@
+// /home/krylon/go/src/ticker/web/chart.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 18. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 02:17:37 krylon>
+
+package web
+
+import (
+	"math"
+
+	"github.com/blicero/ticker/feed"
+)
+
+// The dimensions of a bar chart, in SVG user units. The captions go below
+// the plot, the padding on the sides leaves room for the captions of the
+// outermost bars.
+const (
+	chartWidth       = 600
+	chartHeight      = 150
+	chartCaptionSize = 16
+	chartPadding     = 30
+	chartCaptionCnt  = 6
+)
+
+// chartBar is one bar of a barChart. Label is shown when hovering over
+// the bar, Caption below it. Class allows styling individual bars.
+type chartBar struct {
+	X       float64
+	Y       float64
+	Width   float64
+	Height  float64
+	CenterX float64
+	Value   int64
+	Label   string
+	Caption string
+	Class   string
+}
+
+// barChart holds the geometry of a simple bar chart, so a template can
+// render it as inline SVG without doing any arithmetic.
+type barChart struct {
+	Width    float64
+	Height   float64
+	Baseline float64
+	Max      int64
+	Bars     []chartBar
+}
+
+// newBarChart lays out a bar for each value. If there are more bars than
+// fit comfortably, only some of them get a caption.
+func newBarChart(values []int64, labels, classes []string) *barChart {
+	var (
+		c = &barChart{
+			Width:    chartWidth,
+			Height:   chartHeight + chartCaptionSize,
+			Baseline: chartHeight,
+			Bars:     make([]chartBar, len(values)),
+		}
+		step = (len(values) + chartCaptionCnt - 1) / chartCaptionCnt
+	)
+
+	if len(values) == 0 {
+		return c
+	}
+
+	for _, v := range values {
+		if v > c.Max {
+			c.Max = v
+		}
+	}
+
+	var (
+		slot = float64(chartWidth-2*chartPadding) / float64(len(values))
+		gap  = slot / 10
+	)
+
+	for idx, v := range values {
+		var b = &c.Bars[idx]
+
+		b.Value = v
+		b.Label = labels[idx]
+		b.X = round(chartPadding + float64(idx)*slot + gap)
+		b.Width = round(slot - 2*gap)
+		b.CenterX = round(chartPadding + (float64(idx)+0.5)*slot)
+
+		if c.Max > 0 {
+			// Leave some room at the top for the label of the maximum.
+			b.Height = round(float64(v) * (chartHeight - 12) / float64(c.Max))
+		}
+
+		b.Y = round(chartHeight - b.Height)
+
+		if idx%step == 0 || idx == len(values)-1 {
+			b.Caption = labels[idx]
+		}
+
+		if classes != nil {
+			b.Class = classes[idx]
+		}
+	}
+
+	return c
+} // func newBarChart(values []int64, labels, classes []string) *barChart
+
+// countChart turns a series of Counts into a bar chart, labeling each bar
+// with the date its period starts on.
+func countChart(counts []feed.Count, dateFmt string) *barChart {
+	var (
+		values = make([]int64, len(counts))
+		labels = make([]string, len(counts))
+	)
+
+	for idx, c := range counts {
+		values[idx] = c.Cnt
+		labels[idx] = c.Start.Format(dateFmt)
+	}
+
+	return newBarChart(values, labels, nil)
+} // func countChart(counts []feed.Count, dateFmt string) *barChart
+
+func round(f float64) float64 {
+	return math.Round(f*10) / 10
+} // func round(f float64) float64
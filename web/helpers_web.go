@@ -12,7 +12,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 	"github.com/blicero/ticker/common"
+	"github.com/blicero/ticker/database"
 	"github.com/blicero/ticker/feed"
 )
 
@@ -32,12 +37,234 @@ func jsonEscape(i string) string {
 	return string(b[1 : len(b)-1])
 }
 
+// authFromForm collects the credentials for a Feed from the auth_* fields
+// of a form. Headers are given as "Name: Value", cookies and parameters as
+// "name=value", one per line.
+func authFromForm(r *http.Request) (*feed.Auth, error) {
+	var (
+		err  error
+		auth = &feed.Auth{
+			Username: r.FormValue("auth_username"),
+			Password: r.FormValue("auth_password"),
+			Token:    strings.TrimSpace(r.FormValue("auth_token")),
+		}
+	)
+
+	if auth.Headers, err = feed.ParseAuthPairs(r.FormValue("auth_headers"), ":"); err != nil {
+		return nil, fmt.Errorf("Invalid headers: %w", err)
+	} else if auth.Cookies, err = feed.ParseAuthPairs(r.FormValue("auth_cookies"), "="); err != nil {
+		return nil, fmt.Errorf("Invalid cookies: %w", err)
+	} else if auth.Params, err = feed.ParseAuthPairs(r.FormValue("auth_params"), "="); err != nil {
+		return nil, fmt.Errorf("Invalid parameters: %w", err)
+	}
+
+	return auth, nil
+} // func authFromForm(r *http.Request) (*feed.Auth, error)
+
+// scraperFromForm collects the selectors for a scraped Feed from the
+// scrape_* fields of a form. If no Item selector is given, the Feed is a
+// regular one, and scraperFromForm returns nil.
+func scraperFromForm(r *http.Request) (*feed.Scraper, error) {
+	var s = &feed.Scraper{
+		Item:    strings.TrimSpace(r.FormValue("scrape_item")),
+		Title:   strings.TrimSpace(r.FormValue("scrape_title")),
+		Link:    strings.TrimSpace(r.FormValue("scrape_link")),
+		Date:    strings.TrimSpace(r.FormValue("scrape_date")),
+		Summary: strings.TrimSpace(r.FormValue("scrape_summary")),
+	}
+
+	if s.IsEmpty() {
+		return nil, nil
+	} else if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+} // func scraperFromForm(r *http.Request) (*feed.Scraper, error)
+
+// ruleFromForm builds a Rule from the fields of the Rule form. The Rule is
+// not validated, that is up to the caller.
+func ruleFromForm(r *http.Request) (*feed.Rule, error) {
+	var (
+		err  error
+		rule = &feed.Rule{
+			Name:        strings.TrimSpace(r.FormValue("Name")),
+			Active:      r.FormValue("Active") == "true",
+			Title:       strings.TrimSpace(r.FormValue("Title")),
+			Description: strings.TrimSpace(r.FormValue("Description")),
+			Author:      strings.TrimSpace(r.FormValue("Author")),
+			Language:    strings.TrimSpace(r.FormValue("Language")),
+			Domain:      strings.TrimSpace(r.FormValue("Domain")),
+			MarkRead:    r.FormValue("MarkRead") == "true",
+			ReadLater:   r.FormValue("ReadLater") == "true",
+			Archive:     r.FormValue("Archive") == "true",
+			Drop:        r.FormValue("Drop") == "true",
+		}
+	)
+
+	for _, fld := range []struct {
+		name string
+		dst  *int64
+	}{
+		{"ID", &rule.ID},
+		{"Feed", &rule.FeedID},
+		{"Tag", &rule.TagID},
+	} {
+		var val = r.FormValue(fld.name)
+
+		if val == "" {
+			continue
+		} else if *fld.dst, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return nil, fmt.Errorf("Cannot parse %s %q: %w",
+				fld.name,
+				val,
+				err)
+		}
+	}
+
+	switch rating := r.FormValue("Rating"); rating {
+	case "":
+	case "1":
+		rule.Rate = true
+		rule.Rating = 1
+	case "0":
+		rule.Rate = true
+		rule.Rating = 0
+	default:
+		return nil, fmt.Errorf("Invalid rating %q", rating)
+	}
+
+	return rule, nil
+} // func ruleFromForm(r *http.Request) (*feed.Rule, error)
+
+// muteFromForm builds a Mute from the fields of the Mute form. Days is the
+// number of days until the Mute expires, if it is empty, the Mute never
+// expires.
+func muteFromForm(r *http.Request) (*feed.Mute, error) {
+	var (
+		err error
+		m   = &feed.Mute{
+			Pattern: strings.TrimSpace(r.FormValue("Pattern")),
+			Regex:   r.FormValue("Regex") == "true",
+			Created: time.Now(),
+		}
+	)
+
+	for _, fld := range []struct {
+		name string
+		dst  *int64
+	}{
+		{"Feed", &m.FeedID},
+		{"Group", &m.GroupID},
+	} {
+		var val = r.FormValue(fld.name)
+
+		if val == "" {
+			continue
+		} else if *fld.dst, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return nil, fmt.Errorf("Cannot parse %s %q: %w",
+				fld.name,
+				val,
+				err)
+		}
+	}
+
+	if daysStr := strings.TrimSpace(r.FormValue("Days")); daysStr != "" {
+		var days int64
+
+		if days, err = strconv.ParseInt(daysStr, 10, 64); err != nil || days < 1 {
+			return nil, fmt.Errorf("Invalid number of days: %q", daysStr)
+		}
+
+		m.Expires = m.Created.Add(feed.Day * time.Duration(days))
+	}
+
+	return m, nil
+} // func muteFromForm(r *http.Request) (*feed.Mute, error)
+
+// authSummary describes what kind of credentials are set, without giving
+// away the credentials themselves.
+func authSummary(a *feed.Auth) string {
+	if a.IsEmpty() {
+		return ""
+	}
+
+	return a.String()
+} // func authSummary(a *feed.Auth) string
+
+// isLocalPath returns true if s is a path on our own site, so we can
+// redirect the client there without sending it off to some other site.
+// Browsers treat a backslash like a slash, so "/\evil.example" is just as
+// much a reference to another host as "//evil.example".
+func isLocalPath(s string) bool {
+	var (
+		err error
+		u   *url.URL
+	)
+
+	if !strings.HasPrefix(s, "/") ||
+		strings.HasPrefix(s, "//") ||
+		strings.HasPrefix(s, "/\\") {
+		return false
+	} else if u, err = url.Parse(s); err != nil {
+		return false
+	}
+
+	return u.Scheme == "" && u.Host == ""
+} // func isLocalPath(s string) bool
+
 type itemList []feed.Item
 
 func (il itemList) Len() int           { return len(il) }
 func (il itemList) Less(i, j int) bool { return il[i].Timestamp.After(il[j].Timestamp) }
 func (il itemList) Swap(i, j int)      { il[i], il[j] = il[j], il[i] }
 
+// collapseDuplicates removes all but the first Item of each group of
+// near-duplicate stories from the list, and records on the remaining Item
+// which other Feeds carried the story as well.
+func collapseDuplicates(db *database.Database, items []feed.Item) ([]feed.Item, error) {
+	var (
+		err    error
+		groups []int64
+		feeds  map[int64][]int64
+		seen   = make(map[int64]bool)
+		done   = make(map[int64]bool)
+		list   = make([]feed.Item, 0, len(items))
+	)
+
+	for _, item := range items {
+		if item.DupGroup != 0 && !seen[item.DupGroup] {
+			seen[item.DupGroup] = true
+			groups = append(groups, item.DupGroup)
+		}
+	}
+
+	if feeds, err = db.ItemGetDuplicateFeedsByGroups(groups); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if item.DupGroup == 0 {
+			list = append(list, item)
+			continue
+		} else if done[item.DupGroup] {
+			continue
+		}
+
+		done[item.DupGroup] = true
+
+		for _, id := range feeds[item.DupGroup] {
+			if id != item.FeedID {
+				item.AlsoIn = append(item.AlsoIn, id)
+			}
+		}
+
+		list = append(list, item)
+	}
+
+	return list, nil
+} // func collapseDuplicates(db *database.Database, items []feed.Item) ([]feed.Item, error)
+
 // func getMimeType(path string) (string, error) {
 // 	var (
 // 		fh      *os.File
@@ -62,6 +289,27 @@ func (il itemList) Swap(i, j int)      { il[i], il[j] = il[j], il[i] }
 // }
 // func getMimeType(path string) (string, error)
 
+// groupSubtree returns the IDs of the given Group and all Groups below it.
+func groupSubtree(groups []feed.Group, root int64) map[int64]bool {
+	var (
+		ids     = map[int64]bool{root: true}
+		changed = true
+	)
+
+	// groups is usually sorted by hierarchy, but we don't rely on it.
+	for changed {
+		changed = false
+		for _, g := range groups {
+			if !ids[g.ID] && ids[g.Parent] && g.Parent != 0 {
+				ids[g.ID] = true
+				changed = true
+			}
+		}
+	}
+
+	return ids
+} // func groupSubtree(groups []feed.Group, root int64) map[int64]bool
+
 func (srv *Server) baseData(title string, r *http.Request) tmplDataBase {
 	return tmplDataBase{
 		Title:      title,
@@ -71,6 +319,123 @@ func (srv *Server) baseData(title string, r *http.Request) tmplDataBase {
 	}
 } // func (srv *Server) baseData(title string, r *http.Request) tmplDataBase
 
+// filterMuted removes the Items that match an active Mute applying to their
+// Feed. If show is true, the Items remain in the list, marked with the Mute
+// that hides them. In either case, it returns the number of muted Items.
+func filterMuted(db *database.Database, items []feed.Item, show bool) ([]feed.Item, int, error) {
+	var (
+		err    error
+		cnt    int
+		mutes  []feed.Mute
+		feeds  []feed.Feed
+		groups []feed.Group
+		scope  map[int64]map[int64]bool
+		list   = make([]feed.Item, 0, len(items))
+	)
+
+	if mutes, err = db.MuteGetActive(); err != nil {
+		return nil, 0, err
+	} else if len(mutes) == 0 {
+		return items, 0, nil
+	}
+
+	// For Mutes that apply to a Group, we need to know which Feeds are in
+	// it or one of its subgroups.
+	for _, m := range mutes {
+		if m.GroupID == 0 {
+			continue
+		} else if scope == nil {
+			if feeds, err = db.FeedGetAll(); err != nil {
+				return nil, 0, err
+			} else if groups, err = db.GroupGetAll(); err != nil {
+				return nil, 0, err
+			}
+
+			scope = make(map[int64]map[int64]bool)
+		}
+
+		var (
+			sub     = groupSubtree(groups, m.GroupID)
+			members = make(map[int64]bool)
+		)
+
+		for _, f := range feeds {
+			if f.GroupID != 0 && sub[f.GroupID] {
+				members[f.ID] = true
+			}
+		}
+
+		scope[m.ID] = members
+	}
+
+	for _, item := range items {
+		for idx := range mutes {
+			var m = &mutes[idx]
+
+			if m.FeedID != 0 && m.FeedID != item.FeedID {
+				continue
+			} else if m.GroupID != 0 && !scope[m.ID][item.FeedID] {
+				continue
+			} else if m.Match(&item) {
+				item.Muted = m
+				break
+			}
+		}
+
+		if item.Muted != nil {
+			cnt++
+			if !show {
+				continue
+			}
+		}
+
+		list = append(list, item)
+	}
+
+	return list, cnt, nil
+} // func filterMuted(db *database.Database, items []feed.Item, show bool) ([]feed.Item, int, error)
+
+// showMuted returns true if the client asked to see muted Items.
+func showMuted(r *http.Request) bool {
+	return r.FormValue("muted") == "show"
+} // func showMuted(r *http.Request) bool
+
+// unreadOnly returns true if an Item list should only show unread Items.
+// The client can ask for "only" or "all", otherwise def applies.
+func unreadOnly(r *http.Request, def bool) bool {
+	switch r.FormValue("unread") {
+	case "only":
+		return true
+	case "all":
+		return false
+	default:
+		return def
+	}
+} // func unreadOnly(r *http.Request, def bool) bool
+
+// filterUnread counts the unread Items in a list. If only is true, it
+// removes the Items that have been read. This is only good for lists that
+// hold all the Items there are, like search results, otherwise ask the
+// database for the unread Items and their number.
+func filterUnread(items []feed.Item, only bool) ([]feed.Item, int64) {
+	var (
+		cnt  int64
+		list = make([]feed.Item, 0, len(items))
+	)
+
+	for _, item := range items {
+		if !item.Read {
+			cnt++
+		} else if only {
+			continue
+		}
+
+		list = append(list, item)
+	}
+
+	return list, cnt
+} // func filterUnread(items []feed.Item, only bool) ([]feed.Item, int64)
+
 // Local Variables:  //
 // compile-command: "go generate && go vet && go build -v -p 16 && mygolint ticker/web && go test -v" //
 // End: //
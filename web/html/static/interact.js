@@ -748,6 +748,15 @@ function edit_feed (feed_id) {
     $('#form_name')[0].value = feed.name
     $('#form_homepage')[0].value = feed.homepage
     $('#form_interval')[0].value = feed.interval / 60
+    $('#form_adaptive')[0].checked = feed.adaptive
+    $('#form_interval_min')[0].value = feed.interval_min / 60
+    $('#form_interval_max')[0].value = feed.interval_max / 60
+    $('#form_full_text')[0].checked = feed.full_text
+    $('#form_auth_current')[0].innerText = feed.auth || 'none'
+    for (const fld of ['username', 'password', 'token', 'headers', 'cookies', 'params']) {
+        $(`#form_auth_${fld}`)[0].value = ''
+    }
+    $('#form_auth_clear')[0].checked = false
     // $("#form_active")[0].checked = feed.active;
     $('#form_id')[0].value = feed.id
     $(form_id).show()
@@ -761,19 +770,34 @@ function feed_form_submit () {
     const url = $('#form_url')[0].value
     const homepage = $('#form_homepage')[0].value
     const interval = $('#form_interval')[0].value
+    const adaptive = $('#form_adaptive')[0].checked
+    const intervalMin = $('#form_interval_min')[0].value
+    const intervalMax = $('#form_interval_max')[0].value
+    const fullText = $('#form_full_text')[0].checked
     // const active = $("#form_active")[0].checked;
 
+    const data = {
+        ID: id,
+        Name: name,
+        URL: url,
+        Homepage: homepage,
+        Interval: interval * 60,
+        Adaptive: adaptive,
+        IntervalMin: intervalMin * 60,
+        IntervalMax: intervalMax * 60,
+        FullText: fullText,
+        auth_clear: $('#form_auth_clear')[0].checked
+        // "Active": active,
+    }
+
+    for (const fld of ['username', 'password', 'token', 'headers', 'cookies', 'params']) {
+        data[`auth_${fld}`] = $(`#form_auth_${fld}`)[0].value
+    }
+
     const feed = feeds[id]
 
     const req = $.post('/ajax/feed_update',
-                       {
-                           ID: id,
-                           Name: name,
-                           URL: url,
-                           Homepage: homepage,
-                           Interval: interval * 60
-                           // "Active": active,
-                       },
+                       data,
                        function (reply) {
                            if (reply.Status) {
                                console.log(`Successfully updated Feed ${name}`)
@@ -786,7 +810,22 @@ function feed_form_submit () {
                                lnk.href = url
                                lnk.innerHTML = url
 
-                               $(`#interval_${id}`)[0].innerHTML = fmtDuration(interval * 60)
+                               feed.adaptive = adaptive
+                               feed.interval_min = intervalMin * 60
+                               feed.interval_max = intervalMax * 60
+                               feed.full_text = fullText
+                               feed.auth = reply.Auth
+
+                               $(`#full_text_${id}`)[0].innerHTML = fullText ? '<br />(full text)' : ''
+
+                               $(`#auth_${id}`)[0].innerText = reply.Auth ? `(credentials: ${reply.Auth})` : ''
+
+                               if (adaptive) {
+                                   $(`#interval_${id}`)[0].innerHTML = fmtDuration(interval * 60) +
+                                       '<br /><small>(adaptive, takes effect on next refresh)</small>'
+                               } else {
+                                   $(`#interval_${id}`)[0].innerHTML = fmtDuration(interval * 60)
+                               }
 
                                $('#feed_form').hide()
                            } else {
@@ -831,12 +870,15 @@ function toggle_feed_active (feed_id) {
 function display_tag_items (tag_id) {
     const url = `/ajax/items_by_tag/${tag_id}`
 
+    reload_items = () => { display_tag_items(tag_id) }
+
     const req1 = $.post(url,
-                        {},
+                        list_params(),
                         function (reply) {
                             if (reply.Status) {
                                 $('#item_div')[0].innerHTML = reply.Message
                                 shrink_images()
+                                init_mark_on_scroll()
                             } else {
                                 console.log(reply.Message)
                                 alert(reply.Message)
@@ -898,12 +940,15 @@ function load_feed_items (feed_id) {
     const div_id = '#item_div'
     const url = `/ajax/items_by_feed/${feed_id}`
 
+    reload_items = () => { load_feed_items(feed_id) }
+
     const req = $.get(url,
-                      {},
+                      list_params(),
                       function (reply) {
                           if (reply.Status) {
                               $('#item_div')[0].innerHTML = reply.Message
                               shrink_images()
+                              init_mark_on_scroll()
                           } else {
                               console.log(reply.Message)
                               alert(reply.Message)
@@ -916,6 +961,723 @@ function load_feed_items (feed_id) {
     })
 } // function load_feed_items(feed_id)
 
+function feed_stats_go () {
+    const id = $('#stats_feed')[0].value
+
+    if (id == 0) {
+        window.location = '/feed/stats'
+    } else {
+        window.location = `/feed/${id}/stats`
+    }
+
+    return false
+} // function feed_stats_go ()
+
+// Muted Items are hidden unless the URL of the page asks for them. The
+// setting is passed on to the Items we load via AJAX.
+function muted_param () {
+    const params = new URLSearchParams(window.location.search)
+
+    if (params.get('muted') == 'show') {
+        return { muted: 'show' }
+    }
+
+    return {}
+} // function muted_param ()
+
+// Likewise, the page's URL tells if we only want to see unread Items.
+function unread_param () {
+    const params = new URLSearchParams(window.location.search)
+    const val = params.get('unread')
+
+    if (val == 'only' || val == 'all') {
+        return { unread: val }
+    }
+
+    return {}
+} // function unread_param ()
+
+function list_params () {
+    return Object.assign(muted_param(), unread_param())
+} // function list_params ()
+
+// If the Items on display were loaded via AJAX, reload_items fetches them
+// again.
+let reload_items = null
+
+function toggle_unread (link, only) {
+    if (reload_items != null) {
+        const params = new URLSearchParams(window.location.search)
+        params.set('unread', only ? 'all' : 'only')
+        window.history.replaceState(null, '', `${window.location.pathname}?${params}`)
+        reload_items()
+        return
+    }
+
+    const addr = new URL(link.dataset.url, window.location.href)
+    addr.searchParams.set('unread', only ? 'all' : 'only')
+    window.location = addr.toString()
+} // function toggle_unread (link, only)
+
+function adjust_unread_cnt (delta) {
+    const span = $('#unread_cnt')[0]
+
+    if (span != undefined) {
+        span.innerText = Math.max(0, Number.parseInt(span.innerText) + delta)
+    }
+} // function adjust_unread_cnt (delta)
+
+function show_read (item_id, read) {
+    const row = $(`#item_${item_id}`)
+
+    if (row.hasClass('read') == read) {
+        return
+    } else if (read) {
+        row.addClass('read')
+        $(`#read_button_${item_id}`)[0].value = 'Mark unread'
+        adjust_unread_cnt(-1)
+    } else {
+        row.removeClass('read')
+        $(`#read_button_${item_id}`)[0].value = 'Mark read'
+        adjust_unread_cnt(1)
+    }
+} // function show_read (item_id, read)
+
+function toggle_read (item_id) {
+    const read = !$(`#item_${item_id}`).hasClass('read')
+    const url = `/ajax/item_set_read/${item_id}/${read}`
+
+    const req = $.get(url,
+                      {},
+                      (reply) => {
+                          if (reply.Status) {
+                              show_read(item_id, read)
+                          } else {
+                              console.error(reply.Message)
+                              alert(reply.Message)
+                          }
+                      },
+                      'json')
+
+    req.fail((reply, status_text, xhr) => {
+        console.error(`Error marking Item ${item_id}: ${status_text} - ${xhr}`)
+    })
+} // function toggle_read (item_id)
+
+function mark_items_read (ids) {
+    const req = $.post('/ajax/items_mark_read',
+                       { ids: ids.join(',') },
+                       (reply) => {
+                           if (reply.Status) {
+                               for (const id of ids) {
+                                   show_read(id, true)
+                               }
+                           } else {
+                               console.error(reply.Message)
+                           }
+                       },
+                       'json')
+
+    req.fail((reply, status_text, xhr) => {
+        console.error(`Error marking Items as read: ${status_text} - ${xhr}`)
+    })
+} // function mark_items_read (ids)
+
+// mark_all_read marks all Items of a Feed or Tag as read, if the server
+// gave us the address to do so, otherwise the Items on display.
+function mark_all_read (url) {
+    const rows = $('table.items tr[id^=item_]')
+    const ids = rows.map((idx, row) => Number.parseInt(row.id.substring(5))).get()
+
+    if (url == '') {
+        mark_items_read(ids)
+        return
+    }
+
+    const req = $.get(url,
+                      {},
+                      (reply) => {
+                          if (reply.Status) {
+                              for (const id of ids) {
+                                  show_read(id, true)
+                              }
+                              $('#unread_cnt').text('0')
+                          } else {
+                              console.error(reply.Message)
+                              alert(reply.Message)
+                          }
+                      },
+                      'json')
+
+    req.fail((reply, status_text, xhr) => {
+        console.error(`Error marking Items as read: ${status_text} - ${xhr}`)
+    })
+} // function mark_all_read (url)
+
+// If the user wants it, Items are marked as read once they have been
+// scrolled out of view at the top. We collect them for a moment and send
+// them to the server in one batch.
+let scroll_observer = null
+let scroll_queue = []
+let scroll_timer = null
+
+function init_mark_on_scroll () {
+    if (scroll_observer != null) {
+        scroll_observer.disconnect()
+        scroll_observer = null
+    }
+
+    if (!settings.items.markonscroll) {
+        return
+    }
+
+    scroll_observer = new IntersectionObserver((entries) => {
+        for (const e of entries) {
+            if (e.isIntersecting || e.boundingClientRect.bottom > e.rootBounds.top) {
+                continue
+            } else if (e.target.classList.contains('read')) {
+                continue
+            }
+
+            scroll_queue.push(Number.parseInt(e.target.id.substring(5)))
+            scroll_observer.unobserve(e.target)
+        }
+
+        if (scroll_queue.length > 0 && scroll_timer == null) {
+            scroll_timer = window.setTimeout(flush_mark_on_scroll, 2000)
+        }
+    })
+
+    $('table.items tr[id^=item_]').each((idx, row) => {
+        if (!row.classList.contains('read')) {
+            scroll_observer.observe(row)
+        }
+    })
+} // function init_mark_on_scroll ()
+
+function flush_mark_on_scroll () {
+    const ids = scroll_queue
+
+    scroll_queue = []
+    scroll_timer = null
+
+    if (ids.length > 0) {
+        mark_items_read(ids)
+    }
+} // function flush_mark_on_scroll ()
+
+function toggle_mark_on_scroll () {
+    settings.items.markonscroll = !settings.items.markonscroll
+    saveSetting('items', 'markonscroll', settings.items.markonscroll)
+    init_mark_on_scroll()
+
+    return true
+} // function toggle_mark_on_scroll ()
+
+function toggle_muted () {
+    const params = new URLSearchParams(window.location.search)
+
+    if (params.get('muted') == 'show') {
+        params.delete('muted')
+    } else {
+        params.set('muted', 'show')
+    }
+
+    window.location.search = params.toString()
+} // function toggle_muted ()
+
+function mute_add () {
+    const url = '/ajax/mute_add'
+    const form = $('#mute_form')
+
+    const req = $.post(url,
+                       form.serialize(),
+                       (reply) => {
+                           if (reply.Status) {
+                               window.location.reload()
+                           } else {
+                               const msg = `Error adding Mute: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error adding Mute: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function mute_add ()
+
+function mute_delete (mute_id) {
+    const url = `/ajax/mute_delete/${mute_id}`
+
+    const req = $.post(url,
+                       {},
+                       (reply) => {
+                           if (reply.Status) {
+                               $(`#mute_${mute_id}`).remove()
+                           } else {
+                               const msg = `Error deleting Mute: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error deleting Mute: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function mute_delete (mute_id)
+
+function load_feed_categories (feed_id) {
+    const url = `/ajax/feed_categories/${feed_id}`
+
+    const req = $.get(url,
+                      {},
+                      (reply) => {
+                          if (reply.Status) {
+                              $('#item_div')[0].innerHTML = reply.Message
+                          } else {
+                              console.log(reply.Message)
+                              alert(reply.Message)
+                          }
+                      },
+                      'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error getting categories of Feed ${feed_id}: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function load_feed_categories (feed_id)
+
+function scrape_preview () {
+    const url = '/ajax/scrape_preview'
+    const form = $('#subscribe_form')
+
+    if (form.find('[name=url]')[0].value.trim() == '' || $('#scrape_item')[0].value.trim() == '') {
+        alert('Please enter the URL of the page and a selector for the Items')
+        return
+    }
+
+    $('#scrape_preview')[0].innerHTML = '<em>Loading&hellip;</em>'
+
+    const req = $.post(url,
+                       form.serialize(),
+                       (reply) => {
+                           if (reply.Status) {
+                               $('#scrape_preview')[0].innerHTML = reply.Message
+                           } else {
+                               $('#scrape_preview')[0].innerHTML = ''
+                               console.error(reply.Message)
+                               alert(reply.Message)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        $('#scrape_preview')[0].innerHTML = ''
+        console.error(`Error getting preview: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function scrape_preview ()
+
+function category_tag_add (feed_id) {
+    const url = '/ajax/category_tag_add'
+    const category = $(`#category_name_${feed_id}`)[0].value.trim()
+    const tag_id = $(`#category_tag_${feed_id}`)[0].value
+
+    if (category == '') {
+        alert('Please enter a category')
+        return
+    }
+
+    const req = $.post(url,
+                       { Feed: feed_id, Category: category, Tag: tag_id },
+                       (reply) => {
+                           if (reply.Status) {
+                               load_feed_categories(feed_id)
+                           } else {
+                               const msg = `Error mapping category ${category}: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error mapping category ${category}: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function category_tag_add (feed_id)
+
+function category_tag_delete (map_id, feed_id) {
+    const url = `/ajax/category_tag_delete/${map_id}`
+
+    const req = $.post(url,
+                       {},
+                       (reply) => {
+                           if (reply.Status) {
+                               load_feed_categories(feed_id)
+                           } else {
+                               const msg = `Error deleting category mapping ${map_id}: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error deleting category mapping ${map_id}: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function category_tag_delete (map_id, feed_id)
+
+function retention_set (feed_id) {
+    const url = '/ajax/retention_set'
+    const days = $(`#retention_days_${feed_id}`)[0].value.trim()
+    const read_only = $(`#retention_read_${feed_id}`)[0].checked
+
+    if (days == '' || Number(days) < 1) {
+        alert('Please enter a number of days')
+        return
+    }
+
+    const req = $.post(url,
+                       { Feed: feed_id, Days: days, ReadOnly: read_only },
+                       (reply) => {
+                           if (reply.Status) {
+                               window.location.reload()
+                           } else {
+                               const msg = `Error setting Retention policy: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error setting Retention policy: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function retention_set (feed_id)
+
+function retention_delete (feed_id) {
+    const url = `/ajax/retention_delete/${feed_id}`
+
+    const req = $.post(url,
+                       {},
+                       (reply) => {
+                           if (reply.Status) {
+                               window.location.reload()
+                           } else {
+                               const msg = `Error deleting Retention policy: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error deleting Retention policy: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function retention_delete (feed_id)
+
+function retention_run () {
+    const url = '/ajax/retention_run'
+
+    if (!confirm('Delete all expired Items now?')) {
+        return
+    }
+
+    const req = $.post(url,
+                       {},
+                       (reply) => {
+                           if (reply.Status) {
+                               alert(reply.Message)
+                               window.location.reload()
+                           } else {
+                               const msg = `Error deleting expired Items: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error deleting expired Items: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function retention_run ()
+
+function group_create () {
+    const url = '/ajax/group_create'
+    const name = $('#group_name_0')[0].value.trim()
+    const parent = $('#group_parent_0')[0].value
+
+    if (name == '') {
+        alert('Please enter a name')
+        return
+    }
+
+    const req = $.post(url,
+                       { Name: name, Parent: parent },
+                       (reply) => {
+                           if (reply.Status) {
+                               window.location.reload()
+                           } else {
+                               const msg = `Error creating Group: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error creating Group: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function group_create ()
+
+function group_update (group_id) {
+    const url = '/ajax/group_update'
+    const name = $(`#group_name_${group_id}`)[0].value.trim()
+    const parent = $(`#group_parent_${group_id}`)[0].value
+
+    if (name == '') {
+        alert('Please enter a name')
+        return
+    }
+
+    const req = $.post(url,
+                       { ID: group_id, Name: name, Parent: parent },
+                       (reply) => {
+                           if (reply.Status) {
+                               window.location.reload()
+                           } else {
+                               const msg = `Error updating Group: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error updating Group: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function group_update (group_id)
+
+function group_delete (group_id) {
+    const url = `/ajax/group_delete/${group_id}`
+
+    if (!confirm('Delete Group? The Feeds in it are kept.')) {
+        return
+    }
+
+    const req = $.post(url,
+                       {},
+                       (reply) => {
+                           if (reply.Status) {
+                               window.location.reload()
+                           } else {
+                               const msg = `Error deleting Group: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error deleting Group: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function group_delete (group_id)
+
+function group_set_active (group_id, active) {
+    const url = `/ajax/group_set_active/${group_id}/${active}`
+
+    const req = $.post(url,
+                       {},
+                       (reply) => {
+                           if (reply.Status) {
+                               window.location.reload()
+                           } else {
+                               const msg = `Error setting active flag: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error setting active flag: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function group_set_active (group_id, active)
+
+function group_set_interval (group_id) {
+    const url = '/ajax/group_set_interval'
+    const minutes = $(`#group_interval_${group_id}`)[0].value.trim()
+
+    if (minutes == '' || Number(minutes) < 1) {
+        alert('Please enter the interval in minutes')
+        return
+    }
+
+    const req = $.post(url,
+                       { ID: group_id, Interval: minutes },
+                       (reply) => {
+                           if (reply.Status) {
+                               window.location.reload()
+                           } else {
+                               const msg = `Error setting refresh interval: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error setting refresh interval: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function group_set_interval (group_id)
+
+function feed_set_group (feed_id) {
+    const url = '/ajax/feed_set_group'
+    const group_id = $(`#feed_group_${feed_id}`)[0].value
+
+    const req = $.post(url,
+                       { Feed: feed_id, Group: group_id },
+                       (reply) => {
+                           if (!reply.Status) {
+                               const msg = `Error moving Feed to Group: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error moving Feed to Group: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function feed_set_group (feed_id)
+
+function rule_save () {
+    const url = '/ajax/rule_save'
+    const form = $('#rule_form')
+
+    const req = $.post(url,
+                       form.serialize(),
+                       (reply) => {
+                           if (reply.Status) {
+                               window.location.reload()
+                           } else {
+                               const msg = `Error saving Rule: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error saving Rule: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function rule_save ()
+
+function rule_edit (rule_id) {
+    const rule = rules[rule_id]
+
+    $('#rule_form_title')[0].innerText = `Edit Rule ${rule.name}`
+    $('#rule_id')[0].value = rule.id
+    $('#rule_name')[0].value = rule.name
+    $('#rule_active')[0].checked = rule.active
+    $('#rule_feed')[0].value = rule.feed
+    $('#rule_title')[0].value = rule.title
+    $('#rule_description')[0].value = rule.description
+    $('#rule_author')[0].value = rule.author
+    $('#rule_language')[0].value = rule.language
+    $('#rule_domain')[0].value = rule.domain
+    $('#rule_tag')[0].value = rule.tag
+    $('#rule_rating')[0].value = rule.rating
+    $('#rule_mark_read')[0].checked = rule.mark_read
+    $('#rule_read_later')[0].checked = rule.read_later
+    $('#rule_archive')[0].checked = rule.archive
+    $('#rule_drop')[0].checked = rule.drop
+    $('#rule_preview')[0].innerHTML = ''
+    $('#rule_name')[0].focus()
+} // function rule_edit (rule_id)
+
+function rule_form_reset () {
+    $('#rule_form')[0].reset()
+    $('#rule_id')[0].value = 0
+    $('#rule_form_title')[0].innerText = 'New Rule'
+    $('#rule_preview')[0].innerHTML = ''
+} // function rule_form_reset ()
+
+function rule_delete (rule_id) {
+    const url = `/ajax/rule_delete/${rule_id}`
+
+    if (!confirm(`Delete Rule ${rules[rule_id].name}?`)) {
+        return
+    }
+
+    const req = $.post(url,
+                       {},
+                       (reply) => {
+                           if (reply.Status) {
+                               $(`#rule_${rule_id}`).remove()
+                               delete rules[rule_id]
+                           } else {
+                               const msg = `Error deleting Rule: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error deleting Rule: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function rule_delete (rule_id)
+
+function rule_set_active (rule_id) {
+    const active = $(`#rule_active_${rule_id}`)[0].checked
+    const url = `/ajax/rule_set_active/${rule_id}/${active}`
+
+    const req = $.post(url,
+                       {},
+                       (reply) => {
+                           if (reply.Status) {
+                               rules[rule_id].active = active
+                           } else {
+                               const msg = `Error setting active flag: ${reply.Message}`
+                               console.error(msg)
+                               alert(msg)
+                               $(`#rule_active_${rule_id}`)[0].checked = !active
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error setting active flag: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function rule_set_active (rule_id)
+
+function rule_preview () {
+    const url = '/ajax/rule_preview'
+    const form = $('#rule_form')
+
+    $('#rule_preview')[0].innerHTML = '<em>Loading&hellip;</em>'
+
+    const req = $.post(url,
+                       form.serialize(),
+                       (reply) => {
+                           if (reply.Status) {
+                               $('#rule_preview')[0].innerHTML = reply.Message
+                           } else {
+                               $('#rule_preview')[0].innerHTML = ''
+                               console.error(reply.Message)
+                               alert(reply.Message)
+                           }
+                       },
+                       'json')
+
+    req.fail((rep, stat, xhr) => {
+        $('#rule_preview')[0].innerHTML = ''
+        console.error(`Error getting preview: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function rule_preview ()
+
 function shutdown_server () {
     const url = '/ajax/shutdown'
 
@@ -943,7 +1705,7 @@ function shutdown_server () {
 
 function items_go_page () {
     const idx = $('#choose_page')[0].value
-    const addr = `/items/${idx}`
+    const addr = `/items/${idx}${window.location.search}`
 
     window.location = addr
 } // function items_go_page()
@@ -1119,6 +1881,32 @@ function download_item (item_id) {
     }
 } // function download_item (item_id)
 
+function download_enclosure (enc_id) {
+    const url = `/ajax/enclosure_download/${enc_id}`
+    const span = $(`#enclosure_download_${enc_id}`)[0]
+
+    const req = $.post(
+        url,
+        {},
+        (reply) => {
+            if (reply.Status) {
+                // As with pages, the download runs in the background, so
+                // we cannot link to the local copy, yet.
+                span.innerHTML = '<small>Download queued</small>'
+            } else {
+                const msg = `Error requesting download of Enclosure ${enc_id}: ${reply.Message}`
+                console.error(msg)
+                alert(msg)
+            }
+        },
+        'json'
+    )
+
+    req.fail((rep, stat, xhr) => {
+        console.error(`Error requesting download of Enclosure ${enc_id}: ${rep} / ${stat} / ${xhr}`)
+    })
+} // function download_enclosure (enc_id)
+
 let dl_item_id = 0
 
 function load_archived_page (page_id) {
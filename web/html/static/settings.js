@@ -18,6 +18,7 @@ var settings = {
 
     "items": {
         "hideboring": false,
+        "markonscroll": false,
         "page": 50,
     },
 };
@@ -53,6 +54,9 @@ function initSettings() {
     settings.items.hideboring =
         JSON.parse(localStorage.getItem("items.hideboring")) ? true : false;
 
+    settings.items.markonscroll =
+        JSON.parse(localStorage.getItem("items.markonscroll")) ? true : false;
+
     item = JSON.parse(localStorage.getItem("items.page"));
     if (Number.isInteger(item)) {
         settings.items.page = item;
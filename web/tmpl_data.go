@@ -14,6 +14,7 @@ import (
 	"github.com/blicero/ticker/advisor"
 	"github.com/blicero/ticker/common"
 	"github.com/blicero/ticker/feed"
+	"github.com/blicero/ticker/retention"
 	"github.com/blicero/ticker/tag"
 	"time"
 
@@ -54,6 +55,11 @@ type tmplDataBase struct {
 	AllTags        []tag.Tag
 	TagHierarchy   []tag.Tag
 	TagSuggestions map[int64]map[string]advisor.SuggestedTag
+	ShowMuted      bool
+	MutedCnt       int
+	UnreadOnly     bool
+	UnreadCnt      int64
+	MarkReadURL    string
 }
 
 // TagLinkData returns data for use in the tag_link_form template.
@@ -69,6 +75,8 @@ type tmplDataIndex struct {
 	FeedMap map[int64]feed.Feed
 	Feeds   []feed.Feed
 	Items   []feed.Item
+	Groups  []feed.Group
+	Unread  map[int64]int64
 }
 
 type tmplDataItems struct {
@@ -115,6 +123,223 @@ type tmplDataReadLater struct {
 
 type tmplDataArchive tmplDataIndex
 
+type tmplDataRevisions struct {
+	tmplDataBase
+	Item      *feed.Item
+	Feed      *feed.Feed
+	Revisions []feed.Revision
+}
+
+type tmplDataCategories struct {
+	tmplDataBase
+	Feed       *feed.Feed
+	Categories []feed.Category
+	Mappings   []feed.CategoryTag
+}
+
+// TagName returns the name of the Tag with the given ID.
+func (d *tmplDataCategories) TagName(id int64) string {
+	for _, t := range d.AllTags {
+		if t.ID == id {
+			return t.Name
+		}
+	}
+
+	return fmt.Sprintf("#%d", id)
+} // func (d *tmplDataCategories) TagName(id int64) string
+
+type tmplDataRetention struct {
+	tmplDataBase
+	Feeds    []feed.Feed
+	Policies []feed.Retention
+	Report   *retention.Report
+}
+
+// Global returns the global Retention policy, or nil if there is none.
+func (d *tmplDataRetention) Global() *feed.Retention {
+	return d.Policy(0)
+} // func (d *tmplDataRetention) Global() *feed.Retention
+
+// Policy returns the Retention policy set for the given Feed, or nil if the
+// Feed has none of its own.
+func (d *tmplDataRetention) Policy(feedID int64) *feed.Retention {
+	for i := range d.Policies {
+		if d.Policies[i].FeedID == feedID {
+			return &d.Policies[i]
+		}
+	}
+
+	return nil
+} // func (d *tmplDataRetention) Policy(feedID int64) *feed.Retention
+
+type tmplDataGroups struct {
+	tmplDataBase
+	Groups []feed.Group
+	Feeds  []feed.Feed
+	Unread map[int64]int64
+}
+
+// Members returns the Feeds that belong directly to the given Group.
+func (d *tmplDataGroups) Members(groupID int64) []feed.Feed {
+	var feeds []feed.Feed
+
+	for _, f := range d.Feeds {
+		if f.GroupID == groupID {
+			feeds = append(feeds, f)
+		}
+	}
+
+	return feeds
+} // func (d *tmplDataGroups) Members(groupID int64) []feed.Feed
+
+type tmplDataGroupDetails struct {
+	tmplDataBase
+	Group   *feed.Group
+	Groups  []feed.Group
+	Feeds   []feed.Feed
+	Unread  int64
+	Items   []feed.Item
+	FeedMap map[int64]feed.Feed
+}
+
+type tmplDataRules struct {
+	tmplDataBase
+	Rules     []feed.Rule
+	Feeds     []feed.Feed
+	Tags      []tag.Tag
+	Languages []string
+}
+
+// FeedName returns the name of the Feed with the given ID.
+func (d *tmplDataRules) FeedName(id int64) string {
+	for _, f := range d.Feeds {
+		if f.ID == id {
+			return f.Name
+		}
+	}
+
+	return fmt.Sprintf("Feed %d", id)
+} // func (d *tmplDataRules) FeedName(id int64) string
+
+// TagName returns the name of the Tag with the given ID.
+func (d *tmplDataRules) TagName(id int64) string {
+	for _, t := range d.Tags {
+		if t.ID == id {
+			return t.Name
+		}
+	}
+
+	return fmt.Sprintf("Tag %d", id)
+} // func (d *tmplDataRules) TagName(id int64) string
+
+type tmplDataMutes struct {
+	tmplDataBase
+	Mutes  []feed.Mute
+	Feeds  []feed.Feed
+	Groups []feed.Group
+}
+
+// Scope describes which Feeds a Mute applies to.
+func (d *tmplDataMutes) Scope(m feed.Mute) string {
+	if m.FeedID != 0 {
+		for _, f := range d.Feeds {
+			if f.ID == m.FeedID {
+				return "Feed " + f.Name
+			}
+		}
+	} else if m.GroupID != 0 {
+		for _, g := range d.Groups {
+			if g.ID == m.GroupID {
+				return "Group " + g.Path
+			}
+		}
+	}
+
+	return "All Feeds"
+} // func (d *tmplDataMutes) Scope(m feed.Mute) string
+
+type tmplDataRulePreview struct {
+	tmplDataBase
+	Rule  *feed.Rule
+	Total int
+	Items []feed.Item
+}
+
+type tmplDataScrapePreview struct {
+	tmplDataBase
+	Page    string
+	Scraper *feed.Scraper
+	Items   []feed.Item
+}
+
+type tmplDataDiscover struct {
+	tmplDataBase
+	Page       string
+	Candidates []feed.Candidate
+	Error      string
+}
+
+type tmplDataFeedPreview struct {
+	tmplDataBase
+	Preview *feed.Preview
+	Name    string
+	Carry   map[string]string
+	Error   string
+}
+
+// IntervalSeconds returns the refresh interval suggested by the Preview
+// in seconds, or the default of 15 minutes.
+func (d *tmplDataFeedPreview) IntervalSeconds() int64 {
+	if d.Preview == nil || d.Preview.Interval == 0 {
+		return 900
+	}
+
+	return int64(d.Preview.Interval.Seconds())
+} // func (d *tmplDataFeedPreview) IntervalSeconds() int64
+
+// FeedName returns the name the user entered, or the Feed's title.
+func (d *tmplDataFeedPreview) FeedName() string {
+	if d.Name != "" || d.Preview == nil {
+		return d.Name
+	}
+
+	return d.Preview.Title
+} // func (d *tmplDataFeedPreview) FeedName() string
+
+// tmplDataFeedStats is used for the statistics of a single Feed and of all
+// Feeds combined, in which case Feed is nil and Overview has a summary for
+// each Feed. ClsGood and ClsBad are the classifier's verdicts on a sample
+// of recent Items the user has not rated.
+type tmplDataFeedStats struct {
+	tmplDataBase
+	Feed     *feed.Feed
+	Feeds    []feed.Feed
+	Stats    *feed.Stats
+	Overview map[int64]*feed.Stats
+	PerDay   *barChart
+	PerWeek  *barChart
+	Ratings  *barChart
+	Verdicts *barChart
+	ClsGood  int64
+	ClsBad   int64
+	ClsError string
+}
+
+// ClsCnt returns the number of Items the classifier has rated.
+func (d *tmplDataFeedStats) ClsCnt() int64 {
+	return d.ClsGood + d.ClsBad
+} // func (d *tmplDataFeedStats) ClsCnt() int64
+
+// ClsShare returns the percentage of Items the classifier considers
+// interesting.
+func (d *tmplDataFeedStats) ClsShare() float64 {
+	if d.ClsCnt() == 0 {
+		return 0
+	}
+
+	return float64(d.ClsGood) * 100 / float64(d.ClsCnt())
+} // func (d *tmplDataFeedStats) ClsShare() float64
+
 func (d *tmplDataArchive) GetFeed(id int64) *feed.Feed {
 	if f, ok := d.FeedMap[id]; ok {
 		return &f
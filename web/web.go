@@ -17,6 +17,7 @@ import (
 	"log"
 	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -34,8 +35,12 @@ import (
 	"github.com/blicero/ticker/download"
 	"github.com/blicero/ticker/feed"
 	"github.com/blicero/ticker/logdomain"
+	"github.com/blicero/ticker/opml"
+	"github.com/blicero/ticker/reader"
+	"github.com/blicero/ticker/retention"
 	"github.com/blicero/ticker/search"
 	"github.com/blicero/ticker/tag"
+	"github.com/blicero/ticker/websub"
 
 	"github.com/blicero/krylib"
 	"github.com/pquerna/ffjson/ffjson"
@@ -68,6 +73,7 @@ type Server struct {
 	clsTags   *advisor.Advisor
 	clsStamp  time.Time
 	clsLock   sync.RWMutex
+	reader    *reader.Reader
 }
 
 // Create creates a new Server instance.
@@ -176,6 +182,13 @@ func Create(addr string, keepAlive bool) (*Server, error) {
 	srv.router.HandleFunc("/feed/all", srv.handleFeedAll)
 	srv.router.HandleFunc("/feed/form", srv.handleFeedForm)
 	srv.router.HandleFunc("/feed/subscribe", srv.handleFeedSubscribe)
+	srv.router.HandleFunc("/feed/discover", srv.handleFeedDiscover)
+	srv.router.HandleFunc("/feed/preview", srv.handleFeedPreview)
+	srv.router.HandleFunc("/feed/stats", srv.handleFeedStats)
+	srv.router.HandleFunc("/feed/{id:(?:\\d+)}/stats", srv.handleFeedStats)
+	srv.router.HandleFunc("/opml/export", srv.handleOPMLExport)
+	srv.router.HandleFunc("/opml/import", srv.handleOPMLImport)
+	srv.router.HandleFunc(websub.CallbackPrefix+"{id:(?:\\d+)$}", srv.handleWebSub)
 
 	srv.router.HandleFunc("/items/{page:(?:\\d+|all)$}", srv.handleItems)
 
@@ -188,10 +201,18 @@ func Create(addr string, keepAlive bool) (*Server, error) {
 
 	srv.router.HandleFunc("/later/all", srv.handleReadLaterAll)
 
+	srv.router.HandleFunc("/item/{id:(?:\\d+)}/revisions", srv.handleItemRevisions)
+
 	srv.router.HandleFunc("/classifier/train", srv.handleClassifierTrain)
 
 	srv.router.HandleFunc("/archive/{path:(?:.*)$}", srv.handleArchivedFile)
 	srv.router.HandleFunc("/archive", srv.handleArchive)
+	srv.router.HandleFunc("/retention", srv.handleRetention)
+	srv.router.HandleFunc("/group/all", srv.handleGroupAll)
+	srv.router.HandleFunc("/group/{id:(?:\\d+)$}", srv.handleGroupDetails)
+	srv.router.HandleFunc("/rule/all", srv.handleRuleAll)
+	srv.router.HandleFunc("/mute/all", srv.handleMuteAll)
+	srv.router.HandleFunc("/enclosure/{id:(?:\\d+)$}", srv.handleEnclosureFile)
 
 	srv.router.HandleFunc("/ajax/beacon", srv.handleBeacon)
 	srv.router.HandleFunc("/ajax/get_messages", srv.handleGetNewMessages)
@@ -208,9 +229,33 @@ func Create(addr string, keepAlive bool) (*Server, error) {
 	srv.router.HandleFunc("/ajax/feed_set_active/{id:(?:\\d+)}/{active:(?:true|false)$}", srv.handleFeedActiveToggle)
 	srv.router.HandleFunc("/ajax/items_by_tag/{id:(?:\\d+)$}", srv.handleItemsByTag)
 	srv.router.HandleFunc("/ajax/items_by_feed/{id:(?:\\d+)$}", srv.handleItemsByFeed)
+	srv.router.HandleFunc("/ajax/feed_categories/{id:(?:\\d+)$}", srv.handleFeedCategories)
+	srv.router.HandleFunc("/ajax/scrape_preview", srv.handleScrapePreview)
+	srv.router.HandleFunc("/ajax/category_tag_add", srv.handleCategoryTagAdd)
+	srv.router.HandleFunc("/ajax/category_tag_delete/{id:(?:\\d+)$}", srv.handleCategoryTagDelete)
+	srv.router.HandleFunc("/ajax/retention_set", srv.handleRetentionSet)
+	srv.router.HandleFunc("/ajax/retention_delete/{id:(?:\\d+)$}", srv.handleRetentionDelete)
+	srv.router.HandleFunc("/ajax/retention_run", srv.handleRetentionRun)
+	srv.router.HandleFunc("/ajax/group_create", srv.handleGroupCreate)
+	srv.router.HandleFunc("/ajax/group_update", srv.handleGroupUpdate)
+	srv.router.HandleFunc("/ajax/group_delete/{id:(?:\\d+)$}", srv.handleGroupDelete)
+	srv.router.HandleFunc("/ajax/group_set_active/{id:(?:\\d+)}/{active:(?:true|false)$}", srv.handleGroupSetActive)
+	srv.router.HandleFunc("/ajax/group_set_interval", srv.handleGroupSetInterval)
+	srv.router.HandleFunc("/ajax/feed_set_group", srv.handleFeedSetGroup)
+	srv.router.HandleFunc("/ajax/rule_save", srv.handleRuleSave)
+	srv.router.HandleFunc("/ajax/rule_delete/{id:(?:\\d+)$}", srv.handleRuleDelete)
+	srv.router.HandleFunc("/ajax/rule_set_active/{id:(?:\\d+)}/{active:(?:true|false)$}", srv.handleRuleSetActive)
+	srv.router.HandleFunc("/ajax/rule_preview", srv.handleRulePreview)
+	srv.router.HandleFunc("/ajax/mute_add", srv.handleMuteAdd)
+	srv.router.HandleFunc("/ajax/mute_delete/{id:(?:\\d+)$}", srv.handleMuteDelete)
+	srv.router.HandleFunc("/ajax/item_set_read/{id:(?:\\d+)}/{read:(?:true|false)$}", srv.handleItemSetRead)
+	srv.router.HandleFunc("/ajax/items_mark_read", srv.handleItemsMarkRead)
+	srv.router.HandleFunc("/ajax/feed_mark_read/{id:(?:\\d+)$}", srv.handleFeedMarkRead)
+	srv.router.HandleFunc("/ajax/tag_mark_read/{id:(?:\\d+)$}", srv.handleTagMarkRead)
 
 	// srv.router.HandleFunc("/ajax/download_item", srv.handleItemDownload)
 	srv.router.HandleFunc("/ajax/archive_delete/{id:(?:\\d+)$}", srv.handleArchiveDelete)
+	srv.router.HandleFunc("/ajax/enclosure_download/{id:(?:\\d+)$}", srv.handleEnclosureDownload)
 
 	srv.router.HandleFunc("/ajax/shutdown", srv.handleShutdown)
 
@@ -252,6 +297,18 @@ func (srv *Server) SendMessage(msg string) {
 	}
 } // func (srv *Server) SendMessage(msg string)
 
+// SetReader tells the Server which Reader to hand Items to that are pushed
+// to us by WebSub hubs.
+func (srv *Server) SetReader(r *reader.Reader) {
+	srv.reader = r
+} // func (srv *Server) SetReader(r *reader.Reader)
+
+// ArchiveQueue returns the queue through which the Server's download Agent
+// receives the Items whose pages it should archive.
+func (srv *Server) ArchiveQueue() chan<- *feed.Item {
+	return srv.agent.PageQ
+} // func (srv *Server) ArchiveQueue() chan<- *feed.Item
+
 // Close shuts down the server.
 func (srv *Server) Close() error {
 	var err error
@@ -339,6 +396,8 @@ func (srv *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 				Debug:      common.Debug,
 				URL:        r.URL.String(),
 				TrainStamp: srv.trainStamp(),
+				ShowMuted:  showMuted(r),
+				UnreadOnly: unreadOnly(r, true),
 			},
 		}
 	)
@@ -353,6 +412,12 @@ func (srv *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
+	var getRecent = db.ItemGetRecent
+
+	if data.UnreadOnly {
+		getRecent = db.ItemGetRecentUnread
+	}
+
 	if data.Feeds, err = db.FeedGetAll(); err != nil {
 		msg = fmt.Sprintf("Cannot query all Feeds: %s",
 			err.Error())
@@ -373,12 +438,30 @@ func (srv *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
-	} else if data.Items, err = db.ItemGetRecent(recentCnt); err != nil {
+	} else if data.UnreadCnt, err = db.ItemGetUnreadCnt(); err != nil {
+		msg = fmt.Sprintf("Cannot count unread Items: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if data.Items, err = getRecent(recentCnt); err != nil {
 		msg = fmt.Sprintf("Cannot query all Items: %s",
 			err.Error())
 		srv.log.Printf("[ERROR] %s\n", msg)
 		srv.sendErrorMessage(w, msg)
 		return
+	} else if data.Items, err = collapseDuplicates(db, data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot look up duplicate Items: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if data.Items, data.MutedCnt, err = filterMuted(db, data.Items, data.ShowMuted); err != nil {
+		msg = fmt.Sprintf("Cannot apply Mutes: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
 	} else if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
 		msg = fmt.Sprintf("Cannot generate suggestions: %s\n",
 			err.Error())
@@ -445,6 +528,18 @@ func (srv *Server) handleFeedAll(w http.ResponseWriter, r *http.Request) {
 		srv.log.Printf("[ERROR] %s\n", msg)
 		srv.sendErrorMessage(w, msg)
 		return
+	} else if data.Groups, err = db.GroupGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Groups: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if data.Unread, err = db.FeedGetUnread(); err != nil {
+		msg = fmt.Sprintf("Cannot count unread Items: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
 	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
 		msg = fmt.Sprintf("Cannot load all Tags: %s",
 			err.Error())
@@ -535,7 +630,11 @@ func (srv *Server) handleFeedSubscribe(w http.ResponseWriter, r *http.Request) {
 		err       error
 		msg, iStr string
 		f         feed.Feed
+		existing  *feed.Feed
 		interval  int64
+		auth      *feed.Auth
+		scraper   *feed.Scraper
+		fullText  bool
 		db        *database.Database
 	)
 
@@ -566,12 +665,92 @@ func (srv *Server) handleFeedSubscribe(w http.ResponseWriter, r *http.Request) {
 	}
 
 	f.Interval = time.Second * time.Duration(interval)
+	fullText, _ = strconv.ParseBool(r.FormValue("full_text"))
+
+	if auth, err = authFromForm(r); err != nil {
+		msg = fmt.Sprintf("Cannot parse credentials for Feed %s: %s",
+			f.Name,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/index", http.StatusFound)
+		return
+	} else if scraper, err = scraperFromForm(r); err != nil {
+		msg = fmt.Sprintf("Cannot parse selectors for Feed %s: %s",
+			f.Name,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/index", http.StatusFound)
+		return
+	} else if existing, err = db.FeedGetByURL(f.URL); err != nil {
+		msg = fmt.Sprintf("Cannot look up Feed %s: %s",
+			f.URL,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/index", http.StatusFound)
+		return
+	} else if existing != nil {
+		msg = fmt.Sprintf("You are already subscribed to %s as %s (%s)",
+			f.URL,
+			existing.Name,
+			existing.URL)
+		srv.log.Println("[INFO] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/feed/all", http.StatusFound)
+		return
+	} else if err = db.Begin(); err != nil {
+		msg = fmt.Sprintf("Cannot start transaction: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/index", http.StatusFound)
+		return
+	}
 
+	// The Feed and its settings are stored in one transaction, otherwise
+	// we might end up fetching a Feed without its credentials.
 	if err = db.FeedAdd(&f); err != nil {
 		msg = fmt.Sprintf("Cannot add Feed %s (%s): %s",
 			f.Name,
 			f.URL,
 			err.Error())
+	}
+
+	if err == nil && !auth.IsEmpty() {
+		if err = db.FeedSetAuth(&f, auth); err != nil {
+			msg = fmt.Sprintf("Cannot store credentials for Feed %s: %s",
+				f.Name,
+				err.Error())
+		}
+	}
+
+	if err == nil && scraper != nil {
+		if err = db.FeedSetScraper(&f, scraper); err != nil {
+			msg = fmt.Sprintf("Cannot store selectors for Feed %s: %s",
+				f.Name,
+				err.Error())
+		}
+	}
+
+	if err == nil && fullText {
+		if err = db.FeedSetFullText(&f, true); err != nil {
+			msg = fmt.Sprintf("Cannot enable full text for Feed %s: %s",
+				f.Name,
+				err.Error())
+		}
+	}
+
+	if err != nil {
+		db.Rollback() // nolint: errcheck
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/index", http.StatusFound)
+		return
+	} else if err = db.Commit(); err != nil {
+		msg = fmt.Sprintf("Cannot commit transaction: %s",
+			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
 		http.Redirect(w, r, "/index", http.StatusFound)
@@ -580,168 +759,74 @@ func (srv *Server) handleFeedSubscribe(w http.ResponseWriter, r *http.Request) {
 
 	//var dstURL = fmt.Sprintf("/feed/%d", f.ID)
 
+	// The discovery page sends us back to the list of Feeds, since going
+	// back to it would repeat the discovery.
+	if ret := r.FormValue("return"); isLocalPath(ret) {
+		http.Redirect(w, r, ret, http.StatusFound)
+		return
+	}
+
 	http.Redirect(w, r, r.Referer(), http.StatusFound)
 } // func (srv *Server) handleFeedSubscribe(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleItems(w http.ResponseWriter, r *http.Request) {
-	srv.log.Printf("[TRACE] Handle request for %s\n",
-		r.URL.EscapedPath())
+func (srv *Server) handleFeedDiscover(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
 
 	const (
-		tmplName = "all_items"
-		itemCnt  = 50
+		tmplName = "feed_discover"
 	)
 
 	var (
-		err                                       error
-		msg, pageSpec                             string
-		pageNo, cnt, offset, totalCnt, totalPages int64
-		db                                        *database.Database
-		tmpl                                      *template.Template
-		data                                      = tmplDataItems{
+		err  error
+		msg  string
+		db   *database.Database
+		tmpl *template.Template
+		data = tmplDataDiscover{
 			tmplDataBase: tmplDataBase{
+				Title:      "Discover Feeds",
 				Debug:      common.Debug,
 				URL:        r.URL.String(),
 				TrainStamp: srv.trainStamp(),
 			},
+			Page: strings.TrimSpace(r.FormValue("url")),
 		}
 	)
 
-	vars := mux.Vars(r)
-	pageSpec = vars["page"]
-
-	if pageSpec == "all" {
-		pageNo = -1
-	} else if pageNo, err = strconv.ParseInt(pageSpec, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse page number %q: %s",
-			pageSpec,
-			err.Error())
-		srv.log.Printf("[CANTHAPPEN] %s\n", msg)
+	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Could not find template %q", tmplName)
+		srv.log.Println("[CRITICAL] " + msg)
 		srv.sendErrorMessage(w, msg)
 		return
 	}
 
-	if pageNo != -1 {
-		data.Title = fmt.Sprintf("Items page %d", pageNo+1)
-		cnt = itemCnt
-		offset = itemCnt * pageNo
-	} else {
-		data.Title = "All Items"
-		cnt = -1
-	}
-
-	if pageNo > 0 {
-		data.Prev = strconv.FormatInt(pageNo-1, 10)
-	}
-
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if totalCnt, err = db.ItemGetTotalCnt(); err != nil {
-		msg = fmt.Sprintf("Cannot get total number of items from database: %s",
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, "/index", http.StatusFound)
-		return
-	} else if data.Items, err = db.ItemGetAll(cnt, offset); err != nil {
-		msg = fmt.Sprintf("Cannot load Items (%d / offset %d) from database: %s",
-			itemCnt,
-			offset,
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, "/index", http.StatusFound)
-		return
-	} else if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
-		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
-			err.Error())
-		srv.log.Printf("[ERROR] %s\n", msg)
-		srv.sendErrorMessage(w, msg)
-		return
-	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load all Tags: %s",
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
-		return
-	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
+	if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
 		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
 			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
-	} else if data.FeedMap, err = db.FeedGetMap(); err != nil {
-		msg = fmt.Sprintf("Cannot get all Feeds: %s",
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
-		return
-	} else if pageNo != -1 && len(data.Items) == itemCnt {
-		data.Next = strconv.FormatInt(pageNo+1, 10)
-	}
-
-	if cnt > 0 {
-		totalPages = totalCnt / cnt
-		data.PageCnt = totalPages
 	}
 
-	srv.clsLock.RLock()
-	defer srv.clsLock.RUnlock()
-
-	for idx, item := range data.Items {
-		var class string
-
-		if !math.IsNaN(item.Rating) {
-			if item.Rating == 1 {
-				data.Items[idx].Rating = math.Inf(1)
-			} else if item.Rating == 0 {
-				data.Items[idx].Rating = math.Inf(-1)
-			} else {
-				msg = fmt.Sprintf("Unexpected Rating for Item %s (%d): %f",
-					item.Title,
-					item.ID,
-					item.Rating)
-				srv.log.Println("[ERROR] " + msg)
-				srv.SendMessage(msg)
-				http.Redirect(w, r, r.Referer(), http.StatusFound)
-				return
-			}
-
-			// continue
-		} else if class, err = srv.clsItem.Classify(&item); err != nil {
-			srv.log.Printf("[ERROR] Cannot classify Item %s (%d): %s\n",
-				item.Title,
-				item.ID,
+	if data.Page != "" {
+		if data.Candidates, err = feed.Discover(data.Page); err != nil {
+			data.Error = fmt.Sprintf("Cannot look for Feeds at %s: %s",
+				data.Page,
 				err.Error())
-		} else if class == classifier.Good {
-			data.Items[idx].Rating = 100
-		} else if class == classifier.Bad {
-			data.Items[idx].Rating = -100
-		} else {
-			srv.log.Printf("[ERROR] Could not find classification for Item %d (%s)\n",
-				item.ID,
-				item.Title)
-			// srv.log.Printf("[ERROR] Unexpected classification for Item %s (%d): %s\n",
-			// 	item.Title,
-			// 	item.ID,
-			// 	err.Error())
+			srv.log.Printf("[ERROR] %s\n", data.Error)
+		} else if len(data.Candidates) == 0 {
+			data.Error = fmt.Sprintf("No Feeds were found at %s", data.Page)
 		}
 	}
 
-	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
-		msg = fmt.Sprintf("Could not find template %q", tmplName)
-		srv.log.Println("[CRITICAL] " + msg)
-		srv.sendErrorMessage(w, msg)
-		return
-	}
-
 	data.Messages = srv.getMessages()
 
-	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Cache-Control", "no-store, max-age=0")
 	if err = tmpl.Execute(w, &data); err != nil {
 		msg = fmt.Sprintf("Error rendering template %q: %s",
 			tmplName,
@@ -749,115 +834,81 @@ func (srv *Server) handleItems(w http.ResponseWriter, r *http.Request) {
 		srv.SendMessage(msg)
 		srv.sendErrorMessage(w, msg)
 	}
-} // func (srv *Server) handleItems(w http.ResponseWriter, r *http.Request)
+} // func (srv *Server) handleFeedDiscover(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
-	srv.log.Printf("[TRACE] Handle request for %s\n",
-		r.URL.EscapedPath())
+// handleFeedPreview fetches and parses a Feed without subscribing to it, so
+// the user can see if it works before it ends up in the database.
+func (srv *Server) handleFeedPreview(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
 
 	const (
-		tmplName = "all_items"
-		itemCnt  = 50
+		tmplName = "feed_preview"
 	)
 
 	var (
-		err       error
-		msg, qstr string
-		q         *search.Query
-		db        *database.Database
-		tmpl      *template.Template
-		data      = tmplDataItems{
-			tmplDataBase: tmplDataBase{
-				Title:      "Main",
-				Debug:      common.Debug,
-				URL:        r.URL.String(),
-				TrainStamp: srv.trainStamp(),
-			},
+		err  error
+		msg  string
+		addr string
+		auth *feed.Auth
+		tmpl *template.Template
+		data = tmplDataFeedPreview{
+			tmplDataBase: srv.baseData("Preview Feed", r),
+			Carry:        make(map[string]string),
 		}
 	)
 
-	if err = r.ParseForm(); err != nil {
+	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Could not find template %q", tmplName)
+		srv.log.Println("[CRITICAL] " + msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if err = r.ParseForm(); err != nil {
 		msg = fmt.Sprintf("Could not parse form data: %s", err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, "/index", http.StatusFound)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
 	}
 
-	qstr = r.FormValue("query")
+	addr = strings.TrimSpace(r.FormValue("url"))
+	data.Name = strings.TrimSpace(r.FormValue("name"))
+
+	// The credentials and options are not part of the Preview, but we
+	// pass them on to the subscribe form.
+	for _, k := range []string{
+		"auth_username",
+		"auth_password",
+		"auth_token",
+		"auth_headers",
+		"auth_cookies",
+		"auth_params",
+		"full_text",
+	} {
+		if v := r.FormValue(k); v != "" {
+			data.Carry[k] = v
+		}
+	}
 
-	srv.log.Printf("[TRACE] Receive query for %q\n",
-		qstr)
+	if addr == "" {
+		data.Error = "No URL was given"
+	} else if auth, err = authFromForm(r); err != nil {
+		data.Error = fmt.Sprintf("Cannot parse credentials: %s",
+			err.Error())
+	} else if data.Preview, err = feed.FetchPreview(addr, auth); err != nil {
+		data.Error = fmt.Sprintf("Cannot read Feed at %s: %s",
+			addr,
+			err.Error())
+	}
 
-	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
-		msg = fmt.Sprintf("Could not find template %q", tmplName)
-		srv.log.Println("[CRITICAL] " + msg)
-		srv.sendErrorMessage(w, msg)
-		return
-	}
-
-	db = srv.pool.Get()
-	defer srv.pool.Put(db)
-
-	if q, err = search.ParseQueryStr(db, qstr); err != nil {
-		msg = fmt.Sprintf("Cannot process search query %q: %s\n",
-			qstr,
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.sendErrorMessage(w, msg)
-		return
-	}
-
-	var feeds []feed.Feed
-
-	if feeds, err = db.FeedGetAll(); err != nil {
-		msg = fmt.Sprintf("Cannot get all Feeds: %s",
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, "/index", http.StatusFound)
-		return
-	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
-		return
-	}
-
-	data.FeedMap = make(map[int64]feed.Feed, len(feeds))
-
-	for _, f := range feeds {
-		data.FeedMap[f.ID] = f
-	}
-
-	if data.Items, err = q.Execute(); err != nil {
-		msg = fmt.Sprintf("Cannot search database for %q: %s",
-			qstr,
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, "/index", http.StatusFound)
-		return
-	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load all Tags: %s",
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
-		return
-	} else if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
-		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
-			err.Error())
-		srv.log.Printf("[ERROR] %s\n", msg)
-		srv.sendErrorMessage(w, msg)
-		return
+	if data.Error != "" {
+		srv.log.Printf("[ERROR] %s\n", data.Error)
 	}
 
 	data.Messages = srv.getMessages()
 
-	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Cache-Control", "no-store, max-age=0")
 	if err = tmpl.Execute(w, &data); err != nil {
 		msg = fmt.Sprintf("Error rendering template %q: %s",
 			tmplName,
@@ -865,34 +916,51 @@ func (srv *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
 		srv.SendMessage(msg)
 		srv.sendErrorMessage(w, msg)
 	}
-} // func (srv *Server) handleSearch(w http.ResponseWriter, r *http.Request)
+} // func (srv *Server) handleFeedPreview(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleSearchMore(w http.ResponseWriter, r *http.Request) {
+// handleFeedStats displays statistics for a single Feed, or for all Feeds
+// if no ID is given.
+func (srv *Server) handleFeedStats(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle request for %s\n",
 		r.URL.EscapedPath())
 
-	const tmplName = "search"
+	const (
+		tmplName  = "feed_stats"
+		dayCnt    = 60
+		weekCnt   = 52
+		tagCnt    = 15
+		sampleCnt = 250
+	)
 
 	var (
 		err   error
 		msg   string
-		db    *database.Database
+		id    int64
+		items []feed.Item
 		tmpl  *template.Template
-		feeds []feed.Feed
-		data  = tmplDataIndex{
-			tmplDataBase: tmplDataBase{
-				Title:      "Search",
-				Debug:      common.Debug,
-				URL:        r.URL.String(),
-				TrainStamp: srv.trainStamp(),
-			},
+		db    *database.Database
+		data  = tmplDataFeedStats{
+			tmplDataBase: srv.baseData("Statistics", r),
 		}
 	)
 
+	vars := mux.Vars(r)
+
+	if idStr, ok := vars["id"]; ok {
+		if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+			msg = fmt.Sprintf("Cannot parse Feed ID %q: %s",
+				idStr,
+				err.Error())
+			srv.log.Println("[CANTHAPPEN] " + msg)
+			srv.SendMessage(msg)
+			http.Redirect(w, r, r.Referer(), http.StatusFound)
+			return
+		}
+	}
+
 	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
-		msg = fmt.Sprintf("Cannot find template %s: %s",
-			tmplName,
-			err.Error())
+		msg = fmt.Sprintf("Cannot find Template %s",
+			tmplName)
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
@@ -902,129 +970,79 @@ func (srv *Server) handleSearchMore(w http.ResponseWriter, r *http.Request) {
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	// I should REALLY factor this out into a separate method!
-	if strings.ToLower(r.Method) == "post" {
-		msg = "Actual search is not implemented, yet."
-		srv.SendMessage(msg)
-
-		if err = r.ParseForm(); err != nil {
-			msg = fmt.Sprintf("Cannot parse form data: %s\n",
-				err.Error())
-			srv.log.Printf("[ERROR] %s\n", msg)
-			srv.SendMessage(msg)
-		}
-
-		var qstr = r.FormValue("search_terms")
-		var listStr = r.FormValue("search_tag_id_list")
-		var tagList []int64
-		srv.log.Printf("[DEBUG] Tag list to search: %q\n",
-			listStr)
-
-		if listStr != "" {
-			var strList = strings.Split(listStr, ",")
-
-			tagList = make([]int64, len(strList))
-
-			for idx, tstr := range strList {
-				if tagList[idx], err = strconv.ParseInt(tstr, 10, 64); err != nil {
-					msg = fmt.Sprintf("Cannot parse Tag ID %q: %s",
-						tstr,
-						err.Error())
-					srv.log.Println("[ERROR] " + msg)
-					srv.SendMessage(msg)
-					http.Redirect(w, r, r.Referer(), http.StatusFound)
-					return
-				}
-			}
-		}
-
-		// Filter by date/time range ?
-
-		var filterByDateStr = r.FormValue("search_by_date")
-		var beginDateStr = r.FormValue("begin_date")
-		var beginTimeStr = r.FormValue("begin_time")
-		var endDateStr = r.FormValue("end_date")
-		var endTimeStr = r.FormValue("end_time")
-		var begin, end time.Time
-
-		srv.log.Printf("[DEBUG] Filter by date? %s --> %s / %s to %s / %s\n",
-			filterByDateStr,
-			beginDateStr,
-			beginTimeStr,
-			endDateStr,
-			endTimeStr)
-
-		if filterByDateStr == "on" {
-			if beginTimeStr == "" {
-				beginTimeStr = "00:00"
-			}
-			if endTimeStr == "" {
-				endTimeStr = "23:59"
-			}
-
-			var begStr = beginDateStr + " " + beginTimeStr
-			var endStr = endDateStr + " " + endTimeStr
-
-			if begin, err = time.Parse(common.TimestampFormatMinute, begStr); err != nil {
-				msg = fmt.Sprintf("Cannot parse timestamp %q: %s",
-					begStr,
-					err.Error())
-				srv.log.Println("[ERROR] " + msg)
-				srv.SendMessage(msg)
-				http.Redirect(w, r, r.Referer(), http.StatusFound)
-				return
-			} else if end, err = time.Parse(common.TimestampFormatMinute, endStr); err != nil {
-				msg = fmt.Sprintf("Cannot parse timestamp %q: %s",
-					endStr,
-					err.Error())
-				srv.log.Println("[ERROR] " + msg)
-				srv.SendMessage(msg)
-				http.Redirect(w, r, r.Referer(), http.StatusFound)
-				return
-			}
-		} else {
-			end = time.Now().Add(time.Hour * 24 * 365)
-		}
-
-		if data.Items, err = db.ItemGetSearchExtended(qstr, tagList, begin, end); err != nil {
-			msg = fmt.Sprintf("Cannot search for Items matching %q: %s",
-				qstr,
+	if id != 0 {
+		if data.Feed, err = db.FeedGetByID(id); err != nil {
+			msg = fmt.Sprintf("Cannot load Feed %d: %s",
+				id,
 				err.Error())
 			srv.log.Println("[ERROR] " + msg)
 			srv.SendMessage(msg)
 			http.Redirect(w, r, r.Referer(), http.StatusFound)
 			return
+		} else if data.Feed == nil {
+			msg = fmt.Sprintf("Feed %d does not exist", id)
+			srv.log.Println("[ERROR] " + msg)
+			srv.SendMessage(msg)
+			http.Redirect(w, r, "/feed/stats", http.StatusFound)
+			return
 		}
+
+		data.Title = "Statistics for " + data.Feed.Name
+		items, err = db.ItemGetByFeed(id, sampleCnt)
+	} else {
+		items, err = db.ItemGetRecent(sampleCnt)
 	}
 
-	if feeds, err = db.FeedGetAll(); err != nil {
-		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+	if err != nil {
+		msg = fmt.Sprintf("Cannot load recent Items: %s",
 			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, "/index", http.StatusFound)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
-	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+	} else if data.Stats, err = db.FeedGetStats(id, dayCnt, weekCnt, tagCnt); err != nil {
+		msg = fmt.Sprintf("Cannot compute statistics: %s",
 			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
-	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load all Tags: %s",
+	} else if data.Feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
 			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
+	} else if id == 0 {
+		if data.Overview, err = db.FeedGetStatsAll(); err != nil {
+			msg = fmt.Sprintf("Cannot compute statistics for each Feed: %s",
+				err.Error())
+			srv.log.Println("[ERROR] " + msg)
+			srv.SendMessage(msg)
+			http.Redirect(w, r, r.Referer(), http.StatusFound)
+			return
+		}
 	}
 
-	data.FeedMap = make(map[int64]feed.Feed, len(feeds))
-	for _, f := range feeds {
-		data.FeedMap[f.ID] = f
+	if data.ClsGood, data.ClsBad, err = srv.classifySample(items); err != nil {
+		data.ClsError = err.Error()
 	}
 
+	data.PerDay = countChart(data.Stats.PerDay, "02. 01.")
+	data.PerWeek = countChart(data.Stats.PerWeek, "02. 01. 06")
+	data.Ratings = newBarChart(
+		[]int64{
+			data.Stats.Interesting,
+			data.Stats.Boring,
+			data.Stats.ItemCnt - data.Stats.RatedCnt(),
+		},
+		[]string{"Interesting", "Boring", "Not rated"},
+		[]string{"good", "bad", ""})
+	data.Verdicts = newBarChart(
+		[]int64{data.ClsGood, data.ClsBad},
+		[]string{"Interesting", "Boring"},
+		[]string{"good", "bad"})
 	data.Messages = srv.getMessages()
 
 	w.Header().Set("Cache-Control", cacheControl)
@@ -1035,224 +1053,437 @@ func (srv *Server) handleSearchMore(w http.ResponseWriter, r *http.Request) {
 		srv.SendMessage(msg)
 		srv.sendErrorMessage(w, msg)
 	}
-} // func (srv *Server) handleSearchMore(w http.ResponseWriter, r *http.Request)
+} // func (srv *Server) handleFeedStats(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleTagList(w http.ResponseWriter, r *http.Request) {
-	srv.log.Printf("[TRACE] Handle request for %s\n",
-		r.URL.EscapedPath())
+// classifySample asks the classifier for its opinion on the Items the user
+// has not rated and counts how many it considers interesting and boring.
+func (srv *Server) classifySample(items []feed.Item) (good, bad int64, err error) {
+	srv.clsLock.RLock()
+	defer srv.clsLock.RUnlock()
 
-	const (
-		tmplName = "tags"
-		itemCnt  = 5
-	)
+	for idx := range items {
+		var class string
 
-	var (
-		err  error
-		msg  string
-		tmpl *template.Template
-		db   *database.Database
-		data = tmplDataTags{
-			tmplDataBase: tmplDataBase{
-				Title:      "All Tags",
-				Debug:      common.Debug,
-				URL:        r.URL.String(),
-				TrainStamp: srv.trainStamp(),
-			},
+		if !math.IsNaN(items[idx].Rating) {
+			continue
+		} else if class, err = srv.clsItem.Classify(&items[idx]); err != nil {
+			srv.log.Printf("[ERROR] Cannot classify Item %s (%d): %s\n",
+				items[idx].Title,
+				items[idx].ID,
+				err.Error())
+			return good, bad, err
 		}
+
+		switch class {
+		case classifier.Good:
+			good++
+		case classifier.Bad:
+			bad++
+		}
+	}
+
+	return good, bad, nil
+} // func (srv *Server) classifySample(items []feed.Item) (good, bad int64, err error)
+
+func (srv *Server) handleOPMLExport(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err    error
+		msg    string
+		feeds  []feed.Feed
+		groups []feed.Group
+		buf    bytes.Buffer
+		db     *database.Database
 	)
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if data.Tags, err = db.TagGetHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+	if feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot query all Feeds: %s",
 			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
 		return
-	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load all Tags: %s",
+	} else if groups, err = db.GroupGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Groups: %s",
 			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
 		return
-	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+	} else if err = opml.Export(&buf, feeds, groups); err != nil {
+		msg = fmt.Sprintf("Cannot export Feeds as OPML: %s",
 			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/x-opml; charset=utf-8")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"%s.opml\"",
+			strings.ToLower(common.AppName)))
+	w.Header().Set("Cache-Control", "no-store, max-age=0")
+	w.WriteHeader(200)
+	w.Write(buf.Bytes()) // nolint: errcheck,gosec
+} // func (srv *Server) handleOPMLExport(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleOPMLImport(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	const maxSize = 4 << 20
+
+	var (
+		err  error
+		msg  string
+		fh   io.ReadCloser
+		subs []opml.Subscription
+		rep  *opml.Report
+		db   *database.Database
+	)
+
+	if err = r.ParseMultipartForm(maxSize); err != nil {
+		msg = fmt.Sprintf("Could not parse form data: %s", err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		http.Redirect(w, r, "/feed/all", http.StatusFound)
 		return
-	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
-		msg = fmt.Sprintf("Cannot find Template %s",
-			tmplName)
+	} else if fh, _, err = r.FormFile("opml"); err != nil {
+		msg = fmt.Sprintf("Could not get uploaded file: %s", err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		http.Redirect(w, r, "/feed/all", http.StatusFound)
 		return
 	}
 
-	data.Messages = srv.getMessages()
-	w.Header().Set("Cache-Control", cacheControl)
-	if err = tmpl.Execute(w, &data); err != nil {
-		msg = fmt.Sprintf("Error rendering template %q: %s",
-			tmplName,
-			err.Error())
+	defer fh.Close() // nolint: errcheck
+
+	if subs, err = opml.Parse(fh); err != nil {
+		msg = fmt.Sprintf("Cannot parse OPML file: %s", err.Error())
+		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		srv.sendErrorMessage(w, msg)
+		http.Redirect(w, r, "/feed/all", http.StatusFound)
+		return
 	}
-} // func (srv *Server) handleTagList(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleTagCreate(w http.ResponseWriter, r *http.Request) {
-	srv.log.Printf("[TRACE] Handle request for %s\n",
-		r.URL.EscapedPath())
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if rep, err = opml.Import(db, subs); err != nil {
+		msg = fmt.Sprintf("Cannot import Feeds: %s", err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/feed/all", http.StatusFound)
+		return
+	}
+
+	for _, f := range rep.Duplicates {
+		srv.SendMessage(fmt.Sprintf("OPML import: Skipped duplicate Feed %s (%s)",
+			f.Name,
+			f.URL))
+	}
+
+	for _, x := range rep.Invalid {
+		srv.SendMessage(fmt.Sprintf("OPML import: Skipped invalid Feed %s (%s): %s",
+			x.Feed.Name,
+			x.Feed.URL,
+			x.Reason))
+	}
+
+	msg = fmt.Sprintf("OPML import: %s", rep)
+	srv.log.Printf("[INFO] %s\n", msg)
+	srv.SendMessage(msg)
+
+	http.Redirect(w, r, "/feed/all", http.StatusFound)
+} // func (srv *Server) handleOPMLImport(w http.ResponseWriter, r *http.Request)
+
+// handleWebSub handles the callbacks from WebSub hubs. A GET request asks
+// us to verify that we really want to (un)subscribe, a POST request
+// delivers new content.
+func (srv *Server) handleWebSub(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s %s from %s\n",
+		r.Method,
+		r.URL,
+		r.RemoteAddr)
 
 	var (
-		err                          error
-		msg, name, desc, pStr, idStr string
-		t                            *tag.Tag
-		db                           *database.Database
-		parentID                     int64
+		err   error
+		msg   string
+		idStr string
+		id    int64
+		sub   *websub.Subscription
+		db    *database.Database
 	)
 
-	if err = r.ParseForm(); err != nil {
-		msg = fmt.Sprintf("Cannnot parse form data: %s",
+	idStr = mux.Vars(r)["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		srv.log.Printf("[ERROR] Cannot parse Feed ID %q: %s\n",
+			idStr,
 			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	idStr = r.FormValue("id")
-	name = r.FormValue("name")
-	desc = r.FormValue("description")
-	pStr = r.FormValue("parent")
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
 
-	if parentID, err = strconv.ParseInt(pStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse Parent ID %q: %s",
-			pStr,
+	if sub, err = db.WebSubGetByFeed(id); err != nil {
+		msg = fmt.Sprintf("Cannot load WebSub Subscription for Feed %d: %s",
+			id,
 			err.Error())
-		srv.log.Println("[ERROR] " + msg)
+		srv.log.Printf("[ERROR] %s\n", msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	db = srv.pool.Get()
-	defer srv.pool.Put(db)
+	switch r.Method {
+	case http.MethodGet:
+		srv.webSubVerify(w, r, db, id, sub)
+	case http.MethodPost:
+		srv.webSubReceive(w, r, db, id, sub)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+} // func (srv *Server) handleWebSub(w http.ResponseWriter, r *http.Request)
 
-	if idStr != "" {
-		srv.log.Printf("[TRACE] Updating Tag %s (%s)\n",
-			idStr,
-			name)
+// webSubVerify answers a hub's request to verify our intent. We confirm a
+// subscription only if we have asked for it, and we confirm an
+// unsubscription only if we do not want the Feed pushed to us (anymore).
+func (srv *Server) webSubVerify(w http.ResponseWriter, r *http.Request, db *database.Database, id int64, sub *websub.Subscription) {
+	var (
+		err    error
+		intent *websub.Intent
+	)
 
-		// Update!!!
-		t = &tag.Tag{
-			Name:        name,
-			Description: desc,
-			Parent:      parentID,
+	if intent, err = websub.ParseIntent(r.URL.Query()); err != nil {
+		srv.log.Printf("[ERROR] Invalid verification request for Feed %d: %s\n",
+			id,
+			err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch intent.Mode {
+	case "subscribe":
+		if sub == nil || sub.State == websub.Denied || !intent.Matches(sub) {
+			srv.log.Printf("[INFO] Refuse WebSub subscription for Feed %d to %s\n",
+				id,
+				intent.Topic)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if intent.Lease <= 0 {
+			intent.Lease = websub.DefaultLease
 		}
 
-		if t.ID, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-			msg = fmt.Sprintf("Cannot parse Tag ID %q: %s",
-				idStr,
+		if err = db.WebSubSetState(sub, websub.Active, intent.Lease); err != nil {
+			var msg = fmt.Sprintf("Cannot activate WebSub Subscription for Feed %d: %s",
+				id,
 				err.Error())
 			srv.log.Printf("[ERROR] %s\n", msg)
 			srv.SendMessage(msg)
-			// http.Redirect(w, r, r.Referer(), http.StatusFound)
-			// return
-		} else if err = db.TagUpdate(t); err != nil {
-			msg = fmt.Sprintf("Failed to update Tag %d (%s): %s",
-				t.ID,
-				t.Name,
-				err.Error())
-			srv.log.Printf("[ERROR] %s\n", msg)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		srv.log.Printf("[INFO] %s pushes Feed %d until %s\n",
+			sub.Hub,
+			id,
+			sub.Expires.Format(common.TimestampFormat))
+	case "unsubscribe":
+		if sub != nil && sub.State != websub.Denied && intent.Matches(sub) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	case "denied":
+		if sub != nil && intent.Matches(sub) {
+			var msg = fmt.Sprintf("Hub %s denied subscription to Feed %d: %s",
+				sub.Hub,
+				id,
+				intent.Reason)
+			srv.log.Printf("[INFO] %s\n", msg)
 			srv.SendMessage(msg)
-			// http.Redirect(w, r, r.Referer(), http.StatusFound)
-			// return
+
+			if err = db.WebSubSetState(sub, websub.Denied, 0); err != nil {
+				srv.log.Printf("[ERROR] Cannot update WebSub Subscription for Feed %d: %s\n",
+					id,
+					err.Error())
+			}
 		}
 
-	} else if t, err = db.TagCreate(name, desc, parentID); err != nil {
-		msg = fmt.Sprintf("Cannot create Tag %q: %s",
-			name,
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(intent.Challenge)) // nolint: errcheck
+} // func (srv *Server) webSubVerify(...)
+
+// webSubReceive processes content a hub pushed to us.
+func (srv *Server) webSubReceive(w http.ResponseWriter, r *http.Request, db *database.Database, id int64, sub *websub.Subscription) {
+	var (
+		err   error
+		body  []byte
+		f     *feed.Feed
+		items []feed.Item
+	)
+
+	if sub == nil || sub.State != websub.Active {
+		// Telling the hub we do not know the Subscription should make it
+		// stop sending us stuff.
+		w.WriteHeader(http.StatusGone)
+		return
+	} else if body, err = io.ReadAll(io.LimitReader(r.Body, websub.MaxBodySize)); err != nil {
+		srv.log.Printf("[ERROR] Cannot read content pushed for Feed %d: %s\n",
+			id,
 			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		w.WriteHeader(http.StatusBadRequest)
 		return
-	} else {
-		srv.log.Printf("[DEBUG] Created Tag %s (%d)\n",
-			t.Name,
-			t.ID)
 	}
 
-	http.Redirect(w, r, r.Referer(), http.StatusFound)
-} // func (srv *Server) handleTagCreate(w http.ResponseWriter, r *http.Request)
+	// The spec says we must acknowledge content with an invalid
+	// signature, but ignore it, so a forger cannot tell if they
+	// succeeded.
+	if err = websub.VerifySignature(sub.Secret, r.Header.Get("X-Hub-Signature"), body); err != nil {
+		srv.log.Printf("[ERROR] Discard content pushed for Feed %d from %s: %s\n",
+			id,
+			r.RemoteAddr,
+			err.Error())
+		w.WriteHeader(http.StatusAccepted)
+		return
+	} else if f, err = db.FeedGetByID(id); err != nil || f == nil {
+		srv.log.Printf("[ERROR] Cannot load Feed %d: %v\n",
+			id,
+			err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	} else if items, err = f.Parse(r.Header.Get("Content-Type"), body); err != nil {
+		srv.log.Printf("[ERROR] Cannot parse content pushed for Feed %s: %s\n",
+			f.Name,
+			err.Error())
+		w.WriteHeader(http.StatusAccepted)
+		return
+	} else if srv.reader == nil {
+		srv.log.Printf("[CANTHAPPEN] No Reader to process content pushed for Feed %s\n",
+			f.Name)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	} else if err = srv.reader.Push(*f, items); err != nil {
+		srv.log.Printf("[ERROR] Cannot process content pushed for Feed %s: %s\n",
+			f.Name,
+			err.Error())
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
 
-func (srv *Server) handleTagDetails(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+} // func (srv *Server) webSubReceive(...)
+
+func (srv *Server) handleItems(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle request for %s\n",
 		r.URL.EscapedPath())
 
 	const (
-		tmplName = "tag_details"
-		itemCnt  = 5
+		tmplName = "all_items"
+		itemCnt  = 50
 	)
 
 	var (
-		err        error
-		msg, idStr string
-		id         int64
-		tmpl       *template.Template
-		db         *database.Database
-		data       = tmplDataTagDetails{
+		err                                       error
+		msg, pageSpec                             string
+		pageNo, cnt, offset, totalCnt, totalPages int64
+		db                                        *database.Database
+		tmpl                                      *template.Template
+		data                                      = tmplDataItems{
 			tmplDataBase: tmplDataBase{
 				Debug:      common.Debug,
 				URL:        r.URL.String(),
 				TrainStamp: srv.trainStamp(),
+				ShowMuted:  showMuted(r),
+				UnreadOnly: unreadOnly(r, false),
 			},
 		}
 	)
 
 	vars := mux.Vars(r)
-	idStr = vars["id"]
+	pageSpec = vars["page"]
 
-	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse Tag ID %q: %s",
-			idStr,
+	if pageSpec == "all" {
+		pageNo = -1
+	} else if pageNo, err = strconv.ParseInt(pageSpec, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse page number %q: %s",
+			pageSpec,
 			err.Error())
-		srv.log.Println("[CANTHAPPEN] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		srv.log.Printf("[CANTHAPPEN] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
 		return
 	}
 
+	if pageNo != -1 {
+		data.Title = fmt.Sprintf("Items page %d", pageNo+1)
+		cnt = itemCnt
+		offset = itemCnt * pageNo
+	} else {
+		data.Title = "All Items"
+		cnt = -1
+	}
+
+	if pageNo > 0 {
+		data.Prev = strconv.FormatInt(pageNo-1, 10)
+	}
+
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if data.Tag, err = db.TagGetByID(id); err != nil {
-		msg = fmt.Sprintf("Cannot load Tag %d: %s",
-			id,
+	var getAll = db.ItemGetAll
+
+	if data.UnreadOnly {
+		getAll = db.ItemGetAllUnread
+	}
+
+	if totalCnt, err = db.ItemGetTotalCnt(); err != nil {
+		msg = fmt.Sprintf("Cannot get total number of items from database: %s",
 			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		http.Redirect(w, r, "/index", http.StatusFound)
 		return
-	} else if data.Items, err = db.ItemGetByTag(data.Tag); err != nil {
-		msg = fmt.Sprintf("Cannot load Items tagged as %s: %s",
-			data.Tag.Name,
+	} else if data.UnreadCnt, err = db.ItemGetUnreadCnt(); err != nil {
+		msg = fmt.Sprintf("Cannot count unread Items: %s",
 			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		http.Redirect(w, r, "/index", http.StatusFound)
 		return
-	} else if data.FeedMap, err = db.FeedGetMap(); err != nil {
-		msg = fmt.Sprintf("Cannot get FeedMap: %s", err.Error())
+	} else if data.Items, err = getAll(cnt, offset); err != nil {
+		msg = fmt.Sprintf("Cannot load Items (%d / offset %d) from database: %s",
+			itemCnt,
+			offset,
+			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		http.Redirect(w, r, "/index", http.StatusFound)
+		return
+	} else if data.Items, err = collapseDuplicates(db, data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot look up duplicate Items: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/index", http.StatusFound)
+		return
+	} else if data.Items, data.MutedCnt, err = filterMuted(db, data.Items, data.ShowMuted); err != nil {
+		msg = fmt.Sprintf("Cannot apply Mutes: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/index", http.StatusFound)
 		return
 	} else if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
 		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
@@ -1260,6 +1491,13 @@ func (srv *Server) handleTagDetails(w http.ResponseWriter, r *http.Request) {
 		srv.log.Printf("[ERROR] %s\n", msg)
 		srv.sendErrorMessage(w, msg)
 		return
+	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
 	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
 		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
 			err.Error())
@@ -1267,17 +1505,78 @@ func (srv *Server) handleTagDetails(w http.ResponseWriter, r *http.Request) {
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
-	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
-		msg = fmt.Sprintf("Did not find template %s",
-			tmplName)
+	} else if data.FeedMap, err = db.FeedGetMap(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
+	} else if pageNo != -1 && len(data.Items) == itemCnt {
+		data.Next = strconv.FormatInt(pageNo+1, 10)
+	}
+
+	if data.UnreadOnly {
+		totalCnt = data.UnreadCnt
+	}
+
+	if cnt > 0 {
+		totalPages = totalCnt / cnt
+		data.PageCnt = totalPages
+	}
+
+	srv.clsLock.RLock()
+	defer srv.clsLock.RUnlock()
+
+	for idx, item := range data.Items {
+		var class string
+
+		if !math.IsNaN(item.Rating) {
+			if item.Rating == 1 {
+				data.Items[idx].Rating = math.Inf(1)
+			} else if item.Rating == 0 {
+				data.Items[idx].Rating = math.Inf(-1)
+			} else {
+				msg = fmt.Sprintf("Unexpected Rating for Item %s (%d): %f",
+					item.Title,
+					item.ID,
+					item.Rating)
+				srv.log.Println("[ERROR] " + msg)
+				srv.SendMessage(msg)
+				http.Redirect(w, r, r.Referer(), http.StatusFound)
+				return
+			}
+
+			// continue
+		} else if class, err = srv.clsItem.Classify(&item); err != nil {
+			srv.log.Printf("[ERROR] Cannot classify Item %s (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+		} else if class == classifier.Good {
+			data.Items[idx].Rating = 100
+		} else if class == classifier.Bad {
+			data.Items[idx].Rating = -100
+		} else {
+			srv.log.Printf("[ERROR] Could not find classification for Item %d (%s)\n",
+				item.ID,
+				item.Title)
+			// srv.log.Printf("[ERROR] Unexpected classification for Item %s (%d): %s\n",
+			// 	item.Title,
+			// 	item.ID,
+			// 	err.Error())
+		}
+	}
+
+	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Could not find template %q", tmplName)
+		srv.log.Println("[CRITICAL] " + msg)
+		srv.sendErrorMessage(w, msg)
+		return
 	}
 
-	data.Title = fmt.Sprintf("Details for Tag %s", data.Tag.Name)
 	data.Messages = srv.getMessages()
+
 	w.Header().Set("Cache-Control", cacheControl)
 	if err = tmpl.Execute(w, &data); err != nil {
 		msg = fmt.Sprintf("Error rendering template %q: %s",
@@ -1286,39 +1585,75 @@ func (srv *Server) handleTagDetails(w http.ResponseWriter, r *http.Request) {
 		srv.SendMessage(msg)
 		srv.sendErrorMessage(w, msg)
 	}
-} // func (srv *Server) handleTagDetails(w http.ResponseWriter, r *http.Request)
+} // func (srv *Server) handleItems(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleReadLaterAll(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle request for %s\n",
 		r.URL.EscapedPath())
 
 	const (
-		tmplName = "later_all"
+		tmplName = "all_items"
+		itemCnt  = 50
 	)
 
 	var (
-		err  error
-		msg  string
-		tmpl *template.Template
-		db   *database.Database
-		data = tmplDataReadLater{
+		err       error
+		msg, qstr string
+		q         *search.Query
+		db        *database.Database
+		tmpl      *template.Template
+		data      = tmplDataItems{
 			tmplDataBase: tmplDataBase{
+				Title:      "Main",
 				Debug:      common.Debug,
 				URL:        r.URL.String(),
-				Title:      "Read Later",
 				TrainStamp: srv.trainStamp(),
+				UnreadOnly: unreadOnly(r, false),
 			},
 		}
 	)
 
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Could not parse form data: %s", err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/index", http.StatusFound)
+		return
+	}
+
+	qstr = r.FormValue("query")
+	data.URL = "/search?query=" + url.QueryEscape(qstr)
+
+	srv.log.Printf("[TRACE] Receive query for %q\n",
+		qstr)
+
+	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Could not find template %q", tmplName)
+		srv.log.Println("[CRITICAL] " + msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	}
+
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot get all Tags: %s", err.Error())
+	if q, err = search.ParseQueryStr(db, qstr); err != nil {
+		msg = fmt.Sprintf("Cannot process search query %q: %s\n",
+			qstr,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	}
+
+	var feeds []feed.Feed
+
+	if feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		http.Redirect(w, r, "/index", http.StatusFound)
 		return
 	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
 		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
@@ -1327,30 +1662,51 @@ func (srv *Server) handleReadLaterAll(w http.ResponseWriter, r *http.Request) {
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
-	} else if data.FeedMap, err = db.FeedGetMap(); err != nil {
-		msg = fmt.Sprintf("Cannot get FeedMap: %s", err.Error())
+	}
+
+	data.FeedMap = make(map[int64]feed.Feed, len(feeds))
+
+	for _, f := range feeds {
+		data.FeedMap[f.ID] = f
+	}
+
+	if data.Items, err = q.Execute(); err != nil {
+		msg = fmt.Sprintf("Cannot search database for %q: %s",
+			qstr,
+			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		http.Redirect(w, r, "/index", http.StatusFound)
 		return
-	} else if data.Items, err = db.ReadLaterGetAll(); err != nil {
-		msg = fmt.Sprintf("Cannot get ReadLater items: %s", err.Error())
+	} else if data.Items, err = collapseDuplicates(db, data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot look up duplicate Items: %s",
+			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		http.Redirect(w, r, "/index", http.StatusFound)
 		return
-	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
-		msg = fmt.Sprintf("Did not find template %s",
-			tmplName)
+	}
+
+	data.Items, data.UnreadCnt = filterUnread(data.Items, data.UnreadOnly)
+
+	if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load all Tags: %s",
+			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
 		return
+	} else if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
 	}
 
 	data.Messages = srv.getMessages()
+
 	w.Header().Set("Cache-Control", cacheControl)
-	w.Header().Set("Content-Type", "text/html")
 	if err = tmpl.Execute(w, &data); err != nil {
 		msg = fmt.Sprintf("Error rendering template %q: %s",
 			tmplName,
@@ -1358,42 +1714,34 @@ func (srv *Server) handleReadLaterAll(w http.ResponseWriter, r *http.Request) {
 		srv.SendMessage(msg)
 		srv.sendErrorMessage(w, msg)
 	}
-} // func (srv *Server) handleReadLaterAll(w http.ResponseWriter, r *http.Request)
-
-func (srv *Server) handleClassifierTrain(w http.ResponseWriter, r *http.Request) {
-	srv.log.Printf("[TRACE] Handle request for %s\n",
-		r.URL.EscapedPath())
-
-	var err error
-
-	if err = srv.retrain(); err != nil {
-		srv.SendMessage(err.Error())
-	}
-
-	http.Redirect(w, r, r.Referer(), http.StatusFound)
-} // func (srv *Server) handleClassifierTrain(w http.ResponseWriter, r *http.Request)
+} // func (srv *Server) handleSearch(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleSearchMore(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle request for %s\n",
 		r.URL.EscapedPath())
 
-	const tmplName = "archive"
+	const tmplName = "search"
 
 	var (
-		err     error
-		db      *database.Database
-		tmpl    *template.Template
-		dirh    *os.File
-		folders []string
-		msg     string
-		data    = tmplDataArchive{
-			tmplDataBase: srv.baseData("Archive", r),
+		err   error
+		msg   string
+		db    *database.Database
+		tmpl  *template.Template
+		feeds []feed.Feed
+		data  = tmplDataIndex{
+			tmplDataBase: tmplDataBase{
+				Title:      "Search",
+				Debug:      common.Debug,
+				URL:        r.URL.String(),
+				TrainStamp: srv.trainStamp(),
+			},
 		}
 	)
 
 	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
-		msg = fmt.Sprintf("Cannot find Template %s",
-			tmplName)
+		msg = fmt.Sprintf("Cannot find template %s: %s",
+			tmplName,
+			err.Error())
 		srv.log.Println("[ERROR] " + msg)
 		srv.SendMessage(msg)
 		http.Redirect(w, r, r.Referer(), http.StatusFound)
@@ -1403,72 +1751,132 @@ func (srv *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if data.Feeds, err = db.FeedGetAll(); err != nil {
-		msg = fmt.Sprintf("Cannot get all Feeds: %s",
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
-		return
-	} else if dirh, err = os.Open(common.ArchiveDir); err != nil {
-		msg = fmt.Sprintf("Cannot open archive directory %s: %s",
-			common.ArchiveDir,
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
+	// I should REALLY factor this out into a separate method!
+	if strings.ToLower(r.Method) == "post" {
+		msg = "Actual search is not implemented, yet."
 		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
-		return
-	}
 
-	defer dirh.Close() // nolint: errcheck
+		if err = r.ParseForm(); err != nil {
+			msg = fmt.Sprintf("Cannot parse form data: %s\n",
+				err.Error())
+			srv.log.Printf("[ERROR] %s\n", msg)
+			srv.SendMessage(msg)
+		}
 
-	data.FeedMap = make(map[int64]feed.Feed, len(data.Feeds))
-	for _, f := range data.Feeds {
-		data.FeedMap[f.ID] = f
-	}
+		var qstr = r.FormValue("search_terms")
+		var listStr = r.FormValue("search_tag_id_list")
+		var tagList []int64
+		srv.log.Printf("[DEBUG] Tag list to search: %q\n",
+			listStr)
 
-	if folders, err = dirh.Readdirnames(0); err != nil {
-		msg = fmt.Sprintf("Cannot read archive directory %s: %s",
-			common.ArchiveDir,
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		http.Redirect(w, r, r.Referer(), http.StatusFound)
-		return
-	}
+		if listStr != "" {
+			var strList = strings.Split(listStr, ",")
 
-	for _, name := range folders {
-		var (
-			id   int64
-			item *feed.Item
-		)
+			tagList = make([]int64, len(strList))
 
-		if id, err = strconv.ParseInt(name, 10, 64); err != nil {
-			msg = fmt.Sprintf("Cannot parse Item ID %q: %s",
-				name,
-				err.Error())
-			srv.log.Println("[ERROR] " + msg)
-			srv.SendMessage(msg)
-			http.Redirect(w, r, r.Referer(), http.StatusFound)
-			return
-		} else if item, err = db.ItemGetByID(id); err != nil {
-			msg = fmt.Sprintf("Cannot fetch Item %d: %s",
-				id,
+			for idx, tstr := range strList {
+				if tagList[idx], err = strconv.ParseInt(tstr, 10, 64); err != nil {
+					msg = fmt.Sprintf("Cannot parse Tag ID %q: %s",
+						tstr,
+						err.Error())
+					srv.log.Println("[ERROR] " + msg)
+					srv.SendMessage(msg)
+					http.Redirect(w, r, r.Referer(), http.StatusFound)
+					return
+				}
+			}
+		}
+
+		// Filter by date/time range ?
+
+		var filterByDateStr = r.FormValue("search_by_date")
+		var beginDateStr = r.FormValue("begin_date")
+		var beginTimeStr = r.FormValue("begin_time")
+		var endDateStr = r.FormValue("end_date")
+		var endTimeStr = r.FormValue("end_time")
+		var begin, end time.Time
+
+		srv.log.Printf("[DEBUG] Filter by date? %s --> %s / %s to %s / %s\n",
+			filterByDateStr,
+			beginDateStr,
+			beginTimeStr,
+			endDateStr,
+			endTimeStr)
+
+		if filterByDateStr == "on" {
+			if beginTimeStr == "" {
+				beginTimeStr = "00:00"
+			}
+			if endTimeStr == "" {
+				endTimeStr = "23:59"
+			}
+
+			var begStr = beginDateStr + " " + beginTimeStr
+			var endStr = endDateStr + " " + endTimeStr
+
+			if begin, err = time.Parse(common.TimestampFormatMinute, begStr); err != nil {
+				msg = fmt.Sprintf("Cannot parse timestamp %q: %s",
+					begStr,
+					err.Error())
+				srv.log.Println("[ERROR] " + msg)
+				srv.SendMessage(msg)
+				http.Redirect(w, r, r.Referer(), http.StatusFound)
+				return
+			} else if end, err = time.Parse(common.TimestampFormatMinute, endStr); err != nil {
+				msg = fmt.Sprintf("Cannot parse timestamp %q: %s",
+					endStr,
+					err.Error())
+				srv.log.Println("[ERROR] " + msg)
+				srv.SendMessage(msg)
+				http.Redirect(w, r, r.Referer(), http.StatusFound)
+				return
+			}
+		} else {
+			end = time.Now().Add(time.Hour * 24 * 365)
+		}
+
+		if data.Items, err = db.ItemGetSearchExtended(qstr, tagList, begin, end); err != nil {
+			msg = fmt.Sprintf("Cannot search for Items matching %q: %s",
+				qstr,
 				err.Error())
 			srv.log.Println("[ERROR] " + msg)
 			srv.SendMessage(msg)
 			http.Redirect(w, r, r.Referer(), http.StatusFound)
 			return
 		}
+	}
 
-		data.Items = append(data.Items, *item)
+	if feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, "/index", http.StatusFound)
+		return
+	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
 	}
 
-	sort.Sort(itemList(data.Items))
+	data.FeedMap = make(map[int64]feed.Feed, len(feeds))
+	for _, f := range feeds {
+		data.FeedMap[f.ID] = f
+	}
 
 	data.Messages = srv.getMessages()
+
 	w.Header().Set("Cache-Control", cacheControl)
-	w.Header().Set("Content-Type", "text/html")
 	if err = tmpl.Execute(w, &data); err != nil {
 		msg = fmt.Sprintf("Error rendering template %q: %s",
 			tmplName,
@@ -1476,1160 +1884,3938 @@ func (srv *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
 		srv.SendMessage(msg)
 		srv.sendErrorMessage(w, msg)
 	}
-} // func (srv *Server) handleArchive(w http.ResponseWriter, r *http.Request)
-
-/////////////////////////////////////////
-////////////// Other ////////////////////
-/////////////////////////////////////////
+} // func (srv *Server) handleSearchMore(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleArchivedFile(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleTagList(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle request for %s\n",
 		r.URL.EscapedPath())
 
+	const (
+		tmplName = "tags"
+		itemCnt  = 5
+	)
+
 	var (
-		err                      error
-		fh                       *os.File
-		path, fullPath, mimeType string
-		match                    []string
+		err  error
+		msg  string
+		tmpl *template.Template
+		db   *database.Database
+		data = tmplDataTags{
+			tmplDataBase: tmplDataBase{
+				Title:      "All Tags",
+				Debug:      common.Debug,
+				URL:        r.URL.String(),
+				TrainStamp: srv.trainStamp(),
+			},
+		}
 	)
 
-	vars := mux.Vars(r)
-	path = vars["path"]
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
 
-	fullPath = filepath.Join(common.ArchiveDir, path)
+	if data.Tags, err = db.TagGetHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Cannot find Template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
 
-	srv.log.Printf("[TRACE] Deliver archived Item %s\n", fullPath)
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleTagList(w http.ResponseWriter, r *http.Request)
 
-	if fh, err = os.Open(fullPath); err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
-			w.Header().Set("Content-Type", "text/plain")
-			w.WriteHeader(404)
-			w.Write([]byte(fmt.Sprintf("File %q was not found\n\n", path))) // nolint: errcheck
-			return
-		}
-		srv.log.Printf("[ERROR] Cannot open local archive file %s: %s\n",
-			fullPath,
+func (srv *Server) handleTagCreate(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	var (
+		err                          error
+		msg, name, desc, pStr, idStr string
+		t                            *tag.Tag
+		db                           *database.Database
+		parentID                     int64
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Cannnot parse form data: %s",
 			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
 	}
 
-	defer fh.Close() // nolint: errcheck
+	idStr = r.FormValue("id")
+	name = r.FormValue("name")
+	desc = r.FormValue("description")
+	pStr = r.FormValue("parent")
 
-	if match = common.SuffixPattern.FindStringSubmatch(path); match == nil {
-		mimeType = "text/plain"
-	} else if mime, ok := srv.mimeTypes[match[1]]; ok {
-		mimeType = mime
-	} else {
-		srv.log.Printf("[ERROR] Did not find MIME type for %s\n", path)
+	if parentID, err = strconv.ParseInt(pStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Parent ID %q: %s",
+			pStr,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
 	}
 
-	w.Header().Set("Content-Type", mimeType)
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
 
-	if common.Debug {
-		w.Header().Set("Cache-Control", "no-store, max-age=0")
-	} else {
-		w.Header().Set("Cache-Control", cacheControl)
+	if idStr != "" {
+		srv.log.Printf("[TRACE] Updating Tag %s (%s)\n",
+			idStr,
+			name)
+
+		// Update!!!
+		t = &tag.Tag{
+			Name:        name,
+			Description: desc,
+			Parent:      parentID,
+		}
+
+		if t.ID, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+			msg = fmt.Sprintf("Cannot parse Tag ID %q: %s",
+				idStr,
+				err.Error())
+			srv.log.Printf("[ERROR] %s\n", msg)
+			srv.SendMessage(msg)
+			// http.Redirect(w, r, r.Referer(), http.StatusFound)
+			// return
+		} else if err = db.TagUpdate(t); err != nil {
+			msg = fmt.Sprintf("Failed to update Tag %d (%s): %s",
+				t.ID,
+				t.Name,
+				err.Error())
+			srv.log.Printf("[ERROR] %s\n", msg)
+			srv.SendMessage(msg)
+			// http.Redirect(w, r, r.Referer(), http.StatusFound)
+			// return
+		}
+
+	} else if t, err = db.TagCreate(name, desc, parentID); err != nil {
+		msg = fmt.Sprintf("Cannot create Tag %q: %s",
+			name,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else {
+		srv.log.Printf("[DEBUG] Created Tag %s (%d)\n",
+			t.Name,
+			t.ID)
+	}
+
+	http.Redirect(w, r, r.Referer(), http.StatusFound)
+} // func (srv *Server) handleTagCreate(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleTagDetails(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const (
+		tmplName = "tag_details"
+		itemCnt  = 5
+	)
+
+	var (
+		err        error
+		msg, idStr string
+		id         int64
+		tmpl       *template.Template
+		db         *database.Database
+		getItems   func(*tag.Tag) ([]feed.Item, error)
+		data       = tmplDataTagDetails{
+			tmplDataBase: tmplDataBase{
+				Debug:      common.Debug,
+				URL:        r.URL.String(),
+				TrainStamp: srv.trainStamp(),
+				ShowMuted:  showMuted(r),
+				UnreadOnly: unreadOnly(r, false),
+			},
+		}
+	)
+
+	vars := mux.Vars(r)
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Tag ID %q: %s",
+			idStr,
+			err.Error())
+		srv.log.Println("[CANTHAPPEN] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	data.MarkReadURL = fmt.Sprintf("/ajax/tag_mark_read/%d", id)
+
+	if data.UnreadOnly {
+		getItems = db.ItemGetByTagUnread
+	} else {
+		getItems = db.ItemGetByTag
+	}
+
+	if data.Tag, err = db.TagGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot load Tag %d: %s",
+			id,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Items, err = getItems(data.Tag); err != nil {
+		msg = fmt.Sprintf("Cannot load Items tagged as %s: %s",
+			data.Tag.Name,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Items, err = collapseDuplicates(db, data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot look up duplicate Items: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Items, data.MutedCnt, err = filterMuted(db, data.Items, data.ShowMuted); err != nil {
+		msg = fmt.Sprintf("Cannot apply Mutes: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.UnreadCnt, err = db.ItemGetUnreadCntByTag(data.Tag); err != nil {
+		msg = fmt.Sprintf("Cannot count unread Items tagged as %s: %s",
+			data.Tag.Name,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	if data.FeedMap, err = db.FeedGetMap(); err != nil {
+		msg = fmt.Sprintf("Cannot get FeedMap: %s", err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Did not find template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	data.Title = fmt.Sprintf("Details for Tag %s", data.Tag.Name)
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleTagDetails(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleItemRevisions(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const tmplName = "revisions"
+
+	var (
+		err        error
+		msg, idStr string
+		id         int64
+		tmpl       *template.Template
+		db         *database.Database
+		data       = tmplDataRevisions{
+			tmplDataBase: tmplDataBase{
+				Debug:      common.Debug,
+				URL:        r.URL.String(),
+				TrainStamp: srv.trainStamp(),
+			},
+		}
+	)
+
+	vars := mux.Vars(r)
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Item ID %q: %s",
+			idStr,
+			err.Error())
+		srv.log.Println("[CANTHAPPEN] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if data.Item, err = db.ItemGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot load Item %d: %s",
+			id,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Item == nil {
+		msg = fmt.Sprintf("Item %d does not exist", id)
+		srv.log.Println("[ERROR] " + msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if data.Feed, err = db.FeedGetByID(data.Item.FeedID); err != nil {
+		msg = fmt.Sprintf("Cannot load Feed %d: %s",
+			data.Item.FeedID,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Revisions, err = db.RevisionGetByItem(id); err != nil {
+		msg = fmt.Sprintf("Cannot load previous versions of Item %d: %s",
+			id,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Did not find template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	data.Title = fmt.Sprintf("Revisions of %s", data.Item.Title)
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleItemRevisions(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleReadLaterAll(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const (
+		tmplName = "later_all"
+	)
+
+	var (
+		err  error
+		msg  string
+		tmpl *template.Template
+		db   *database.Database
+		data = tmplDataReadLater{
+			tmplDataBase: tmplDataBase{
+				Debug:      common.Debug,
+				URL:        r.URL.String(),
+				Title:      "Read Later",
+				TrainStamp: srv.trainStamp(),
+			},
+		}
+	)
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Tags: %s", err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.FeedMap, err = db.FeedGetMap(); err != nil {
+		msg = fmt.Sprintf("Cannot get FeedMap: %s", err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Items, err = db.ReadLaterGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get ReadLater items: %s", err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Did not find template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Content-Type", "text/html")
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleReadLaterAll(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleClassifierTrain(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	var err error
+
+	if err = srv.retrain(); err != nil {
+		srv.SendMessage(err.Error())
+	}
+
+	http.Redirect(w, r, r.Referer(), http.StatusFound)
+} // func (srv *Server) handleClassifierTrain(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const tmplName = "archive"
+
+	var (
+		err     error
+		db      *database.Database
+		tmpl    *template.Template
+		dirh    *os.File
+		folders []string
+		msg     string
+		data    = tmplDataArchive{
+			tmplDataBase: srv.baseData("Archive", r),
+		}
+	)
+
+	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Cannot find Template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if data.Feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if dirh, err = os.Open(common.ArchiveDir); err != nil {
+		msg = fmt.Sprintf("Cannot open archive directory %s: %s",
+			common.ArchiveDir,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	defer dirh.Close() // nolint: errcheck
+
+	data.FeedMap = make(map[int64]feed.Feed, len(data.Feeds))
+	for _, f := range data.Feeds {
+		data.FeedMap[f.ID] = f
+	}
+
+	if folders, err = dirh.Readdirnames(0); err != nil {
+		msg = fmt.Sprintf("Cannot read archive directory %s: %s",
+			common.ArchiveDir,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	for _, name := range folders {
+		var (
+			id   int64
+			item *feed.Item
+		)
+
+		if id, err = strconv.ParseInt(name, 10, 64); err != nil {
+			msg = fmt.Sprintf("Cannot parse Item ID %q: %s",
+				name,
+				err.Error())
+			srv.log.Println("[ERROR] " + msg)
+			srv.SendMessage(msg)
+			http.Redirect(w, r, r.Referer(), http.StatusFound)
+			return
+		} else if item, err = db.ItemGetByID(id); err != nil {
+			msg = fmt.Sprintf("Cannot fetch Item %d: %s",
+				id,
+				err.Error())
+			srv.log.Println("[ERROR] " + msg)
+			srv.SendMessage(msg)
+			http.Redirect(w, r, r.Referer(), http.StatusFound)
+			return
+		}
+
+		data.Items = append(data.Items, *item)
+	}
+
+	sort.Sort(itemList(data.Items))
+
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Content-Type", "text/html")
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleArchive(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleRetention(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const tmplName = "retention"
+
+	var (
+		err  error
+		msg  string
+		tmpl *template.Template
+		db   *database.Database
+		data = tmplDataRetention{
+			tmplDataBase: srv.baseData("Retention", r),
+		}
+	)
+
+	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Cannot find Template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if data.Feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Policies, err = db.RetentionGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Retention policies: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Report, err = retention.Run(db, true); err != nil {
+		msg = fmt.Sprintf("Cannot compute which Items have expired: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Content-Type", "text/html")
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleRetention(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleGroupAll(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const tmplName = "group_all"
+
+	var (
+		err  error
+		msg  string
+		tmpl *template.Template
+		db   *database.Database
+		data = tmplDataGroups{
+			tmplDataBase: srv.baseData("Groups", r),
+		}
+	)
+
+	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Cannot find Template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if data.Groups, err = db.GroupGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Groups: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Unread, err = db.GroupGetUnread(); err != nil {
+		msg = fmt.Sprintf("Cannot count unread Items: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Content-Type", "text/html")
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleGroupAll(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleGroupDetails(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const (
+		tmplName = "group_details"
+		itemCnt  = 100
+	)
+
+	var (
+		err        error
+		msg, idStr string
+		id         int64
+		feeds      []feed.Feed
+		unread     map[int64]int64
+		tmpl       *template.Template
+		db         *database.Database
+		getItems   func(int64, int) ([]feed.Item, error)
+		data       = tmplDataGroupDetails{
+			tmplDataBase: srv.baseData("", r),
+		}
+	)
+
+	data.ShowMuted = showMuted(r)
+	data.UnreadOnly = unreadOnly(r, false)
+
+	vars := mux.Vars(r)
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Group ID %q: %s",
+			idStr,
+			err.Error())
+		srv.log.Println("[CANTHAPPEN] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Cannot find Template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if data.UnreadOnly {
+		getItems = db.ItemGetByGroupUnread
+	} else {
+		getItems = db.ItemGetByGroup
+	}
+
+	if data.Groups, err = db.GroupGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Groups: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	for i := range data.Groups {
+		if data.Groups[i].ID == id {
+			data.Group = &data.Groups[i]
+			break
+		}
+	}
+
+	if data.Group == nil {
+		msg = fmt.Sprintf("Group %d does not exist", id)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if unread, err = db.GroupGetUnread(); err != nil {
+		msg = fmt.Sprintf("Cannot count unread Items: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Items, err = getItems(id, itemCnt); err != nil {
+		msg = fmt.Sprintf("Cannot load Items in Group %s: %s",
+			data.Group.Path,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Items, err = collapseDuplicates(db, data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot look up duplicate Items: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Items, data.MutedCnt, err = filterMuted(db, data.Items, data.ShowMuted); err != nil {
+		msg = fmt.Sprintf("Cannot apply Mutes: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	data.UnreadCnt = unread[id]
+
+	if data.FeedMap, err = db.FeedGetMap(); err != nil {
+		msg = fmt.Sprintf("Cannot get FeedMap: %s", err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	var members = groupSubtree(data.Groups, id)
+
+	for _, f := range feeds {
+		if members[f.GroupID] {
+			data.Feeds = append(data.Feeds, f)
+		}
+	}
+
+	data.Unread = unread[id]
+	data.Title = fmt.Sprintf("Group %s", data.Group.Path)
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Content-Type", "text/html")
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleGroupDetails(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleRuleAll(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const tmplName = "rule_all"
+
+	var (
+		err  error
+		msg  string
+		tmpl *template.Template
+		db   *database.Database
+		data = tmplDataRules{
+			tmplDataBase: srv.baseData("Rules", r),
+			Languages:    common.Languages,
+		}
+	)
+
+	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Cannot find Template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if data.Rules, err = db.RuleGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Rules: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Tags, err = db.TagGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Tags: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Content-Type", "text/html")
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleRuleAll(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleMuteAll(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const tmplName = "mute_all"
+
+	var (
+		err  error
+		msg  string
+		tmpl *template.Template
+		db   *database.Database
+		data = tmplDataMutes{
+			tmplDataBase: srv.baseData("Mutes", r),
+		}
+	)
+
+	if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Cannot find Template %s",
+			tmplName)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if data.Mutes, err = db.MuteGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Mutes: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Feeds, err = db.FeedGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	} else if data.Groups, err = db.GroupGetAll(); err != nil {
+		msg = fmt.Sprintf("Cannot load Groups: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		http.Redirect(w, r, r.Referer(), http.StatusFound)
+		return
+	}
+
+	data.Messages = srv.getMessages()
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Content-Type", "text/html")
+	if err = tmpl.Execute(w, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %q: %s",
+			tmplName,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.sendErrorMessage(w, msg)
+	}
+} // func (srv *Server) handleMuteAll(w http.ResponseWriter, r *http.Request)
+
+/////////////////////////////////////////
+////////////// Other ////////////////////
+/////////////////////////////////////////
+
+func (srv *Server) handleArchivedFile(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	var (
+		err                      error
+		fh                       *os.File
+		path, fullPath, mimeType string
+		match                    []string
+	)
+
+	vars := mux.Vars(r)
+	path = vars["path"]
+
+	fullPath = filepath.Join(common.ArchiveDir, path)
+
+	srv.log.Printf("[TRACE] Deliver archived Item %s\n", fullPath)
+
+	if fh, err = os.Open(fullPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(404)
+			w.Write([]byte(fmt.Sprintf("File %q was not found\n\n", path))) // nolint: errcheck
+			return
+		}
+		srv.log.Printf("[ERROR] Cannot open local archive file %s: %s\n",
+			fullPath,
+			err.Error())
+	}
+
+	defer fh.Close() // nolint: errcheck
+
+	if match = common.SuffixPattern.FindStringSubmatch(path); match == nil {
+		mimeType = "text/plain"
+	} else if mime, ok := srv.mimeTypes[match[1]]; ok {
+		mimeType = mime
+	} else {
+		srv.log.Printf("[ERROR] Did not find MIME type for %s\n", path)
+	}
+
+	w.Header().Set("Content-Type", mimeType)
+
+	if common.Debug {
+		w.Header().Set("Cache-Control", "no-store, max-age=0")
+	} else {
+		w.Header().Set("Cache-Control", cacheControl)
+	}
+
+	w.WriteHeader(200)
+	io.Copy(w, fh) // nolint: errcheck
+} // func (srv *Server) handleArchivedFile(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleEnclosureFile(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err        error
+		db         *database.Database
+		idStr, msg string
+		id         int64
+		enc        *feed.Enclosure
+	)
+
+	vars := mux.Vars(r)
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idStr,
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if enc, err = db.EnclosureGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot load Enclosure %d: %s",
+			id,
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if enc == nil || !enc.IsDownloaded() {
+		http.NotFound(w, r)
+		return
+	}
+
+	if enc.Type != "" {
+		w.Header().Set("Content-Type", enc.Type)
+	}
+
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("inline; filename=%q", enc.Filename()))
+	w.Header().Set("Cache-Control", cacheControl)
+
+	// ServeFile handles Range requests, so players can seek.
+	http.ServeFile(w, r, enc.LocalPath())
+} // func (srv *Server) handleEnclosureFile(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleCachedImg(w http.ResponseWriter, r *http.Request) {
+	// srv.log.Printf("[TRACE] Handle request for %s\n",
+	// 	r.URL.EscapedPath())
+
+	const notFound = "Not found"
+
+	var (
+		err                     error
+		basename, fullPath, msg string
+		fh                      *os.File
+		match                   []string
+	)
+
+	vars := mux.Vars(r)
+	basename = vars["img"]
+	fullPath = filepath.Join(common.CacheDir, basename)
+
+	if match = common.SuffixPattern.FindStringSubmatch(basename); match == nil {
+		msg = fmt.Sprintf("Could not determine file type for %s", basename)
+		srv.sendErrorMessage(w, msg)
+		return
+	}
+
+	var imgType = "image/" + match[1][1:]
+
+	// srv.log.Printf("[DEBUG] MIME type for %s is %s\n",
+	// 	fullPath,
+	// 	imgType)
+
+	if fh, err = os.Open(fullPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(notFound)) // nolint: errcheck
+		} else {
+			msg = fmt.Sprintf("Error opening %s: %s",
+				fullPath,
+				err.Error())
+			srv.sendErrorMessage(w, msg)
+		}
+
+		return
+	}
+
+	defer fh.Close() // nolint: errcheck
+
+	if common.Debug {
+		w.Header().Set("Cache-Control", "no-store, max-age=0")
+	} else {
+		w.Header().Set("Cache-Control", cacheControl)
+	}
+	w.Header().Set("Content-Type", imgType)
+	w.WriteHeader(200)
+
+	if _, err = io.Copy(w, fh); err != nil {
+		srv.log.Printf("[ERROR] Error sending file %s to %s: %s\n",
+			fullPath,
+			r.RemoteAddr,
+			err.Error())
+	}
+} // func (srv *Server) handleCachedImg(w http.ResponseWriter, request *http.Request)
+
+func (srv *Server) handleFavIco(w http.ResponseWriter, request *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		request.URL.EscapedPath())
+
+	const (
+		filename = "html/static/favicon.ico"
+		mimeType = "image/vnd.microsoft.icon"
+	)
+
+	w.Header().Set("Content-Type", mimeType)
+
+	if !common.Debug {
+		w.Header().Set("Cache-Control", "max-age=7200")
+	} else {
+		w.Header().Set("Cache-Control", cacheControl)
+	}
+
+	var (
+		err error
+		fh  fs.File
+	)
+
+	if fh, err = assets.Open(filename); err != nil {
+		msg := fmt.Sprintf("ERROR - cannot find file %s", filename)
+		srv.sendErrorMessage(w, msg)
+	} else {
+		defer fh.Close()
+		w.WriteHeader(200)
+		io.Copy(w, fh) // nolint: errcheck
+	}
+} // func (srv *Server) handleFavIco(w http.ResponseWriter, request *http.Request)
+
+func (srv *Server) handleStaticFile(w http.ResponseWriter, request *http.Request) {
+	// srv.log.Printf("[TRACE] Handle request for %s\n",
+	// 	request.URL.EscapedPath())
+
+	// Since we controll what static files the server has available, we
+	// can easily map MIME type to slice. Soon.
+
+	vars := mux.Vars(request)
+	filename := vars["file"]
+	path := filepath.Join("html", "static", filename)
+
+	var mimeType string
+
+	srv.log.Printf("[TRACE] Delivering static file %s to client\n", filename)
+
+	var match []string
+
+	if match = common.SuffixPattern.FindStringSubmatch(filename); match == nil {
+		mimeType = "text/plain"
+	} else if mime, ok := srv.mimeTypes[match[1]]; ok {
+		mimeType = mime
+	} else {
+		srv.log.Printf("[ERROR] Did not find MIME type for %s\n", filename)
+	}
+
+	w.Header().Set("Content-Type", mimeType)
+
+	if common.Debug {
+		w.Header().Set("Cache-Control", "no-store, max-age=0")
+	} else {
+		w.Header().Set("Cache-Control", cacheControl)
+	}
+
+	var (
+		err error
+		fh  fs.File
+	)
+
+	if fh, err = assets.Open(path); err != nil {
+		msg := fmt.Sprintf("ERROR - cannot find file %s", path)
+		srv.sendErrorMessage(w, msg)
+	} else {
+		defer fh.Close()
+		w.WriteHeader(200)
+		io.Copy(w, fh) // nolint: errcheck
+	}
+} // func (srv *Server) handleStaticFile(w http.ResponseWriter, request *http.Request)
+
+func (srv *Server) sendErrorMessage(w http.ResponseWriter, msg string) {
+	html := `
+<!DOCTYPE html>
+<html>
+  <head>
+    <title>Internal Error</title>
+  </head>
+  <body>
+    <h1>Internal Error</h1>
+    <hr />
+    We are sorry to inform you an internal application error has occured:<br />
+    %s
+    <p>
+    Back to <a href="/index">Homepage</a>
+    <hr />
+    &copy; 2018 <a href="mailto:krylon@gmx.net">Benjamin Walkenhorst</a>
+  </body>
+</html>
+`
+
+	srv.log.Printf("[ERROR] %s\n", msg)
+
+	output := fmt.Sprintf(html, msg)
+	w.WriteHeader(500)
+	_, _ = w.Write([]byte(output)) // nolint: gosec
+} // func (srv *Server) sendErrorMessage(w http.ResponseWriter, msg string)
+
+////////////////////////////////////////////////////////////////////////////////
+//// Ajax handlers /////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////
+
+// const success = "Success"
+
+func (srv *Server) handleBeacon(w http.ResponseWriter, r *http.Request) {
+	// srv.log.Printf("[TRACE] Handle %s from %s\n",
+	// 	r.URL,
+	// 	r.RemoteAddr)
+	var timestamp = time.Now().Format(common.TimestampFormat)
+	const appName = common.AppName + " " + common.Version
+	var jstr = fmt.Sprintf(`{ "Status": true, "Message": "%s", "Timestamp": "%s", "Hostname": "%s" }`,
+		appName,
+		timestamp,
+		hostname())
+	var response = []byte(jstr)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store, max-age=0")
+	w.WriteHeader(200)
+	w.Write(response) // nolint: errcheck,gosec
+} // func (srv *Web) handleBeacon(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleGetNewMessages(w http.ResponseWriter, r *http.Request) {
+	// srv.log.Printf("[TRACE] Handle %s from %s\n",
+	// 	r.URL,
+	// 	r.RemoteAddr)
+
+	type msgItem struct {
+		Time    string
+		Level   logutils.LogLevel
+		Message string
+	}
+
+	type resBody struct {
+		Status   bool
+		Message  string
+		Messages []msgItem
+	}
+
+	var messages = srv.getMessages()
+	var res = resBody{
+		Status:   true,
+		Messages: make([]msgItem, len(messages)),
+	}
+
+	for idx, i := range messages {
+		res.Messages[idx] = msgItem{
+			Time:    i.TimeString(),
+			Level:   i.Level,
+			Message: i.Message,
+		}
+	}
+
+	var (
+		err error
+		msg string
+		buf []byte
+	)
+
+	if buf, err = json.Marshal(&res); err != nil {
+		msg = fmt.Sprintf("Error serializing response: %s",
+			err.Error())
+		srv.SendMessage(msg)
+		res.Message = msg
+		buf = errJSON(msg)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store, max-age=0")
+	w.WriteHeader(200)
+	if _, err = w.Write(buf); err != nil {
+		msg = fmt.Sprintf("Failed to send result: %s",
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+	}
+} // func (srv *Server) handleGetNewMessages(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleRateItem(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err                     error
+		db                      *database.Database
+		idStr, rStr, msg, reply string
+		id                      int64
+		item                    *feed.Item
+		rating                  float64
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Could not parse form data: %s", err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		return
+	}
+
+	idStr = r.PostFormValue("ID")
+	rStr = r.PostFormValue("Rating")
+
+	srv.log.Printf("[DEBUG] Rate Item %s - %s\n",
+		idStr,
+		rStr)
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s", idStr, err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		goto SEND_ERROR_MESSAGE
+	} else if rating, err = strconv.ParseFloat(rStr, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Rating %q: %s", rStr, err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if item, err = db.ItemGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot load Item by ID %d: %s",
+			id,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		goto SEND_ERROR_MESSAGE
+	} else if item == nil {
+		msg = fmt.Sprintf("No such Item: %d",
+			id)
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		goto SEND_ERROR_MESSAGE
+	} else if err = db.ItemRatingSet(item, rating); err != nil {
+		msg = fmt.Sprintf("Cannot set Rating of Item %d to %.2f: %s",
+			item.ID,
+			rating,
+			err.Error())
+		srv.log.Println("[ERROR] " + msg)
+		srv.SendMessage(msg)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	reply = fmt.Sprintf(`{ "Status": true, "ID": %d, "Rating": %f, "Message": "Success" }`,
+		id,
+		rating)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	reply = fmt.Sprintf(`{ "Status": false, "ID": %d, "Rating": %f, "Message": "%s" }`,
+		id,
+		math.NaN(),
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleRateItem(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleUnrateItem(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err                       error
+		db                        *database.Database
+		idStr, msg, reply, rating string
+		id                        int64
+		item                      *feed.Item
+	)
+
+	vars := mux.Vars(r)
+
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if item, err = db.ItemGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot load Item %d: %s",
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if item == nil {
+		msg = fmt.Sprintf("No such Item: %d", id)
+		goto SEND_ERROR_MESSAGE
+	} else if !item.IsRated() {
+		msg = fmt.Sprintf("Item %q (%d) is not rated",
+			item.Title,
+			item.ID)
+		goto SEND_ERROR_MESSAGE
+	} else if item.Rating > 0.5 {
+		rating = classifier.Good
+	} else {
+		rating = classifier.Bad
+	}
+
+	if err = srv.clsItem.Unlearn(rating, item); err != nil {
+		msg = fmt.Sprintf("Error unlearning rating for Item %q (%d): %s",
+			item.Title,
+			item.ID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if err = db.ItemRatingClear(item); err != nil {
+		msg = fmt.Sprintf("Cannot clear Rating of Item %d (%q): %s",
+			id,
+			item.Title,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	reply = fmt.Sprintf(`{ "Status": true, "ID": %d, "Message": "Success" }`,
+		id)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "ID": %d, "Message": "%s" }`,
+		id,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleUnrateItem(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleSuggestRating(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	type replyMsg struct {
+		Status  bool
+		Message string
+		Rating  float64
+	}
+
+	var (
+		err                     error
+		db                      *database.Database
+		msg, reply, idStr, sugg string
+		id                      int64
+		item                    *feed.Item
+		answer                  replyMsg
+		buf                     []byte
+	)
+
+	vars := mux.Vars(r)
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if item, err = db.ItemGetByID(id); err != nil {
+		msg = fmt.Sprintf("Failed to get Item %d: %s",
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if item == nil {
+		msg = fmt.Sprintf("Item %d was not found in database", id)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	srv.clsLock.RLock()
+	defer srv.clsLock.RUnlock()
+
+	if sugg, err = srv.clsItem.Classify(item); err != nil {
+		msg = fmt.Sprintf("Error trying to classify Item %d (%s): %s",
+			id,
+			item.Title,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	answer.Status = true
+	answer.Message = "Success"
+
+	switch sugg {
+	case classifier.Good:
+		answer.Rating = 100
+	case classifier.Bad:
+		answer.Rating = -100
+	}
+
+	if buf, err = json.Marshal(&answer); err != nil {
+		msg = fmt.Sprintf("Error serializing answer: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "ID": %d, "Message": "%s" }`,
+		id,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleSuggestRating(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleRebuildFTS(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err        error
+		db         *database.Database
+		msg, reply string
+		status     bool
+	)
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if err = db.FTSRebuild(); err != nil {
+		msg = fmt.Sprintf("Cannot rebuild FTS index: %s",
+			err.Error())
+	} else {
+		msg = "FTS index was rebuilt successfully."
+		status = true
+	}
+
+	reply = fmt.Sprintf(`{ "Status": %t, "Message": "%s" }`,
+		status,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	// w.Header().Set("Content-Length", strconv.FormatInt(len(reply), 10))
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleRebuildFTS(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleAjaxTagDetails(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	type tagResponse struct {
+		Status  bool
+		Message string
+		Tag     *tag.Tag
+	}
+
+	var (
+		err               error
+		db                *database.Database
+		id                int64
+		t                 *tag.Tag
+		idStr, msg, reply string
+		buf               []byte
+		resp              tagResponse
+	)
+
+	vars := mux.Vars(r)
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Tag ID %s: %s",
+			idStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if t, err = db.TagGetByID(id); err != nil {
+		msg = fmt.Sprintf("Failed to get Tag %d from database: %s",
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	resp = tagResponse{
+		Status:  true,
+		Message: "Success",
+		Tag:     t,
+	}
+
+	if buf, err = json.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize Response for Tag %d (%s): %s",
+			id,
+			t.Name,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	w.Header().Set("Content-Length", strconv.FormatInt(int64(len(buf)), 10))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(buf) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleAjaxTagDetails(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleTagLinkCreate(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err                         error
+		db                          *database.Database
+		tagStr, itemStr, msg, reply string
+		itemID, tagID               int64
+		item                        *feed.Item
+		t                           *tag.Tag
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	itemStr = r.FormValue("Item")
+	tagStr = r.FormValue("Tag")
+
+	if itemID, err = strconv.ParseInt(itemStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot Parse Item ID %q: %s",
+			itemStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if tagID, err = strconv.ParseInt(tagStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot Parse Tag ID %q: %s",
+			tagStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if item, err = db.ItemGetByID(itemID); err != nil {
+		msg = fmt.Sprintf("Failed to fetch Item #%d from database: %s",
+			itemID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if item == nil {
+		msg = fmt.Sprintf("Did not find Item #%d in database", itemID)
+		goto SEND_ERROR_MESSAGE
+	} else if err = db.TagLinkCreate(itemID, tagID); err != nil {
+		msg = fmt.Sprintf("Cannot Attach Tag %d to Item %d: %s",
+			tagID,
+			itemID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if t, err = db.TagGetByID(tagID); err != nil {
+		msg = fmt.Sprintf("Cannot load Tag %d: %s",
+			tagID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if err = srv.clsTags.Learn(t, item); err != nil {
+		srv.log.Printf("[ERROR] Failed to learn Tag %s for Item %q: %s\n",
+			t.Name,
+			item.Title,
+			err.Error())
+	}
+
+	srv.log.Printf("[DEBUG] Attach Tag %d to Item %d successfully.\n",
+		tagID,
+		itemID)
+	reply = fmt.Sprintf(`{ "Status": true, "Message": "Success", "ID": %d, "Name": %q }`,
+		tagID,
+		t.Name)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleTagLinkCreate(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleTagLinkDelete(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err                         error
+		db                          *database.Database
+		tagStr, itemStr, msg, reply string
+		itemID, tagID               int64
+		item                        *feed.Item
+		t                           *tag.Tag
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	itemStr = r.FormValue("Item")
+	tagStr = r.FormValue("Tag")
+
+	if itemID, err = strconv.ParseInt(itemStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot Parse Item ID %q: %s",
+			itemStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if tagID, err = strconv.ParseInt(tagStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot Parse Tag ID %q: %s",
+			tagStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if item, err = db.ItemGetByID(itemID); err != nil {
+		msg = fmt.Sprintf("Failed to fetch Item #%d from database: %s",
+			itemID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if item == nil {
+		msg = fmt.Sprintf("Did not find Item #%d in database",
+			itemID)
+		goto SEND_ERROR_MESSAGE
+	} else if t, err = db.TagGetByID(tagID); err != nil {
+		msg = fmt.Sprintf("Failed to load Tag #%d: %s",
+			tagID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if t == nil {
+		msg = fmt.Sprintf("Did not find Tag #%d in database",
+			tagID)
+		goto SEND_ERROR_MESSAGE
+	} else if err = db.TagLinkDelete(itemID, tagID); err != nil {
+		msg = fmt.Sprintf("Cannot Attach Tag %d to Item %d: %s",
+			tagID,
+			itemID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if err = srv.clsTags.Unlearn(t, item); err != nil {
+		srv.log.Printf("[ERROR] Failed to unlearn Tag %s for Item %s (%d): %s\n",
+			t.Name,
+			item.Title,
+			item.ID,
+			err.Error())
+	}
+
+	srv.log.Printf("[DEBUG] Detach Tag %d from Item %d successfully.\n",
+		tagID,
+		itemID)
+	reply = `{ "Status": true, "Message": "Success" }`
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleTagLinkDelete(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleReadLaterMark(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err                      error
+		msg, reply               string
+		db                       *database.Database
+		idStr, note, deadlineStr string
+		itemID, timestamp        int64
+		item                     *feed.Item
+		deadline                 time.Time
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	idStr = r.FormValue("ItemID")
+	note = r.FormValue("Note")
+	deadlineStr = r.FormValue("Deadline")
+
+	if itemID, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Item ID %q: %s",
+			idStr,
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		goto SEND_ERROR_MESSAGE
+	} else if timestamp, err = strconv.ParseInt(deadlineStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse deadline %q: %s",
+			deadlineStr,
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	deadline = time.Unix(timestamp, 0)
+	srv.log.Printf("[DEBUG] Set deadline for Item %d to %s\n",
+		itemID,
+		deadline.Format(common.TimestampFormat))
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if item, err = db.ItemGetByID(itemID); err != nil {
+		msg = fmt.Sprintf("Cannot load Item %d: %s",
+			itemID,
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n",
+			msg)
+		goto SEND_ERROR_MESSAGE
+	} else if _, err = db.ReadLaterAdd(item, note, deadline); err != nil {
+		msg = fmt.Sprintf("Cannot mark Item %q (%d) for reading later: %s",
+			item.Title,
+			item.ID,
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n",
+			msg)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	srv.log.Printf("[DEBUG] %s -- Mark Item %q (%d) for later reading: Deadline = %s, Note = %q\n",
+		r.URL,
+		item.Title,
+		itemID,
+		deadline.Format(common.TimestampFormat),
+		note)
+
+	reply = `{ "Status": true, "Message": "Success" }`
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleReadLaterMark(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleReadLaterSetRead(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err             error
+		msg, reply      string
+		db              *database.Database
+		idStr, stateStr string
+		itemID, state   int64
+	)
+
+	vars := mux.Vars(r)
+
+	idStr = vars["id"]
+	stateStr = vars["state"]
+
+	if itemID, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Item ID %q: %s",
+			idStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if state, err = strconv.ParseInt(stateStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse State %q: %s",
+			stateStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if state != 0 {
+		if err = db.ReadLaterMarkRead(itemID); err != nil {
+			msg = fmt.Sprintf("Cannot mark Item %d as read: %s",
+				itemID,
+				err.Error())
+			goto SEND_ERROR_MESSAGE
+		}
+	} else if err = db.ReadLaterMarkUnread(itemID); err != nil {
+		msg = fmt.Sprintf("Cannot mark Item %d as unread: %s",
+			itemID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	reply = `{ "Status": true, "Message": "Success" }`
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleReadLaterSetRead(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleFeedUpdate(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err                        error
+		db                         *database.Database
+		name, lnkFeed, lnkHomepage string
+		intervalStr, idStr         string
+		minStr, maxStr             string
+		reply, msg                 string
+		seconds, id                int64
+		minSec, maxSec             int64
+		interval                   time.Duration
+		adaptive, clearAuth        bool
+		fullText                   bool
+		fd                         *feed.Feed
+		auth                       *feed.Auth
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Error parsing form data: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	name = r.FormValue("Name")
+	lnkFeed = r.FormValue("URL")
+	lnkHomepage = r.FormValue("Homepage")
+	intervalStr = r.FormValue("Interval")
+	idStr = r.FormValue("ID")
+	minStr = r.FormValue("IntervalMin")
+	maxStr = r.FormValue("IntervalMax")
+	adaptive, _ = strconv.ParseBool(r.FormValue("Adaptive"))
+	clearAuth, _ = strconv.ParseBool(r.FormValue("auth_clear"))
+	fullText, _ = strconv.ParseBool(r.FormValue("FullText"))
+
+	// Older clients do not send the bounds for adaptive mode.
+	if minStr == "" {
+		minStr = "0"
+	}
+
+	if maxStr == "" {
+		maxStr = "0"
+	}
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if seconds, err = strconv.ParseInt(intervalStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Interval %q: %s",
+			intervalStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if minSec, err = strconv.ParseInt(minStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse minimum Interval %q: %s",
+			minStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if maxSec, err = strconv.ParseInt(maxStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse maximum Interval %q: %s",
+			maxStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if auth, err = authFromForm(r); err != nil {
+		msg = fmt.Sprintf("Cannot parse credentials: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	interval = time.Second * time.Duration(seconds)
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if fd, err = db.FeedGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot get Feed %d: %s",
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if fd == nil {
+		msg = fmt.Sprintf("Feed %d was not found in database",
+			id)
+		goto SEND_ERROR_MESSAGE
+	} else if err = db.FeedModify(fd, name, lnkFeed, lnkHomepage, interval); err != nil {
+		msg = fmt.Sprintf("Error updating Feed %s (%d): %s",
+			fd.Name,
+			fd.ID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if err = db.FeedSetAdaptive(
+		fd,
+		adaptive,
+		time.Second*time.Duration(minSec),
+		time.Second*time.Duration(maxSec)); err != nil {
+		msg = fmt.Sprintf("Error updating adaptive mode for Feed %s (%d): %s",
+			fd.Name,
+			fd.ID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if err = db.FeedSetFullText(fd, fullText); err != nil {
+		msg = fmt.Sprintf("Error updating full text option for Feed %s (%d): %s",
+			fd.Name,
+			fd.ID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	// Credentials are never sent to the client, so empty fields mean
+	// the user wants to keep what is there.
+	if clearAuth {
+		auth = nil
+	} else {
+		auth = fd.Auth.Merge(auth)
+	}
+
+	if err = db.FeedSetAuth(fd, auth); err != nil {
+		msg = fmt.Sprintf("Error updating credentials for Feed %s (%d): %s",
+			fd.Name,
+			fd.ID,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	reply = fmt.Sprintf(`{ "Status": true, "Message": "Success", "Auth": "%s" }`,
+		jsonEscape(authSummary(fd.Auth)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleFeedUpdate(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleFeedActiveToggle(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	var (
+		err              error
+		db               *database.Database
+		idStr, activeStr string
+		msg, reply       string
+		id               int64
+		active           bool
+	)
+
+	vars := mux.Vars(r)
+
+	idStr = vars["id"]
+	activeStr = vars["active"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+			idStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if active, err = strconv.ParseBool(activeStr); err != nil {
+		msg = fmt.Sprintf("Cannot parse flag %q: %s",
+			activeStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if err = db.FeedSetActive(id, active); err != nil {
+		msg = fmt.Sprintf("Cannot set active flag for Feed %d: %s",
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	reply = `{ "Status": true, "Message": "Success" }`
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleFeedActiveToggle(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleItemsByTag(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const tmplName = "items"
+
+	type response struct {
+		Status  bool
+		Message string
+	}
+
+	var (
+		err               error
+		db                *database.Database
+		tmpl              *template.Template
+		idStr, msg, reply string
+		id                int64
+		buf               bytes.Buffer
+		res               response
+		t                 *tag.Tag
+		raw               []byte
+		getItems          func(*tag.Tag) ([]feed.Item, error)
+		data              = tmplDataItems{
+			tmplDataBase: tmplDataBase{
+				Title:      "Items",
+				Debug:      common.Debug,
+				URL:        r.URL.String(),
+				TrainStamp: srv.trainStamp(),
+				ShowMuted:  showMuted(r),
+				UnreadOnly: unreadOnly(r, false),
+			},
+		}
+	)
+
+	vars := mux.Vars(r)
+
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("[ERROR] Cannot parse Tag ID %q: %s\n",
+			idStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Did not find template %q", tmplName)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	data.MarkReadURL = fmt.Sprintf("/ajax/tag_mark_read/%d", id)
+
+	if data.UnreadOnly {
+		getItems = db.ItemGetByTagRecursiveUnread
+	} else {
+		getItems = db.ItemGetByTagRecursive
+	}
+
+	if t, err = db.TagGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot get Tag %d: %s",
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if t == nil {
+		msg = fmt.Sprintf("Tag %d was not found",
+			id)
+		goto SEND_ERROR_MESSAGE
+	} else if data.Items, err = getItems(t); err != nil {
+		msg = fmt.Sprintf("Cannot load Items for Tag %s (%d): %s",
+			t.Name,
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.Items, data.MutedCnt, err = filterMuted(db, data.Items, data.ShowMuted); err != nil {
+		msg = fmt.Sprintf("Cannot apply Mutes: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.UnreadCnt, err = db.ItemGetUnreadCntByTagRecursive(t); err != nil {
+		msg = fmt.Sprintf("Cannot count unread Items for Tag %s (%d): %s",
+			t.Name,
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load all Tags: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.FeedMap, err = db.FeedGetMap(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	srv.clsLock.RLock()
+	defer srv.clsLock.RUnlock()
+
+	for idx, item := range data.Items {
+		var class string
+
+		if !math.IsNaN(item.Rating) {
+			if item.Rating == 1 {
+				data.Items[idx].Rating = math.Inf(1)
+			} else if item.Rating == 0 {
+				data.Items[idx].Rating = math.Inf(-1)
+			} else {
+				msg = fmt.Sprintf("Unexpected Rating for Item %s (%d): %f",
+					item.Title,
+					item.ID,
+					item.Rating)
+				srv.log.Println("[ERROR] " + msg)
+				srv.SendMessage(msg)
+				http.Redirect(w, r, r.Referer(), http.StatusFound)
+				return
+			}
+
+			continue
+		} else if class, err = srv.clsItem.Classify(&item); err != nil {
+			srv.log.Printf("[ERROR] Cannot classify Item %s (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+		} else if class == classifier.Good {
+			data.Items[idx].Rating = 100
+		} else if class == classifier.Bad {
+			data.Items[idx].Rating = -100
+		} else {
+			srv.log.Printf("[ERROR] Unexpected classification for Item %s (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+		}
+	}
+
+	if err = tmpl.Execute(&buf, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %s: %s",
+			tmplName,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	res.Status = true
+	res.Message = buf.String()
+
+	if raw, err = json.Marshal(&res); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if _, err = w.Write(raw); err != nil {
+		msg = fmt.Sprintf("Cannot send message to client %s: %s",
+			r.RemoteAddr,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.log.Printf("[ERROR] %s\n", msg)
+	}
+
+	return
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleItemsByTag(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleItemsByFeed(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const tmplName = "items"
+
+	type response struct {
+		Status  bool
+		Message string
+	}
+
+	var (
+		err               error
+		db                *database.Database
+		tmpl              *template.Template
+		idStr, msg, reply string
+		id                int64
+		buf               bytes.Buffer
+		res               response
+		t                 *tag.Tag
+		raw               []byte
+		getItems          func(int64, int64) ([]feed.Item, error)
+		data              = tmplDataItems{
+			tmplDataBase: tmplDataBase{
+				Title:      "Items",
+				Debug:      common.Debug,
+				URL:        r.URL.String(),
+				TrainStamp: srv.trainStamp(),
+				ShowMuted:  showMuted(r),
+				UnreadOnly: unreadOnly(r, false),
+			},
+		}
+	)
+
+	vars := mux.Vars(r)
+
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("[ERROR] Cannot parse Tag ID %q: %s\n",
+			idStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Did not find template %q", tmplName)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	data.MarkReadURL = fmt.Sprintf("/ajax/feed_mark_read/%d", id)
+
+	if data.UnreadOnly {
+		getItems = db.ItemGetByFeedUnread
+	} else {
+		getItems = db.ItemGetByFeed
+	}
+
+	if data.Items, err = getItems(id, -1); err != nil {
+		msg = fmt.Sprintf("Cannot load Items for Tag %s (%d): %s",
+			t.Name,
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.Items, data.MutedCnt, err = filterMuted(db, data.Items, data.ShowMuted); err != nil {
+		msg = fmt.Sprintf("Cannot apply Mutes: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.UnreadCnt, err = db.ItemGetUnreadCntByFeed(id); err != nil {
+		msg = fmt.Sprintf("Cannot count unread Items: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
+		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
+			err.Error())
+		srv.log.Printf("[ERROR] %s\n", msg)
+		srv.sendErrorMessage(w, msg)
+		return
+	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load all Tags: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.FeedMap, err = db.FeedGetMap(); err != nil {
+		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	srv.clsLock.RLock()
+	defer srv.clsLock.RUnlock()
+
+	for idx, item := range data.Items {
+		var class string
+
+		if !math.IsNaN(item.Rating) {
+			if item.Rating == 1 {
+				data.Items[idx].Rating = math.Inf(1)
+			} else if item.Rating == 0 {
+				data.Items[idx].Rating = math.Inf(-1)
+			} else {
+				msg = fmt.Sprintf("Unexpected Rating for Item %s (%d): %f",
+					item.Title,
+					item.ID,
+					item.Rating)
+				srv.log.Println("[ERROR] " + msg)
+				srv.SendMessage(msg)
+				http.Redirect(w, r, r.Referer(), http.StatusFound)
+				return
+			}
+
+			continue
+		} else if class, err = srv.clsItem.Classify(&item); err != nil {
+			srv.log.Printf("[ERROR] Cannot classify Item %s (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+		} else if class == classifier.Good {
+			data.Items[idx].Rating = 100
+		} else if class == classifier.Bad {
+			data.Items[idx].Rating = -100
+		} else {
+			srv.log.Printf("[ERROR] Unexpected classification for Item %s (%d): %s\n",
+				item.Title,
+				item.ID,
+				err.Error())
+		}
+	}
+
+	if err = tmpl.Execute(&buf, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %s: %s",
+			tmplName,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	res.Status = true
+	res.Message = buf.String()
+
+	if raw, err = json.Marshal(&res); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if _, err = w.Write(raw); err != nil {
+		msg = fmt.Sprintf("Cannot send message to client %s: %s",
+			r.RemoteAddr,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.log.Printf("[ERROR] %s\n", msg)
+	}
+
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleItemsByFeed(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleFeedCategories(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	const tmplName = "feed_categories"
+
+	var (
+		err               error
+		db                *database.Database
+		tmpl              *template.Template
+		idStr, msg, reply string
+		id                int64
+		buf               bytes.Buffer
+		res               ajaxResponse
+		raw               []byte
+		data              = tmplDataCategories{
+			tmplDataBase: tmplDataBase{
+				Title:      "Categories",
+				Debug:      common.Debug,
+				URL:        r.URL.String(),
+				TrainStamp: srv.trainStamp(),
+			},
+		}
+	)
+
+	vars := mux.Vars(r)
+
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		msg = fmt.Sprintf("Cannot parse Feed ID %q: %s",
+			idStr,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Did not find template %q", tmplName)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if data.Feed, err = db.FeedGetByID(id); err != nil {
+		msg = fmt.Sprintf("Cannot load Feed %d: %s",
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.Feed == nil {
+		msg = fmt.Sprintf("No such Feed: %d", id)
+		goto SEND_ERROR_MESSAGE
+	} else if data.Categories, err = db.CategoryGetByFeed(id); err != nil {
+		msg = fmt.Sprintf("Cannot load categories of Feed %s (%d): %s",
+			data.Feed.Name,
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.Mappings, err = db.CategoryTagGetByFeed(id); err != nil {
+		msg = fmt.Sprintf("Cannot load category mappings of Feed %s (%d): %s",
+			data.Feed.Name,
+			id,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
+		msg = fmt.Sprintf("Cannot load all Tags: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	if err = tmpl.Execute(&buf, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %s: %s",
+			tmplName,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	res.Status = true
+	res.Message = buf.String()
+
+	if raw, err = json.Marshal(&res); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	if _, err = w.Write(raw); err != nil {
+		msg = fmt.Sprintf("Cannot send message to client %s: %s",
+			r.RemoteAddr,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.log.Printf("[ERROR] %s\n", msg)
+	}
+
+	return
+
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	srv.SendMessage(msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": %q }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleFeedCategories(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleScrapePreview(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
+
+	const tmplName = "scrape_preview"
+
+	var (
+		err        error
+		tmpl       *template.Template
+		msg, reply string
+		auth       *feed.Auth
+		buf        bytes.Buffer
+		res        ajaxResponse
+		raw        []byte
+		data       = tmplDataScrapePreview{
+			tmplDataBase: srv.baseData("Preview", r),
+		}
+	)
+
+	if err = r.ParseForm(); err != nil {
+		msg = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Did not find template %q", tmplName)
+		goto SEND_ERROR_MESSAGE
+	}
+
+	data.Page = r.FormValue("url")
+
+	if data.Scraper, err = scraperFromForm(r); err != nil {
+		msg = fmt.Sprintf("Cannot parse selectors: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.Scraper == nil {
+		msg = "Please enter a selector for the Items"
+		goto SEND_ERROR_MESSAGE
+	} else if auth, err = authFromForm(r); err != nil {
+		msg = fmt.Sprintf("Cannot parse credentials: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if data.Items, err = data.Scraper.Preview(data.Page, auth); err != nil {
+		msg = fmt.Sprintf("Cannot extract Items from %s: %s",
+			data.Page,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	} else if err = tmpl.Execute(&buf, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %s: %s",
+			tmplName,
+			err.Error())
+		goto SEND_ERROR_MESSAGE
+	}
+
+	res.Status = true
+	res.Message = buf.String()
+
+	if raw, err = json.Marshal(&res); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %s",
+			err.Error())
+		goto SEND_ERROR_MESSAGE
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
-	io.Copy(w, fh) // nolint: errcheck
-} // func (srv *Server) handleArchivedFile(w http.ResponseWriter, r *http.Request)
+	if _, err = w.Write(raw); err != nil {
+		msg = fmt.Sprintf("Cannot send message to client %s: %s",
+			r.RemoteAddr,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.log.Printf("[ERROR] %s\n", msg)
+	}
 
-func (srv *Server) handleCachedImg(w http.ResponseWriter, r *http.Request) {
-	// srv.log.Printf("[TRACE] Handle request for %s\n",
-	// 	r.URL.EscapedPath())
+	return
 
-	const notFound = "Not found"
+SEND_ERROR_MESSAGE:
+	srv.log.Printf("[ERROR] %s\n", msg)
+	reply = fmt.Sprintf(`{ "Status": false, "Message": %q }`,
+		msg)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(reply)) // nolint: errcheck
+} // func (srv *Server) handleScrapePreview(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleCategoryTagAdd(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
 
 	var (
-		err                     error
-		basename, fullPath, msg string
-		fh                      *os.File
-		match                   []string
+		err             error
+		db              *database.Database
+		feedStr, tagStr string
+		category, msg   string
+		feedID, tagID   int64
+		m               *feed.CategoryTag
+		resp            ajaxResponse
+		replyBuffer     []byte
 	)
 
-	vars := mux.Vars(r)
-	basename = vars["img"]
-	fullPath = filepath.Join(common.CacheDir, basename)
-
-	if match = common.SuffixPattern.FindStringSubmatch(basename); match == nil {
-		msg = fmt.Sprintf("Could not determine file type for %s", basename)
-		srv.sendErrorMessage(w, msg)
-		return
+	if err = r.ParseForm(); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	var imgType = "image/" + match[1][1:]
+	feedStr = r.FormValue("Feed")
+	tagStr = r.FormValue("Tag")
+	category = strings.TrimSpace(r.FormValue("Category"))
 
-	// srv.log.Printf("[DEBUG] MIME type for %s is %s\n",
-	// 	fullPath,
-	// 	imgType)
+	if feedID, err = strconv.ParseInt(feedStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Feed ID %q: %s",
+			feedStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	} else if tagID, err = strconv.ParseInt(tagStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Tag ID %q: %s",
+			tagStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	}
 
-	if fh, err = os.Open(fullPath); err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
-			w.Header().Set("Content-Type", "text/plain")
-			w.WriteHeader(http.StatusNotFound)
-			w.Write([]byte(notFound)) // nolint: errcheck
-		} else {
-			msg = fmt.Sprintf("Error opening %s: %s",
-				fullPath,
-				err.Error())
-			srv.sendErrorMessage(w, msg)
-		}
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
 
-		return
+	if m, err = db.CategoryTagAdd(feedID, category, tagID); err != nil {
+		resp.Message = fmt.Sprintf("Cannot map category %q to Tag %d: %s",
+			category,
+			tagID,
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	defer fh.Close() // nolint: errcheck
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Category %q is now mapped to Tag %d",
+		m.Category,
+		m.TagID)
 
-	if common.Debug {
-		w.Header().Set("Cache-Control", "no-store, max-age=0")
-	} else {
-		w.Header().Set("Cache-Control", cacheControl)
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
 	}
-	w.Header().Set("Content-Type", imgType)
-	w.WriteHeader(200)
 
-	if _, err = io.Copy(w, fh); err != nil {
-		srv.log.Printf("[ERROR] Error sending file %s to %s: %s\n",
-			fullPath,
-			r.RemoteAddr,
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
 			err.Error())
+		replyBuffer = errJSON(msg)
 	}
-} // func (srv *Server) handleCachedImg(w http.ResponseWriter, request *http.Request)
 
-func (srv *Server) handleFavIco(w http.ResponseWriter, request *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleCategoryTagAdd(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleCategoryTagDelete(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle request for %s\n",
-		request.URL.EscapedPath())
+		r.URL.EscapedPath())
 
-	const (
-		filename = "html/static/favicon.ico"
-		mimeType = "image/vnd.microsoft.icon"
+	var (
+		err         error
+		db          *database.Database
+		idStr, msg  string
+		id          int64
+		resp        ajaxResponse
+		replyBuffer []byte
 	)
 
-	w.Header().Set("Content-Type", mimeType)
+	vars := mux.Vars(r)
 
-	if !common.Debug {
-		w.Header().Set("Cache-Control", "max-age=7200")
-	} else {
-		w.Header().Set("Cache-Control", cacheControl)
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse ID %q: %s",
+			idStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	var (
-		err error
-		fh  fs.File
-	)
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
 
-	if fh, err = assets.Open(filename); err != nil {
-		msg := fmt.Sprintf("ERROR - cannot find file %s", filename)
-		srv.sendErrorMessage(w, msg)
-	} else {
-		defer fh.Close()
-		w.WriteHeader(200)
-		io.Copy(w, fh) // nolint: errcheck
+	if err = db.CategoryTagDelete(id); err != nil {
+		resp.Message = fmt.Sprintf("Cannot delete category mapping %d: %s",
+			id,
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
-} // func (srv *Server) handleFavIco(w http.ResponseWriter, request *http.Request)
 
-func (srv *Server) handleStaticFile(w http.ResponseWriter, request *http.Request) {
-	// srv.log.Printf("[TRACE] Handle request for %s\n",
-	// 	request.URL.EscapedPath())
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Category mapping %d deleted", id)
 
-	// Since we controll what static files the server has available, we
-	// can easily map MIME type to slice. Soon.
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
 
-	vars := mux.Vars(request)
-	filename := vars["file"]
-	path := filepath.Join("html", "static", filename)
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
 
-	var mimeType string
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleCategoryTagDelete(w http.ResponseWriter, r *http.Request)
 
-	srv.log.Printf("[TRACE] Delivering static file %s to client\n", filename)
+func (srv *Server) handleRetentionSet(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
 
-	var match []string
+	var (
+		err              error
+		db               *database.Database
+		feedStr, daysStr string
+		msg              string
+		days             int64
+		pol              feed.Retention
+		resp             ajaxResponse
+		replyBuffer      []byte
+	)
 
-	if match = common.SuffixPattern.FindStringSubmatch(filename); match == nil {
-		mimeType = "text/plain"
-	} else if mime, ok := srv.mimeTypes[match[1]]; ok {
-		mimeType = mime
-	} else {
-		srv.log.Printf("[ERROR] Did not find MIME type for %s\n", filename)
+	if err = r.ParseForm(); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	w.Header().Set("Content-Type", mimeType)
+	feedStr = r.FormValue("Feed")
+	daysStr = r.FormValue("Days")
+	pol.ReadOnly = r.FormValue("ReadOnly") == "true"
 
-	if common.Debug {
-		w.Header().Set("Cache-Control", "no-store, max-age=0")
-	} else {
-		w.Header().Set("Cache-Control", cacheControl)
+	if pol.FeedID, err = strconv.ParseInt(feedStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Feed ID %q: %s",
+			feedStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	} else if days, err = strconv.ParseInt(daysStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse number of days %q: %s",
+			daysStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	var (
-		err error
-		fh  fs.File
-	)
+	pol.MaxAge = feed.Day * time.Duration(days)
 
-	if fh, err = assets.Open(path); err != nil {
-		msg := fmt.Sprintf("ERROR - cannot find file %s", path)
-		srv.sendErrorMessage(w, msg)
-	} else {
-		defer fh.Close()
-		w.WriteHeader(200)
-		io.Copy(w, fh) // nolint: errcheck
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if err = db.RetentionSet(&pol); err != nil {
+		resp.Message = fmt.Sprintf("Cannot set Retention policy: %s",
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
-} // func (srv *Server) handleStaticFile(w http.ResponseWriter, request *http.Request)
 
-func (srv *Server) sendErrorMessage(w http.ResponseWriter, msg string) {
-	html := `
-<!DOCTYPE html>
-<html>
-  <head>
-    <title>Internal Error</title>
-  </head>
-  <body>
-    <h1>Internal Error</h1>
-    <hr />
-    We are sorry to inform you an internal application error has occured:<br />
-    %s
-    <p>
-    Back to <a href="/index">Homepage</a>
-    <hr />
-    &copy; 2018 <a href="mailto:krylon@gmx.net">Benjamin Walkenhorst</a>
-  </body>
-</html>
-`
+	resp.Status = true
+	resp.Message = pol.String()
 
-	srv.log.Printf("[ERROR] %s\n", msg)
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
 
-	output := fmt.Sprintf(html, msg)
-	w.WriteHeader(500)
-	_, _ = w.Write([]byte(output)) // nolint: gosec
-} // func (srv *Server) sendErrorMessage(w http.ResponseWriter, msg string)
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
 
-////////////////////////////////////////////////////////////////////////////////
-//// Ajax handlers /////////////////////////////////////////////////////////////
-////////////////////////////////////////////////////////////////////////////////
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleRetentionSet(w http.ResponseWriter, r *http.Request)
 
-// const success = "Success"
+func (srv *Server) handleRetentionDelete(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
 
-func (srv *Server) handleBeacon(w http.ResponseWriter, r *http.Request) {
-	// srv.log.Printf("[TRACE] Handle %s from %s\n",
-	// 	r.URL,
-	// 	r.RemoteAddr)
-	var timestamp = time.Now().Format(common.TimestampFormat)
-	const appName = common.AppName + " " + common.Version
-	var jstr = fmt.Sprintf(`{ "Status": true, "Message": "%s", "Timestamp": "%s", "Hostname": "%s" }`,
-		appName,
-		timestamp,
-		hostname())
-	var response = []byte(jstr)
+	var (
+		err         error
+		db          *database.Database
+		idStr, msg  string
+		id          int64
+		resp        ajaxResponse
+		replyBuffer []byte
+	)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "no-store, max-age=0")
-	w.WriteHeader(200)
-	w.Write(response) // nolint: errcheck,gosec
-} // func (srv *Web) handleBeacon(w http.ResponseWriter, r *http.Request)
+	vars := mux.Vars(r)
 
-func (srv *Server) handleGetNewMessages(w http.ResponseWriter, r *http.Request) {
-	// srv.log.Printf("[TRACE] Handle %s from %s\n",
-	// 	r.URL,
-	// 	r.RemoteAddr)
+	idStr = vars["id"]
 
-	type msgItem struct {
-		Time    string
-		Level   logutils.LogLevel
-		Message string
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Feed ID %q: %s",
+			idStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	type resBody struct {
-		Status   bool
-		Message  string
-		Messages []msgItem
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if err = db.RetentionDelete(id); err != nil {
+		resp.Message = fmt.Sprintf("Cannot delete Retention policy for Feed %d: %s",
+			id,
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	var messages = srv.getMessages()
-	var res = resBody{
-		Status:   true,
-		Messages: make([]msgItem, len(messages)),
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Retention policy for Feed %d deleted", id)
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
 	}
 
-	for idx, i := range messages {
-		res.Messages[idx] = msgItem{
-			Time:    i.TimeString(),
-			Level:   i.Level,
-			Message: i.Message,
-		}
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleRetentionDelete(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleRetentionRun(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
 	var (
-		err error
-		msg string
-		buf []byte
+		err         error
+		db          *database.Database
+		msg         string
+		rep         *retention.Report
+		resp        ajaxResponse
+		replyBuffer []byte
 	)
 
-	if buf, err = json.Marshal(&res); err != nil {
-		msg = fmt.Sprintf("Error serializing response: %s",
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if rep, err = retention.Run(db, false); err != nil {
+		resp.Message = fmt.Sprintf("Cannot delete expired Items: %s",
 			err.Error())
-		srv.SendMessage(msg)
-		res.Message = msg
-		buf = errJSON(msg)
+		goto SERIALIZE_RESPONSE
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "no-store, max-age=0")
-	w.WriteHeader(200)
-	if _, err = w.Write(buf); err != nil {
-		msg = fmt.Sprintf("Failed to send result: %s",
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Deleted %d expired Items from %d Feeds and %d cached images",
+		rep.ItemCnt,
+		len(rep.Feeds),
+		len(rep.Files))
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
 			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
+		replyBuffer = errJSON(msg)
 	}
-} // func (srv *Server) handleGetNewMessages(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleRateItem(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleRetentionRun(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleGroupCreate(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
 	var (
-		err                     error
-		db                      *database.Database
-		idStr, rStr, msg, reply string
-		id                      int64
-		item                    *feed.Item
-		rating                  float64
+		err             error
+		db              *database.Database
+		name, parentStr string
+		msg             string
+		parent          int64
+		g               *feed.Group
+		resp            ajaxResponse
+		replyBuffer     []byte
 	)
 
 	if err = r.ParseForm(); err != nil {
-		msg = fmt.Sprintf("Could not parse form data: %s", err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		return
+		resp.Message = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	idStr = r.PostFormValue("ID")
-	rStr = r.PostFormValue("Rating")
-
-	srv.log.Printf("[DEBUG] Rate Item %s - %s\n",
-		idStr,
-		rStr)
+	name = strings.TrimSpace(r.FormValue("Name"))
+	parentStr = r.FormValue("Parent")
 
-	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse ID %q: %s", idStr, err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		goto SEND_ERROR_MESSAGE
-	} else if rating, err = strconv.ParseFloat(rStr, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse Rating %q: %s", rStr, err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		goto SEND_ERROR_MESSAGE
+	if name == "" {
+		resp.Message = "Group name must not be empty"
+		goto SERIALIZE_RESPONSE
+	} else if parent, err = strconv.ParseInt(parentStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Group ID %q: %s",
+			parentStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if item, err = db.ItemGetByID(id); err != nil {
-		msg = fmt.Sprintf("Cannot load Item by ID %d: %s",
-			id,
-			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		goto SEND_ERROR_MESSAGE
-	} else if item == nil {
-		msg = fmt.Sprintf("No such Item: %d",
-			id)
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		goto SEND_ERROR_MESSAGE
-	} else if err = db.ItemRatingSet(item, rating); err != nil {
-		msg = fmt.Sprintf("Cannot set Rating of Item %d to %.2f: %s",
-			item.ID,
-			rating,
+	if g, err = db.GroupAdd(name, parent); err != nil {
+		resp.Message = fmt.Sprintf("Cannot create Group %s: %s",
+			name,
 			err.Error())
-		srv.log.Println("[ERROR] " + msg)
-		srv.SendMessage(msg)
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
-	reply = fmt.Sprintf(`{ "Status": true, "ID": %d, "Rating": %f, "Message": "Success" }`,
-		id,
-		rating)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-	return
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Created Group %s (%d)", g.Name, g.ID)
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
 
-SEND_ERROR_MESSAGE:
-	reply = fmt.Sprintf(`{ "Status": false, "ID": %d, "Rating": %f, "Message": "%s" }`,
-		id,
-		math.NaN(),
-		msg)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleRateItem(w http.ResponseWriter, r *http.Request)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleGroupCreate(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleUnrateItem(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleGroupUpdate(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
 	var (
-		err                       error
-		db                        *database.Database
-		idStr, msg, reply, rating string
-		id                        int64
-		item                      *feed.Item
+		err                    error
+		db                     *database.Database
+		name, idStr, parentStr string
+		msg                    string
+		id, parent             int64
+		groups                 []feed.Group
+		resp                   ajaxResponse
+		replyBuffer            []byte
 	)
 
-	vars := mux.Vars(r)
+	if err = r.ParseForm(); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	}
 
-	idStr = vars["id"]
+	idStr = r.FormValue("ID")
+	name = strings.TrimSpace(r.FormValue("Name"))
+	parentStr = r.FormValue("Parent")
 
-	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+	if name == "" {
+		resp.Message = "Group name must not be empty"
+		goto SERIALIZE_RESPONSE
+	} else if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Group ID %q: %s",
 			idStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
+	} else if parent, err = strconv.ParseInt(parentStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Group ID %q: %s",
+			parentStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if item, err = db.ItemGetByID(id); err != nil {
-		msg = fmt.Sprintf("Cannot load Item %d: %s",
-			id,
+	if groups, err = db.GroupGetAll(); err != nil {
+		resp.Message = fmt.Sprintf("Cannot load Groups: %s",
 			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if item == nil {
-		msg = fmt.Sprintf("No such Item: %d", id)
-		goto SEND_ERROR_MESSAGE
-	} else if !item.IsRated() {
-		msg = fmt.Sprintf("Item %q (%d) is not rated",
-			item.Title,
-			item.ID)
-		goto SEND_ERROR_MESSAGE
-	} else if item.Rating > 0.5 {
-		rating = classifier.Good
-	} else {
-		rating = classifier.Bad
-	}
-
-	if err = srv.clsItem.Unlearn(rating, item); err != nil {
-		msg = fmt.Sprintf("Error unlearning rating for Item %q (%d): %s",
-			item.Title,
-			item.ID,
+		goto SERIALIZE_RESPONSE
+	} else if groupSubtree(groups, id)[parent] {
+		resp.Message = "A Group cannot be moved into itself or one of its subgroups"
+		goto SERIALIZE_RESPONSE
+	} else if err = db.Begin(); err != nil {
+		resp.Message = fmt.Sprintf("Cannot start transaction: %s",
 			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if err = db.ItemRatingClear(item); err != nil {
-		msg = fmt.Sprintf("Cannot clear Rating of Item %d (%q): %s",
+		goto SERIALIZE_RESPONSE
+	} else if err = db.GroupRename(id, name); err != nil {
+		db.Rollback() // nolint: errcheck
+		resp.Message = fmt.Sprintf("Cannot rename Group %d: %s",
 			id,
-			item.Title,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
+	} else if err = db.GroupSetParent(id, parent); err != nil {
+		db.Rollback() // nolint: errcheck
+		resp.Message = fmt.Sprintf("Cannot move Group %d: %s",
+			id,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	} else if err = db.Commit(); err != nil {
+		resp.Message = fmt.Sprintf("Cannot commit transaction: %s",
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	reply = fmt.Sprintf(`{ "Status": true, "ID": %d, "Message": "Success" }`,
-		id)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-	return
-
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "ID": %d, "Message": "%s" }`,
-		id,
-		msg)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleUnrateItem(w http.ResponseWriter, r *http.Request)
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Group %d updated", id)
 
-func (srv *Server) handleSuggestRating(w http.ResponseWriter, r *http.Request) {
-	srv.log.Printf("[TRACE] Handle %s from %s\n",
-		r.URL,
-		r.RemoteAddr)
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
 
-	type replyMsg struct {
-		Status  bool
-		Message string
-		Rating  float64
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleGroupUpdate(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleGroupDelete(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
 	var (
-		err                     error
-		db                      *database.Database
-		msg, reply, idStr, sugg string
-		id                      int64
-		item                    *feed.Item
-		answer                  replyMsg
-		buf                     []byte
+		err         error
+		db          *database.Database
+		idStr, msg  string
+		id          int64
+		resp        ajaxResponse
+		replyBuffer []byte
 	)
 
 	vars := mux.Vars(r)
+
 	idStr = vars["id"]
 
 	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+		resp.Message = fmt.Sprintf("Cannot parse Group ID %q: %s",
 			idStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if item, err = db.ItemGetByID(id); err != nil {
-		msg = fmt.Sprintf("Failed to get Item %d: %s",
-			id,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if item == nil {
-		msg = fmt.Sprintf("Item %d was not found in database", id)
-		goto SEND_ERROR_MESSAGE
-	}
-
-	srv.clsLock.RLock()
-	defer srv.clsLock.RUnlock()
-
-	if sugg, err = srv.clsItem.Classify(item); err != nil {
-		msg = fmt.Sprintf("Error trying to classify Item %d (%s): %s",
+	if err = db.GroupDelete(id); err != nil {
+		resp.Message = fmt.Sprintf("Cannot delete Group %d (does it still contain other Groups?): %s",
 			id,
-			item.Title,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
-	answer.Status = true
-	answer.Message = "Success"
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Group %d deleted", id)
 
-	switch sugg {
-	case classifier.Good:
-		answer.Rating = 100
-	case classifier.Bad:
-		answer.Rating = -100
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
 	}
 
-	if buf, err = json.Marshal(&answer); err != nil {
-		msg = fmt.Sprintf("Error serializing answer: %s",
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		replyBuffer = errJSON(msg)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write(buf) // nolint: errcheck
-	return
-
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "ID": %d, "Message": "%s" }`,
-		id,
-		msg)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleSuggestRating(w http.ResponseWriter, r *http.Request)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleGroupDelete(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleRebuildFTS(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleFeedSetGroup(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
 	var (
-		err        error
-		db         *database.Database
-		msg, reply string
-		status     bool
+		err               error
+		db                *database.Database
+		feedStr, groupStr string
+		msg               string
+		feedID, groupID   int64
+		resp              ajaxResponse
+		replyBuffer       []byte
 	)
 
+	if err = r.ParseForm(); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	}
+
+	feedStr = r.FormValue("Feed")
+	groupStr = r.FormValue("Group")
+
+	if feedID, err = strconv.ParseInt(feedStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Feed ID %q: %s",
+			feedStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	} else if groupID, err = strconv.ParseInt(groupStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Group ID %q: %s",
+			groupStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	}
+
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if err = db.FTSRebuild(); err != nil {
-		msg = fmt.Sprintf("Cannot rebuild FTS index: %s",
+	if err = db.FeedSetGroup(feedID, groupID); err != nil {
+		resp.Message = fmt.Sprintf("Cannot put Feed %d in Group %d: %s",
+			feedID,
+			groupID,
 			err.Error())
-	} else {
-		msg = "FTS index was rebuilt successfully."
-		status = true
+		goto SERIALIZE_RESPONSE
+	}
+
+	resp.Status = true
+	resp.Message = "Success"
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
 	}
 
-	reply = fmt.Sprintf(`{ "Status": %t, "Message": "%s" }`,
-		status,
-		msg)
 	w.Header().Set("Content-Type", "application/json")
-	// w.Header().Set("Content-Length", strconv.FormatInt(len(reply), 10))
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleRebuildFTS(w http.ResponseWriter, r *http.Request)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleFeedSetGroup(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleAjaxTagDetails(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleRuleSave(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
-	type tagResponse struct {
-		Status  bool
-		Message string
-		Tag     *tag.Tag
+	var (
+		err         error
+		db          *database.Database
+		msg         string
+		rule        *feed.Rule
+		resp        ajaxResponse
+		replyBuffer []byte
+	)
+
+	if err = r.ParseForm(); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	} else if rule, err = ruleFromForm(r); err != nil {
+		resp.Message = err.Error()
+		goto SERIALIZE_RESPONSE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if rule.ID == 0 {
+		err = db.RuleAdd(rule)
+	} else {
+		err = db.RuleUpdate(rule)
+	}
+
+	if err != nil {
+		resp.Message = fmt.Sprintf("Cannot save Rule %s: %s",
+			rule.Name,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	}
+
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Saved Rule %s (%d)", rule.Name, rule.ID)
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleRuleSave(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleRuleDelete(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
 	var (
-		err               error
-		db                *database.Database
-		id                int64
-		t                 *tag.Tag
-		idStr, msg, reply string
-		buf               []byte
-		resp              tagResponse
+		err         error
+		db          *database.Database
+		idStr, msg  string
+		id          int64
+		resp        ajaxResponse
+		replyBuffer []byte
 	)
 
 	vars := mux.Vars(r)
+
 	idStr = vars["id"]
 
 	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse Tag ID %s: %s",
+		resp.Message = fmt.Sprintf("Cannot parse Rule ID %q: %s",
 			idStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if t, err = db.TagGetByID(id); err != nil {
-		msg = fmt.Sprintf("Failed to get Tag %d from database: %s",
+	if err = db.RuleDelete(id); err != nil {
+		resp.Message = fmt.Sprintf("Cannot delete Rule %d: %s",
 			id,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
-	resp = tagResponse{
-		Status:  true,
-		Message: "Success",
-		Tag:     t,
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Rule %d deleted", id)
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
 	}
 
-	if buf, err = json.Marshal(&resp); err != nil {
-		msg = fmt.Sprintf("Cannot serialize Response for Tag %d (%s): %s",
-			id,
-			t.Name,
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		replyBuffer = errJSON(msg)
 	}
 
-	w.Header().Set("Content-Length", strconv.FormatInt(int64(len(buf)), 10))
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write(buf) // nolint: errcheck
-	return
-
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
-		msg)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleAjaxTagDetails(w http.ResponseWriter, r *http.Request)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleRuleDelete(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleTagLinkCreate(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleRuleSetActive(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
 	var (
-		err                         error
-		db                          *database.Database
-		tagStr, itemStr, msg, reply string
-		itemID, tagID               int64
-		item                        *feed.Item
-		t                           *tag.Tag
+		err              error
+		db               *database.Database
+		idStr, activeStr string
+		msg              string
+		id               int64
+		active           bool
+		resp             ajaxResponse
+		replyBuffer      []byte
 	)
 
-	if err = r.ParseForm(); err != nil {
-		msg = fmt.Sprintf("Cannot parse form data: %s",
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	}
+	vars := mux.Vars(r)
 
-	itemStr = r.FormValue("Item")
-	tagStr = r.FormValue("Tag")
+	idStr = vars["id"]
+	activeStr = vars["active"]
 
-	if itemID, err = strconv.ParseInt(itemStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot Parse Item ID %q: %s",
-			itemStr,
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Rule ID %q: %s",
+			idStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if tagID, err = strconv.ParseInt(tagStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot Parse Tag ID %q: %s",
-			tagStr,
+		goto SERIALIZE_RESPONSE
+	} else if active, err = strconv.ParseBool(activeStr); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse flag %q: %s",
+			activeStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
 	db = srv.pool.Get()
-	defer srv.pool.Put(db)
-
-	if item, err = db.ItemGetByID(itemID); err != nil {
-		msg = fmt.Sprintf("Failed to fetch Item #%d from database: %s",
-			itemID,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if item == nil {
-		msg = fmt.Sprintf("Did not find Item #%d in database", itemID)
-		goto SEND_ERROR_MESSAGE
-	} else if err = db.TagLinkCreate(itemID, tagID); err != nil {
-		msg = fmt.Sprintf("Cannot Attach Tag %d to Item %d: %s",
-			tagID,
-			itemID,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if t, err = db.TagGetByID(tagID); err != nil {
-		msg = fmt.Sprintf("Cannot load Tag %d: %s",
-			tagID,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if err = srv.clsTags.Learn(t, item); err != nil {
-		srv.log.Printf("[ERROR] Failed to learn Tag %s for Item %q: %s\n",
-			t.Name,
-			item.Title,
+	defer srv.pool.Put(db)
+
+	if err = db.RuleSetActive(id, active); err != nil {
+		resp.Message = fmt.Sprintf("Cannot set active flag for Rule %d: %s",
+			id,
 			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	srv.log.Printf("[DEBUG] Attach Tag %d to Item %d successfully.\n",
-		tagID,
-		itemID)
-	reply = fmt.Sprintf(`{ "Status": true, "Message": "Success", "ID": %d, "Name": %q }`,
-		tagID,
-		t.Name)
+	resp.Status = true
+	resp.Message = "Success"
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-	return
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
 
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
-		msg)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleTagLinkCreate(w http.ResponseWriter, r *http.Request)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleRuleSetActive(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleTagLinkDelete(w http.ResponseWriter, r *http.Request) {
+// handleRulePreview shows which of the most recent Items a Rule would have
+// matched, so the user can try out a Rule before saving it.
+func (srv *Server) handleRulePreview(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
+	const (
+		tmplName     = "rule_preview"
+		defaultCount = 100
+		maxCount     = 5000
+	)
+
 	var (
-		err                         error
-		db                          *database.Database
-		tagStr, itemStr, msg, reply string
-		itemID, tagID               int64
-		item                        *feed.Item
-		t                           *tag.Tag
+		err        error
+		tmpl       *template.Template
+		db         *database.Database
+		msg, reply string
+		cnt        int64
+		items      []feed.Item
+		buf        bytes.Buffer
+		res        ajaxResponse
+		raw        []byte
+		data       = tmplDataRulePreview{
+			tmplDataBase: srv.baseData("Preview", r),
+		}
 	)
 
 	if err = r.ParseForm(); err != nil {
 		msg = fmt.Sprintf("Cannot parse form data: %s",
 			err.Error())
 		goto SEND_ERROR_MESSAGE
-	}
-
-	itemStr = r.FormValue("Item")
-	tagStr = r.FormValue("Tag")
-
-	if itemID, err = strconv.ParseInt(itemStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot Parse Item ID %q: %s",
-			itemStr,
-			err.Error())
+	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
+		msg = fmt.Sprintf("Did not find template %q", tmplName)
 		goto SEND_ERROR_MESSAGE
-	} else if tagID, err = strconv.ParseInt(tagStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot Parse Tag ID %q: %s",
-			tagStr,
+	} else if data.Rule, err = ruleFromForm(r); err != nil {
+		msg = err.Error()
+		goto SEND_ERROR_MESSAGE
+	} else if err = data.Rule.Validate(); err != nil {
+		msg = fmt.Sprintf("Invalid Rule: %s",
 			err.Error())
 		goto SEND_ERROR_MESSAGE
 	}
 
+	if cntStr := r.FormValue("Count"); cntStr == "" {
+		cnt = defaultCount
+	} else if cnt, err = strconv.ParseInt(cntStr, 10, 64); err != nil || cnt < 1 {
+		msg = fmt.Sprintf("Invalid number of Items: %q",
+			cntStr)
+		goto SEND_ERROR_MESSAGE
+	} else if cnt > maxCount {
+		cnt = maxCount
+	}
+
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if item, err = db.ItemGetByID(itemID); err != nil {
-		msg = fmt.Sprintf("Failed to fetch Item #%d from database: %s",
-			itemID,
+	if items, err = db.ItemGetRecent(int(cnt)); err != nil {
+		msg = fmt.Sprintf("Cannot load recent Items: %s",
 			err.Error())
 		goto SEND_ERROR_MESSAGE
-	} else if item == nil {
-		msg = fmt.Sprintf("Did not find Item #%d in database",
-			itemID)
-		goto SEND_ERROR_MESSAGE
-	} else if t, err = db.TagGetByID(tagID); err != nil {
-		msg = fmt.Sprintf("Failed to load Tag #%d: %s",
-			tagID,
+	}
+
+	data.Total = len(items)
+
+	for idx := range items {
+		if data.Rule.Match(&items[idx]) {
+			data.Items = append(data.Items, items[idx])
+		}
+	}
+
+	if err = tmpl.Execute(&buf, &data); err != nil {
+		msg = fmt.Sprintf("Error rendering template %s: %s",
+			tmplName,
 			err.Error())
 		goto SEND_ERROR_MESSAGE
-	} else if t == nil {
-		msg = fmt.Sprintf("Did not find Tag #%d in database",
-			tagID)
-		goto SEND_ERROR_MESSAGE
-	} else if err = db.TagLinkDelete(itemID, tagID); err != nil {
-		msg = fmt.Sprintf("Cannot Attach Tag %d to Item %d: %s",
-			tagID,
-			itemID,
+	}
+
+	res.Status = true
+	res.Message = buf.String()
+
+	if raw, err = json.Marshal(&res); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %s",
 			err.Error())
 		goto SEND_ERROR_MESSAGE
-	} else if err = srv.clsTags.Unlearn(t, item); err != nil {
-		srv.log.Printf("[ERROR] Failed to unlearn Tag %s for Item %s (%d): %s\n",
-			t.Name,
-			item.Title,
-			item.ID,
-			err.Error())
 	}
 
-	srv.log.Printf("[DEBUG] Detach Tag %d from Item %d successfully.\n",
-		tagID,
-		itemID)
-	reply = `{ "Status": true, "Message": "Success" }`
-
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
+	if _, err = w.Write(raw); err != nil {
+		msg = fmt.Sprintf("Cannot send message to client %s: %s",
+			r.RemoteAddr,
+			err.Error())
+		srv.SendMessage(msg)
+		srv.log.Printf("[ERROR] %s\n", msg)
+	}
+
 	return
 
 SEND_ERROR_MESSAGE:
 	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
+	reply = fmt.Sprintf(`{ "Status": false, "Message": %q }`,
 		msg)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleTagLinkDelete(w http.ResponseWriter, r *http.Request)
+} // func (srv *Server) handleRulePreview(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleReadLaterMark(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleMuteAdd(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
 	var (
-		err                      error
-		msg, reply               string
-		db                       *database.Database
-		idStr, note, deadlineStr string
-		itemID, timestamp        int64
-		item                     *feed.Item
-		deadline                 time.Time
+		err         error
+		db          *database.Database
+		msg         string
+		m           *feed.Mute
+		resp        ajaxResponse
+		replyBuffer []byte
 	)
 
 	if err = r.ParseForm(); err != nil {
-		msg = fmt.Sprintf("Cannot parse form data: %s",
+		resp.Message = fmt.Sprintf("Cannot parse form data: %s",
 			err.Error())
-		srv.log.Printf("[ERROR] %s\n", msg)
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
+	} else if m, err = muteFromForm(r); err != nil {
+		resp.Message = err.Error()
+		goto SERIALIZE_RESPONSE
 	}
 
-	idStr = r.FormValue("ItemID")
-	note = r.FormValue("Note")
-	deadlineStr = r.FormValue("Deadline")
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
 
-	if itemID, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse Item ID %q: %s",
-			idStr,
+	if err = db.MuteAdd(m); err != nil {
+		resp.Message = fmt.Sprintf("Cannot add Mute %s: %s",
+			m,
 			err.Error())
-		srv.log.Printf("[ERROR] %s\n", msg)
-		goto SEND_ERROR_MESSAGE
-	} else if timestamp, err = strconv.ParseInt(deadlineStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse deadline %q: %s",
-			deadlineStr,
+		goto SERIALIZE_RESPONSE
+	}
+
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Added Mute %s (%d)", m, m.ID)
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
 			err.Error())
-		srv.log.Printf("[ERROR] %s\n", msg)
-		goto SEND_ERROR_MESSAGE
+		replyBuffer = errJSON(msg)
 	}
 
-	deadline = time.Unix(timestamp, 0)
-	srv.log.Printf("[DEBUG] Set deadline for Item %d to %s\n",
-		itemID,
-		deadline.Format(common.TimestampFormat))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleMuteAdd(w http.ResponseWriter, r *http.Request)
+
+func (srv *Server) handleMuteDelete(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	var (
+		err         error
+		db          *database.Database
+		idStr, msg  string
+		id          int64
+		resp        ajaxResponse
+		replyBuffer []byte
+	)
+
+	vars := mux.Vars(r)
+
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Mute ID %q: %s",
+			idStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	}
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if item, err = db.ItemGetByID(itemID); err != nil {
-		msg = fmt.Sprintf("Cannot load Item %d: %s",
-			itemID,
-			err.Error())
-		srv.log.Printf("[ERROR] %s\n",
-			msg)
-		goto SEND_ERROR_MESSAGE
-	} else if _, err = db.ReadLaterAdd(item, note, deadline); err != nil {
-		msg = fmt.Sprintf("Cannot mark Item %q (%d) for reading later: %s",
-			item.Title,
-			item.ID,
+	if err = db.MuteDelete(id); err != nil {
+		resp.Message = fmt.Sprintf("Cannot delete Mute %d: %s",
+			id,
 			err.Error())
-		srv.log.Printf("[ERROR] %s\n",
-			msg)
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
-	srv.log.Printf("[DEBUG] %s -- Mark Item %q (%d) for later reading: Deadline = %s, Note = %q\n",
-		r.URL,
-		item.Title,
-		itemID,
-		deadline.Format(common.TimestampFormat),
-		note)
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Mute %d deleted", id)
 
-	reply = `{ "Status": true, "Message": "Success" }`
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-	return
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
 
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
-		msg)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleReadLaterMark(w http.ResponseWriter, r *http.Request)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleMuteDelete(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleReadLaterSetRead(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleItemSetRead(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
 	var (
-		err             error
-		msg, reply      string
-		db              *database.Database
-		idStr, stateStr string
-		itemID, state   int64
+		err            error
+		db             *database.Database
+		idStr, readStr string
+		msg            string
+		item           feed.Item
+		read           bool
+		resp           ajaxResponse
+		replyBuffer    []byte
 	)
 
 	vars := mux.Vars(r)
 
 	idStr = vars["id"]
-	stateStr = vars["state"]
+	readStr = vars["read"]
 
-	if itemID, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse Item ID %q: %s",
+	if item.ID, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Item ID %q: %s",
 			idStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if state, err = strconv.ParseInt(stateStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse State %q: %s",
-			stateStr,
+		goto SERIALIZE_RESPONSE
+	} else if read, err = strconv.ParseBool(readStr); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse flag %q: %s",
+			readStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if state != 0 {
-		if err = db.ReadLaterMarkRead(itemID); err != nil {
-			msg = fmt.Sprintf("Cannot mark Item %d as read: %s",
-				itemID,
-				err.Error())
-			goto SEND_ERROR_MESSAGE
-		}
-	} else if err = db.ReadLaterMarkUnread(itemID); err != nil {
-		msg = fmt.Sprintf("Cannot mark Item %d as unread: %s",
-			itemID,
+	if read {
+		err = db.ItemMarkRead(&item)
+	} else {
+		err = db.ItemMarkUnread(&item)
+	}
+
+	if err != nil {
+		resp.Message = fmt.Sprintf("Cannot set read flag of Item %d: %s",
+			item.ID,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
-	reply = `{ "Status": true, "Message": "Success" }`
+	resp.Status = true
+	resp.Message = "Success"
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-	return
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
 
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
-		msg)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleReadLaterSetRead(w http.ResponseWriter, r *http.Request)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleItemSetRead(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleFeedUpdate(w http.ResponseWriter, r *http.Request) {
+// handleItemsMarkRead marks a batch of Items as read, the client sends their
+// IDs as a comma-separated list. This is used for marking all Items on a
+// page as read, as well as for marking Items as read while scrolling past
+// them.
+func (srv *Server) handleItemsMarkRead(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
 	var (
-		err                        error
-		db                         *database.Database
-		name, lnkFeed, lnkHomepage string
-		intervalStr, idStr         string
-		reply, msg                 string
-		seconds, id                int64
-		interval                   time.Duration
-		fd                         *feed.Feed
+		err         error
+		db          *database.Database
+		msg         string
+		ids         []int64
+		cnt         int64
+		resp        ajaxResponse
+		replyBuffer []byte
 	)
 
 	if err = r.ParseForm(); err != nil {
-		msg = fmt.Sprintf("Error parsing form data: %s",
+		resp.Message = fmt.Sprintf("Cannot parse form data: %s",
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
-	name = r.FormValue("Name")
-	lnkFeed = r.FormValue("URL")
-	lnkHomepage = r.FormValue("Homepage")
-	intervalStr = r.FormValue("Interval")
-	idStr = r.FormValue("ID")
+	for _, idStr := range strings.Split(r.FormValue("ids"), ",") {
+		var id int64
 
-	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse ID %q: %s",
-			idStr,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if seconds, err = strconv.ParseInt(intervalStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse Interval %q: %s",
-			intervalStr,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
+		if idStr = strings.TrimSpace(idStr); idStr == "" {
+			continue
+		} else if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+			resp.Message = fmt.Sprintf("Cannot parse Item ID %q: %s",
+				idStr,
+				err.Error())
+			goto SERIALIZE_RESPONSE
+		}
+
+		ids = append(ids, id)
 	}
 
-	interval = time.Second * time.Duration(seconds)
+	if len(ids) == 0 {
+		resp.Status = true
+		resp.Message = "No Items to mark as read"
+		goto SERIALIZE_RESPONSE
+	}
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if fd, err = db.FeedGetByID(id); err != nil {
-		msg = fmt.Sprintf("Cannot get Feed %d: %s",
-			id,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if fd == nil {
-		msg = fmt.Sprintf("Feed %d was not found in database",
-			id)
-		goto SEND_ERROR_MESSAGE
-	} else if err = db.FeedModify(fd, name, lnkFeed, lnkHomepage, interval); err != nil {
-		msg = fmt.Sprintf("Error updating Feed %s (%d): %s",
-			fd.Name,
-			fd.ID,
+	if cnt, err = db.ItemMarkReadBatch(ids); err != nil {
+		resp.Message = fmt.Sprintf("Cannot mark %d Items as read: %s",
+			len(ids),
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
-	reply = `{ "Status": true, "Message": "Success" }`
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Marked %d Items as read", cnt)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-	return
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
 
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
-		msg)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleFeedUpdate(w http.ResponseWriter, r *http.Request)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleItemsMarkRead(w http.ResponseWriter, r *http.Request)
 
-func (srv *Server) handleFeedActiveToggle(w http.ResponseWriter, r *http.Request) {
+func (srv *Server) handleFeedMarkRead(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle %s from %s\n",
 		r.URL,
 		r.RemoteAddr)
 
 	var (
-		err              error
-		db               *database.Database
-		idStr, activeStr string
-		msg, reply       string
-		id               int64
-		active           bool
+		err         error
+		db          *database.Database
+		idStr, msg  string
+		id, cnt     int64
+		resp        ajaxResponse
+		replyBuffer []byte
 	)
 
 	vars := mux.Vars(r)
 
 	idStr = vars["id"]
-	activeStr = vars["active"]
 
 	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("Cannot parse ID %q: %s",
+		resp.Message = fmt.Sprintf("Cannot parse Feed ID %q: %s",
 			idStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if active, err = strconv.ParseBool(activeStr); err != nil {
-		msg = fmt.Sprintf("Cannot parse flag %q: %s",
-			activeStr,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if err = db.FeedSetActive(id, active); err != nil {
-		msg = fmt.Sprintf("Cannot set active flag for Feed %d: %s",
+	if cnt, err = db.ItemMarkReadByFeed(id); err != nil {
+		resp.Message = fmt.Sprintf("Cannot mark Items of Feed %d as read: %s",
 			id,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
-	reply = `{ "Status": true, "Message": "Success" }`
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Marked %d Items as read", cnt)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-	return
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
 
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
-		msg)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleFeedActiveToggle(w http.ResponseWriter, r *http.Request)
-
-func (srv *Server) handleItemsByTag(w http.ResponseWriter, r *http.Request) {
-	srv.log.Printf("[TRACE] Handle request for %s\n",
-		r.URL.EscapedPath())
-
-	const tmplName = "items"
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleFeedMarkRead(w http.ResponseWriter, r *http.Request)
 
-	type response struct {
-		Status  bool
-		Message string
-	}
+func (srv *Server) handleTagMarkRead(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
 
 	var (
-		err               error
-		db                *database.Database
-		tmpl              *template.Template
-		idStr, msg, reply string
-		id                int64
-		buf               bytes.Buffer
-		res               response
-		t                 *tag.Tag
-		raw               []byte
-		data              = tmplDataItems{
-			tmplDataBase: tmplDataBase{
-				Title:      "Items",
-				Debug:      common.Debug,
-				URL:        r.URL.String(),
-				TrainStamp: srv.trainStamp(),
-			},
-		}
+		err         error
+		db          *database.Database
+		idStr, msg  string
+		id, cnt     int64
+		resp        ajaxResponse
+		replyBuffer []byte
 	)
 
 	vars := mux.Vars(r)
@@ -2637,273 +5823,173 @@ func (srv *Server) handleItemsByTag(w http.ResponseWriter, r *http.Request) {
 	idStr = vars["id"]
 
 	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("[ERROR] Cannot parse Tag ID %q: %s\n",
+		resp.Message = fmt.Sprintf("Cannot parse Tag ID %q: %s",
 			idStr,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
-		msg = fmt.Sprintf("Did not find template %q", tmplName)
-		goto SEND_ERROR_MESSAGE
-	}
-
-	db = srv.pool.Get()
-	defer srv.pool.Put(db)
-
-	if t, err = db.TagGetByID(id); err != nil {
-		msg = fmt.Sprintf("Cannot get Tag %d: %s",
-			id,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if t == nil {
-		msg = fmt.Sprintf("Tag %d was not found",
-			id)
-		goto SEND_ERROR_MESSAGE
-	} else if data.Items, err = db.ItemGetByTagRecursive(t); err != nil {
-		msg = fmt.Sprintf("Cannot load Items for Tag %s (%d): %s",
-			t.Name,
-			id,
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
-		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
-			err.Error())
-		srv.log.Printf("[ERROR] %s\n", msg)
-		srv.sendErrorMessage(w, msg)
-		return
-	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load all Tags: %s",
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if data.FeedMap, err = db.FeedGetMap(); err != nil {
-		msg = fmt.Sprintf("Cannot get all Feeds: %s",
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	}
-
-	srv.clsLock.RLock()
-	defer srv.clsLock.RUnlock()
-
-	for idx, item := range data.Items {
-		var class string
-
-		if !math.IsNaN(item.Rating) {
-			if item.Rating == 1 {
-				data.Items[idx].Rating = math.Inf(1)
-			} else if item.Rating == 0 {
-				data.Items[idx].Rating = math.Inf(-1)
-			} else {
-				msg = fmt.Sprintf("Unexpected Rating for Item %s (%d): %f",
-					item.Title,
-					item.ID,
-					item.Rating)
-				srv.log.Println("[ERROR] " + msg)
-				srv.SendMessage(msg)
-				http.Redirect(w, r, r.Referer(), http.StatusFound)
-				return
-			}
-
-			continue
-		} else if class, err = srv.clsItem.Classify(&item); err != nil {
-			srv.log.Printf("[ERROR] Cannot classify Item %s (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-		} else if class == classifier.Good {
-			data.Items[idx].Rating = 100
-		} else if class == classifier.Bad {
-			data.Items[idx].Rating = -100
-		} else {
-			srv.log.Printf("[ERROR] Unexpected classification for Item %s (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-		}
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	if err = tmpl.Execute(&buf, &data); err != nil {
-		msg = fmt.Sprintf("Error rendering template %s: %s",
-			tmplName,
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if cnt, err = db.ItemMarkReadByTag(id); err != nil {
+		resp.Message = fmt.Sprintf("Cannot mark Items with Tag %d as read: %s",
+			id,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
 	}
 
-	res.Status = true
-	res.Message = buf.String()
+	resp.Status = true
+	resp.Message = fmt.Sprintf("Marked %d Items as read", cnt)
 
-	if raw, err = json.Marshal(&res); err != nil {
-		msg = fmt.Sprintf("Cannot serialize response: %s",
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if _, err = w.Write(raw); err != nil {
-		msg = fmt.Sprintf("Cannot send message to client %s: %s",
-			r.RemoteAddr,
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
 			err.Error())
-		srv.SendMessage(msg)
-		srv.log.Printf("[ERROR] %s\n", msg)
+		replyBuffer = errJSON(msg)
 	}
 
-	return
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
-		msg)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleItemsByTag(w http.ResponseWriter, r *http.Request)
-
-func (srv *Server) handleItemsByFeed(w http.ResponseWriter, r *http.Request) {
-	srv.log.Printf("[TRACE] Handle request for %s\n",
-		r.URL.EscapedPath())
-
-	const tmplName = "items"
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleTagMarkRead(w http.ResponseWriter, r *http.Request)
 
-	type response struct {
-		Status  bool
-		Message string
-	}
+func (srv *Server) handleGroupSetActive(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
 
 	var (
-		err               error
-		db                *database.Database
-		tmpl              *template.Template
-		idStr, msg, reply string
-		id                int64
-		buf               bytes.Buffer
-		res               response
-		t                 *tag.Tag
-		raw               []byte
-		data              = tmplDataItems{
-			tmplDataBase: tmplDataBase{
-				Title:      "Items",
-				Debug:      common.Debug,
-				URL:        r.URL.String(),
-				TrainStamp: srv.trainStamp(),
-			},
-		}
+		err              error
+		db               *database.Database
+		idStr, activeStr string
+		msg              string
+		id               int64
+		active           bool
+		resp             ajaxResponse
+		replyBuffer      []byte
 	)
 
 	vars := mux.Vars(r)
 
 	idStr = vars["id"]
+	activeStr = vars["active"]
 
 	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
-		msg = fmt.Sprintf("[ERROR] Cannot parse Tag ID %q: %s\n",
+		resp.Message = fmt.Sprintf("Cannot parse Group ID %q: %s",
 			idStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if tmpl = srv.tmpl.Lookup(tmplName); tmpl == nil {
-		msg = fmt.Sprintf("Did not find template %q", tmplName)
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
+	} else if active, err = strconv.ParseBool(activeStr); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse flag %q: %s",
+			activeStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
 	db = srv.pool.Get()
 	defer srv.pool.Put(db)
 
-	if data.Items, err = db.ItemGetByFeed(id, -1); err != nil {
-		msg = fmt.Sprintf("Cannot load Items for Tag %s (%d): %s",
-			t.Name,
+	if err = db.GroupSetActive(id, active); err != nil {
+		resp.Message = fmt.Sprintf("Cannot set active flag for Feeds in Group %d: %s",
 			id,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if data.TagSuggestions, err = srv.suggestTags(data.Items); err != nil {
-		msg = fmt.Sprintf("Cannot generate Tag suggestions: %s",
-			err.Error())
-		srv.log.Printf("[ERROR] %s\n", msg)
-		srv.sendErrorMessage(w, msg)
-		return
-	} else if data.AllTags, err = db.TagGetAllByHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load all Tags: %s",
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if data.TagHierarchy, err = db.TagGetHierarchy(); err != nil {
-		msg = fmt.Sprintf("Cannot load list of all Tags: %s",
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if data.FeedMap, err = db.FeedGetMap(); err != nil {
-		msg = fmt.Sprintf("Cannot get all Feeds: %s",
+		goto SERIALIZE_RESPONSE
+	}
+
+	resp.Status = true
+	resp.Message = "Success"
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		replyBuffer = errJSON(msg)
 	}
 
-	srv.clsLock.RLock()
-	defer srv.clsLock.RUnlock()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleGroupSetActive(w http.ResponseWriter, r *http.Request)
 
-	for idx, item := range data.Items {
-		var class string
+func (srv *Server) handleGroupSetInterval(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle %s from %s\n",
+		r.URL,
+		r.RemoteAddr)
 
-		if !math.IsNaN(item.Rating) {
-			if item.Rating == 1 {
-				data.Items[idx].Rating = math.Inf(1)
-			} else if item.Rating == 0 {
-				data.Items[idx].Rating = math.Inf(-1)
-			} else {
-				msg = fmt.Sprintf("Unexpected Rating for Item %s (%d): %f",
-					item.Title,
-					item.ID,
-					item.Rating)
-				srv.log.Println("[ERROR] " + msg)
-				srv.SendMessage(msg)
-				http.Redirect(w, r, r.Referer(), http.StatusFound)
-				return
-			}
+	var (
+		err                error
+		db                 *database.Database
+		idStr, intervalStr string
+		msg                string
+		id, minutes        int64
+		resp               ajaxResponse
+		replyBuffer        []byte
+	)
 
-			continue
-		} else if class, err = srv.clsItem.Classify(&item); err != nil {
-			srv.log.Printf("[ERROR] Cannot classify Item %s (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-		} else if class == classifier.Good {
-			data.Items[idx].Rating = 100
-		} else if class == classifier.Bad {
-			data.Items[idx].Rating = -100
-		} else {
-			srv.log.Printf("[ERROR] Unexpected classification for Item %s (%d): %s\n",
-				item.Title,
-				item.ID,
-				err.Error())
-		}
+	if err = r.ParseForm(); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse form data: %s",
+			err.Error())
+		goto SERIALIZE_RESPONSE
 	}
 
-	if err = tmpl.Execute(&buf, &data); err != nil {
-		msg = fmt.Sprintf("Error rendering template %s: %s",
-			tmplName,
+	idStr = r.FormValue("ID")
+	intervalStr = r.FormValue("Interval")
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse Group ID %q: %s",
+			idStr,
 			err.Error())
-		goto SEND_ERROR_MESSAGE
+		goto SERIALIZE_RESPONSE
+	} else if minutes, err = strconv.ParseInt(intervalStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse interval %q: %s",
+			intervalStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	} else if minutes < 1 {
+		resp.Message = fmt.Sprintf("Invalid interval: %d minutes", minutes)
+		goto SERIALIZE_RESPONSE
 	}
 
-	res.Status = true
-	res.Message = buf.String()
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
 
-	if raw, err = json.Marshal(&res); err != nil {
-		msg = fmt.Sprintf("Cannot serialize response: %s",
-			err.Error())
-		goto SEND_ERROR_MESSAGE
-	} else if _, err = w.Write(raw); err != nil {
-		msg = fmt.Sprintf("Cannot send message to client %s: %s",
-			r.RemoteAddr,
+	if err = db.GroupSetInterval(id, time.Minute*time.Duration(minutes)); err != nil {
+		resp.Message = fmt.Sprintf("Cannot set refresh interval for Feeds in Group %d: %s",
+			id,
 			err.Error())
-		srv.SendMessage(msg)
-		srv.log.Printf("[ERROR] %s\n", msg)
+		goto SERIALIZE_RESPONSE
 	}
 
-	return
+	resp.Status = true
+	resp.Message = "Success"
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
 
-SEND_ERROR_MESSAGE:
-	srv.log.Printf("[ERROR] %s\n", msg)
-	srv.SendMessage(msg)
-	reply = fmt.Sprintf(`{ "Status": false, "Message": "%s" }`,
-		msg)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
 	w.WriteHeader(200)
-	w.Write([]byte(reply)) // nolint: errcheck
-} // func (srv *Server) handleItemsByFeed(w http.ResponseWriter, r *http.Request)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleGroupSetInterval(w http.ResponseWriter, r *http.Request)
 
 func (srv *Server) handleArchiveDelete(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle request for %s\n",
@@ -2946,6 +6032,73 @@ SERIALIZE_RESPONSE:
 	w.Write(replyBuffer) // nolint: errcheck
 } // func (srv *Server) handleArchiveDelete(w http.ResponseWriter, r *http.Request)
 
+func (srv *Server) handleEnclosureDownload(w http.ResponseWriter, r *http.Request) {
+	srv.log.Printf("[TRACE] Handle request for %s\n",
+		r.URL.EscapedPath())
+
+	var (
+		err         error
+		db          *database.Database
+		idStr, msg  string
+		id          int64
+		enc         *feed.Enclosure
+		resp        ajaxResponse
+		replyBuffer []byte
+	)
+
+	vars := mux.Vars(r)
+
+	idStr = vars["id"]
+
+	if id, err = strconv.ParseInt(idStr, 10, 64); err != nil {
+		resp.Message = fmt.Sprintf("Cannot parse ID %q: %s",
+			idStr,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	}
+
+	db = srv.pool.Get()
+	defer srv.pool.Put(db)
+
+	if enc, err = db.EnclosureGetByID(id); err != nil {
+		resp.Message = fmt.Sprintf("Cannot load Enclosure %d: %s",
+			id,
+			err.Error())
+		goto SERIALIZE_RESPONSE
+	} else if enc == nil {
+		resp.Message = fmt.Sprintf("No such Enclosure: %d", id)
+		goto SERIALIZE_RESPONSE
+	} else if enc.IsDownloaded() {
+		resp.Message = fmt.Sprintf("Enclosure %d has already been downloaded", id)
+		goto SERIALIZE_RESPONSE
+	}
+
+	select {
+	case srv.agent.EnclosureQ <- enc:
+		resp.Status = true
+		resp.Message = fmt.Sprintf("Download of %s has been queued",
+			enc.Filename())
+	default:
+		resp.Message = "Download queue is full, please try again later"
+	}
+
+SERIALIZE_RESPONSE:
+	if !resp.Status {
+		srv.log.Printf("[ERROR] %s\n", resp.Message)
+	}
+
+	if replyBuffer, err = ffjson.Marshal(&resp); err != nil {
+		msg = fmt.Sprintf("Cannot serialize response: %q",
+			err.Error())
+		replyBuffer = errJSON(msg)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Size", strconv.FormatInt(int64(len(replyBuffer)), 10))
+	w.WriteHeader(200)
+	w.Write(replyBuffer) // nolint: errcheck
+} // func (srv *Server) handleEnclosureDownload(w http.ResponseWriter, r *http.Request)
+
 func (srv *Server) handleShutdown(w http.ResponseWriter, r *http.Request) {
 	srv.log.Printf("[TRACE] Handle request for %s\n",
 		r.URL.EscapedPath())
@@ -0,0 +1,154 @@
+// /home/krylon/go/src/ticker/websub/00_websub_hub_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:50:03 krylon>
+
+package websub
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+)
+
+// testHub is a minimal stand-in for a WebSub hub. When it receives a
+// subscription request, it verifies the subscriber's intent and remembers
+// the Subscription, so it can later publish content to the subscriber.
+type testHub struct {
+	srv      *httptest.Server
+	lock     sync.Mutex
+	subs     map[string]hubSub
+	verified chan error
+}
+
+type hubSub struct {
+	callback string
+	secret   string
+}
+
+func newTestHub() *testHub {
+	var h = &testHub{
+		subs:     make(map[string]hubSub),
+		verified: make(chan error, 4),
+	}
+
+	h.srv = httptest.NewServer(http.HandlerFunc(h.handle))
+
+	return h
+} // func newTestHub() *testHub
+
+func (h *testHub) handle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var (
+		mode = r.PostForm.Get("hub.mode")
+		sub  = hubSub{
+			callback: r.PostForm.Get("hub.callback"),
+			secret:   r.PostForm.Get("hub.secret"),
+		}
+		topic = r.PostForm.Get("hub.topic")
+	)
+
+	if mode != "subscribe" || sub.callback == "" || topic == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+
+	go func() {
+		var err = h.verify(sub.callback, topic, r.PostForm.Get("hub.lease_seconds"))
+
+		if err == nil {
+			h.lock.Lock()
+			h.subs[topic] = sub
+			h.lock.Unlock()
+		}
+
+		h.verified <- err
+	}()
+} // func (h *testHub) handle(w http.ResponseWriter, r *http.Request)
+
+func (h *testHub) verify(callback, topic, lease string) error {
+	const challenge = "Schwer zu erraten"
+
+	var (
+		err  error
+		res  *http.Response
+		body []byte
+		u    *url.URL
+		q    = make(url.Values)
+	)
+
+	if u, err = url.Parse(callback); err != nil {
+		return err
+	}
+
+	q.Set("hub.mode", "subscribe")
+	q.Set("hub.topic", topic)
+	q.Set("hub.challenge", challenge)
+	q.Set("hub.lease_seconds", lease)
+	u.RawQuery = q.Encode()
+
+	if res, err = http.Get(u.String()); err != nil {
+		return err
+	}
+
+	defer res.Body.Close() // nolint: errcheck
+
+	if body, err = io.ReadAll(res.Body); err != nil {
+		return err
+	} else if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("Subscriber refused verification: %s", res.Status)
+	} else if string(body) != challenge {
+		return fmt.Errorf("Subscriber echoed wrong challenge: %q", body)
+	}
+
+	return nil
+} // func (h *testHub) verify(callback, topic, lease string) error
+
+// publish delivers content to the subscriber of the given topic. If forge
+// is true, the content is signed with the wrong secret.
+func (h *testHub) publish(topic string, content []byte, forge bool) (int, error) {
+	h.lock.Lock()
+	var sub, ok = h.subs[topic]
+	h.lock.Unlock()
+
+	if !ok {
+		return 0, fmt.Errorf("Nobody subscribed to %s", topic)
+	}
+
+	var (
+		err    error
+		req    *http.Request
+		res    *http.Response
+		secret = sub.secret
+	)
+
+	if forge {
+		secret = "not the secret"
+	}
+
+	if req, err = http.NewRequest(http.MethodPost, sub.callback, bytes.NewReader(content)); err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/rss+xml")
+	req.Header.Set("X-Hub-Signature", Sign(secret, content))
+
+	if res, err = http.DefaultClient.Do(req); err != nil {
+		return 0, err
+	}
+
+	res.Body.Close() // nolint: errcheck
+
+	return res.StatusCode, nil
+} // func (h *testHub) publish(topic string, content []byte, forge bool) (int, error)
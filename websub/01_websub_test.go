@@ -0,0 +1,168 @@
+// /home/krylon/go/src/ticker/websub/01_websub_test.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-17 23:50:03 krylon>
+
+package websub
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testSubscriber mimics the callback handler of the web server.
+type testSubscriber struct {
+	lock     sync.Mutex
+	sub      *Subscription
+	received [][]byte
+	rejected int
+}
+
+func (s *testSubscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		var intent, err = ParseIntent(r.URL.Query())
+
+		if err != nil || intent.Mode != "subscribe" || !intent.Matches(s.sub) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.sub.State = Active
+		s.sub.Lease = intent.Lease
+		s.sub.Expires = time.Now().Add(intent.Lease)
+		w.Write([]byte(intent.Challenge)) // nolint: errcheck
+	case http.MethodPost:
+		var body, _ = io.ReadAll(r.Body)
+
+		if err := VerifySignature(s.sub.Secret, r.Header.Get("X-Hub-Signature"), body); err != nil {
+			s.rejected++
+		} else {
+			s.received = append(s.received, body)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+} // func (s *testSubscriber) ServeHTTP(w http.ResponseWriter, r *http.Request)
+
+func TestSubscribe(t *testing.T) {
+	const (
+		topic   = "http://www.example.com/feed.xml"
+		content = "<rss><channel><title>Pushed</title></channel></rss>"
+	)
+
+	var (
+		err    error
+		secret string
+		hub    = newTestHub()
+		ts     = &testSubscriber{}
+		cb     = httptest.NewServer(ts)
+	)
+
+	defer hub.srv.Close()
+	defer cb.Close()
+
+	if secret, err = NewSecret(); err != nil {
+		t.Fatalf("Cannot generate secret: %s", err.Error())
+	}
+
+	ts.sub = &Subscription{
+		FeedID: 42,
+		Hub:    hub.srv.URL,
+		Topic:  topic,
+		Secret: secret,
+		State:  Pending,
+	}
+
+	if err = Subscribe(ts.sub, ts.sub.Callback(cb.URL)); err != nil {
+		t.Fatalf("Cannot subscribe to %s: %s", topic, err.Error())
+	}
+
+	select {
+	case err = <-hub.verified:
+		if err != nil {
+			t.Fatalf("Hub failed to verify Subscription: %s", err.Error())
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("Hub did not verify Subscription")
+	}
+
+	ts.lock.Lock()
+	if !ts.sub.IsActive() {
+		t.Errorf("Subscription is not active after verification: %s", ts.sub.State)
+	} else if ts.sub.Lease != DefaultLease {
+		t.Errorf("Unexpected lease: %s (expected %s)", ts.sub.Lease, DefaultLease)
+	} else if ts.sub.NeedsRenewal() {
+		t.Error("Fresh Subscription should not need renewal")
+	}
+	ts.lock.Unlock()
+
+	for _, forge := range []bool{false, true} {
+		var status int
+
+		if status, err = hub.publish(topic, []byte(content), forge); err != nil {
+			t.Fatalf("Cannot publish content: %s", err.Error())
+		} else if status != http.StatusAccepted {
+			t.Errorf("Subscriber responded with unexpected status %d", status)
+		}
+	}
+
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	if len(ts.received) != 1 {
+		t.Errorf("Subscriber accepted %d deliveries (expected 1)", len(ts.received))
+	} else if string(ts.received[0]) != content {
+		t.Errorf("Subscriber received unexpected content: %q", ts.received[0])
+	}
+
+	if ts.rejected != 1 {
+		t.Errorf("Subscriber rejected %d deliveries (expected 1)", ts.rejected)
+	}
+} // func TestSubscribe(t *testing.T)
+
+func TestVerifySignature(t *testing.T) {
+	type testCase struct {
+		header string
+		err    error
+	}
+
+	const (
+		secret = "geheim"
+		body   = "Hello, World"
+	)
+
+	var cases = []testCase{
+		testCase{header: Sign(secret, []byte(body))},
+		// echo -n 'Hello, World' | openssl dgst -sha1 -hmac geheim
+		testCase{header: "sha1=c9c8dab5e6e071ffb4805db4a4339b2c9a112047"},
+		testCase{header: Sign("falsch", []byte(body)), err: ErrInvalidSignature},
+		testCase{header: "md5=abcdef", err: ErrInvalidSignature},
+		testCase{header: "sha256=xyz", err: ErrInvalidSignature},
+		testCase{header: "", err: ErrNoSignature},
+	}
+
+	for _, c := range cases {
+		var err = VerifySignature(secret, c.header, []byte(body))
+
+		if c.err == nil && err != nil {
+			t.Errorf("Valid signature %q was rejected: %s",
+				c.header,
+				err.Error())
+		} else if c.err != nil && !errors.Is(err, c.err) {
+			t.Errorf("Unexpected result for signature %q: %v (expected %v)",
+				c.header,
+				err,
+				c.err)
+		}
+	}
+} // func TestVerifySignature(t *testing.T)
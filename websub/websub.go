@@ -0,0 +1,287 @@
+// /home/krylon/go/src/ticker/websub/websub.go
+// -*- mode: go; coding: utf-8; -*-
+// Created on 17. 10. 2026 by Benjamin Walkenhorst
+// (c) 2026 Benjamin Walkenhorst
+// Time-stamp: <2026-10-18 00:01:53 krylon>
+
+// Package websub implements the subscriber side of WebSub (formerly known
+// as PubSubHubbub), so Feeds that announce a hub can push new content to us
+// instead of waiting for us to poll them.
+//
+// The spec is at https://www.w3.org/TR/websub/
+package websub
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint: gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blicero/ticker/common"
+)
+
+// State is the state of a Subscription.
+type State uint8
+
+// A Subscription is Pending from the moment we send a request to the hub
+// until the hub has verified our intent. Once verified, it is Active until
+// its lease expires. If the hub refuses the Subscription, it is Denied.
+const (
+	Pending State = iota
+	Active
+	Denied
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "Pending"
+	case Active:
+		return "Active"
+	case Denied:
+		return "Denied"
+	default:
+		return fmt.Sprintf("State(%d)", s)
+	}
+} // func (s State) String() string
+
+// CallbackBase is the externally reachable base URL of our web server, e.g.
+// "https://ticker.example.org". Hubs need to reach us there to verify and
+// deliver Subscriptions. If it is empty, we do not subscribe to any hubs.
+var CallbackBase string
+
+// CallbackPrefix is the path under which we receive callbacks from hubs.
+const CallbackPrefix = "/websub/"
+
+// DefaultLease is the lease we ask hubs for. Hubs are free to grant a
+// different one.
+const DefaultLease = time.Hour * 24 * 7
+
+// RenewBefore is how long before its lease expires a Subscription is renewed.
+const RenewBefore = time.Hour * 12
+
+// RetryDelay is how long we wait for a hub to verify a request before we
+// send it again.
+const RetryDelay = time.Hour
+
+// MaxBodySize is the maximum size of content we accept from a hub.
+const MaxBodySize = 4 << 20
+
+// Errors that may occur while processing callbacks from a hub.
+var (
+	ErrUnknownMode      = errors.New("unknown hub.mode")
+	ErrNoSignature      = errors.New("content is not signed")
+	ErrInvalidSignature = errors.New("signature does not match content")
+)
+
+// Subscription is our subscription to a Feed's topic at a hub.
+type Subscription struct {
+	FeedID    int64
+	Hub       string
+	Topic     string
+	Secret    string
+	State     State
+	Lease     time.Duration
+	Expires   time.Time
+	Requested time.Time
+}
+
+// IsActive returns true if the hub has verified the Subscription and its
+// lease has not expired, yet.
+func (s *Subscription) IsActive() bool {
+	return s.State == Active && s.Expires.After(time.Now())
+} // func (s *Subscription) IsActive() bool
+
+// NeedsRenewal returns true if the Subscription's lease is about to
+// expire, and we have not asked the hub to renew it recently.
+func (s *Subscription) NeedsRenewal() bool {
+	var now = time.Now()
+
+	return s.State == Active &&
+		s.Expires.Before(now.Add(RenewBefore)) &&
+		s.Requested.Before(now.Add(-RetryDelay))
+} // func (s *Subscription) NeedsRenewal() bool
+
+// Callback returns the URL at which the hub is to reach us for this
+// Subscription.
+func (s *Subscription) Callback(base string) string {
+	return fmt.Sprintf("%s%s%d",
+		strings.TrimSuffix(base, "/"),
+		CallbackPrefix,
+		s.FeedID)
+} // func (s *Subscription) Callback(base string) string
+
+// NewSecret generates a random secret the hub uses to sign content it
+// delivers to us.
+func NewSecret() (string, error) {
+	var buf = make([]byte, 32)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+} // func NewSecret() (string, error)
+
+// Subscribe asks the hub to deliver updates to the Subscription's topic to
+// the given callback URL. The hub verifies our intent asynchronously, so a
+// nil error only means the hub has accepted the request.
+func Subscribe(s *Subscription, callback string) error {
+	return request(s, callback, "subscribe")
+} // func Subscribe(s *Subscription, callback string) error
+
+// Unsubscribe asks the hub to stop delivering updates for the Subscription.
+func Unsubscribe(s *Subscription, callback string) error {
+	return request(s, callback, "unsubscribe")
+} // func Unsubscribe(s *Subscription, callback string) error
+
+func request(s *Subscription, callback, mode string) error {
+	var (
+		err  error
+		res  *http.Response
+		form = url.Values{
+			"hub.mode":     []string{mode},
+			"hub.topic":    []string{s.Topic},
+			"hub.callback": []string{callback},
+		}
+	)
+
+	if mode == "subscribe" {
+		form.Set("hub.lease_seconds", strconv.FormatInt(int64(DefaultLease.Seconds()), 10))
+
+		if s.Secret != "" {
+			form.Set("hub.secret", s.Secret)
+		}
+	}
+
+	if res, err = common.HTTPClient().PostForm(s.Hub, form); err != nil {
+		return err
+	}
+
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("Hub %s refused to %s to %s: %s",
+			s.Hub,
+			mode,
+			s.Topic,
+			res.Status)
+	}
+
+	return nil
+} // func request(s *Subscription, callback, mode string) error
+
+// Intent is a request from a hub to verify a (un)subscription, or the
+// notice that a Subscription has been denied.
+type Intent struct {
+	Mode      string
+	Topic     string
+	Challenge string
+	Lease     time.Duration
+	Reason    string
+}
+
+// ParseIntent extracts the hub's request from the query parameters of a
+// verification request.
+func ParseIntent(q url.Values) (*Intent, error) {
+	var i = &Intent{
+		Mode:      q.Get("hub.mode"),
+		Topic:     q.Get("hub.topic"),
+		Challenge: q.Get("hub.challenge"),
+		Reason:    q.Get("hub.reason"),
+	}
+
+	switch i.Mode {
+	case "subscribe", "unsubscribe":
+		if i.Challenge == "" {
+			return nil, fmt.Errorf("Hub did not send a challenge to %s", i.Mode)
+		}
+	case "denied":
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMode, i.Mode)
+	}
+
+	if s := q.Get("hub.lease_seconds"); s != "" {
+		var (
+			err  error
+			secs int64
+		)
+
+		if secs, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("Invalid hub.lease_seconds %q: %w", s, err)
+		}
+
+		i.Lease = time.Second * time.Duration(secs)
+	}
+
+	return i, nil
+} // func ParseIntent(q url.Values) (*Intent, error)
+
+// Matches returns true if the Intent refers to the given Subscription.
+func (i *Intent) Matches(s *Subscription) bool {
+	return i.Topic == s.Topic
+} // func (i *Intent) Matches(s *Subscription) bool
+
+// Sign computes the value of the X-Hub-Signature header for the given
+// content using SHA-256. We only need this for testing, but it is the
+// counterpart of VerifySignature.
+func Sign(secret string, body []byte) string {
+	var mac = hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) // nolint: errcheck
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+} // func Sign(secret string, body []byte) string
+
+// VerifySignature checks the X-Hub-Signature header a hub sent along with
+// content against the secret of the Subscription.
+func VerifySignature(secret, header string, body []byte) error {
+	var (
+		err       error
+		method    string
+		sig, want []byte
+		hfunc     func() hash.Hash
+		pieces    = strings.SplitN(header, "=", 2)
+	)
+
+	if header == "" {
+		return ErrNoSignature
+	} else if len(pieces) != 2 {
+		return fmt.Errorf("%w: malformed header %q", ErrInvalidSignature, header)
+	}
+
+	switch method = strings.ToLower(pieces[0]); method {
+	case "sha1":
+		hfunc = sha1.New
+	case "sha256":
+		hfunc = sha256.New
+	case "sha384":
+		hfunc = sha512.New384
+	case "sha512":
+		hfunc = sha512.New
+	default:
+		return fmt.Errorf("%w: unsupported method %q", ErrInvalidSignature, method)
+	}
+
+	if sig, err = hex.DecodeString(pieces[1]); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err.Error())
+	}
+
+	var mac = hmac.New(hfunc, []byte(secret))
+	mac.Write(body) // nolint: errcheck
+	want = mac.Sum(nil)
+
+	if !hmac.Equal(sig, want) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+} // func VerifySignature(secret, header string, body []byte) error